@@ -20,6 +20,8 @@ import (
 	"os"
 	"strings"
 
+	"github.com/guacsec/guac/pkg/assembler/graphql/limits"
+	"github.com/guacsec/guac/pkg/auth"
 	"github.com/guacsec/guac/pkg/cli"
 	"github.com/guacsec/guac/pkg/version"
 	"github.com/spf13/cobra"
@@ -34,6 +36,10 @@ var flags = struct {
 	tlsKeyFile  string
 	debug       bool
 	tracegql    bool
+	auth        auth.Config
+	limits      limits.Config
+	// eventsPubSubAddr is the topic to which ingestion events are forwarded
+	eventsPubSubAddr string
 
 	// Needed only if using neo4j backend
 	nAddr  string
@@ -75,6 +81,15 @@ var rootCmd = &cobra.Command{
 		flags.tlsKeyFile = viper.GetString("gql-tls-key-file")
 		flags.debug = viper.GetBool("gql-debug")
 		flags.tracegql = viper.GetBool("gql-trace")
+		flags.auth = cli.AuthConfig()
+		flags.eventsPubSubAddr = viper.GetString("gql-events-pubsub-addr")
+		flags.limits = limits.Config{
+			MaxComplexity: viper.GetInt("gql-max-complexity"),
+			MaxDepth:      viper.GetInt("gql-max-depth"),
+			Timeout:       viper.GetDuration("gql-request-timeout"),
+			RateLimit:     viper.GetFloat64("gql-rate-limit"),
+			RateBurst:     viper.GetInt("gql-rate-burst"),
+		}
 
 		flags.nUser = viper.GetString("neo4j-user")
 		flags.nPass = viper.GetString("neo4j-pass")
@@ -107,14 +122,16 @@ var rootCmd = &cobra.Command{
 func init() {
 	cobra.OnInitialize(cli.InitConfig)
 
-	set, err := cli.BuildFlags([]string{
+	set, err := cli.BuildFlags(append([]string{
 		"arango-addr", "arango-user", "arango-pass",
 		"neo4j-addr", "neo4j-user", "neo4j-pass", "neo4j-realm",
 		"neptune-endpoint", "neptune-port", "neptune-region", "neptune-user", "neptune-realm",
 		"gql-listen-port", "gql-tls-cert-file", "gql-tls-key-file", "gql-debug", "gql-backend", "gql-trace",
+		"gql-max-complexity", "gql-max-depth", "gql-request-timeout", "gql-rate-limit", "gql-rate-burst",
+		"gql-events-pubsub-addr",
 		"db-address", "db-driver", "db-debug", "db-migrate",
 		"kv-store", "kv-redis", "kv-tikv", "enable-prometheus", "prometheus-addr",
-	})
+	}, cli.AuthFlags...))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to setup flag: %v", err)
 		os.Exit(1)
@@ -33,11 +33,15 @@ import (
 	_ "github.com/guacsec/guac/pkg/assembler/backends/keyvalue"
 	"github.com/guacsec/guac/pkg/assembler/backends/neo4j"
 	"github.com/guacsec/guac/pkg/assembler/backends/neptune"
+	"github.com/guacsec/guac/pkg/assembler/eventbus"
 	"github.com/guacsec/guac/pkg/assembler/graphql/generated"
+	"github.com/guacsec/guac/pkg/assembler/graphql/limits"
 	"github.com/guacsec/guac/pkg/assembler/graphql/resolvers"
 	"github.com/guacsec/guac/pkg/assembler/kv"
 	"github.com/guacsec/guac/pkg/assembler/kv/redis"
+	"github.com/guacsec/guac/pkg/auth"
 	"github.com/guacsec/guac/pkg/cli"
+	"github.com/guacsec/guac/pkg/emitter"
 	"github.com/guacsec/guac/pkg/logging"
 	"github.com/spf13/cobra"
 	"golang.org/x/exp/maps"
@@ -99,9 +103,22 @@ func startServer(cmd *cobra.Command) {
 		srv.Use(tracer)
 	}
 
+	authenticators, err := auth.NewAuthenticators(ctx, flags.auth)
+	if err != nil {
+		logger.Fatalf("unable to set up authentication: %v", err)
+	}
+	if len(authenticators) == 0 {
+		logger.Warn("authentication is disabled, any client can query and mutate the graph")
+	}
+	srv.AroundOperations(auth.AuthorizeOperation)
+
 	http.HandleFunc("/healthz", healthHandler)
 
-	http.Handle("/query", srvHandler)
+	if flags.limits.RateLimit > 0 {
+		srvHandler = limits.NewRateLimiter(flags.limits.RateLimit, flags.limits.RateBurst).Middleware(srvHandler)
+	}
+
+	http.Handle("/query", auth.Middleware(authenticators)(srvHandler))
 	proto := "http"
 	if flags.tlsCertFile != "" && flags.tlsKeyFile != "" {
 		proto = "https"
@@ -112,6 +129,12 @@ func startServer(cmd *cobra.Command) {
 	}
 
 	server := &http.Server{Addr: fmt.Sprintf(":%d", flags.port)}
+	if flags.auth.ClientCAFile != "" {
+		server.TLSConfig, err = auth.ClientCertTLSConfig(flags.auth.ClientCAFile)
+		if err != nil {
+			logger.Fatalf("unable to set up mTLS: %v", err)
+		}
+	}
 	logger.Info("starting server")
 	go func() {
 		if proto == "https" {
@@ -150,6 +173,9 @@ func validateFlags() error {
 	if !slices.Contains([]string{"memmap", "redis", "tikv"}, flags.kvStore) {
 		return fmt.Errorf("invalid kv store specified: %v", flags.kvStore)
 	}
+	if flags.auth.ClientCAFile != "" && (flags.tlsCertFile == "" || flags.tlsKeyFile == "") {
+		return fmt.Errorf("mTLS client authentication requires gql-tls-cert-file and gql-tls-key-file")
+	}
 	return nil
 }
 
@@ -160,11 +186,17 @@ func getGraphqlServer(ctx context.Context) (*handler.Server, error) {
 	if err != nil {
 		return nil, fmt.Errorf("Error creating %v backend: %w", flags.backend, err)
 	}
-	topResolver = resolvers.Resolver{Backend: backend}
+	bus := eventbus.New()
+	if flags.eventsPubSubAddr != "" {
+		pubsub := &emitter.EmitterPubSub{ServiceURL: flags.eventsPubSubAddr, Subject: eventbus.NATSSubject}
+		go eventbus.Forward(ctx, bus, pubsub.Publish)
+	}
+	topResolver = resolvers.Resolver{Backend: eventbus.WithEvents(backend, bus), Events: bus}
 
 	config := generated.Config{Resolvers: &topResolver}
 	config.Directives.Filter = resolvers.Filter
-	srv := handler.NewDefaultServer(generated.NewExecutableSchema(config))
+	srv := handler.NewDefaultServer(limits.WithComplexity(generated.NewExecutableSchema(config)))
+	limits.Apply(srv, flags.limits)
 
 	return srv, nil
 }
@@ -0,0 +1,95 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/guacsec/guac/pkg/auth"
+	"github.com/guacsec/guac/pkg/cli"
+	"github.com/guacsec/guac/pkg/version"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var flags = struct {
+	gqlServerAddress string
+	// gqlToken authenticates the service to the graphql server
+	gqlToken string
+
+	port        int
+	tlsCertFile string
+	tlsKeyFile  string
+	auth        auth.Config
+
+	storeFile  string
+	maxDepth   int
+	pubsubAddr string
+}{}
+
+var rootCmd = &cobra.Command{
+	Use:   "guacnotify",
+	Short: "GUAC notification service for vulnerabilities affecting watched packages",
+	Long: "guacnotify lets users register watches on packages, purl patterns, SBOM subjects " +
+		"and source repositories. When a CertifyVuln or CertifyBad is ingested for a watched " +
+		"subject or one of its dependencies, a signed notification is posted to the webhook " +
+		"of the watch. It follows the subscriptions of the GraphQL API Server, which must be running.",
+	Version: version.Version,
+	Run: func(command *cobra.Command, args []string) {
+		flags.gqlServerAddress = viper.GetString("gql-addr")
+		flags.gqlToken = viper.GetString("notify-gql-token")
+		flags.port = viper.GetInt("notify-listen-port")
+		flags.tlsCertFile = viper.GetString("notify-tls-cert-file")
+		flags.tlsKeyFile = viper.GetString("notify-tls-key-file")
+		flags.auth = cli.AuthConfig()
+		flags.storeFile = viper.GetString("notify-store-file")
+		flags.maxDepth = viper.GetInt("notify-max-depth")
+		flags.pubsubAddr = viper.GetString("notify-pubsub-addr")
+
+		startServer()
+	},
+}
+
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func init() {
+	cobra.OnInitialize(cli.InitConfig)
+	set, err := cli.BuildFlags(append([]string{
+		"gql-addr", "notify-gql-token", "notify-listen-port", "notify-tls-cert-file", "notify-tls-key-file",
+		"notify-store-file", "notify-max-depth", "notify-pubsub-addr",
+	}, cli.AuthFlags...))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to setup flag: %v", err)
+		os.Exit(1)
+	}
+
+	rootCmd.Flags().AddFlagSet(set)
+	if err := viper.BindPFlags(rootCmd.Flags()); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to bind flags: %v", err)
+		os.Exit(1)
+	}
+
+	viper.SetEnvPrefix("GUAC")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	viper.AutomaticEnv()
+}
@@ -0,0 +1,126 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/go-chi/chi"
+	"github.com/guacsec/guac/pkg/assembler/eventbus"
+	"github.com/guacsec/guac/pkg/auth"
+	"github.com/guacsec/guac/pkg/emitter"
+	"github.com/guacsec/guac/pkg/logging"
+	"github.com/guacsec/guac/pkg/notify"
+)
+
+// eventBuffer is the number of ingestion events queued while notifications
+// are being delivered
+const eventBuffer = 1024
+
+func startServer() {
+	ctx, cf := context.WithCancel(logging.WithLogger(context.Background()))
+	defer cf()
+	logger := logging.FromContext(ctx)
+
+	store, err := notify.OpenStore(flags.storeFile)
+	if err != nil {
+		logger.Fatalf("unable to open the watch store: %v", err)
+	}
+
+	httpClient := &http.Client{}
+	header := http.Header{}
+	if flags.gqlToken != "" {
+		httpClient.Transport = &auth.BearerTransport{Token: flags.gqlToken}
+		header.Set("Authorization", "Bearer "+flags.gqlToken)
+	}
+	gqlClient := graphql.NewClient(flags.gqlServerAddress, httpClient)
+
+	config := notify.Config{MaxDepth: flags.maxDepth}
+	if flags.pubsubAddr != "" {
+		config.Publish = (&emitter.EmitterPubSub{ServiceURL: flags.pubsubAddr, Subject: notify.NATSSubject}).Publish
+	}
+	notifier := notify.NewNotifier(gqlClient, store, config)
+
+	events := make(chan eventbus.Event, eventBuffer)
+	subscriber := &notify.Subscriber{URL: flags.gqlServerAddress, Header: header}
+	go subscriber.Listen(ctx, events)
+	go notifier.Run(ctx, events)
+
+	authenticators, err := auth.NewAuthenticators(ctx, flags.auth)
+	if err != nil {
+		logger.Fatalf("unable to set up authentication: %v", err)
+	}
+	if len(authenticators) == 0 {
+		logger.Warn("authentication is disabled, any client can register watches")
+	}
+
+	router := chi.NewRouter()
+	router.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("Server is healthy"))
+	})
+	router.With(auth.Middleware(authenticators)).Mount("/", notify.NewAPI(store))
+	server := http.Server{
+		Addr:    fmt.Sprintf(":%d", flags.port),
+		Handler: router,
+	}
+
+	proto := "http"
+	if flags.tlsCertFile != "" && flags.tlsKeyFile != "" {
+		proto = "https"
+	}
+	if flags.auth.ClientCAFile != "" {
+		if proto != "https" {
+			logger.Fatalf("mTLS client authentication requires notify-tls-cert-file and notify-tls-key-file")
+		}
+		server.TLSConfig, err = auth.ClientCertTLSConfig(flags.auth.ClientCAFile)
+		if err != nil {
+			logger.Fatalf("unable to set up mTLS: %v", err)
+		}
+	}
+
+	logger.Infof("Watch API listening at %s://0.0.0.0:%d/watches", proto, flags.port)
+	go func() {
+		var err error
+		if proto == "https" {
+			err = server.ListenAndServeTLS(flags.tlsCertFile, flags.tlsKeyFile)
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			logger.Errorf("Server finished with error: %s", err)
+		}
+	}()
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	s := <-sigs
+	logger.Infof("Signal received: %s, shutting down gracefully\n", s.String())
+
+	shutdownCtx, shutdownCf := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCf()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Errorf("forcibly shutting down the watch API: %v", err)
+		server.Close()
+	}
+	cf()
+}
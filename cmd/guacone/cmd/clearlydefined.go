@@ -0,0 +1,276 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/guacsec/guac/pkg/certifier"
+	"github.com/guacsec/guac/pkg/certifier/certify"
+	"github.com/guacsec/guac/pkg/certifier/clearlydefined"
+	"github.com/guacsec/guac/pkg/certifier/components/legal_package"
+	"github.com/guacsec/guac/pkg/cli"
+	"github.com/guacsec/guac/pkg/collectsub/client"
+	csub_client "github.com/guacsec/guac/pkg/collectsub/client"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/ingestor"
+	"github.com/guacsec/guac/pkg/logging"
+	"github.com/guacsec/guac/pkg/version"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type clearlyDefinedOptions struct {
+	graphqlEndpoint   string
+	poll              bool
+	csubClientOptions client.CsubClientOptions
+	interval          time.Duration
+	url               string
+	dumpPaths         []string
+	daysSinceLastScan int
+}
+
+var clearlyDefinedCmd = &cobra.Command{
+	Use:   "clearlydefined [flags]",
+	Short: "runs the ClearlyDefined license certifier",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := logging.WithLogger(context.Background())
+		logger := logging.FromContext(ctx)
+
+		opts, err := validateClearlyDefinedFlags(
+			viper.GetString("gql-addr"),
+			viper.GetBool("poll"),
+			viper.GetString("interval"),
+			viper.GetString("csub-addr"),
+			viper.GetBool("csub-tls"),
+			viper.GetBool("csub-tls-skip-verify"),
+			viper.GetString("clearlydefined-url"),
+			viper.GetStringSlice("clearlydefined-dump"),
+			viper.GetInt("clearlydefined-days-since-last-scan"),
+		)
+
+		if err != nil {
+			fmt.Printf("unable to validate flags: %v\n", err)
+			_ = cmd.Help()
+			os.Exit(1)
+		}
+
+		newCertifier, err := newClearlyDefinedCertifier(opts)
+		if err != nil {
+			logger.Fatalf("unable to create the ClearlyDefined certifier: %v", err)
+		}
+		if err := certify.RegisterCertifier(newCertifier, certifier.CertifierClearlyDefined); err != nil {
+			logger.Fatalf("unable to register certifier: %v", err)
+		}
+
+		// initialize collectsub client
+		csubClient, err := csub_client.NewClient(opts.csubClientOptions)
+		if err != nil {
+			logger.Infof("collectsub client initialization failed, this ingestion will not pull in any additional data through the collectsub service: %v", err)
+			csubClient = nil
+		} else {
+			defer csubClient.Close()
+		}
+
+		httpClient := http.Client{}
+		gqlclient := graphql.NewClient(opts.graphqlEndpoint, &httpClient)
+		legalQuery := legal_package.NewLegalQuery(gqlclient, opts.daysSinceLastScan)
+
+		totalNum := 0
+		docChan := make(chan *processor.Document)
+		ingestionStop := make(chan bool, 1)
+		tickInterval := 30 * time.Second
+		ticker := time.NewTicker(tickInterval)
+
+		var gotErr int32
+		var wg sync.WaitGroup
+		ingestion := func() {
+			defer wg.Done()
+			var totalDocs []*processor.Document
+			const threshold = 1000
+			stop := false
+			for !stop {
+				select {
+				case <-ticker.C:
+					if len(totalDocs) > 0 {
+						err = ingestor.MergedIngest(ctx, totalDocs, opts.graphqlEndpoint, csubClient)
+						if err != nil {
+							stop = true
+							atomic.StoreInt32(&gotErr, 1)
+							logger.Errorf("unable to ingest documents: %v", err)
+						}
+						totalDocs = []*processor.Document{}
+					}
+					ticker.Reset(tickInterval)
+				case d := <-docChan:
+					totalNum += 1
+					totalDocs = append(totalDocs, d)
+					if len(totalDocs) >= threshold {
+						err = ingestor.MergedIngest(ctx, totalDocs, opts.graphqlEndpoint, csubClient)
+						if err != nil {
+							stop = true
+							atomic.StoreInt32(&gotErr, 1)
+							logger.Errorf("unable to ingest documents: %v", err)
+						}
+						totalDocs = []*processor.Document{}
+						ticker.Reset(tickInterval)
+					}
+				case <-ingestionStop:
+					stop = true
+				case <-ctx.Done():
+					return
+				}
+			}
+			for len(docChan) > 0 {
+				totalNum += 1
+				totalDocs = append(totalDocs, <-docChan)
+				if len(totalDocs) >= threshold {
+					err = ingestor.MergedIngest(ctx, totalDocs, opts.graphqlEndpoint, csubClient)
+					if err != nil {
+						atomic.StoreInt32(&gotErr, 1)
+						logger.Errorf("unable to ingest documents: %v", err)
+					}
+					totalDocs = []*processor.Document{}
+				}
+			}
+			if len(totalDocs) > 0 {
+				err = ingestor.MergedIngest(ctx, totalDocs, opts.graphqlEndpoint, csubClient)
+				if err != nil {
+					atomic.StoreInt32(&gotErr, 1)
+					logger.Errorf("unable to ingest documents: %v", err)
+				}
+			}
+		}
+		wg.Add(1)
+		go ingestion()
+
+		// Set emit function to go through the entire pipeline
+		emit := func(d *processor.Document) error {
+			docChan <- d
+			return nil
+		}
+
+		// Collect
+		errHandler := func(err error) bool {
+			if err == nil {
+				logger.Info("certifier ended gracefully")
+				return true
+			}
+			logger.Errorf("certifier ended with error: %v", err)
+			atomic.StoreInt32(&gotErr, 1)
+			// process documents already captures
+			return true
+		}
+
+		ctx, cf := context.WithCancel(ctx)
+		done := make(chan bool, 1)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := certify.Certify(ctx, legalQuery, emit, errHandler, opts.poll, opts.interval); err != nil {
+				logger.Errorf("Unhandled error in the certifier: %s", err)
+			}
+			done <- true
+		}()
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+		select {
+		case s := <-sigs:
+			logger.Infof("Signal received: %s, shutting down gracefully\n", s.String())
+			cf()
+		case <-done:
+			logger.Infof("All certifiers completed")
+		}
+		ingestionStop <- true
+		wg.Wait()
+		cf()
+
+		if atomic.LoadInt32(&gotErr) == 1 {
+			logger.Errorf("completed ingestion with errors")
+		} else {
+			logger.Infof("completed ingesting %v documents", totalNum)
+		}
+	},
+}
+
+// newClearlyDefinedCertifier returns the ClearlyDefined certifier, which
+// reads the definitions from the dump when given instead of the API
+func newClearlyDefinedCertifier(opts clearlyDefinedOptions) (func() certifier.Certifier, error) {
+	definitions := clearlydefined.NewAPI(opts.url, &http.Client{Transport: version.UATransport})
+	if len(opts.dumpPaths) > 0 {
+		var err error
+		definitions, err = clearlydefined.NewDump(opts.dumpPaths...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the ClearlyDefined definitions: %w", err)
+		}
+	}
+	return func() certifier.Certifier {
+		return clearlydefined.NewClearlyDefinedCertifier(definitions)
+	}, nil
+}
+
+func validateClearlyDefinedFlags(graphqlEndpoint string, poll bool, interval string, csubAddr string, csubTls bool, csubTlsSkipVerify bool,
+	url string, dumpPaths []string, daysSinceLastScan int) (clearlyDefinedOptions, error) {
+	var opts clearlyDefinedOptions
+	opts.graphqlEndpoint = graphqlEndpoint
+	opts.poll = poll
+	if url == "" && len(dumpPaths) == 0 {
+		return opts, fmt.Errorf("either a ClearlyDefined url or dump must be specified")
+	}
+	opts.url = url
+	opts.dumpPaths = dumpPaths
+	if daysSinceLastScan < 0 {
+		return opts, fmt.Errorf("days since last scan must not be negative")
+	}
+	opts.daysSinceLastScan = daysSinceLastScan
+	i, err := time.ParseDuration(interval)
+	if err != nil {
+		return opts, err
+	}
+	opts.interval = i
+
+	csubOpts, err := client.ValidateCsubClientFlags(csubAddr, csubTls, csubTlsSkipVerify)
+	if err != nil {
+		return opts, fmt.Errorf("unable to validate csub client flags: %w", err)
+	}
+	opts.csubClientOptions = csubOpts
+
+	return opts, nil
+}
+
+func init() {
+	set, err := cli.BuildFlags([]string{"clearlydefined-url", "clearlydefined-dump", "clearlydefined-days-since-last-scan"})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to setup flag: %v", err)
+		os.Exit(1)
+	}
+	clearlyDefinedCmd.Flags().AddFlagSet(set)
+	if err := viper.BindPFlags(clearlyDefinedCmd.Flags()); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to bind flags: %v", err)
+		os.Exit(1)
+	}
+
+	certifierCmd.AddCommand(clearlyDefinedCmd)
+}
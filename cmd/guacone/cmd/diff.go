@@ -0,0 +1,139 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/guacsec/guac/pkg/cli"
+	analysis "github.com/guacsec/guac/pkg/guacanalytics"
+	"github.com/guacsec/guac/pkg/logging"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type queryDiffOptions struct {
+	graphqlEndpoint string
+	base            string
+	target          string
+	output          string
+}
+
+var queryDiffCmd = &cobra.Command{
+	Use:   "diff [flags] <base> <target>",
+	Short: "Compare the packages, vulnerabilities and licenses of two SBOMs",
+	Long: `Compare the packages, vulnerabilities and licenses of two SBOMs.
+  <base> and <target> are either HasSBOM IDs or the purls of package versions,
+  in which case the most recent SBOM of the package version is used.`,
+	PreRun: bindFlagsPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := logging.WithLogger(context.Background())
+		logger := logging.FromContext(ctx)
+
+		opts, err := validateQueryDiffFlags(
+			viper.GetString("gql-addr"),
+			viper.GetString("output"),
+			args,
+		)
+		if err != nil {
+			fmt.Printf("unable to validate flags: %v\n", err)
+			_ = cmd.Help()
+			os.Exit(1)
+		}
+
+		httpClient := http.Client{}
+		gqlclient := graphql.NewClient(opts.graphqlEndpoint, &httpClient)
+
+		diff, err := analysis.DiffSBOMs(ctx, gqlclient, opts.base, opts.target)
+		if err != nil {
+			logger.Fatalf("failed to diff SBOMs: %v", err)
+		}
+
+		if opts.output == "json" {
+			out, err := json.MarshalIndent(diff, "", "  ")
+			if err != nil {
+				logger.Fatalf("failed to marshal SBOM diff: %v", err)
+			}
+			fmt.Println(string(out))
+			return
+		}
+		printSBOMDiff(diff)
+	},
+}
+
+func printSBOMDiff(diff *analysis.SBOMDiff) {
+	fmt.Printf("base SBOM: %s (%s)\n", diff.Base.URI, diff.Base.Digest)
+	fmt.Printf("target SBOM: %s (%s)\n", diff.Target.URI, diff.Target.Digest)
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"Change", "Base", "Target", "Details"})
+	for _, purl := range diff.AddedPackages {
+		t.AppendRow(table.Row{"package added", "", purl, ""})
+	}
+	for _, purl := range diff.RemovedPackages {
+		t.AppendRow(table.Row{"package removed", purl, "", ""})
+	}
+	for _, change := range diff.ChangedPackages {
+		t.AppendRow(table.Row{"version changed", change.Base, change.Target, ""})
+	}
+	for _, change := range diff.NewVulnerabilities {
+		t.AppendRow(table.Row{"new vulnerability", "", change.Package, change.VulnerabilityID})
+	}
+	for _, change := range diff.FixedVulnerabilities {
+		t.AppendRow(table.Row{"fixed vulnerability", change.Package, "", change.VulnerabilityID})
+	}
+	for _, change := range diff.LicenseChanges {
+		t.AppendRow(table.Row{"license changed", change.Base, change.Target, change.BaseLicense + " -> " + change.TargetLicense})
+	}
+	t.Render()
+}
+
+func validateQueryDiffFlags(graphqlEndpoint string, output string, args []string) (queryDiffOptions, error) {
+	var opts queryDiffOptions
+	opts.graphqlEndpoint = graphqlEndpoint
+
+	if len(args) != 2 {
+		return opts, fmt.Errorf("expected positional arguments for base and target")
+	}
+	opts.base = args[0]
+	opts.target = args[1]
+
+	switch output {
+	case "table", "json":
+		opts.output = output
+	default:
+		return opts, fmt.Errorf("unsupported output format %q, expected table or json", output)
+	}
+
+	return opts, nil
+}
+
+func init() {
+	set, err := cli.BuildFlags([]string{"output"})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to setup flag: %v", err)
+		os.Exit(1)
+	}
+	queryDiffCmd.Flags().AddFlagSet(set)
+
+	queryCmd.AddCommand(queryDiffCmd)
+}
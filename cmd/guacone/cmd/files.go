@@ -44,6 +44,8 @@ type fileOptions struct {
 	keyPath string
 	// ID related to the key being stored
 	keyID string
+	// path to the Sigstore trusted root for keyless verification
+	trustedRootPath string
 	// path to folder with documents to collect
 	path string
 	// gql endpoint
@@ -62,6 +64,7 @@ var filesCmd = &cobra.Command{
 		opts, err := validateFilesFlags(
 			viper.GetString("verifier-key-path"),
 			viper.GetString("verifier-key-id"),
+			viper.GetString("verifier-trusted-root"),
 			viper.GetString("gql-addr"),
 			viper.GetString("csub-addr"),
 			viper.GetBool("csub-tls"),
@@ -92,7 +95,15 @@ var filesCmd = &cobra.Command{
 		}
 
 		// Register Verifier
-		sigstoreAndKeyVerifier := sigstore_verifier.NewSigstoreAndKeyVerifier()
+		var verifierOpts []sigstore_verifier.Opt
+		if opts.trustedRootPath != "" {
+			trustedRoot, err := sigstore_verifier.LoadTrustedRoot(opts.trustedRootPath)
+			if err != nil {
+				logger.Fatalf("unable to load trusted root: %v", err)
+			}
+			verifierOpts = append(verifierOpts, sigstore_verifier.WithTrustedRoot(trustedRoot))
+		}
+		sigstoreAndKeyVerifier := sigstore_verifier.NewSigstoreAndKeyVerifier(verifierOpts...)
 		err = verifier.RegisterVerifier(sigstoreAndKeyVerifier, sigstoreAndKeyVerifier.Type())
 		if err != nil {
 			logger.Fatalf("unable to register key provider: %v", err)
@@ -154,7 +165,7 @@ var filesCmd = &cobra.Command{
 	},
 }
 
-func validateFilesFlags(keyPath string, keyID string, graphqlEndpoint string, csubAddr string, csubTls bool, csubTlsSkipVerify bool, args []string) (fileOptions, error) {
+func validateFilesFlags(keyPath string, keyID string, trustedRootPath string, graphqlEndpoint string, csubAddr string, csubTls bool, csubTlsSkipVerify bool, args []string) (fileOptions, error) {
 	var opts fileOptions
 	opts.graphqlEndpoint = graphqlEndpoint
 
@@ -168,6 +179,7 @@ func validateFilesFlags(keyPath string, keyID string, graphqlEndpoint string, cs
 	if keyPath != "" {
 		opts.keyID = keyID
 	}
+	opts.trustedRootPath = trustedRootPath
 
 	if len(args) != 1 {
 		return opts, fmt.Errorf("expected positional argument for file_path")
@@ -192,7 +204,7 @@ func printErrors(filesWithErrors []string) string {
 }
 
 func init() {
-	set, err := cli.BuildFlags([]string{"verifier-key-path", "verifier-key-id"})
+	set, err := cli.BuildFlags([]string{"verifier-key-path", "verifier-key-id", "verifier-trusted-root"})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to setup flag: %v", err)
 		os.Exit(1)
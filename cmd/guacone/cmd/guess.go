@@ -0,0 +1,137 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/guacsec/guac/pkg/cli"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/handler/processor/guesser"
+	"github.com/guacsec/guac/pkg/handler/processor/process"
+	"github.com/guacsec/guac/pkg/logging"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type guessOptions struct {
+	path   string
+	output string
+}
+
+var guessCmd = &cobra.Command{
+	Use:   "guess [flags] file_path",
+	Short: "Shows the format and document type guessed for a file and the guessers that matched it",
+	Long: `Shows the format and document type guessed for a file and the guessers that matched it.
+  All document type guessers are evaluated, and the type matched with the highest confidence
+  is picked. Guessers of equal confidence are ordered by name.`,
+	PreRun: bindFlagsPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := logging.WithLogger(context.Background())
+		logger := logging.FromContext(ctx)
+
+		opts, err := validateGuessFlags(viper.GetString("output"), args)
+		if err != nil {
+			fmt.Printf("unable to validate flags: %v\n", err)
+			_ = cmd.Help()
+			os.Exit(1)
+		}
+
+		blob, err := os.ReadFile(opts.path)
+		if err != nil {
+			logger.Fatalf("failed to read file: %v", err)
+		}
+		doc := &processor.Document{
+			Blob:   blob,
+			Type:   processor.DocumentUnknown,
+			Format: processor.FormatUnknown,
+			SourceInformation: processor.SourceInformation{
+				Collector: "guess",
+				Source:    opts.path,
+			},
+		}
+
+		guess, err := process.Guess(ctx, doc)
+		if err != nil {
+			logger.Fatalf("failed to guess document: %v", err)
+		}
+
+		if opts.output == "json" {
+			out, err := json.MarshalIndent(guess, "", "  ")
+			if err != nil {
+				logger.Fatalf("failed to marshal guess: %v", err)
+			}
+			fmt.Println(string(out))
+		} else {
+			printGuess(opts.path, doc.Encoding, guess)
+		}
+	},
+}
+
+func printGuess(path string, encoding processor.EncodingType, guess *guesser.Guess) {
+	if encoding != "" && encoding != processor.EncodingUnknown {
+		fmt.Printf("%s is encoded with %s\n", path, encoding)
+	}
+	if guess.FormatGuesser != "" {
+		fmt.Printf("format: %s (guessed by %s)\n", guess.Format, guess.FormatGuesser)
+	} else {
+		fmt.Printf("format: %s (no format guesser matched)\n", guess.Format)
+	}
+	if guess.Match.Guesser == "" {
+		fmt.Printf("type: %s (no document type guesser matched)\n", guess.Type)
+		return
+	}
+	fmt.Printf("type: %s (guessed by %s with confidence %d)\n", guess.Type, guess.Match.Guesser, guess.Match.Confidence)
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"Guesser", "Type", "Confidence", "Result"})
+	t.AppendRow(table.Row{guess.Match.Guesser, guess.Match.Type, guess.Match.Confidence, "selected"})
+	for _, alt := range guess.Alternatives {
+		t.AppendRow(table.Row{alt.Guesser, alt.Type, alt.Confidence, "alternative"})
+	}
+	t.Render()
+}
+
+func validateGuessFlags(output string, args []string) (guessOptions, error) {
+	var opts guessOptions
+	if len(args) != 1 {
+		return opts, fmt.Errorf("expected positional argument for file_path")
+	}
+	opts.path = args[0]
+
+	switch output {
+	case "table", "json":
+		opts.output = output
+	default:
+		return opts, fmt.Errorf("unsupported output format %q, expected table or json", output)
+	}
+	return opts, nil
+}
+
+func init() {
+	set, err := cli.BuildFlags([]string{"output"})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to setup flag: %v", err)
+		os.Exit(1)
+	}
+	guessCmd.Flags().AddFlagSet(set)
+
+	rootCmd.AddCommand(guessCmd)
+}
@@ -44,6 +44,7 @@ const (
 	badLinkStr          string = "badLink"
 	goodLinkStr         string = "goodLink"
 	pkgEqualStr         string = "pkgEqual"
+	hasMetadataStr      string = "hasMetadata"
 	packageSubjectType  string = "package"
 	sourceSubjectType   string = "source"
 	artifactSubjectType string = "artifact"
@@ -70,6 +71,7 @@ type neighbors struct {
 	badLinks     []*model.NeighborsNeighborsCertifyBad
 	goodLinks    []*model.NeighborsNeighborsCertifyGood
 	pkgEquals    []*model.NeighborsNeighborsPkgEqual
+	hasMetadata  []*model.NeighborsNeighborsHasMetadata
 }
 
 var (
@@ -230,6 +232,8 @@ var queryKnownCmd = &cobra.Command{
 			t.AppendRows(getOutputBasedOnNode(ctx, gqlclient, sourceNeighbors, badLinkStr, sourceSubjectType))
 			t.AppendSeparator()
 			t.AppendRows(getOutputBasedOnNode(ctx, gqlclient, sourceNeighbors, goodLinkStr, sourceSubjectType))
+			t.AppendSeparator()
+			t.AppendRows(getOutputBasedOnNode(ctx, gqlclient, sourceNeighbors, hasMetadataStr, sourceSubjectType))
 			path = append([]string{srcResponse.Sources[0].Namespaces[0].Names[0].Id,
 				srcResponse.Sources[0].Namespaces[0].Id, srcResponse.Sources[0].Id}, neighborsPath...)
 
@@ -321,6 +325,9 @@ func queryKnownNeighbors(ctx context.Context, gqlclient graphql.Client, subjectQ
 		case *model.NeighborsNeighborsPkgEqual:
 			collectedNeighbors.pkgEquals = append(collectedNeighbors.pkgEquals, v)
 			path = append(path, v.Id)
+		case *model.NeighborsNeighborsHasMetadata:
+			collectedNeighbors.hasMetadata = append(collectedNeighbors.hasMetadata, v)
+			path = append(path, v.Id)
 		default:
 			continue
 		}
@@ -442,6 +449,10 @@ func getOutputBasedOnNode(ctx context.Context, gqlclient graphql.Client, collect
 		for _, equal := range collectedNeighbors.pkgEquals {
 			tableRows = append(tableRows, table.Row{pkgEqualStr, equal.Id, ""})
 		}
+	case hasMetadataStr:
+		for _, metadata := range collectedNeighbors.hasMetadata {
+			tableRows = append(tableRows, table.Row{hasMetadataStr, metadata.Id, "Metadata: " + metadata.Key + " = " + metadata.Value})
+		}
 	}
 
 	return tableRows
@@ -31,6 +31,8 @@ import (
 	"github.com/guacsec/guac/pkg/certifier/certify"
 	"github.com/guacsec/guac/pkg/certifier/components/root_package"
 	"github.com/guacsec/guac/pkg/certifier/osv"
+	"github.com/guacsec/guac/pkg/certifier/osv/osvdb"
+	"github.com/guacsec/guac/pkg/cli"
 	"github.com/guacsec/guac/pkg/collectsub/client"
 	csub_client "github.com/guacsec/guac/pkg/collectsub/client"
 	"github.com/guacsec/guac/pkg/handler/processor"
@@ -45,6 +47,7 @@ type osvOptions struct {
 	poll              bool
 	csubClientOptions client.CsubClientOptions
 	interval          time.Duration
+	dbPaths           []string
 }
 
 var osvCmd = &cobra.Command{
@@ -61,6 +64,7 @@ var osvCmd = &cobra.Command{
 			viper.GetString("csub-addr"),
 			viper.GetBool("csub-tls"),
 			viper.GetBool("csub-tls-skip-verify"),
+			viper.GetStringSlice("osv-db"),
 		)
 
 		if err != nil {
@@ -69,7 +73,11 @@ var osvCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		if err := certify.RegisterCertifier(osv.NewOSVCertificationParser, certifier.CertifierOSV); err != nil {
+		newCertifier, err := newOSVCertifier(ctx, opts.dbPaths)
+		if err != nil {
+			logger.Fatalf("unable to create the osv certifier: %v", err)
+		}
+		if err := certify.RegisterCertifier(newCertifier, certifier.CertifierOSV); err != nil {
 			logger.Fatalf("unable to register certifier: %v", err)
 		}
 
@@ -203,10 +211,27 @@ var osvCmd = &cobra.Command{
 	},
 }
 
-func validateOSVFlags(graphqlEndpoint string, poll bool, interval string, csubAddr string, csubTls bool, csubTlsSkipVerify bool) (osvOptions, error) {
+// newOSVCertifier returns the osv certifier, which matches the packages
+// against the OSV databases when given instead of querying osv.dev
+func newOSVCertifier(ctx context.Context, dbPaths []string) (func() certifier.Certifier, error) {
+	if len(dbPaths) == 0 {
+		return osv.NewOSVCertificationParser, nil
+	}
+	db, err := osvdb.Load(dbPaths...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the OSV database: %w", err)
+	}
+	logging.FromContext(ctx).Infof("loaded %d OSV vulnerabilities, database version %s", db.Len(), db.Version())
+	return func() certifier.Certifier {
+		return osv.NewOfflineOSVCertificationParser(db)
+	}, nil
+}
+
+func validateOSVFlags(graphqlEndpoint string, poll bool, interval string, csubAddr string, csubTls bool, csubTlsSkipVerify bool, dbPaths []string) (osvOptions, error) {
 	var opts osvOptions
 	opts.graphqlEndpoint = graphqlEndpoint
 	opts.poll = poll
+	opts.dbPaths = dbPaths
 	i, err := time.ParseDuration(interval)
 	if err != nil {
 		return opts, err
@@ -223,5 +248,16 @@ func validateOSVFlags(graphqlEndpoint string, poll bool, interval string, csubAd
 }
 
 func init() {
+	set, err := cli.BuildFlags([]string{"osv-db"})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to setup flag: %v", err)
+		os.Exit(1)
+	}
+	osvCmd.Flags().AddFlagSet(set)
+	if err := viper.BindPFlags(osvCmd.Flags()); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to bind flags: %v", err)
+		os.Exit(1)
+	}
+
 	certifierCmd.AddCommand(osvCmd)
 }
@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/Khan/genqlient/graphql"
+	"github.com/guacsec/guac/internal/testing/ptrfrom"
 	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
 	"github.com/guacsec/guac/pkg/assembler/helpers"
 	"github.com/guacsec/guac/pkg/cli"
@@ -35,15 +36,20 @@ import (
 type queryPatchOptions struct {
 	graphqlEndpoint       string
 	startPurl             string
+	startVuln             string
+	startSource           string
+	startArtifact         string
 	stopPurl              string
 	depth                 int
 	isPackageVersionStart bool
 	isPackageVersionStop  bool
+	output                string
 }
 
 var queryPatchCmd = &cobra.Command{
-	Use:   "patch plan [flags] purl",
-	Short: "query which packages are affected by the vulnerability associated with specified packageName or packageVersion",
+	Use:    "patch plan [flags] purl",
+	Short:  "query which packages are affected by the vulnerability associated with specified packageName, packageVersion, vulnerability, source or artifact",
+	PreRun: bindFlagsPreRun,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := logging.WithLogger(context.Background())
 		logger := logging.FromContext(ctx)
@@ -51,10 +57,14 @@ var queryPatchCmd = &cobra.Command{
 		opts, err := validateQueryPatchFlags(
 			viper.GetString("gql-addr"),
 			viper.GetString("start-purl"),
+			viper.GetString("start-vuln"),
+			viper.GetString("start-source"),
+			viper.GetString("start-artifact"),
 			viper.GetString("stop-purl"),
 			viper.GetInt("search-depth"),
 			viper.GetBool("is-pkg-version-start"),
 			viper.GetBool("is-pkg-version-stop"),
+			viper.GetString("output"),
 			args,
 		)
 
@@ -69,10 +79,19 @@ var queryPatchCmd = &cobra.Command{
 		var stopID *string
 		stopID = nil
 
-		startID, err = getPkgID(ctx, gqlClient, opts.startPurl, opts.isPackageVersionStart)
+		switch {
+		case opts.startVuln != "":
+			startID, err = getVulnID(ctx, gqlClient, opts.startVuln)
+		case opts.startSource != "":
+			startID, err = getSrcNameID(ctx, gqlClient, opts.startSource)
+		case opts.startArtifact != "":
+			startID, err = getArtifactID(ctx, gqlClient, opts.startArtifact)
+		default:
+			startID, err = getPkgID(ctx, gqlClient, opts.startPurl, opts.isPackageVersionStart)
+		}
 
 		if err != nil {
-			logger.Fatalf("error getting start pkg from purl inputted: %s \n", err)
+			logger.Fatalf("error getting start node from input: %s \n", err)
 		}
 
 		if opts.stopPurl != "" {
@@ -86,7 +105,7 @@ var queryPatchCmd = &cobra.Command{
 
 		}
 
-		bfsMap, path, err := analysis.SearchDependentsFromStartPackage(ctx, gqlClient, startID, stopID, opts.depth)
+		bfsMap, path, err := analysis.SearchDependentsFromStartNode(ctx, gqlClient, startID, stopID, opts.depth)
 
 		if err != nil {
 			logger.Fatalf("error searching dependents-- %s\n", err)
@@ -95,7 +114,14 @@ var queryPatchCmd = &cobra.Command{
 		frontiers, infoNodes, err := analysis.TopoSortFromBfsNodeMap(ctx, gqlClient, bfsMap)
 
 		if err != nil {
-			fmt.Printf("WARNING: There was cycle detected in the toposort so the results are incomplete: %s\n", err)
+			fmt.Fprintf(os.Stderr, "WARNING: There was cycle detected in the toposort so the results are incomplete: %s\n", err)
+		}
+
+		if opts.output != "table" {
+			if err := writePatchPlan(ctx, gqlClient, bfsMap, frontiers, opts.output); err != nil {
+				logger.Fatalf("error writing patch plan: %s", err)
+			}
+			return
 		}
 
 		var poc []string
@@ -143,29 +169,57 @@ var queryPatchCmd = &cobra.Command{
 	},
 }
 
+func writePatchPlan(ctx context.Context, gqlClient graphql.Client, bfsMap map[string]analysis.BfsNode, frontiers map[int][]string, output string) error {
+	labels := map[string]string{}
+	for id := range bfsMap {
+		pretty, err := makeNodePretty(ctx, gqlClient, bfsMap, id)
+		if err != nil {
+			return err
+		}
+		labels[id] = pretty
+	}
+
+	plan := analysis.NewPatchPlan(bfsMap, frontiers, labels)
+
+	switch output {
+	case "json":
+		return plan.WriteJSON(os.Stdout)
+	case "dot":
+		return plan.WriteDot(os.Stdout)
+	case "mermaid":
+		return plan.WriteMermaid(os.Stdout)
+	}
+	return fmt.Errorf("unsupported output format: %s", output)
+}
+
+func makeNodePretty(ctx context.Context, gqlClient graphql.Client, bfsMap map[string]analysis.BfsNode, id string) (string, error) {
+	node, err := model.Node(ctx, gqlClient, id)
+
+	if err != nil {
+		return "", fmt.Errorf("error getting node %s: %w", id, err)
+	}
+
+	switch node := node.Node.(type) {
+	case *model.NodeNodePackage:
+		if bfsMap[id].Type == analysis.PackageName {
+			return makePkgPretty(*node, false), nil
+		}
+		return makePkgPretty(*node, true), nil
+	case *model.NodeNodeSource:
+		return makeSrcPretty(*node), nil
+	case *model.NodeNodeArtifact:
+		return makeArtifactPretty(*node), nil
+	}
+	return "", fmt.Errorf("discovered unexpected node type in bfsMap (expect plg, source, or artifact)")
+}
+
 func printNodesInfo(ctx context.Context, gqlClient graphql.Client, bfsMap map[string]analysis.BfsNode, nodes []string) ([]string, error) {
 	poc := []string{}
 	for _, id := range nodes {
-		node, err := model.Node(ctx, gqlClient, id)
+		pretty, err := makeNodePretty(ctx, gqlClient, bfsMap, id)
 
 		if err != nil {
-			fmt.Printf("error: %s \n", err)
-		}
-
-		var pretty string
-		switch node := node.Node.(type) {
-		case *model.NodeNodePackage:
-			if bfsMap[id].Type == analysis.PackageName {
-				pretty = makePkgPretty(*node, false)
-			} else {
-				pretty = makePkgPretty(*node, true)
-			}
-		case *model.NodeNodeSource:
-			pretty = makeSrcPretty(*node)
-		case *model.NodeNodeArtifact:
-			pretty = makeArtifactPretty(*node)
-		default:
-			return nil, fmt.Errorf("discovered unexpected node type in bfsMap (expect plg, source, or artifact)")
+			return nil, err
 		}
 
 		fmt.Printf("%s: %s\n", id, pretty)
@@ -263,9 +317,89 @@ func getPkgID(ctx context.Context, gqlClient graphql.Client, purl string, isPack
 	return pkgResponse.Packages[0].Namespaces[0].Names[0].Id, nil
 }
 
-func validateQueryPatchFlags(graphqlEndpoint, startPurl string, stopPurl string, depth int, isPackageVersionStart bool, isPackageVersionStop bool, args []string) (queryPatchOptions, error) {
+func getVulnID(ctx context.Context, gqlClient graphql.Client, vulnID string) (string, error) {
+	vulnResponse, err := model.Vulnerabilities(ctx, gqlClient, model.VulnerabilitySpec{VulnerabilityID: &vulnID})
+
+	if err != nil {
+		return "", fmt.Errorf("error finding vulnerability with given ID: %s, got error: %s", vulnID, err)
+	}
+
+	if len(vulnResponse.Vulnerabilities) == 0 || len(vulnResponse.Vulnerabilities[0].VulnerabilityIDs) == 0 {
+		return "", fmt.Errorf("error finding a matching vulnerability with given ID: %s", vulnID)
+	}
+
+	return vulnResponse.Vulnerabilities[0].VulnerabilityIDs[0].Id, nil
+}
+
+func getSrcNameID(ctx context.Context, gqlClient graphql.Client, vcsURI string) (string, error) {
+	srcInput, err := helpers.VcsToSrc(vcsURI)
+
+	if err != nil {
+		return "", fmt.Errorf("error getting source ID: %s", err)
+	}
+
+	srcFilter := model.SourceSpec{
+		Type:      &srcInput.Type,
+		Namespace: &srcInput.Namespace,
+		Name:      &srcInput.Name,
+	}
+
+	srcResponse, err := model.Sources(ctx, gqlClient, srcFilter)
+
+	if err != nil || len(srcResponse.Sources) == 0 {
+		if err != nil {
+			return "", fmt.Errorf("error finding source with given URI: %s, got error: %s", vcsURI, err)
+		}
+		return "", fmt.Errorf("error finding a matching source with given URI: %s", vcsURI)
+	}
+
+	return srcResponse.Sources[0].Namespaces[0].Names[0].Id, nil
+}
+
+func getArtifactID(ctx context.Context, gqlClient graphql.Client, artifact string) (string, error) {
+	split := strings.Split(artifact, ":")
+	if len(split) != 2 {
+		return "", fmt.Errorf("failed to parse artifact. Needs to be in algorithm:digest form")
+	}
+
+	artifactFilter := model.ArtifactSpec{
+		Algorithm: ptrfrom.String(strings.ToLower(split[0])),
+		Digest:    ptrfrom.String(strings.ToLower(split[1])),
+	}
+
+	artifactResponse, err := model.Artifacts(ctx, gqlClient, artifactFilter)
+
+	if err != nil || len(artifactResponse.Artifacts) == 0 {
+		if err != nil {
+			return "", fmt.Errorf("error finding artifact with given digest: %s, got error: %s", artifact, err)
+		}
+		return "", fmt.Errorf("error finding a matching artifact with given digest: %s", artifact)
+	}
+
+	return artifactResponse.Artifacts[0].Id, nil
+}
+
+func validateQueryPatchFlags(graphqlEndpoint, startPurl string, startVuln string, startSource string, startArtifact string, stopPurl string, depth int, isPackageVersionStart bool, isPackageVersionStop bool, output string, args []string) (queryPatchOptions, error) {
 	var opts queryPatchOptions
 	opts.startPurl = startPurl
+	opts.startVuln = startVuln
+	opts.startSource = startSource
+	opts.startArtifact = startArtifact
+
+	numStarts := 0
+	for _, start := range []string{startPurl, startVuln, startSource, startArtifact} {
+		if start != "" {
+			numStarts++
+		}
+	}
+
+	if numStarts > 1 {
+		return opts, fmt.Errorf("expected only one of start-purl, start-vuln, start-source or start-artifact")
+	}
+
+	if startSource != "" && !helpers.IsVcs(startSource) {
+		return opts, fmt.Errorf("expected start source input to be a VCS URI")
+	}
 
 	if _, err := helpers.PurlToPkg(startPurl); startPurl != "" && err != nil {
 		if err != nil {
@@ -285,20 +419,23 @@ func validateQueryPatchFlags(graphqlEndpoint, startPurl string, stopPurl string,
 	opts.isPackageVersionStart = isPackageVersionStart
 	opts.isPackageVersionStop = isPackageVersionStop
 
+	switch output {
+	case "table", "json", "dot", "mermaid":
+		opts.output = output
+	default:
+		return opts, fmt.Errorf("expected output to be one of table, json, dot or mermaid")
+	}
+
 	return opts, nil
 }
 
 func init() {
-	set, err := cli.BuildFlags([]string{"start-purl", "stop-purl", "search-depth", "is-pkg-version-start", "is-pkg-version-stop"})
+	set, err := cli.BuildFlags([]string{"start-purl", "start-vuln", "start-source", "start-artifact", "stop-purl", "search-depth", "is-pkg-version-start", "is-pkg-version-stop", "output"})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to setup flag: %s", err)
 		os.Exit(1)
 	}
 	queryPatchCmd.Flags().AddFlagSet(set)
-	if err := viper.BindPFlags(queryPatchCmd.Flags()); err != nil {
-		fmt.Fprintf(os.Stderr, "failed to bind flags: %s", err)
-		os.Exit(1)
-	}
 
 	queryCmd.AddCommand(queryPatchCmd)
 }
@@ -0,0 +1,163 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/guacsec/guac/pkg/cli"
+	"github.com/guacsec/guac/pkg/logging"
+	"github.com/guacsec/guac/pkg/policy"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type policyEvalOptions struct {
+	graphqlEndpoint string
+	policyFile      string
+	subject         string
+	output          string
+}
+
+var policyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Evaluates policies against the GUAC graph",
+}
+
+var policyEvalCmd = &cobra.Command{
+	Use:   "eval [flags] <subject>",
+	Short: "Evaluate a policy against a package or artifact and exit with a non-zero status if it fails",
+	Long: `Evaluate a policy against a package or artifact and exit with a non-zero status if it fails.
+  <subject> is in the form of "<purl>" for a package version or "<algorithm>:<digest>" for an artifact.`,
+	PreRun: bindFlagsPreRun,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := logging.WithLogger(context.Background())
+		logger := logging.FromContext(ctx)
+
+		opts, err := validatePolicyEvalFlags(
+			viper.GetString("gql-addr"),
+			viper.GetString("policy-file"),
+			viper.GetString("output"),
+			args,
+		)
+		if err != nil {
+			fmt.Printf("unable to validate flags: %v\n", err)
+			_ = cmd.Help()
+			os.Exit(1)
+		}
+
+		f, err := os.Open(opts.policyFile)
+		if err != nil {
+			logger.Fatalf("failed to open policy file: %v", err)
+		}
+		p, err := policy.LoadPolicy(f)
+		f.Close()
+		if err != nil {
+			logger.Fatalf("failed to load policy: %v", err)
+		}
+
+		httpClient := http.Client{}
+		gqlclient := graphql.NewClient(opts.graphqlEndpoint, &httpClient)
+
+		subjectID, err := policy.FindSubject(ctx, gqlclient, opts.subject)
+		if err != nil {
+			logger.Fatalf("failed to find subject: %v", err)
+		}
+
+		result, err := policy.Evaluate(ctx, gqlclient, p, subjectID)
+		if err != nil {
+			logger.Fatalf("failed to evaluate policy: %v", err)
+		}
+
+		if opts.output == "json" {
+			out, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				logger.Fatalf("failed to marshal result: %v", err)
+			}
+			fmt.Println(string(out))
+		} else {
+			printPolicyResult(result)
+		}
+
+		if !result.Passed {
+			os.Exit(1)
+		}
+	},
+}
+
+func printPolicyResult(result *policy.Result) {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"Rule", "Type", "Result", "Violation", "Path"})
+	for _, rule := range result.Rules {
+		if rule.Passed {
+			t.AppendRow(table.Row{rule.Name, rule.Type, "pass", "", ""})
+			continue
+		}
+		for _, violation := range rule.Violations {
+			t.AppendRow(table.Row{rule.Name, rule.Type, "fail", violation.Message, strings.Join(violation.Path, " -> ")})
+		}
+	}
+	t.Render()
+
+	status := "PASSED"
+	if !result.Passed {
+		status = "FAILED"
+	}
+	fmt.Printf("policy %q %s for %s\n", result.Policy, status, result.Subject)
+}
+
+func validatePolicyEvalFlags(graphqlEndpoint string, policyFile string, output string, args []string) (policyEvalOptions, error) {
+	var opts policyEvalOptions
+	opts.graphqlEndpoint = graphqlEndpoint
+
+	if policyFile == "" {
+		return opts, fmt.Errorf("expected a policy file")
+	}
+	opts.policyFile = policyFile
+
+	if len(args) != 1 {
+		return opts, fmt.Errorf("expected positional argument for subject")
+	}
+	opts.subject = args[0]
+
+	switch output {
+	case "table", "json":
+		opts.output = output
+	default:
+		return opts, fmt.Errorf("unsupported output format %q, expected table or json", output)
+	}
+
+	return opts, nil
+}
+
+func init() {
+	set, err := cli.BuildFlags([]string{"policy-file", "output"})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to setup flag: %v", err)
+		os.Exit(1)
+	}
+	policyEvalCmd.Flags().AddFlagSet(set)
+
+	policyCmd.AddCommand(policyEvalCmd)
+	rootCmd.AddCommand(policyCmd)
+}
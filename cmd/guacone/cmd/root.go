@@ -52,6 +52,16 @@ var rootCmd = &cobra.Command{
 	Version: version.Version,
 }
 
+// bindFlagsPreRun binds the flags of a command to viper when it runs. Flags
+// such as output are shared with other commands, so binding them at init
+// would let the last command registered win.
+func bindFlagsPreRun(cmd *cobra.Command, args []string) {
+	if err := viper.BindPFlags(cmd.Flags()); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to bind flags: %s", err)
+		os.Exit(1)
+	}
+}
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -0,0 +1,407 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/guacsec/guac/pkg/certifier"
+	"github.com/guacsec/guac/pkg/certifier/certify"
+	"github.com/guacsec/guac/pkg/certifier/components/legal_package"
+	"github.com/guacsec/guac/pkg/certifier/components/root_package"
+	"github.com/guacsec/guac/pkg/certifier/components/vulnerability"
+	"github.com/guacsec/guac/pkg/cli"
+	"github.com/guacsec/guac/pkg/collectsub/client"
+	csub_client "github.com/guacsec/guac/pkg/collectsub/client"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/ingestor"
+	"github.com/guacsec/guac/pkg/logging"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// runCertifiers are the certifiers that guacone certifier run can schedule
+var runCertifiers = []string{"osv", "scorecard", "clearlydefined", "exploitability"}
+
+type certifierSchedule struct {
+	interval    time.Duration
+	concurrency int
+	rateLimit   float64
+}
+
+type certifierRunOptions struct {
+	graphqlEndpoint   string
+	csubClientOptions client.CsubClientOptions
+	certifiers        []string
+	schedules         map[string]certifierSchedule
+	enablePrometheus  bool
+	prometheusPort    int
+}
+
+var certifierRunCmd = &cobra.Command{
+	Use:   "run [flags]",
+	Short: "runs multiple certifiers concurrently, each on its own schedule",
+	Long: `runs multiple certifiers concurrently, each on its own schedule.
+
+The certifiers run once, or at the --interval when polling, unless their
+interval is set with --certifier-interval. A failed run is retried at the next
+interval and does not affect the other certifiers. The status of the
+certifiers is served on /status and their metrics on /metrics of the
+prometheus address.`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		// the certifier flags are shared with the other certifier commands,
+		// bind them only when this command runs
+		if err := viper.BindPFlags(cmd.Flags()); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to bind flags: %v", err)
+			os.Exit(1)
+		}
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := logging.WithLogger(context.Background())
+		logger := logging.FromContext(ctx)
+
+		opts, err := validateCertifierRunFlags(
+			viper.GetString("gql-addr"),
+			viper.GetString("csub-addr"),
+			viper.GetBool("csub-tls"),
+			viper.GetBool("csub-tls-skip-verify"),
+			viper.GetBool("poll"),
+			viper.GetString("interval"),
+			viper.GetStringSlice("certifiers"),
+			viper.GetStringSlice("certifier-interval"),
+			viper.GetStringSlice("certifier-concurrency"),
+			viper.GetStringSlice("certifier-rate-limit"),
+			viper.GetBool("enable-prometheus"),
+			viper.GetInt("prometheus-addr"),
+		)
+		if err != nil {
+			fmt.Printf("unable to validate flags: %v\n", err)
+			_ = cmd.Help()
+			os.Exit(1)
+		}
+
+		// initialize collectsub client
+		csubClient, err := csub_client.NewClient(opts.csubClientOptions)
+		if err != nil {
+			logger.Infof("collectsub client initialization failed, this ingestion will not pull in any additional data through the collectsub service: %v", err)
+			csubClient = nil
+		} else {
+			defer csubClient.Close()
+		}
+
+		gqlclient := graphql.NewClient(opts.graphqlEndpoint, &http.Client{})
+
+		docChan := make(chan *processor.Document)
+		// the scheduler emits the documents of all the certifiers concurrently
+		emit := func(d *processor.Document) error {
+			select {
+			case docChan <- d:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		scheduler, err := certify.NewScheduler(ctx, emit)
+		if err != nil {
+			logger.Fatalf("unable to create the certifier scheduler: %v", err)
+		}
+		for _, name := range opts.certifiers {
+			config, err := newCertifierConfig(ctx, name, gqlclient)
+			if err != nil {
+				logger.Fatalf("unable to create the %s certifier: %v", name, err)
+			}
+			schedule := opts.schedules[name]
+			config.Interval = schedule.interval
+			config.Concurrency = schedule.concurrency
+			config.RateLimit = schedule.rateLimit
+			if err := scheduler.Register(config); err != nil {
+				logger.Fatalf("unable to register the %s certifier: %v", name, err)
+			}
+			logger.Infof("scheduled the %s certifier, interval: %v, concurrency: %d, rate limit: %v", name, schedule.interval, schedule.concurrency, schedule.rateLimit)
+		}
+
+		if opts.enablePrometheus {
+			go func() {
+				mux := http.NewServeMux()
+				mux.Handle("/metrics", scheduler.MetricsHandler())
+				mux.Handle("/status", scheduler.StatusHandler())
+				logger.Infof("Prometheus and status server is listening on: %d", opts.prometheusPort)
+				if err := http.ListenAndServe(fmt.Sprintf(":%d", opts.prometheusPort), mux); err != nil {
+					logger.Fatalf("Error starting HTTP server: %v", err)
+				}
+			}()
+		}
+
+		totalNum := 0
+		gotErr := false
+		ingestionStop := make(chan bool, 1)
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			const threshold = 1000
+			tickInterval := 30 * time.Second
+			ticker := time.NewTicker(tickInterval)
+			defer ticker.Stop()
+			var totalDocs []*processor.Document
+			ingest := func() {
+				if len(totalDocs) == 0 {
+					return
+				}
+				if err := ingestor.MergedIngest(ctx, totalDocs, opts.graphqlEndpoint, csubClient); err != nil {
+					gotErr = true
+					logger.Errorf("unable to ingest documents: %v", err)
+				}
+				totalDocs = []*processor.Document{}
+				ticker.Reset(tickInterval)
+			}
+			for {
+				select {
+				case <-ticker.C:
+					ingest()
+				case d := <-docChan:
+					totalNum += 1
+					totalDocs = append(totalDocs, d)
+					if len(totalDocs) >= threshold {
+						ingest()
+					}
+				case <-ingestionStop:
+					ingest()
+					return
+				}
+			}
+		}()
+
+		ctx, cf := context.WithCancel(ctx)
+		done := make(chan bool, 1)
+		go func() {
+			if err := scheduler.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				logger.Errorf("Unhandled error in the certifier scheduler: %s", err)
+			}
+			done <- true
+		}()
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+		select {
+		case s := <-sigs:
+			logger.Infof("Signal received: %s, shutting down gracefully\n", s.String())
+			cf()
+			<-done
+		case <-done:
+			logger.Infof("All certifiers completed")
+		}
+		ingestionStop <- true
+		wg.Wait()
+		cf()
+
+		for _, status := range scheduler.Status() {
+			if status.Errors > 0 {
+				gotErr = true
+			}
+			logger.Infof("certifier %s: %d runs, %d components, %d documents, %d errors",
+				status.Type, status.Runs, status.ComponentsProcessed, status.DocumentsEmitted, status.Errors)
+		}
+		if gotErr {
+			logger.Errorf("completed ingestion with errors")
+		} else {
+			logger.Infof("completed ingesting %v documents", totalNum)
+		}
+	},
+}
+
+// newCertifierConfig returns the certifier and query of a certifier name,
+// configured with the flags of its own command
+func newCertifierConfig(ctx context.Context, name string, gqlclient graphql.Client) (certify.CertifierConfig, error) {
+	switch name {
+	case "osv":
+		newCertifier, err := newOSVCertifier(ctx, viper.GetStringSlice("osv-db"))
+		if err != nil {
+			return certify.CertifierConfig{}, err
+		}
+		return certify.CertifierConfig{
+			Type:         certifier.CertifierOSV,
+			Query:        root_package.NewPackageQuery(gqlclient, 0),
+			NewCertifier: newCertifier,
+		}, nil
+	case "scorecard":
+		opts, err := validateScorecardFlags(
+			viper.GetString("gql-addr"),
+			viper.GetString("csub-addr"),
+			viper.GetBool("csub-tls"),
+			viper.GetBool("csub-tls-skip-verify"),
+			viper.GetBool("poll"),
+			viper.GetString("interval"),
+			viper.GetString("scorecard-source"),
+			viper.GetStringSlice("scorecard-results"),
+			viper.GetString("scorecard-api-url"),
+			viper.GetString("scorecard-bigquery-project"),
+			viper.GetString("scorecard-bigquery-table"),
+			viper.GetInt("scorecard-days-since-last-scan"),
+			viper.GetStringSlice("scorecard-check-days-since-last-scan"),
+		)
+		if err != nil {
+			return certify.CertifierConfig{}, err
+		}
+		scorecardCertifier, query, err := newScorecardCertifier(ctx, opts, gqlclient)
+		if err != nil {
+			return certify.CertifierConfig{}, err
+		}
+		return certify.CertifierConfig{
+			Type:         certifier.CertifierScorecard,
+			Query:        query,
+			NewCertifier: func() certifier.Certifier { return scorecardCertifier },
+		}, nil
+	case "clearlydefined":
+		opts := clearlyDefinedOptions{
+			url:               viper.GetString("clearlydefined-url"),
+			dumpPaths:         viper.GetStringSlice("clearlydefined-dump"),
+			daysSinceLastScan: viper.GetInt("clearlydefined-days-since-last-scan"),
+		}
+		newCertifier, err := newClearlyDefinedCertifier(opts)
+		if err != nil {
+			return certify.CertifierConfig{}, err
+		}
+		return certify.CertifierConfig{
+			Type:         certifier.CertifierClearlyDefined,
+			Query:        legal_package.NewLegalQuery(gqlclient, opts.daysSinceLastScan),
+			NewCertifier: newCertifier,
+		}, nil
+	case "exploitability":
+		opts := exploitabilityOptions{
+			epssSource: viper.GetString("epss-source"),
+			kevSource:  viper.GetString("kev-source"),
+		}
+		newCertifier, err := newExploitabilityCertifier(ctx, opts)
+		if err != nil {
+			return certify.CertifierConfig{}, err
+		}
+		return certify.CertifierConfig{
+			Type:         certifier.CertifierExploitability,
+			Query:        vulnerability.NewVulnerabilityQuery(gqlclient, "cve"),
+			NewCertifier: newCertifier,
+		}, nil
+	}
+	return certify.CertifierConfig{}, fmt.Errorf("unknown certifier %s, expected one of %s", name, strings.Join(runCertifiers, ", "))
+}
+
+func validateCertifierRunFlags(graphqlEndpoint string, csubAddr string, csubTls bool, csubTlsSkipVerify bool, poll bool, interval string,
+	certifiers []string, intervals []string, concurrency []string, rateLimits []string, enablePrometheus bool, prometheusPort int) (certifierRunOptions, error) {
+	var opts certifierRunOptions
+	opts.graphqlEndpoint = graphqlEndpoint
+	opts.enablePrometheus = enablePrometheus
+	opts.prometheusPort = prometheusPort
+
+	csubOpts, err := client.ValidateCsubClientFlags(csubAddr, csubTls, csubTlsSkipVerify)
+	if err != nil {
+		return opts, fmt.Errorf("unable to validate csub client flags: %w", err)
+	}
+	opts.csubClientOptions = csubOpts
+
+	if len(certifiers) == 0 {
+		return opts, fmt.Errorf("at least one certifier must be specified")
+	}
+	var defaultInterval time.Duration
+	if poll {
+		defaultInterval, err = time.ParseDuration(interval)
+		if err != nil {
+			return opts, err
+		}
+	}
+	opts.schedules = map[string]certifierSchedule{}
+	for _, name := range certifiers {
+		if _, ok := opts.schedules[name]; ok {
+			return opts, fmt.Errorf("certifier %s specified twice", name)
+		}
+		opts.schedules[name] = certifierSchedule{interval: defaultInterval, concurrency: 1}
+		opts.certifiers = append(opts.certifiers, name)
+	}
+
+	set := func(flag string, values []string, apply func(schedule *certifierSchedule, value string) error) error {
+		for _, value := range values {
+			name, setting, ok := strings.Cut(value, "=")
+			schedule, scheduled := opts.schedules[name]
+			if !ok || !scheduled {
+				return fmt.Errorf("invalid %s %q, expected certifier=value for one of the certifiers", flag, value)
+			}
+			if err := apply(&schedule, setting); err != nil {
+				return fmt.Errorf("invalid %s of %s: %w", flag, name, err)
+			}
+			opts.schedules[name] = schedule
+		}
+		return nil
+	}
+	err = set("certifier-interval", intervals, func(schedule *certifierSchedule, value string) error {
+		d, err := time.ParseDuration(value)
+		if err == nil && d < 0 {
+			err = fmt.Errorf("must not be negative")
+		}
+		schedule.interval = d
+		return err
+	})
+	if err != nil {
+		return opts, err
+	}
+	err = set("certifier-concurrency", concurrency, func(schedule *certifierSchedule, value string) error {
+		n, err := strconv.Atoi(value)
+		if err == nil && n < 1 {
+			err = fmt.Errorf("must be at least 1")
+		}
+		schedule.concurrency = n
+		return err
+	})
+	if err != nil {
+		return opts, err
+	}
+	err = set("certifier-rate-limit", rateLimits, func(schedule *certifierSchedule, value string) error {
+		limit, err := strconv.ParseFloat(value, 64)
+		if err == nil && limit < 0 {
+			err = fmt.Errorf("must not be negative")
+		}
+		schedule.rateLimit = limit
+		return err
+	})
+	if err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+func init() {
+	set, err := cli.BuildFlags([]string{"certifiers", "certifier-interval", "certifier-concurrency", "certifier-rate-limit",
+		"enable-prometheus", "prometheus-addr", "osv-db",
+		"scorecard-source", "scorecard-results", "scorecard-api-url", "scorecard-bigquery-project", "scorecard-bigquery-table",
+		"scorecard-days-since-last-scan", "scorecard-check-days-since-last-scan",
+		"clearlydefined-url", "clearlydefined-dump", "clearlydefined-days-since-last-scan",
+		"epss-source", "kev-source"})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to setup flag: %v", err)
+		os.Exit(1)
+	}
+	certifierRunCmd.Flags().AddFlagSet(set)
+
+	certifierCmd.AddCommand(certifierRunCmd)
+}
@@ -21,12 +21,15 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
 
 	"github.com/Khan/genqlient/graphql"
 	sc "github.com/guacsec/guac/pkg/certifier/components/source"
+	"github.com/guacsec/guac/pkg/cli"
 	"github.com/guacsec/guac/pkg/collectsub/client"
 	csub_client "github.com/guacsec/guac/pkg/collectsub/client"
 	"github.com/guacsec/guac/pkg/ingestor"
@@ -37,6 +40,7 @@ import (
 	"github.com/guacsec/guac/pkg/certifier/certify"
 	"github.com/guacsec/guac/pkg/handler/processor"
 	"github.com/guacsec/guac/pkg/logging"
+	"github.com/guacsec/guac/pkg/version"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -46,6 +50,14 @@ type scorecardOptions struct {
 	poll              bool
 	interval          time.Duration
 	csubClientOptions client.CsubClientOptions
+	// source is live, file, api or bigquery
+	source                 string
+	results                []string
+	apiURL                 string
+	bigQueryProject        string
+	bigQueryTable          string
+	daysSinceLastScan      int
+	checkDaysSinceLastScan map[string]int
 }
 
 var scorecardCmd = &cobra.Command{
@@ -62,6 +74,13 @@ var scorecardCmd = &cobra.Command{
 			viper.GetBool("csub-tls-skip-verify"),
 			viper.GetBool("poll"),
 			viper.GetString("interval"),
+			viper.GetString("scorecard-source"),
+			viper.GetStringSlice("scorecard-results"),
+			viper.GetString("scorecard-api-url"),
+			viper.GetString("scorecard-bigquery-project"),
+			viper.GetString("scorecard-bigquery-table"),
+			viper.GetInt("scorecard-days-since-last-scan"),
+			viper.GetStringSlice("scorecard-check-days-since-last-scan"),
 		)
 
 		if err != nil {
@@ -69,14 +88,6 @@ var scorecardCmd = &cobra.Command{
 			_ = cmd.Help()
 			os.Exit(1)
 		}
-		// scorecard runner is the scorecard library that runs the scorecard checks
-		scorecardRunner, err := scorecard.NewScorecardRunner(ctx)
-
-		if err != nil {
-			fmt.Printf("unable to create scorecard runner: %v\n", err)
-			_ = cmd.Help()
-			os.Exit(1)
-		}
 
 		// initialize collectsub client
 		csubClient, err := csub_client.NewClient(opts.csubClientOptions)
@@ -90,21 +101,9 @@ var scorecardCmd = &cobra.Command{
 		httpClient := http.Client{}
 		gqlclient := graphql.NewClient(opts.graphqlEndpoint, &httpClient)
 
-		// running and getting the scorecard checks
-		scorecardCertifier, err := scorecard.NewScorecardCertifier(scorecardRunner)
-
-		if err != nil {
-			fmt.Printf("unable to create scorecard certifier: %v\n", err)
-			_ = cmd.Help()
-			os.Exit(1)
-		}
-
-		// scorecard certifier is the certifier that gets the scorecard data graphQL
-		// setting "daysSinceLastScan" to 0 does not check the timestamp on the scorecard that exist
-		query, err := sc.NewCertifier(gqlclient, 0)
-
+		scorecardCertifier, query, err := newScorecardCertifier(ctx, opts, gqlclient)
 		if err != nil {
-			fmt.Printf("unable to create scorecard certifier: %v\n", err)
+			fmt.Printf("%v\n", err)
 			_ = cmd.Help()
 			os.Exit(1)
 		}
@@ -170,7 +169,57 @@ var scorecardCmd = &cobra.Command{
 	},
 }
 
-func validateScorecardFlags(graphqlEndpoint string, csubAddr string, csubTls bool, csubTlsSkipVerify bool, poll bool, interval string) (scorecardOptions, error) {
+// newScorecardCertifier returns the scorecard certifier and the query of the
+// sources without a fresh scorecard
+func newScorecardCertifier(ctx context.Context, opts scorecardOptions, gqlclient graphql.Client) (certifier.Certifier, certifier.QueryComponents, error) {
+	scorecardRunner, err := newScorecard(ctx, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create scorecard runner: %w", err)
+	}
+
+	// running and getting the scorecard checks
+	var scorecardCertifier certifier.Certifier
+	if opts.source == "live" {
+		scorecardCertifier, err = scorecard.NewScorecardCertifier(scorecardRunner)
+	} else {
+		scorecardCertifier, err = scorecard.NewPrecomputedScorecardCertifier(scorecardRunner)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create scorecard certifier: %w", err)
+	}
+
+	// scorecard certifier is the certifier that gets the scorecard data graphQL
+	// setting "daysSinceLastScan" to 0 does not check the timestamp on the scorecard that exist
+	query, err := sc.NewCertifierWithCheckFreshness(gqlclient, opts.daysSinceLastScan, opts.checkDaysSinceLastScan)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create scorecard certifier: %w", err)
+	}
+	return scorecardCertifier, query, nil
+}
+
+// newScorecard returns the scorecard library that runs the scorecard checks,
+// or a reader of precomputed results
+func newScorecard(ctx context.Context, opts scorecardOptions) (scorecard.Scorecard, error) {
+	var results scorecard.ResultSource
+	var err error
+	switch opts.source {
+	case "live":
+		return scorecard.NewScorecardRunner(ctx)
+	case "file":
+		results, err = scorecard.NewFileSource(opts.results...)
+	case "api":
+		results = scorecard.NewHTTPSource(opts.apiURL, &http.Client{Transport: version.UATransport})
+	case "bigquery":
+		results, err = scorecard.NewBigQuerySource(ctx, opts.bigQueryProject, opts.bigQueryTable)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return scorecard.NewPrecomputedScorecard(ctx, results)
+}
+
+func validateScorecardFlags(graphqlEndpoint string, csubAddr string, csubTls bool, csubTlsSkipVerify bool, poll bool, interval string,
+	source string, results []string, apiURL string, bigQueryProject string, bigQueryTable string, daysSinceLastScan int, checkDaysSinceLastScan []string) (scorecardOptions, error) {
 	var opts scorecardOptions
 	opts.graphqlEndpoint = graphqlEndpoint
 
@@ -187,9 +236,54 @@ func validateScorecardFlags(graphqlEndpoint string, csubAddr string, csubTls boo
 	}
 	opts.interval = i
 
+	switch source {
+	case "live", "api":
+	case "file":
+		if len(results) == 0 {
+			return opts, fmt.Errorf("the file scorecard source requires scorecard-results")
+		}
+	case "bigquery":
+		if bigQueryProject == "" {
+			return opts, fmt.Errorf("the bigquery scorecard source requires scorecard-bigquery-project")
+		}
+	default:
+		return opts, fmt.Errorf("unknown scorecard source %q, expected live, file, api or bigquery", source)
+	}
+	opts.source = source
+	opts.results = results
+	opts.apiURL = apiURL
+	opts.bigQueryProject = bigQueryProject
+	opts.bigQueryTable = bigQueryTable
+
+	if daysSinceLastScan < 0 {
+		return opts, fmt.Errorf("scorecard-days-since-last-scan cannot be negative")
+	}
+	opts.daysSinceLastScan = daysSinceLastScan
+	opts.checkDaysSinceLastScan = map[string]int{}
+	for _, checkDays := range checkDaysSinceLastScan {
+		check, days, ok := strings.Cut(checkDays, "=")
+		n, err := strconv.Atoi(days)
+		if !ok || check == "" || err != nil || n < 0 {
+			return opts, fmt.Errorf("invalid scorecard-check-days-since-last-scan %q, expected check=days", checkDays)
+		}
+		opts.checkDaysSinceLastScan[check] = n
+	}
+
 	return opts, nil
 }
 
 func init() {
+	set, err := cli.BuildFlags([]string{"scorecard-source", "scorecard-results", "scorecard-api-url", "scorecard-bigquery-project",
+		"scorecard-bigquery-table", "scorecard-days-since-last-scan", "scorecard-check-days-since-last-scan"})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to setup flag: %v", err)
+		os.Exit(1)
+	}
+	scorecardCmd.Flags().AddFlagSet(set)
+	if err := viper.BindPFlags(scorecardCmd.Flags()); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to bind flags: %v", err)
+		os.Exit(1)
+	}
+
 	certifierCmd.AddCommand(scorecardCmd)
 }
@@ -20,13 +20,16 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/Khan/genqlient/graphql"
 	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
 	"github.com/guacsec/guac/pkg/assembler/helpers"
 	"github.com/guacsec/guac/pkg/cli"
+	analysis "github.com/guacsec/guac/pkg/guacanalytics"
 	"github.com/guacsec/guac/pkg/logging"
 	"github.com/guacsec/guac/pkg/misc/depversion"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -35,8 +38,9 @@ import (
 )
 
 const (
-	guacType   string = "guac"
-	noVulnType string = "novuln"
+	guacType          string = "guac"
+	noVulnType        string = "novuln"
+	knownExploitedStr string = "KNOWN EXPLOITED"
 )
 
 type queryOptions struct {
@@ -133,15 +137,67 @@ func printVulnInfo(ctx context.Context, gqlclient graphql.Client, t table.Writer
 	tableRows = append(tableRows, depVulnTableRows...)
 
 	if len(path) > 0 {
-		t.AppendRows(tableRows)
+		t.AppendRows(highlightKnownExploited(tableRows))
 
 		fmt.Println(t.Render())
 		fmt.Printf("Visualizer url: http://localhost:3000/?path=%v\n", strings.Join(removeDuplicateValuesFromPath(path), `,`))
+		printVulnReachability(ctx, gqlclient, opts)
 	} else {
 		fmt.Printf("No path to vulnerabilities found!\n")
 	}
 }
 
+// printVulnReachability prints whether the vulnerabilities are in direct or
+// transitive dependencies, and the dependencies whose upgrade may fix the most
+func printVulnReachability(ctx context.Context, gqlclient graphql.Client, opts queryOptions) {
+	logger := logging.FromContext(ctx)
+
+	var topPkgVersionID string
+	if opts.isPurl {
+		pkgResponse, err := getPkgResponseFromPurl(ctx, gqlclient, opts.searchString)
+		if err != nil {
+			logger.Errorf("getPkgResponseFromPurl - error: %v", err)
+			return
+		}
+		topPkgVersionID = pkgResponse.Packages[0].Namespaces[0].Names[0].Versions[0].Id
+	} else {
+		foundHasSBOMPkg, err := model.HasSBOMs(ctx, gqlclient, model.HasSBOMSpec{Uri: &opts.searchString})
+		if err != nil || len(foundHasSBOMPkg.HasSBOM) != 1 {
+			logger.Errorf("failed to locate singular hasSBOM based on URI: %s", opts.searchString)
+			return
+		}
+		pkgResponse, ok := foundHasSBOMPkg.HasSBOM[0].Subject.(*model.AllHasSBOMTreeSubjectPackage)
+		if !ok {
+			// the reachability of the dependencies of artifacts is not analyzed
+			return
+		}
+		topPkgVersionID = pkgResponse.Namespaces[0].Names[0].Versions[0].Id
+	}
+
+	report, err := analysis.AnalyzeReachability(ctx, gqlclient, topPkgVersionID, opts.depth)
+	if err != nil {
+		logger.Errorf("error analyzing vulnerability reachability: %v", err)
+		return
+	}
+	if len(report.Vulnerabilities) == 0 {
+		return
+	}
+
+	t := table.NewWriter()
+	t.AppendHeader(table.Row{"Vulnerability", "Package", "Reachability", "Min Depth", "Paths", "Via"})
+	for _, v := range report.Vulnerabilities {
+		t.AppendRow(table.Row{v.VulnerabilityID, v.Package, v.Reachability, v.MinDepth, v.Paths, strings.Join(v.Via, "\n")})
+	}
+	fmt.Println(t.Render())
+
+	t = table.NewWriter()
+	t.AppendHeader(table.Row{"Dependency", "Vulnerabilities Reached"})
+	for _, d := range report.Dependencies {
+		t.AppendRow(table.Row{d.Package, fmt.Sprintf("%d: %s", len(d.Vulnerabilities), strings.Join(d.Vulnerabilities, ", "))})
+	}
+	fmt.Println(t.Render())
+}
+
 func printVulnInfoByVulnId(ctx context.Context, gqlclient graphql.Client, t table.Writer, opts queryOptions) {
 	logger := logging.FromContext(ctx)
 	var tableRows []table.Row
@@ -184,7 +240,7 @@ func printVulnInfoByVulnId(ctx context.Context, gqlclient graphql.Client, t tabl
 		}
 	}
 	if len(path) > 0 {
-		t.AppendRows(tableRows)
+		t.AppendRows(highlightKnownExploited(tableRows))
 		fmt.Println(t.Render())
 		fmt.Printf("Visualizer url: http://localhost:3000/?path=%v\n", strings.Join(removeDuplicateValuesFromPath(path), `,`))
 	} else {
@@ -207,7 +263,7 @@ func queryVulnsViaPackageNeighbors(ctx context.Context, gqlclient graphql.Client
 			certifyVulnFound = true
 			if certifyVuln.Vulnerability.Type != noVulnType {
 				for _, vuln := range certifyVuln.Vulnerability.VulnerabilityIDs {
-					tableRows = append(tableRows, table.Row{certifyVulnStr, certifyVuln.Id, "vulnerability ID: " + vuln.VulnerabilityID})
+					tableRows = append(tableRows, table.Row{certifyVulnStr, certifyVuln.Id, "vulnerability ID: " + vuln.VulnerabilityID + vulnExploitability(ctx, gqlclient, vuln.VulnerabilityID)})
 					path = append(path, []string{vuln.Id, certifyVuln.Id,
 						certifyVuln.Package.Namespaces[0].Names[0].Versions[0].Id,
 						certifyVuln.Package.Namespaces[0].Names[0].Id, certifyVuln.Package.Namespaces[0].Id,
@@ -294,7 +350,8 @@ func queryVulnsViaVulnNodeNeighbors(ctx context.Context, gqlclient graphql.Clien
 				return nil, nil, fmt.Errorf("error searching dependency packages match: %w", err)
 			}
 			if len(pkgPath) > 0 {
-				tableRows = append(tableRows, table.Row{certifyVulnStr, certifyVuln.Id, "vulnerability ID: " + certifyVuln.Vulnerability.VulnerabilityIDs[0].VulnerabilityID})
+				vulnID := certifyVuln.Vulnerability.VulnerabilityIDs[0].VulnerabilityID
+				tableRows = append(tableRows, table.Row{certifyVulnStr, certifyVuln.Id, "vulnerability ID: " + vulnID + vulnExploitability(ctx, gqlclient, vulnID)})
 				fullVulnPath := append([]string{certifyVuln.Vulnerability.Id, certifyVuln.Vulnerability.VulnerabilityIDs[0].Id, certifyVuln.Id,
 					certifyVuln.Package.Namespaces[0].Names[0].Versions[0].Id,
 					certifyVuln.Package.Namespaces[0].Names[0].Id, certifyVuln.Package.Namespaces[0].Id,
@@ -581,7 +638,7 @@ func searchPkgViaHasSBOM(ctx context.Context, gqlclient graphql.Client, searchSt
 					if certifyVuln.Vulnerability.Type != noVulnType {
 						checkedCertifyVulnIDs[certifyVuln.Id] = true
 						for _, vuln := range certifyVuln.Vulnerability.VulnerabilityIDs {
-							tableRows = append(tableRows, table.Row{certifyVulnStr, certifyVuln.Id, "vulnerability ID: " + vuln.VulnerabilityID})
+							tableRows = append(tableRows, table.Row{certifyVulnStr, certifyVuln.Id, "vulnerability ID: " + vuln.VulnerabilityID + vulnExploitability(ctx, gqlclient, vuln.VulnerabilityID)})
 							path = append(path, []string{vuln.Id, certifyVuln.Id,
 								certifyVuln.Package.Namespaces[0].Names[0].Versions[0].Id,
 								certifyVuln.Package.Namespaces[0].Names[0].Id, certifyVuln.Package.Namespaces[0].Id,
@@ -655,6 +712,101 @@ func removeDuplicateValuesFromPath(path []string) []string {
 	return list
 }
 
+// exploitabilityCache caches the exploitability of the vulnerability IDs, as
+// they are usually found in many packages
+var exploitabilityCache = map[string]string{}
+
+// vulnExploitability returns the KEV listing and the latest EPSS score of a
+// vulnerability, or of the vulnerabilities it is equal to, as recorded by the
+// exploitability certifier
+func vulnExploitability(ctx context.Context, gqlclient graphql.Client, vulnID string) string {
+	if description, ok := exploitabilityCache[vulnID]; ok {
+		return description
+	}
+	logger := logging.FromContext(ctx)
+
+	ids := map[string]bool{vulnID: true}
+	vulnResponse, err := model.Vulnerabilities(ctx, gqlclient, model.VulnerabilitySpec{VulnerabilityID: &vulnID})
+	if err != nil {
+		logger.Debugf("error querying for vulnerability %s: %v", vulnID, err)
+	} else {
+		for _, vuln := range vulnResponse.Vulnerabilities {
+			for _, vulnNodeID := range vuln.VulnerabilityIDs {
+				neighbors, err := model.Neighbors(ctx, gqlclient, vulnNodeID.Id, []model.Edge{model.EdgeVulnerabilityVulnEqual})
+				if err != nil {
+					logger.Debugf("error querying for vulnerabilities equal to %s: %v", vulnID, err)
+					continue
+				}
+				for _, neighbor := range neighbors.Neighbors {
+					if vulnEqual, ok := neighbor.(*model.NeighborsNeighborsVulnEqual); ok {
+						for _, equal := range vulnEqual.Vulnerabilities {
+							for _, equalID := range equal.VulnerabilityIDs {
+								ids[equalID.VulnerabilityID] = true
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	var kevAdded, epssDate time.Time
+	var epss float64
+	for id := range ids {
+		id := id
+		metadata, err := model.VulnerabilityMetadata(ctx, gqlclient, model.VulnerabilityMetadataSpec{Vulnerability: &model.VulnerabilitySpec{VulnerabilityID: &id}})
+		if err != nil {
+			logger.Debugf("error querying for the metadata of vulnerability %s: %v", id, err)
+			continue
+		}
+		for _, m := range metadata.VulnerabilityMetadata {
+			switch m.ScoreType {
+			case model.VulnerabilityScoreTypeKev:
+				if kevAdded.IsZero() || m.Timestamp.Before(kevAdded) {
+					kevAdded = m.Timestamp
+				}
+			case model.VulnerabilityScoreTypeEpssv1, model.VulnerabilityScoreTypeEpssv2, model.VulnerabilityScoreTypeEpssv3, model.VulnerabilityScoreTypeEpssv4:
+				if m.Timestamp.After(epssDate) {
+					epssDate = m.Timestamp
+					epss = m.ScoreValue
+				}
+			}
+		}
+	}
+
+	description := ""
+	if !kevAdded.IsZero() {
+		description += fmt.Sprintf(", %s (CISA KEV since %s)", knownExploitedStr, kevAdded.Format("2006-01-02"))
+	}
+	if !epssDate.IsZero() {
+		description += fmt.Sprintf(", EPSS: %.5f", epss)
+	}
+	exploitabilityCache[vulnID] = description
+	return description
+}
+
+// highlightKnownExploited moves the known exploited vulnerabilities first and
+// reports their number
+func highlightKnownExploited(tableRows []table.Row) []table.Row {
+	isKnownExploited := func(row table.Row) bool {
+		value, ok := row[2].(string)
+		return ok && row[0] == certifyVulnStr && strings.Contains(value, knownExploitedStr)
+	}
+	sort.SliceStable(tableRows, func(i, j int) bool {
+		return isKnownExploited(tableRows[i]) && !isKnownExploited(tableRows[j])
+	})
+	knownExploited := 0
+	for _, row := range tableRows {
+		if isKnownExploited(row) {
+			knownExploited++
+		}
+	}
+	if knownExploited > 0 {
+		fmt.Printf("%d known exploited vulnerabilities found, listed first\n", knownExploited)
+	}
+	return tableRows
+}
+
 func validateQueryVulnFlags(graphqlEndpoint, vulnID string, depth, path int, args []string) (queryOptions, error) {
 	var opts queryOptions
 	opts.graphqlEndpoint = graphqlEndpoint
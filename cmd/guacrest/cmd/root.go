@@ -20,6 +20,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/guacsec/guac/pkg/auth"
 	"github.com/guacsec/guac/pkg/cli"
 	"github.com/guacsec/guac/pkg/version"
 	"github.com/spf13/cobra"
@@ -32,6 +33,10 @@ var flags = struct {
 
 	tlsCertFile string
 	tlsKeyFile  string
+
+	// gqlToken authenticates the server to the graphql server
+	gqlToken string
+	auth     auth.Config
 }{}
 
 var rootCmd = &cobra.Command{
@@ -46,6 +51,8 @@ var rootCmd = &cobra.Command{
 		flags.gqlServerAddress = viper.GetString("gql-addr")
 		flags.tlsCertFile = viper.GetString("rest-api-tls-cert-file")
 		flags.tlsKeyFile = viper.GetString("rest-api-tls-key-file")
+		flags.gqlToken = viper.GetString("rest-api-gql-token")
+		flags.auth = cli.AuthConfig()
 
 		startServer()
 	},
@@ -60,7 +67,7 @@ func Execute() {
 
 func init() {
 	cobra.OnInitialize(cli.InitConfig)
-	set, err := cli.BuildFlags([]string{"gql-addr", "rest-api-server-port", "rest-api-tls-cert-file", "rest-api-tls-key-file"})
+	set, err := cli.BuildFlags(append([]string{"gql-addr", "rest-api-server-port", "rest-api-tls-cert-file", "rest-api-tls-key-file", "rest-api-gql-token"}, cli.AuthFlags...))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to setup flag: %v", err)
 		os.Exit(1)
@@ -27,6 +27,7 @@ import (
 
 	"github.com/Khan/genqlient/graphql"
 	"github.com/go-chi/chi"
+	"github.com/guacsec/guac/pkg/auth"
 	gen "github.com/guacsec/guac/pkg/guacrest/generated"
 	"github.com/guacsec/guac/pkg/guacrest/server"
 	"github.com/guacsec/guac/pkg/logging"
@@ -40,10 +41,24 @@ func startServer() {
 	logger := logging.FromContext(ctx)
 
 	httpClient := &http.Client{}
+	if flags.gqlToken != "" {
+		httpClient.Transport = &auth.BearerTransport{Token: flags.gqlToken}
+	}
 	gqlClient := getGraphqlServerClientOrExit(ctx, httpClient)
 	handler := server.NewDefaultServer(gqlClient)
 	handlerWrapper := gen.NewStrictHandler(handler, nil)
+
+	authenticators, err := auth.NewAuthenticators(ctx, flags.auth)
+	if err != nil {
+		logger.Fatalf("unable to set up authentication: %v", err)
+	}
+	if len(authenticators) == 0 {
+		logger.Warn("authentication is disabled, any client can query the REST API")
+	}
+
 	router := chi.NewRouter()
+	// all endpoints only read the graph, so any authenticated role may call them
+	router.Use(authenticate(auth.Middleware(authenticators)))
 	router.Mount("/", gen.Handler(handlerWrapper))
 	server := http.Server{
 		Addr:    fmt.Sprintf(":%d", flags.restAPIServerPort),
@@ -54,6 +69,15 @@ func startServer() {
 	if flags.tlsCertFile != "" && flags.tlsKeyFile != "" {
 		proto = "https"
 	}
+	if flags.auth.ClientCAFile != "" {
+		if proto != "https" {
+			logger.Fatalf("mTLS client authentication requires rest-api-tls-cert-file and rest-api-tls-key-file")
+		}
+		server.TLSConfig, err = auth.ClientCertTLSConfig(flags.auth.ClientCAFile)
+		if err != nil {
+			logger.Fatalf("unable to set up mTLS: %v", err)
+		}
+	}
 
 	logger.Infof("Connect to the server at %s://0.0.0.0:%d/", proto, flags.restAPIServerPort)
 	logger.Info("Starting Server")
@@ -90,6 +114,21 @@ func startServer() {
 	cf()
 }
 
+// authenticate applies the authentication middleware to every endpoint except
+// the health check
+func authenticate(middleware func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		authenticated := middleware(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/healthz" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			authenticated.ServeHTTP(w, r)
+		})
+	}
+}
+
 // get the graphql client and test the connection
 func getGraphqlServerClientOrExit(ctx context.Context, httpClient *http.Client) graphql.Client {
 	logger := logging.FromContext(ctx)
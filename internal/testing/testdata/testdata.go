@@ -41,6 +41,9 @@ var (
 	//go:embed exampledata/small-spdx.json
 	SpdxExampleSmall []byte
 
+	//go:embed exampledata/spdx3-example.json
+	Spdx3Example []byte
+
 	//go:embed exampledata/alpine-spdx.json
 	SpdxExampleBig []byte
 
@@ -187,7 +190,7 @@ var (
 				Status:           generated.VexStatusNotAffected,
 				VexJustification: generated.VexJustificationVulnerableCodeNotInExecutePath,
 				Statement:        "Automated dataflow analysis and manual code review indicates that the vulnerable code is not reachable, either directly or indirectly.",
-				StatusNotes:      fmt.Sprintf("%s:%s", generated.VexStatusNotAffected, generated.VexJustificationVulnerableCodeNotInExecutePath),
+				StatusNotes:      fmt.Sprintf("%s:%s; response: will_not_fix,update", generated.VexStatusNotAffected, generated.VexJustificationVulnerableCodeNotInExecutePath),
 				KnownSince:       parseUTCTime("2020-12-03T00:00:00.000Z"),
 			},
 		},
@@ -199,6 +202,7 @@ var (
 				ScoreType:  generated.VulnerabilityScoreTypeCvssv31,
 				ScoreValue: 7.5,
 				Timestamp:  parseUTCTime("2020-12-03T00:00:00.000Z"),
+				Origin:     "NVD",
 			},
 		},
 		{
@@ -207,6 +211,7 @@ var (
 				ScoreType:  generated.VulnerabilityScoreTypeCvssv31,
 				ScoreValue: 8.2,
 				Timestamp:  parseUTCTime("2020-12-03T00:00:00.000Z"),
+				Origin:     "SNYK",
 			},
 		},
 		{
@@ -215,6 +220,7 @@ var (
 				ScoreType:  generated.VulnerabilityScoreTypeCvssv31,
 				ScoreValue: 0.0,
 				Timestamp:  parseUTCTime("2020-12-03T00:00:00.000Z"),
+				Origin:     "Acme Inc",
 			},
 		},
 	}
@@ -232,7 +238,7 @@ var (
 		Status:           generated.VexStatusAffected,
 		VexJustification: generated.VexJustificationNotProvided,
 		Statement:        "Versions of Product ABC are affected by the vulnerability. Customers are advised to upgrade to the latest release.",
-		StatusNotes:      fmt.Sprintf("%s:%s", generated.VexStatusAffected, generated.VexJustificationNotProvided),
+		StatusNotes:      fmt.Sprintf("%s:%s; response: will_not_fix,update", generated.VexStatusAffected, generated.VexJustificationNotProvided),
 		KnownSince:       time.Unix(0, 0),
 	}
 	CycloneDXAffectedVulnMetadata = []assembler.VulnMetadataIngest{
@@ -242,6 +248,7 @@ var (
 				ScoreType:  generated.VulnerabilityScoreTypeCvssv31,
 				ScoreValue: 10,
 				Timestamp:  time.Unix(0, 0),
+				Origin:     "NVD",
 			},
 		},
 	}
@@ -922,12 +929,38 @@ var (
 		},
 	}
 
+	spdxOriginator = &model.PointOfContactInputSpec{
+		Email:         "ncopa@alpinelinux.org",
+		Info:          "originator: Natanael Copa (person)",
+		Since:         spdxTime,
+		Justification: "Found in SPDX package originator.",
+	}
+
+	SpdxPointOfContact = []assembler.PointOfContactIngest{
+		{
+			Pkg:            baselayoutPack,
+			PkgMatchFlag:   model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+			PointOfContact: spdxOriginator,
+		},
+		{
+			Pkg:            baselayoutdataPack,
+			PkgMatchFlag:   model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+			PointOfContact: spdxOriginator,
+		},
+		{
+			Pkg:            keysPack,
+			PkgMatchFlag:   model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+			PointOfContact: spdxOriginator,
+		},
+	}
+
 	SpdxIngestionPredicates = assembler.IngestPredicates{
-		IsDependency: SpdxDeps,
-		IsOccurrence: SpdxOccurences,
-		HasSBOM:      SpdxHasSBOM,
-		HasMetadata:  SpdxHasMetadata,
-		CertifyLegal: SpdxCertifyLegal,
+		IsDependency:   SpdxDeps,
+		IsOccurrence:   SpdxOccurences,
+		HasSBOM:        SpdxHasSBOM,
+		HasMetadata:    SpdxHasMetadata,
+		CertifyLegal:   SpdxCertifyLegal,
+		PointOfContact: SpdxPointOfContact,
 	}
 
 	// CycloneDX Testdata
@@ -987,9 +1020,43 @@ var (
 		},
 	}
 
+	CdxPointOfContact = []assembler.PointOfContactIngest{
+		{
+			Pkg:          cdxBasefilesPack,
+			PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+			PointOfContact: &model.PointOfContactInputSpec{
+				Email:         "sanvila@debian.org",
+				Info:          "publisher: Santiago Vila",
+				Since:         cdxTime,
+				Justification: "Found in CycloneDX component publisher.",
+			},
+		},
+		{
+			Pkg:          cdxNetbasePack,
+			PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+			PointOfContact: &model.PointOfContactInputSpec{
+				Email:         "md@linux.it",
+				Info:          "publisher: Marco d'Itri",
+				Since:         cdxTime,
+				Justification: "Found in CycloneDX component publisher.",
+			},
+		},
+		{
+			Pkg:          cdxTzdataPack,
+			PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+			PointOfContact: &model.PointOfContactInputSpec{
+				Email:         "debian-glibc@lists.debian.org",
+				Info:          "publisher: GNU Libc Maintainers",
+				Since:         cdxTime,
+				Justification: "Found in CycloneDX component publisher.",
+			},
+		},
+	}
+
 	CdxIngestionPredicates = assembler.IngestPredicates{
-		IsDependency: CdxDeps,
-		HasSBOM:      CdxHasSBOM,
+		IsDependency:   CdxDeps,
+		HasSBOM:        CdxHasSBOM,
+		PointOfContact: CdxPointOfContact,
 	}
 
 	cdxTopQuarkusPack, _ = asmhelpers.PurlToPkg("pkg:maven/org.acme/getting-started@1.0.0-SNAPSHOT?type=jar")
@@ -1081,10 +1148,49 @@ var (
 		},
 	}
 
+	CdxQuarkusHasSourceAt = []assembler.HasSourceAtIngest{
+		{
+			Pkg:          cdxResteasyPack,
+			PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+			Src: &model.SourceInputSpec{
+				Type:      "git",
+				Namespace: "github.com/quarkusio",
+				Name:      "quarkus",
+			},
+			HasSourceAt: &model.HasSourceAtInputSpec{
+				KnownSince:    cdxQuarkusTime,
+				Justification: "Found in CycloneDX vcs external reference.",
+			},
+		},
+	}
+
+	CdxQuarkusPointOfContact = []assembler.PointOfContactIngest{
+		{
+			Pkg:          cdxResteasyPack,
+			PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+			PointOfContact: &model.PointOfContactInputSpec{
+				Info:          "publisher: JBoss by Red Hat",
+				Since:         cdxQuarkusTime,
+				Justification: "Found in CycloneDX component publisher.",
+			},
+		},
+		{
+			Pkg:          cdxReactiveCommonPack,
+			PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+			PointOfContact: &model.PointOfContactInputSpec{
+				Info:          "publisher: JBoss by Red Hat",
+				Since:         cdxQuarkusTime,
+				Justification: "Found in CycloneDX component publisher.",
+			},
+		},
+	}
+
 	CdxQuarkusIngestionPredicates = assembler.IngestPredicates{
-		IsDependency: CdxQuarkusDeps,
-		IsOccurrence: CdxQuarkusOccurrence,
-		HasSBOM:      CdxQuarkusHasSBOM,
+		IsDependency:   CdxQuarkusDeps,
+		IsOccurrence:   CdxQuarkusOccurrence,
+		HasSBOM:        CdxQuarkusHasSBOM,
+		HasSourceAt:    CdxQuarkusHasSourceAt,
+		PointOfContact: CdxQuarkusPointOfContact,
 	}
 
 	cdxWebAppPackage, _ = asmhelpers.PurlToPkg("pkg:npm/web-app@1.0.0")
@@ -1142,6 +1248,32 @@ var (
 
 	CdxEmptyIngestionPredicates = assembler.IngestPredicates{
 		HasSBOM: quarkusParentPackageHasSBOM,
+		HasSourceAt: []assembler.HasSourceAtIngest{
+			{
+				Pkg:          quarkusParentPackage,
+				PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+				Src: &model.SourceInputSpec{
+					Type:      "git",
+					Namespace: "github.com/quarkusio",
+					Name:      "quarkus",
+				},
+				HasSourceAt: &model.HasSourceAtInputSpec{
+					KnownSince:    quarkusTime,
+					Justification: "Found in CycloneDX vcs external reference.",
+				},
+			},
+		},
+		PointOfContact: []assembler.PointOfContactIngest{
+			{
+				Pkg:          quarkusParentPackage,
+				PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+				PointOfContact: &model.PointOfContactInputSpec{
+					Info:          "publisher: JBoss by Red Hat",
+					Since:         quarkusTime,
+					Justification: "Found in CycloneDX component publisher.",
+				},
+			},
+		},
 	}
 
 	// ceritifer testdata
@@ -2246,6 +2378,22 @@ var (
 		},
 	}
 
+	// Native scanner reports
+
+	//go:embed exampledata/syft-alpine.json
+	SyftExampleAlpine []byte
+
+	//go:embed exampledata/trivy-alpine.json
+	TrivyExampleAlpine []byte
+
+	//go:embed exampledata/grype-alpine.json
+	GrypeExampleAlpine []byte
+
+	// SARIF
+
+	//go:embed exampledata/sarif-codeql-semgrep.json
+	SARIFExampleCodeQLSemgrep []byte
+
 	// CSAF
 	//go:embed exampledata/rhsa-csaf.json
 	CsafExampleRedHat []byte
@@ -2868,6 +3016,8 @@ var IngestPredicatesCmpOpts = []cmp.Option{
 	cmpopts.SortSlices(hasMetadataLess),
 	cmpopts.SortSlices(vexLess),
 	cmpopts.SortSlices(certifyVulnLess),
+	cmpopts.SortSlices(hasSourceAtLess),
+	cmpopts.SortSlices(pointOfContactLess),
 }
 
 func certifyScorecardLess(e1, e2 assembler.CertifyScorecardIngest) bool {
@@ -2914,6 +3064,14 @@ func certifyVulnLess(e1, e2 assembler.CertifyVulnIngest) bool {
 	return gLess(e1, e2)
 }
 
+func hasSourceAtLess(e1, e2 assembler.HasSourceAtIngest) bool {
+	return gLess(e1, e2)
+}
+
+func pointOfContactLess(e1, e2 assembler.PointOfContactIngest) bool {
+	return gLess(e1, e2)
+}
+
 func gLess(e1, e2 any) bool {
 	s1, _ := json.Marshal(e1)
 	s2, _ := json.Marshal(e2)
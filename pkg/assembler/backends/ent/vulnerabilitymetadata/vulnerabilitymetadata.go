@@ -67,14 +67,18 @@ type ScoreType string
 
 // ScoreType values.
 const (
-	ScoreTypeCVSSv2  ScoreType = "CVSSv2"
-	ScoreTypeCVSSv3  ScoreType = "CVSSv3"
-	ScoreTypeEPSSv1  ScoreType = "EPSSv1"
-	ScoreTypeEPSSv2  ScoreType = "EPSSv2"
-	ScoreTypeCVSSv31 ScoreType = "CVSSv31"
-	ScoreTypeCVSSv4  ScoreType = "CVSSv4"
-	ScoreTypeOWASP   ScoreType = "OWASP"
-	ScoreTypeSSVC    ScoreType = "SSVC"
+	ScoreTypeCVSSv2   ScoreType = "CVSSv2"
+	ScoreTypeCVSSv3   ScoreType = "CVSSv3"
+	ScoreTypeEPSSv1   ScoreType = "EPSSv1"
+	ScoreTypeEPSSv2   ScoreType = "EPSSv2"
+	ScoreTypeCVSSv31  ScoreType = "CVSSv31"
+	ScoreTypeCVSSv4   ScoreType = "CVSSv4"
+	ScoreTypeOWASP    ScoreType = "OWASP"
+	ScoreTypeSSVC     ScoreType = "SSVC"
+	ScoreTypeEPSSv3   ScoreType = "EPSSv3"
+	ScoreTypeEPSSv4   ScoreType = "EPSSv4"
+	ScoreTypeKEV      ScoreType = "KEV"
+	ScoreTypeSEVERITY ScoreType = "SEVERITY"
 )
 
 func (st ScoreType) String() string {
@@ -84,7 +88,7 @@ func (st ScoreType) String() string {
 // ScoreTypeValidator is a validator for the "score_type" field enum values. It is called by the builders before save.
 func ScoreTypeValidator(st ScoreType) error {
 	switch st {
-	case ScoreTypeCVSSv2, ScoreTypeCVSSv3, ScoreTypeEPSSv1, ScoreTypeEPSSv2, ScoreTypeCVSSv31, ScoreTypeCVSSv4, ScoreTypeOWASP, ScoreTypeSSVC:
+	case ScoreTypeCVSSv2, ScoreTypeCVSSv3, ScoreTypeEPSSv1, ScoreTypeEPSSv2, ScoreTypeCVSSv31, ScoreTypeCVSSv4, ScoreTypeOWASP, ScoreTypeSSVC, ScoreTypeEPSSv3, ScoreTypeEPSSv4, ScoreTypeKEV, ScoreTypeSEVERITY:
 		return nil
 	default:
 		return fmt.Errorf("vulnerabilitymetadata: invalid enum value for score_type field: %q", st)
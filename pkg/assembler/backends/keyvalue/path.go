@@ -90,6 +90,9 @@ func (c *demoClient) bfs(ctx context.Context, from, to string, maxLength int, al
 
 	found := false
 	for len(queue) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		now := queue[0]
 		queue = queue[1:]
 		nowNode := nodeMap[now]
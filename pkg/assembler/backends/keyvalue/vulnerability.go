@@ -81,7 +81,7 @@ func (n *vulnIDNode) Neighbors(allowedEdges edgeMap) []string {
 	if allowedEdges[model.EdgeVulnerabilityCertifyVexStatement] {
 		out = append(out, n.VexLinks...)
 	}
-	if allowedEdges[model.EdgeVulnMetadataVulnerability] {
+	if allowedEdges[model.EdgeVulnerabilityVulnMetadata] {
 		out = append(out, n.VulnMetadataLinks...)
 	}
 
@@ -0,0 +1,59 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyvalue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/guacsec/guac/pkg/assembler/graphql/model"
+)
+
+func TestVulnerabilityNeighborsVulnMetadata(t *testing.T) {
+	ctx := context.Background()
+	b, err := getBackend(ctx, nil)
+	if err != nil {
+		t.Fatalf("error creating backend: %v", err)
+	}
+
+	vuln := model.IDorVulnerabilityInput{VulnerabilityInput: &model.VulnerabilityInputSpec{Type: "cve", VulnerabilityID: "cve-2024-0001"}}
+	ids, err := b.IngestVulnerability(ctx, vuln)
+	if err != nil {
+		t.Fatalf("error ingesting vulnerability: %v", err)
+	}
+	metadataID, err := b.IngestVulnerabilityMetadata(ctx, vuln, model.VulnerabilityMetadataInputSpec{
+		ScoreType:  model.VulnerabilityScoreTypeCVSSv3,
+		ScoreValue: 9.8,
+		Timestamp:  time.Unix(1e9, 0),
+		Origin:     "test",
+		Collector:  "test",
+	})
+	if err != nil {
+		t.Fatalf("error ingesting vulnerability metadata: %v", err)
+	}
+
+	neighbors, err := b.Neighbors(ctx, ids.VulnerabilityNodeID, []model.Edge{model.EdgeVulnerabilityVulnMetadata})
+	if err != nil {
+		t.Fatalf("error getting neighbors: %v", err)
+	}
+	if len(neighbors) != 1 {
+		t.Fatalf("expected the vulnerability metadata as the only neighbor, got %v", neighbors)
+	}
+	if metadata, ok := neighbors[0].(*model.VulnerabilityMetadata); !ok || metadata.ID != metadataID {
+		t.Errorf("expected vulnerability metadata %s, got %v", metadataID, neighbors[0])
+	}
+}
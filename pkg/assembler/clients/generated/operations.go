@@ -7039,6 +7039,370 @@ type CertifyLegalsResponse struct {
 // GetCertifyLegal returns CertifyLegalsResponse.CertifyLegal, and is useful for accessing the field via an interface.
 func (v *CertifyLegalsResponse) GetCertifyLegal() []CertifyLegalsCertifyLegal { return v.CertifyLegal }
 
+// CertifyVEXStatementSpec allows filtering the list of VEX statements to
+// return in a query.
+//
+// Only one subject type (package or artifact) and one vulnerability may be specified.
+//
+// Note that setting noVuln vulnerability type is invalid for VEX statements!
+type CertifyVEXStatementSpec struct {
+	Id               *string                `json:"id"`
+	Subject          *PackageOrArtifactSpec `json:"subject"`
+	Vulnerability    *VulnerabilitySpec     `json:"vulnerability"`
+	Status           *VexStatus             `json:"status"`
+	VexJustification *VexJustification      `json:"vexJustification"`
+	Statement        *string                `json:"statement"`
+	StatusNotes      *string                `json:"statusNotes"`
+	KnownSince       *time.Time             `json:"knownSince"`
+	Origin           *string                `json:"origin"`
+	Collector        *string                `json:"collector"`
+	// asOf returns the VEX statements as they were known at the given time. For
+	// each subject and vulnerability, only the most recent statement known at or
+	// before asOf is returned, as newer statements supersede older ones.
+	AsOf *time.Time `json:"asOf"`
+}
+
+// GetId returns CertifyVEXStatementSpec.Id, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementSpec) GetId() *string { return v.Id }
+
+// GetSubject returns CertifyVEXStatementSpec.Subject, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementSpec) GetSubject() *PackageOrArtifactSpec { return v.Subject }
+
+// GetVulnerability returns CertifyVEXStatementSpec.Vulnerability, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementSpec) GetVulnerability() *VulnerabilitySpec { return v.Vulnerability }
+
+// GetStatus returns CertifyVEXStatementSpec.Status, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementSpec) GetStatus() *VexStatus { return v.Status }
+
+// GetVexJustification returns CertifyVEXStatementSpec.VexJustification, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementSpec) GetVexJustification() *VexJustification { return v.VexJustification }
+
+// GetStatement returns CertifyVEXStatementSpec.Statement, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementSpec) GetStatement() *string { return v.Statement }
+
+// GetStatusNotes returns CertifyVEXStatementSpec.StatusNotes, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementSpec) GetStatusNotes() *string { return v.StatusNotes }
+
+// GetKnownSince returns CertifyVEXStatementSpec.KnownSince, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementSpec) GetKnownSince() *time.Time { return v.KnownSince }
+
+// GetOrigin returns CertifyVEXStatementSpec.Origin, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementSpec) GetOrigin() *string { return v.Origin }
+
+// GetCollector returns CertifyVEXStatementSpec.Collector, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementSpec) GetCollector() *string { return v.Collector }
+
+// GetAsOf returns CertifyVEXStatementSpec.AsOf, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementSpec) GetAsOf() *time.Time { return v.AsOf }
+
+// CertifyVEXStatementsCertifyVEXStatement includes the requested fields of the GraphQL type CertifyVEXStatement.
+// The GraphQL type's documentation follows.
+//
+// CertifyVEXStatement is an attestation to attach VEX statements to a package or
+// artifact to clarify the impact of a specific vulnerability.
+type CertifyVEXStatementsCertifyVEXStatement struct {
+	AllCertifyVEXStatement `json:"-"`
+}
+
+// GetId returns CertifyVEXStatementsCertifyVEXStatement.Id, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementsCertifyVEXStatement) GetId() string { return v.AllCertifyVEXStatement.Id }
+
+// GetSubject returns CertifyVEXStatementsCertifyVEXStatement.Subject, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementsCertifyVEXStatement) GetSubject() AllCertifyVEXStatementSubjectPackageOrArtifact {
+	return v.AllCertifyVEXStatement.Subject
+}
+
+// GetVulnerability returns CertifyVEXStatementsCertifyVEXStatement.Vulnerability, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementsCertifyVEXStatement) GetVulnerability() AllCertifyVEXStatementVulnerability {
+	return v.AllCertifyVEXStatement.Vulnerability
+}
+
+// GetStatus returns CertifyVEXStatementsCertifyVEXStatement.Status, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementsCertifyVEXStatement) GetStatus() VexStatus {
+	return v.AllCertifyVEXStatement.Status
+}
+
+// GetVexJustification returns CertifyVEXStatementsCertifyVEXStatement.VexJustification, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementsCertifyVEXStatement) GetVexJustification() VexJustification {
+	return v.AllCertifyVEXStatement.VexJustification
+}
+
+// GetStatement returns CertifyVEXStatementsCertifyVEXStatement.Statement, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementsCertifyVEXStatement) GetStatement() string {
+	return v.AllCertifyVEXStatement.Statement
+}
+
+// GetStatusNotes returns CertifyVEXStatementsCertifyVEXStatement.StatusNotes, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementsCertifyVEXStatement) GetStatusNotes() string {
+	return v.AllCertifyVEXStatement.StatusNotes
+}
+
+// GetKnownSince returns CertifyVEXStatementsCertifyVEXStatement.KnownSince, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementsCertifyVEXStatement) GetKnownSince() time.Time {
+	return v.AllCertifyVEXStatement.KnownSince
+}
+
+// GetOrigin returns CertifyVEXStatementsCertifyVEXStatement.Origin, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementsCertifyVEXStatement) GetOrigin() string {
+	return v.AllCertifyVEXStatement.Origin
+}
+
+// GetCollector returns CertifyVEXStatementsCertifyVEXStatement.Collector, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementsCertifyVEXStatement) GetCollector() string {
+	return v.AllCertifyVEXStatement.Collector
+}
+
+func (v *CertifyVEXStatementsCertifyVEXStatement) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*CertifyVEXStatementsCertifyVEXStatement
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.CertifyVEXStatementsCertifyVEXStatement = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.AllCertifyVEXStatement)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalCertifyVEXStatementsCertifyVEXStatement struct {
+	Id string `json:"id"`
+
+	Subject json.RawMessage `json:"subject"`
+
+	Vulnerability AllCertifyVEXStatementVulnerability `json:"vulnerability"`
+
+	Status VexStatus `json:"status"`
+
+	VexJustification VexJustification `json:"vexJustification"`
+
+	Statement string `json:"statement"`
+
+	StatusNotes string `json:"statusNotes"`
+
+	KnownSince time.Time `json:"knownSince"`
+
+	Origin string `json:"origin"`
+
+	Collector string `json:"collector"`
+}
+
+func (v *CertifyVEXStatementsCertifyVEXStatement) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *CertifyVEXStatementsCertifyVEXStatement) __premarshalJSON() (*__premarshalCertifyVEXStatementsCertifyVEXStatement, error) {
+	var retval __premarshalCertifyVEXStatementsCertifyVEXStatement
+
+	retval.Id = v.AllCertifyVEXStatement.Id
+	{
+
+		dst := &retval.Subject
+		src := v.AllCertifyVEXStatement.Subject
+		var err error
+		*dst, err = __marshalAllCertifyVEXStatementSubjectPackageOrArtifact(
+			&src)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to marshal CertifyVEXStatementsCertifyVEXStatement.AllCertifyVEXStatement.Subject: %w", err)
+		}
+	}
+	retval.Vulnerability = v.AllCertifyVEXStatement.Vulnerability
+	retval.Status = v.AllCertifyVEXStatement.Status
+	retval.VexJustification = v.AllCertifyVEXStatement.VexJustification
+	retval.Statement = v.AllCertifyVEXStatement.Statement
+	retval.StatusNotes = v.AllCertifyVEXStatement.StatusNotes
+	retval.KnownSince = v.AllCertifyVEXStatement.KnownSince
+	retval.Origin = v.AllCertifyVEXStatement.Origin
+	retval.Collector = v.AllCertifyVEXStatement.Collector
+	return &retval, nil
+}
+
+// CertifyVEXStatementsResponse is returned by CertifyVEXStatements on success.
+type CertifyVEXStatementsResponse struct {
+	// Returns all VEX certifications matching the input filter.
+	CertifyVEXStatement []CertifyVEXStatementsCertifyVEXStatement `json:"CertifyVEXStatement"`
+}
+
+// GetCertifyVEXStatement returns CertifyVEXStatementsResponse.CertifyVEXStatement, and is useful for accessing the field via an interface.
+func (v *CertifyVEXStatementsResponse) GetCertifyVEXStatement() []CertifyVEXStatementsCertifyVEXStatement {
+	return v.CertifyVEXStatement
+}
+
+// CertifyVulnSpec allows filtering the list of vulnerability certifications to
+// return in a query.
+//
+// Specifying just the package allows to query for all vulnerabilities associated
+// with the package.
+//
+// Only one vulnerability (or NoVuln vulnerability type) may be
+// specified.
+type CertifyVulnSpec struct {
+	Id             *string            `json:"id"`
+	Package        *PkgSpec           `json:"package"`
+	Vulnerability  *VulnerabilitySpec `json:"vulnerability"`
+	TimeScanned    *time.Time         `json:"timeScanned"`
+	DbUri          *string            `json:"dbUri"`
+	DbVersion      *string            `json:"dbVersion"`
+	ScannerUri     *string            `json:"scannerUri"`
+	ScannerVersion *string            `json:"scannerVersion"`
+	Origin         *string            `json:"origin"`
+	Collector      *string            `json:"collector"`
+	// asOf returns the certifications as they were known at the given time. Only
+	// scans performed at or before asOf are returned.
+	AsOf *time.Time `json:"asOf"`
+}
+
+// GetId returns CertifyVulnSpec.Id, and is useful for accessing the field via an interface.
+func (v *CertifyVulnSpec) GetId() *string { return v.Id }
+
+// GetPackage returns CertifyVulnSpec.Package, and is useful for accessing the field via an interface.
+func (v *CertifyVulnSpec) GetPackage() *PkgSpec { return v.Package }
+
+// GetVulnerability returns CertifyVulnSpec.Vulnerability, and is useful for accessing the field via an interface.
+func (v *CertifyVulnSpec) GetVulnerability() *VulnerabilitySpec { return v.Vulnerability }
+
+// GetTimeScanned returns CertifyVulnSpec.TimeScanned, and is useful for accessing the field via an interface.
+func (v *CertifyVulnSpec) GetTimeScanned() *time.Time { return v.TimeScanned }
+
+// GetDbUri returns CertifyVulnSpec.DbUri, and is useful for accessing the field via an interface.
+func (v *CertifyVulnSpec) GetDbUri() *string { return v.DbUri }
+
+// GetDbVersion returns CertifyVulnSpec.DbVersion, and is useful for accessing the field via an interface.
+func (v *CertifyVulnSpec) GetDbVersion() *string { return v.DbVersion }
+
+// GetScannerUri returns CertifyVulnSpec.ScannerUri, and is useful for accessing the field via an interface.
+func (v *CertifyVulnSpec) GetScannerUri() *string { return v.ScannerUri }
+
+// GetScannerVersion returns CertifyVulnSpec.ScannerVersion, and is useful for accessing the field via an interface.
+func (v *CertifyVulnSpec) GetScannerVersion() *string { return v.ScannerVersion }
+
+// GetOrigin returns CertifyVulnSpec.Origin, and is useful for accessing the field via an interface.
+func (v *CertifyVulnSpec) GetOrigin() *string { return v.Origin }
+
+// GetCollector returns CertifyVulnSpec.Collector, and is useful for accessing the field via an interface.
+func (v *CertifyVulnSpec) GetCollector() *string { return v.Collector }
+
+// GetAsOf returns CertifyVulnSpec.AsOf, and is useful for accessing the field via an interface.
+func (v *CertifyVulnSpec) GetAsOf() *time.Time { return v.AsOf }
+
+// CertifyVulnsCertifyVuln includes the requested fields of the GraphQL type CertifyVuln.
+// The GraphQL type's documentation follows.
+//
+// CertifyVuln is an attestation to attach vulnerability information to a package.
+//
+// This information is obtained via a scanner. If there is no vulnerability
+// detected, we attach the a vulnerability with "NoVuln" type and an empty string
+// for the vulnerability ID.
+type CertifyVulnsCertifyVuln struct {
+	AllCertifyVuln `json:"-"`
+}
+
+// GetId returns CertifyVulnsCertifyVuln.Id, and is useful for accessing the field via an interface.
+func (v *CertifyVulnsCertifyVuln) GetId() string { return v.AllCertifyVuln.Id }
+
+// GetPackage returns CertifyVulnsCertifyVuln.Package, and is useful for accessing the field via an interface.
+func (v *CertifyVulnsCertifyVuln) GetPackage() AllCertifyVulnPackage { return v.AllCertifyVuln.Package }
+
+// GetVulnerability returns CertifyVulnsCertifyVuln.Vulnerability, and is useful for accessing the field via an interface.
+func (v *CertifyVulnsCertifyVuln) GetVulnerability() AllCertifyVulnVulnerability {
+	return v.AllCertifyVuln.Vulnerability
+}
+
+// GetMetadata returns CertifyVulnsCertifyVuln.Metadata, and is useful for accessing the field via an interface.
+func (v *CertifyVulnsCertifyVuln) GetMetadata() AllCertifyVulnMetadataScanMetadata {
+	return v.AllCertifyVuln.Metadata
+}
+
+func (v *CertifyVulnsCertifyVuln) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*CertifyVulnsCertifyVuln
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.CertifyVulnsCertifyVuln = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.AllCertifyVuln)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalCertifyVulnsCertifyVuln struct {
+	Id string `json:"id"`
+
+	Package AllCertifyVulnPackage `json:"package"`
+
+	Vulnerability AllCertifyVulnVulnerability `json:"vulnerability"`
+
+	Metadata AllCertifyVulnMetadataScanMetadata `json:"metadata"`
+}
+
+func (v *CertifyVulnsCertifyVuln) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *CertifyVulnsCertifyVuln) __premarshalJSON() (*__premarshalCertifyVulnsCertifyVuln, error) {
+	var retval __premarshalCertifyVulnsCertifyVuln
+
+	retval.Id = v.AllCertifyVuln.Id
+	retval.Package = v.AllCertifyVuln.Package
+	retval.Vulnerability = v.AllCertifyVuln.Vulnerability
+	retval.Metadata = v.AllCertifyVuln.Metadata
+	return &retval, nil
+}
+
+// CertifyVulnsResponse is returned by CertifyVulns on success.
+type CertifyVulnsResponse struct {
+	// Returns all vulnerability certifications matching the input filter.
+	CertifyVuln []CertifyVulnsCertifyVuln `json:"CertifyVuln"`
+}
+
+// GetCertifyVuln returns CertifyVulnsResponse.CertifyVuln, and is useful for accessing the field via an interface.
+func (v *CertifyVulnsResponse) GetCertifyVuln() []CertifyVulnsCertifyVuln { return v.CertifyVuln }
+
+// The Comparator is used by the vulnerability score filter on ranges
+type Comparator string
+
+const (
+	ComparatorGreater      Comparator = "GREATER"
+	ComparatorEqual        Comparator = "EQUAL"
+	ComparatorLess         Comparator = "LESS"
+	ComparatorGreaterEqual Comparator = "GREATER_EQUAL"
+	ComparatorLessEqual    Comparator = "LESS_EQUAL"
+)
+
 // DependenciesIsDependency includes the requested fields of the GraphQL type IsDependency.
 // The GraphQL type's documentation follows.
 //
@@ -7826,6 +8190,9 @@ type HasSBOMSpec struct {
 	IncludedSoftware     []PackageOrArtifactSpec `json:"includedSoftware"`
 	IncludedDependencies []IsDependencySpec      `json:"includedDependencies"`
 	IncludedOccurrences  []IsOccurrenceSpec      `json:"includedOccurrences"`
+	// asOf returns the SBOMs as they were known at the given time. Only SBOMs known
+	// at or before asOf are returned.
+	AsOf *time.Time `json:"asOf"`
 }
 
 // GetId returns HasSBOMSpec.Id, and is useful for accessing the field via an interface.
@@ -7864,6 +8231,9 @@ func (v *HasSBOMSpec) GetIncludedDependencies() []IsDependencySpec { return v.In
 // GetIncludedOccurrences returns HasSBOMSpec.IncludedOccurrences, and is useful for accessing the field via an interface.
 func (v *HasSBOMSpec) GetIncludedOccurrences() []IsOccurrenceSpec { return v.IncludedOccurrences }
 
+// GetAsOf returns HasSBOMSpec.AsOf, and is useful for accessing the field via an interface.
+func (v *HasSBOMSpec) GetAsOf() *time.Time { return v.AsOf }
+
 // HasSBOMsHasSBOM includes the requested fields of the GraphQL type HasSBOM.
 type HasSBOMsHasSBOM struct {
 	AllHasSBOMTree `json:"-"`
@@ -12194,6 +12564,10 @@ type NeighborsResponse struct {
 	//
 	// Specifying any Edge value in `usingOnly` will make the neighbors list only
 	// contain the corresponding GUAC evidence trees (GUAC verbs).
+	//
+	// Specifying `asOf` returns the neighbors as they were known at the given time.
+	// Evidence trees recorded after asOf are excluded and, for each subject and
+	// vulnerability, only the most recent VEX statement known at asOf is kept.
 	Neighbors []NeighborsNeighborsNode `json:"-"`
 }
 
@@ -21638,6 +22012,11 @@ type PathResponse struct {
 	//
 	// Specifying any Edge value in `usingOnly` will make the path only contain the
 	// corresponding GUAC evidence trees (GUAC verbs).
+	//
+	// Specifying `asOf` returns the path as it was known at the given time. The
+	// graph is traversed through the neighbors of each node as returned by
+	// `neighbors` with the same asOf, so the path does not go through evidence
+	// recorded later or VEX statements superseded by then.
 	Path []PathPathNode `json:"-"`
 }
 
@@ -21853,40 +22232,1277 @@ func (v *PointOfContactInputSpec) GetOrigin() string { return v.Origin }
 // GetCollector returns PointOfContactInputSpec.Collector, and is useful for accessing the field via an interface.
 func (v *PointOfContactInputSpec) GetCollector() string { return v.Collector }
 
-// SLSAInputSpec is the same as SLSA but for mutation input.
-type SLSAInputSpec struct {
-	BuildType     string                   `json:"buildType"`
-	SlsaPredicate []SLSAPredicateInputSpec `json:"slsaPredicate"`
-	SlsaVersion   string                   `json:"slsaVersion"`
-	StartedOn     *time.Time               `json:"startedOn"`
-	FinishedOn    *time.Time               `json:"finishedOn"`
-	Origin        string                   `json:"origin"`
-	Collector     string                   `json:"collector"`
+// SBOMDiffHasSBOM includes the GraphQL fields of HasSBOM requested by the fragment SBOMDiffHasSBOM.
+type SBOMDiffHasSBOM struct {
+	Id string `json:"id"`
+	// Identifier for the SBOM document
+	Uri string `json:"uri"`
+	// Algorithm by which SBOMs digest was computed
+	Algorithm string `json:"algorithm"`
+	// Digest of SBOM
+	Digest string `json:"digest"`
+	// Timestamp for SBOM creation
+	KnownSince time.Time `json:"knownSince"`
 }
 
-// GetBuildType returns SLSAInputSpec.BuildType, and is useful for accessing the field via an interface.
-func (v *SLSAInputSpec) GetBuildType() string { return v.BuildType }
+// GetId returns SBOMDiffHasSBOM.Id, and is useful for accessing the field via an interface.
+func (v *SBOMDiffHasSBOM) GetId() string { return v.Id }
 
-// GetSlsaPredicate returns SLSAInputSpec.SlsaPredicate, and is useful for accessing the field via an interface.
-func (v *SLSAInputSpec) GetSlsaPredicate() []SLSAPredicateInputSpec { return v.SlsaPredicate }
+// GetUri returns SBOMDiffHasSBOM.Uri, and is useful for accessing the field via an interface.
+func (v *SBOMDiffHasSBOM) GetUri() string { return v.Uri }
 
-// GetSlsaVersion returns SLSAInputSpec.SlsaVersion, and is useful for accessing the field via an interface.
-func (v *SLSAInputSpec) GetSlsaVersion() string { return v.SlsaVersion }
+// GetAlgorithm returns SBOMDiffHasSBOM.Algorithm, and is useful for accessing the field via an interface.
+func (v *SBOMDiffHasSBOM) GetAlgorithm() string { return v.Algorithm }
 
-// GetStartedOn returns SLSAInputSpec.StartedOn, and is useful for accessing the field via an interface.
-func (v *SLSAInputSpec) GetStartedOn() *time.Time { return v.StartedOn }
+// GetDigest returns SBOMDiffHasSBOM.Digest, and is useful for accessing the field via an interface.
+func (v *SBOMDiffHasSBOM) GetDigest() string { return v.Digest }
 
-// GetFinishedOn returns SLSAInputSpec.FinishedOn, and is useful for accessing the field via an interface.
-func (v *SLSAInputSpec) GetFinishedOn() *time.Time { return v.FinishedOn }
+// GetKnownSince returns SBOMDiffHasSBOM.KnownSince, and is useful for accessing the field via an interface.
+func (v *SBOMDiffHasSBOM) GetKnownSince() time.Time { return v.KnownSince }
 
-// GetOrigin returns SLSAInputSpec.Origin, and is useful for accessing the field via an interface.
-func (v *SLSAInputSpec) GetOrigin() string { return v.Origin }
+// SBOMDiffResponse is returned by SBOMDiff on success.
+type SBOMDiffResponse struct {
+	// sbomDiff compares the target SBOM to the base SBOM.
+	//
+	// Both base and target are either HasSBOM IDs or packageVersion IDs. For a
+	// packageVersion, its most recent HasSBOM is used.
+	SbomDiff SBOMDiffSbomDiffSBOMDiff `json:"sbomDiff"`
+}
 
-// GetCollector returns SLSAInputSpec.Collector, and is useful for accessing the field via an interface.
-func (v *SLSAInputSpec) GetCollector() string { return v.Collector }
+// GetSbomDiff returns SBOMDiffResponse.SbomDiff, and is useful for accessing the field via an interface.
+func (v *SBOMDiffResponse) GetSbomDiff() SBOMDiffSbomDiffSBOMDiff { return v.SbomDiff }
 
-// SLSAPredicateInputSpec allows ingesting SLSAPredicateSpec.
-type SLSAPredicateInputSpec struct {
+// SBOMDiffSbomDiffSBOMDiff includes the requested fields of the GraphQL type SBOMDiff.
+// The GraphQL type's documentation follows.
+//
+// SBOMDiff is the difference between a base and a target SBOM.
+//
+// The packages of an SBOM are collected from its includedSoftware,
+// includedDependencies and includedOccurrences. Packages of both SBOMs are
+// matched by type, namespace and name.
+type SBOMDiffSbomDiffSBOMDiff struct {
+	// SBOM that is compared against
+	Base SBOMDiffSbomDiffSBOMDiffBaseHasSBOM `json:"base"`
+	// SBOM that is compared to the base
+	Target SBOMDiffSbomDiffSBOMDiffTargetHasSBOM `json:"target"`
+	// Package versions whose package is only included in the target SBOM
+	AddedPackages []SBOMDiffSbomDiffSBOMDiffAddedPackagesPackage `json:"addedPackages"`
+	// Package versions whose package is only included in the base SBOM
+	RemovedPackages []SBOMDiffSbomDiffSBOMDiffRemovedPackagesPackage `json:"removedPackages"`
+	// Packages included in both SBOMs at a different version
+	ChangedPackages []SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChange `json:"changedPackages"`
+	// Vulnerabilities that only affect packages of the target SBOM
+	NewVulnerabilities []SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChange `json:"newVulnerabilities"`
+	// Vulnerabilities that only affect packages of the base SBOM
+	FixedVulnerabilities []SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChange `json:"fixedVulnerabilities"`
+	// Packages included in both SBOMs with a different license
+	LicenseChanges []SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChange `json:"licenseChanges"`
+}
+
+// GetBase returns SBOMDiffSbomDiffSBOMDiff.Base, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiff) GetBase() SBOMDiffSbomDiffSBOMDiffBaseHasSBOM { return v.Base }
+
+// GetTarget returns SBOMDiffSbomDiffSBOMDiff.Target, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiff) GetTarget() SBOMDiffSbomDiffSBOMDiffTargetHasSBOM { return v.Target }
+
+// GetAddedPackages returns SBOMDiffSbomDiffSBOMDiff.AddedPackages, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiff) GetAddedPackages() []SBOMDiffSbomDiffSBOMDiffAddedPackagesPackage {
+	return v.AddedPackages
+}
+
+// GetRemovedPackages returns SBOMDiffSbomDiffSBOMDiff.RemovedPackages, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiff) GetRemovedPackages() []SBOMDiffSbomDiffSBOMDiffRemovedPackagesPackage {
+	return v.RemovedPackages
+}
+
+// GetChangedPackages returns SBOMDiffSbomDiffSBOMDiff.ChangedPackages, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiff) GetChangedPackages() []SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChange {
+	return v.ChangedPackages
+}
+
+// GetNewVulnerabilities returns SBOMDiffSbomDiffSBOMDiff.NewVulnerabilities, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiff) GetNewVulnerabilities() []SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChange {
+	return v.NewVulnerabilities
+}
+
+// GetFixedVulnerabilities returns SBOMDiffSbomDiffSBOMDiff.FixedVulnerabilities, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiff) GetFixedVulnerabilities() []SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChange {
+	return v.FixedVulnerabilities
+}
+
+// GetLicenseChanges returns SBOMDiffSbomDiffSBOMDiff.LicenseChanges, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiff) GetLicenseChanges() []SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChange {
+	return v.LicenseChanges
+}
+
+// SBOMDiffSbomDiffSBOMDiffAddedPackagesPackage includes the requested fields of the GraphQL type Package.
+// The GraphQL type's documentation follows.
+//
+// Package represents the root of the package trie/tree.
+//
+// We map package information to a trie, closely matching the pURL specification
+// (https://github.com/package-url/purl-spec/blob/0dd92f26f8bb11956ffdf5e8acfcee71e8560407/README.rst),
+// but deviating from it where GUAC heuristics allow for better representation of
+// package information. Each path in the trie fully represents a package; we split
+// the trie based on the pURL components.
+//
+// This node matches a pkg:<type> partial pURL. The type field matches the
+// pURL types but we might also use "guac" for the cases where the pURL
+// representation is not complete or when we have custom rules.
+//
+// Since this node is at the root of the package trie, it is named Package, not
+// PackageType.
+type SBOMDiffSbomDiffSBOMDiffAddedPackagesPackage struct {
+	AllPkgTree `json:"-"`
+}
+
+// GetId returns SBOMDiffSbomDiffSBOMDiffAddedPackagesPackage.Id, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffAddedPackagesPackage) GetId() string { return v.AllPkgTree.Id }
+
+// GetType returns SBOMDiffSbomDiffSBOMDiffAddedPackagesPackage.Type, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffAddedPackagesPackage) GetType() string { return v.AllPkgTree.Type }
+
+// GetNamespaces returns SBOMDiffSbomDiffSBOMDiffAddedPackagesPackage.Namespaces, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffAddedPackagesPackage) GetNamespaces() []AllPkgTreeNamespacesPackageNamespace {
+	return v.AllPkgTree.Namespaces
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffAddedPackagesPackage) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*SBOMDiffSbomDiffSBOMDiffAddedPackagesPackage
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.SBOMDiffSbomDiffSBOMDiffAddedPackagesPackage = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.AllPkgTree)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalSBOMDiffSbomDiffSBOMDiffAddedPackagesPackage struct {
+	Id string `json:"id"`
+
+	Type string `json:"type"`
+
+	Namespaces []AllPkgTreeNamespacesPackageNamespace `json:"namespaces"`
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffAddedPackagesPackage) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffAddedPackagesPackage) __premarshalJSON() (*__premarshalSBOMDiffSbomDiffSBOMDiffAddedPackagesPackage, error) {
+	var retval __premarshalSBOMDiffSbomDiffSBOMDiffAddedPackagesPackage
+
+	retval.Id = v.AllPkgTree.Id
+	retval.Type = v.AllPkgTree.Type
+	retval.Namespaces = v.AllPkgTree.Namespaces
+	return &retval, nil
+}
+
+// SBOMDiffSbomDiffSBOMDiffBaseHasSBOM includes the requested fields of the GraphQL type HasSBOM.
+type SBOMDiffSbomDiffSBOMDiffBaseHasSBOM struct {
+	SBOMDiffHasSBOM `json:"-"`
+}
+
+// GetId returns SBOMDiffSbomDiffSBOMDiffBaseHasSBOM.Id, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffBaseHasSBOM) GetId() string { return v.SBOMDiffHasSBOM.Id }
+
+// GetUri returns SBOMDiffSbomDiffSBOMDiffBaseHasSBOM.Uri, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffBaseHasSBOM) GetUri() string { return v.SBOMDiffHasSBOM.Uri }
+
+// GetAlgorithm returns SBOMDiffSbomDiffSBOMDiffBaseHasSBOM.Algorithm, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffBaseHasSBOM) GetAlgorithm() string {
+	return v.SBOMDiffHasSBOM.Algorithm
+}
+
+// GetDigest returns SBOMDiffSbomDiffSBOMDiffBaseHasSBOM.Digest, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffBaseHasSBOM) GetDigest() string { return v.SBOMDiffHasSBOM.Digest }
+
+// GetKnownSince returns SBOMDiffSbomDiffSBOMDiffBaseHasSBOM.KnownSince, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffBaseHasSBOM) GetKnownSince() time.Time {
+	return v.SBOMDiffHasSBOM.KnownSince
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffBaseHasSBOM) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*SBOMDiffSbomDiffSBOMDiffBaseHasSBOM
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.SBOMDiffSbomDiffSBOMDiffBaseHasSBOM = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.SBOMDiffHasSBOM)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalSBOMDiffSbomDiffSBOMDiffBaseHasSBOM struct {
+	Id string `json:"id"`
+
+	Uri string `json:"uri"`
+
+	Algorithm string `json:"algorithm"`
+
+	Digest string `json:"digest"`
+
+	KnownSince time.Time `json:"knownSince"`
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffBaseHasSBOM) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffBaseHasSBOM) __premarshalJSON() (*__premarshalSBOMDiffSbomDiffSBOMDiffBaseHasSBOM, error) {
+	var retval __premarshalSBOMDiffSbomDiffSBOMDiffBaseHasSBOM
+
+	retval.Id = v.SBOMDiffHasSBOM.Id
+	retval.Uri = v.SBOMDiffHasSBOM.Uri
+	retval.Algorithm = v.SBOMDiffHasSBOM.Algorithm
+	retval.Digest = v.SBOMDiffHasSBOM.Digest
+	retval.KnownSince = v.SBOMDiffHasSBOM.KnownSince
+	return &retval, nil
+}
+
+// SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChange includes the requested fields of the GraphQL type PackageChange.
+// The GraphQL type's documentation follows.
+//
+// PackageChange is a package that is included in both SBOMs at a different version.
+type SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChange struct {
+	// Package version in the base SBOM
+	Base SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeBasePackage `json:"base"`
+	// Package version in the target SBOM
+	Target SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeTargetPackage `json:"target"`
+}
+
+// GetBase returns SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChange.Base, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChange) GetBase() SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeBasePackage {
+	return v.Base
+}
+
+// GetTarget returns SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChange.Target, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChange) GetTarget() SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeTargetPackage {
+	return v.Target
+}
+
+// SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeBasePackage includes the requested fields of the GraphQL type Package.
+// The GraphQL type's documentation follows.
+//
+// Package represents the root of the package trie/tree.
+//
+// We map package information to a trie, closely matching the pURL specification
+// (https://github.com/package-url/purl-spec/blob/0dd92f26f8bb11956ffdf5e8acfcee71e8560407/README.rst),
+// but deviating from it where GUAC heuristics allow for better representation of
+// package information. Each path in the trie fully represents a package; we split
+// the trie based on the pURL components.
+//
+// This node matches a pkg:<type> partial pURL. The type field matches the
+// pURL types but we might also use "guac" for the cases where the pURL
+// representation is not complete or when we have custom rules.
+//
+// Since this node is at the root of the package trie, it is named Package, not
+// PackageType.
+type SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeBasePackage struct {
+	AllPkgTree `json:"-"`
+}
+
+// GetId returns SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeBasePackage.Id, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeBasePackage) GetId() string {
+	return v.AllPkgTree.Id
+}
+
+// GetType returns SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeBasePackage.Type, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeBasePackage) GetType() string {
+	return v.AllPkgTree.Type
+}
+
+// GetNamespaces returns SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeBasePackage.Namespaces, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeBasePackage) GetNamespaces() []AllPkgTreeNamespacesPackageNamespace {
+	return v.AllPkgTree.Namespaces
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeBasePackage) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeBasePackage
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeBasePackage = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.AllPkgTree)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalSBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeBasePackage struct {
+	Id string `json:"id"`
+
+	Type string `json:"type"`
+
+	Namespaces []AllPkgTreeNamespacesPackageNamespace `json:"namespaces"`
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeBasePackage) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeBasePackage) __premarshalJSON() (*__premarshalSBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeBasePackage, error) {
+	var retval __premarshalSBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeBasePackage
+
+	retval.Id = v.AllPkgTree.Id
+	retval.Type = v.AllPkgTree.Type
+	retval.Namespaces = v.AllPkgTree.Namespaces
+	return &retval, nil
+}
+
+// SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeTargetPackage includes the requested fields of the GraphQL type Package.
+// The GraphQL type's documentation follows.
+//
+// Package represents the root of the package trie/tree.
+//
+// We map package information to a trie, closely matching the pURL specification
+// (https://github.com/package-url/purl-spec/blob/0dd92f26f8bb11956ffdf5e8acfcee71e8560407/README.rst),
+// but deviating from it where GUAC heuristics allow for better representation of
+// package information. Each path in the trie fully represents a package; we split
+// the trie based on the pURL components.
+//
+// This node matches a pkg:<type> partial pURL. The type field matches the
+// pURL types but we might also use "guac" for the cases where the pURL
+// representation is not complete or when we have custom rules.
+//
+// Since this node is at the root of the package trie, it is named Package, not
+// PackageType.
+type SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeTargetPackage struct {
+	AllPkgTree `json:"-"`
+}
+
+// GetId returns SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeTargetPackage.Id, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeTargetPackage) GetId() string {
+	return v.AllPkgTree.Id
+}
+
+// GetType returns SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeTargetPackage.Type, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeTargetPackage) GetType() string {
+	return v.AllPkgTree.Type
+}
+
+// GetNamespaces returns SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeTargetPackage.Namespaces, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeTargetPackage) GetNamespaces() []AllPkgTreeNamespacesPackageNamespace {
+	return v.AllPkgTree.Namespaces
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeTargetPackage) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeTargetPackage
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeTargetPackage = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.AllPkgTree)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalSBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeTargetPackage struct {
+	Id string `json:"id"`
+
+	Type string `json:"type"`
+
+	Namespaces []AllPkgTreeNamespacesPackageNamespace `json:"namespaces"`
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeTargetPackage) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeTargetPackage) __premarshalJSON() (*__premarshalSBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeTargetPackage, error) {
+	var retval __premarshalSBOMDiffSbomDiffSBOMDiffChangedPackagesPackageChangeTargetPackage
+
+	retval.Id = v.AllPkgTree.Id
+	retval.Type = v.AllPkgTree.Type
+	retval.Namespaces = v.AllPkgTree.Namespaces
+	return &retval, nil
+}
+
+// SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChange includes the requested fields of the GraphQL type VulnerabilityChange.
+// The GraphQL type's documentation follows.
+//
+// VulnerabilityChange is a vulnerability that is only found in one of the SBOMs.
+type SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChange struct {
+	Vulnerability SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangeVulnerability `json:"vulnerability"`
+	// Package version of the SBOM that is affected by the vulnerability
+	Package SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangePackage `json:"package"`
+}
+
+// GetVulnerability returns SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChange.Vulnerability, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChange) GetVulnerability() SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangeVulnerability {
+	return v.Vulnerability
+}
+
+// GetPackage returns SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChange.Package, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChange) GetPackage() SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangePackage {
+	return v.Package
+}
+
+// SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangePackage includes the requested fields of the GraphQL type Package.
+// The GraphQL type's documentation follows.
+//
+// Package represents the root of the package trie/tree.
+//
+// We map package information to a trie, closely matching the pURL specification
+// (https://github.com/package-url/purl-spec/blob/0dd92f26f8bb11956ffdf5e8acfcee71e8560407/README.rst),
+// but deviating from it where GUAC heuristics allow for better representation of
+// package information. Each path in the trie fully represents a package; we split
+// the trie based on the pURL components.
+//
+// This node matches a pkg:<type> partial pURL. The type field matches the
+// pURL types but we might also use "guac" for the cases where the pURL
+// representation is not complete or when we have custom rules.
+//
+// Since this node is at the root of the package trie, it is named Package, not
+// PackageType.
+type SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangePackage struct {
+	AllPkgTree `json:"-"`
+}
+
+// GetId returns SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangePackage.Id, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangePackage) GetId() string {
+	return v.AllPkgTree.Id
+}
+
+// GetType returns SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangePackage.Type, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangePackage) GetType() string {
+	return v.AllPkgTree.Type
+}
+
+// GetNamespaces returns SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangePackage.Namespaces, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangePackage) GetNamespaces() []AllPkgTreeNamespacesPackageNamespace {
+	return v.AllPkgTree.Namespaces
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangePackage) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangePackage
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangePackage = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.AllPkgTree)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalSBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangePackage struct {
+	Id string `json:"id"`
+
+	Type string `json:"type"`
+
+	Namespaces []AllPkgTreeNamespacesPackageNamespace `json:"namespaces"`
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangePackage) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangePackage) __premarshalJSON() (*__premarshalSBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangePackage, error) {
+	var retval __premarshalSBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangePackage
+
+	retval.Id = v.AllPkgTree.Id
+	retval.Type = v.AllPkgTree.Type
+	retval.Namespaces = v.AllPkgTree.Namespaces
+	return &retval, nil
+}
+
+// SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangeVulnerability includes the requested fields of the GraphQL type Vulnerability.
+// The GraphQL type's documentation follows.
+//
+// Vulnerability represents the root of the vulnerability trie/tree.
+//
+// We map vulnerability information to a trie, as a derivative of the pURL specification:
+// each path in the trie represents a type and a vulnerability ID. This allows for generic
+// representation of the various vulnerabilities and does not limit to just cve, ghsa or osv.
+// This would be in the general format: vuln://<general-type>/<vuln-id>
+//
+// Examples:
+//
+// CVE, using path separator: vuln://cve/cve-2023-20753
+// OSV, representing its knowledge of a GHSA: vuln://osv/ghsa-205hk
+// Random vendor: vuln://snyk/sn-whatever
+// NoVuln: vuln://novuln/
+//
+// This node represents the type part of the trie path. It is used to represent
+// the specific type of the vulnerability: cve, ghsa, osv or some other vendor specific
+//
+// Since this node is at the root of the vulnerability trie, it is named Vulnerability, not
+// VulnerabilityType.
+//
+// NoVuln is a special vulnerability node to attest that no vulnerability has been
+// found during a vulnerability scan. It will have the type "novuln" and contain an empty string
+// for vulnerabilityID
+//
+// The resolvers will enforce that both the type and vulnerability IDs are lower case.
+type SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangeVulnerability struct {
+	AllVulnerabilityTree `json:"-"`
+}
+
+// GetId returns SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangeVulnerability.Id, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangeVulnerability) GetId() string {
+	return v.AllVulnerabilityTree.Id
+}
+
+// GetType returns SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangeVulnerability.Type, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangeVulnerability) GetType() string {
+	return v.AllVulnerabilityTree.Type
+}
+
+// GetVulnerabilityIDs returns SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangeVulnerability.VulnerabilityIDs, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangeVulnerability) GetVulnerabilityIDs() []AllVulnerabilityTreeVulnerabilityIDsVulnerabilityID {
+	return v.AllVulnerabilityTree.VulnerabilityIDs
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangeVulnerability) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangeVulnerability
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangeVulnerability = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.AllVulnerabilityTree)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalSBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangeVulnerability struct {
+	Id string `json:"id"`
+
+	Type string `json:"type"`
+
+	VulnerabilityIDs []AllVulnerabilityTreeVulnerabilityIDsVulnerabilityID `json:"vulnerabilityIDs"`
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangeVulnerability) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangeVulnerability) __premarshalJSON() (*__premarshalSBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangeVulnerability, error) {
+	var retval __premarshalSBOMDiffSbomDiffSBOMDiffFixedVulnerabilitiesVulnerabilityChangeVulnerability
+
+	retval.Id = v.AllVulnerabilityTree.Id
+	retval.Type = v.AllVulnerabilityTree.Type
+	retval.VulnerabilityIDs = v.AllVulnerabilityTree.VulnerabilityIDs
+	return &retval, nil
+}
+
+// SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChange includes the requested fields of the GraphQL type LicenseChange.
+// The GraphQL type's documentation follows.
+//
+// LicenseChange is a package that is included in both SBOMs with a different
+// license.
+//
+// The license of a package version is the declared license of its most recent
+// CertifyLegal, or the discovered license if none was declared.
+type SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChange struct {
+	// Package version in the base SBOM
+	Base SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeBasePackage `json:"base"`
+	// Package version in the target SBOM
+	Target SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeTargetPackage `json:"target"`
+	// License of the package version in the base SBOM
+	BaseLicense string `json:"baseLicense"`
+	// License of the package version in the target SBOM
+	TargetLicense string `json:"targetLicense"`
+}
+
+// GetBase returns SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChange.Base, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChange) GetBase() SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeBasePackage {
+	return v.Base
+}
+
+// GetTarget returns SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChange.Target, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChange) GetTarget() SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeTargetPackage {
+	return v.Target
+}
+
+// GetBaseLicense returns SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChange.BaseLicense, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChange) GetBaseLicense() string {
+	return v.BaseLicense
+}
+
+// GetTargetLicense returns SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChange.TargetLicense, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChange) GetTargetLicense() string {
+	return v.TargetLicense
+}
+
+// SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeBasePackage includes the requested fields of the GraphQL type Package.
+// The GraphQL type's documentation follows.
+//
+// Package represents the root of the package trie/tree.
+//
+// We map package information to a trie, closely matching the pURL specification
+// (https://github.com/package-url/purl-spec/blob/0dd92f26f8bb11956ffdf5e8acfcee71e8560407/README.rst),
+// but deviating from it where GUAC heuristics allow for better representation of
+// package information. Each path in the trie fully represents a package; we split
+// the trie based on the pURL components.
+//
+// This node matches a pkg:<type> partial pURL. The type field matches the
+// pURL types but we might also use "guac" for the cases where the pURL
+// representation is not complete or when we have custom rules.
+//
+// Since this node is at the root of the package trie, it is named Package, not
+// PackageType.
+type SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeBasePackage struct {
+	AllPkgTree `json:"-"`
+}
+
+// GetId returns SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeBasePackage.Id, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeBasePackage) GetId() string {
+	return v.AllPkgTree.Id
+}
+
+// GetType returns SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeBasePackage.Type, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeBasePackage) GetType() string {
+	return v.AllPkgTree.Type
+}
+
+// GetNamespaces returns SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeBasePackage.Namespaces, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeBasePackage) GetNamespaces() []AllPkgTreeNamespacesPackageNamespace {
+	return v.AllPkgTree.Namespaces
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeBasePackage) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeBasePackage
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeBasePackage = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.AllPkgTree)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalSBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeBasePackage struct {
+	Id string `json:"id"`
+
+	Type string `json:"type"`
+
+	Namespaces []AllPkgTreeNamespacesPackageNamespace `json:"namespaces"`
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeBasePackage) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeBasePackage) __premarshalJSON() (*__premarshalSBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeBasePackage, error) {
+	var retval __premarshalSBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeBasePackage
+
+	retval.Id = v.AllPkgTree.Id
+	retval.Type = v.AllPkgTree.Type
+	retval.Namespaces = v.AllPkgTree.Namespaces
+	return &retval, nil
+}
+
+// SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeTargetPackage includes the requested fields of the GraphQL type Package.
+// The GraphQL type's documentation follows.
+//
+// Package represents the root of the package trie/tree.
+//
+// We map package information to a trie, closely matching the pURL specification
+// (https://github.com/package-url/purl-spec/blob/0dd92f26f8bb11956ffdf5e8acfcee71e8560407/README.rst),
+// but deviating from it where GUAC heuristics allow for better representation of
+// package information. Each path in the trie fully represents a package; we split
+// the trie based on the pURL components.
+//
+// This node matches a pkg:<type> partial pURL. The type field matches the
+// pURL types but we might also use "guac" for the cases where the pURL
+// representation is not complete or when we have custom rules.
+//
+// Since this node is at the root of the package trie, it is named Package, not
+// PackageType.
+type SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeTargetPackage struct {
+	AllPkgTree `json:"-"`
+}
+
+// GetId returns SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeTargetPackage.Id, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeTargetPackage) GetId() string {
+	return v.AllPkgTree.Id
+}
+
+// GetType returns SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeTargetPackage.Type, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeTargetPackage) GetType() string {
+	return v.AllPkgTree.Type
+}
+
+// GetNamespaces returns SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeTargetPackage.Namespaces, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeTargetPackage) GetNamespaces() []AllPkgTreeNamespacesPackageNamespace {
+	return v.AllPkgTree.Namespaces
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeTargetPackage) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeTargetPackage
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeTargetPackage = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.AllPkgTree)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalSBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeTargetPackage struct {
+	Id string `json:"id"`
+
+	Type string `json:"type"`
+
+	Namespaces []AllPkgTreeNamespacesPackageNamespace `json:"namespaces"`
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeTargetPackage) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeTargetPackage) __premarshalJSON() (*__premarshalSBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeTargetPackage, error) {
+	var retval __premarshalSBOMDiffSbomDiffSBOMDiffLicenseChangesLicenseChangeTargetPackage
+
+	retval.Id = v.AllPkgTree.Id
+	retval.Type = v.AllPkgTree.Type
+	retval.Namespaces = v.AllPkgTree.Namespaces
+	return &retval, nil
+}
+
+// SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChange includes the requested fields of the GraphQL type VulnerabilityChange.
+// The GraphQL type's documentation follows.
+//
+// VulnerabilityChange is a vulnerability that is only found in one of the SBOMs.
+type SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChange struct {
+	Vulnerability SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangeVulnerability `json:"vulnerability"`
+	// Package version of the SBOM that is affected by the vulnerability
+	Package SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangePackage `json:"package"`
+}
+
+// GetVulnerability returns SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChange.Vulnerability, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChange) GetVulnerability() SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangeVulnerability {
+	return v.Vulnerability
+}
+
+// GetPackage returns SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChange.Package, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChange) GetPackage() SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangePackage {
+	return v.Package
+}
+
+// SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangePackage includes the requested fields of the GraphQL type Package.
+// The GraphQL type's documentation follows.
+//
+// Package represents the root of the package trie/tree.
+//
+// We map package information to a trie, closely matching the pURL specification
+// (https://github.com/package-url/purl-spec/blob/0dd92f26f8bb11956ffdf5e8acfcee71e8560407/README.rst),
+// but deviating from it where GUAC heuristics allow for better representation of
+// package information. Each path in the trie fully represents a package; we split
+// the trie based on the pURL components.
+//
+// This node matches a pkg:<type> partial pURL. The type field matches the
+// pURL types but we might also use "guac" for the cases where the pURL
+// representation is not complete or when we have custom rules.
+//
+// Since this node is at the root of the package trie, it is named Package, not
+// PackageType.
+type SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangePackage struct {
+	AllPkgTree `json:"-"`
+}
+
+// GetId returns SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangePackage.Id, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangePackage) GetId() string {
+	return v.AllPkgTree.Id
+}
+
+// GetType returns SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangePackage.Type, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangePackage) GetType() string {
+	return v.AllPkgTree.Type
+}
+
+// GetNamespaces returns SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangePackage.Namespaces, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangePackage) GetNamespaces() []AllPkgTreeNamespacesPackageNamespace {
+	return v.AllPkgTree.Namespaces
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangePackage) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangePackage
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangePackage = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.AllPkgTree)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalSBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangePackage struct {
+	Id string `json:"id"`
+
+	Type string `json:"type"`
+
+	Namespaces []AllPkgTreeNamespacesPackageNamespace `json:"namespaces"`
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangePackage) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangePackage) __premarshalJSON() (*__premarshalSBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangePackage, error) {
+	var retval __premarshalSBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangePackage
+
+	retval.Id = v.AllPkgTree.Id
+	retval.Type = v.AllPkgTree.Type
+	retval.Namespaces = v.AllPkgTree.Namespaces
+	return &retval, nil
+}
+
+// SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangeVulnerability includes the requested fields of the GraphQL type Vulnerability.
+// The GraphQL type's documentation follows.
+//
+// Vulnerability represents the root of the vulnerability trie/tree.
+//
+// We map vulnerability information to a trie, as a derivative of the pURL specification:
+// each path in the trie represents a type and a vulnerability ID. This allows for generic
+// representation of the various vulnerabilities and does not limit to just cve, ghsa or osv.
+// This would be in the general format: vuln://<general-type>/<vuln-id>
+//
+// Examples:
+//
+// CVE, using path separator: vuln://cve/cve-2023-20753
+// OSV, representing its knowledge of a GHSA: vuln://osv/ghsa-205hk
+// Random vendor: vuln://snyk/sn-whatever
+// NoVuln: vuln://novuln/
+//
+// This node represents the type part of the trie path. It is used to represent
+// the specific type of the vulnerability: cve, ghsa, osv or some other vendor specific
+//
+// Since this node is at the root of the vulnerability trie, it is named Vulnerability, not
+// VulnerabilityType.
+//
+// NoVuln is a special vulnerability node to attest that no vulnerability has been
+// found during a vulnerability scan. It will have the type "novuln" and contain an empty string
+// for vulnerabilityID
+//
+// The resolvers will enforce that both the type and vulnerability IDs are lower case.
+type SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangeVulnerability struct {
+	AllVulnerabilityTree `json:"-"`
+}
+
+// GetId returns SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangeVulnerability.Id, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangeVulnerability) GetId() string {
+	return v.AllVulnerabilityTree.Id
+}
+
+// GetType returns SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangeVulnerability.Type, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangeVulnerability) GetType() string {
+	return v.AllVulnerabilityTree.Type
+}
+
+// GetVulnerabilityIDs returns SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangeVulnerability.VulnerabilityIDs, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangeVulnerability) GetVulnerabilityIDs() []AllVulnerabilityTreeVulnerabilityIDsVulnerabilityID {
+	return v.AllVulnerabilityTree.VulnerabilityIDs
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangeVulnerability) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangeVulnerability
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangeVulnerability = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.AllVulnerabilityTree)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalSBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangeVulnerability struct {
+	Id string `json:"id"`
+
+	Type string `json:"type"`
+
+	VulnerabilityIDs []AllVulnerabilityTreeVulnerabilityIDsVulnerabilityID `json:"vulnerabilityIDs"`
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangeVulnerability) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangeVulnerability) __premarshalJSON() (*__premarshalSBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangeVulnerability, error) {
+	var retval __premarshalSBOMDiffSbomDiffSBOMDiffNewVulnerabilitiesVulnerabilityChangeVulnerability
+
+	retval.Id = v.AllVulnerabilityTree.Id
+	retval.Type = v.AllVulnerabilityTree.Type
+	retval.VulnerabilityIDs = v.AllVulnerabilityTree.VulnerabilityIDs
+	return &retval, nil
+}
+
+// SBOMDiffSbomDiffSBOMDiffRemovedPackagesPackage includes the requested fields of the GraphQL type Package.
+// The GraphQL type's documentation follows.
+//
+// Package represents the root of the package trie/tree.
+//
+// We map package information to a trie, closely matching the pURL specification
+// (https://github.com/package-url/purl-spec/blob/0dd92f26f8bb11956ffdf5e8acfcee71e8560407/README.rst),
+// but deviating from it where GUAC heuristics allow for better representation of
+// package information. Each path in the trie fully represents a package; we split
+// the trie based on the pURL components.
+//
+// This node matches a pkg:<type> partial pURL. The type field matches the
+// pURL types but we might also use "guac" for the cases where the pURL
+// representation is not complete or when we have custom rules.
+//
+// Since this node is at the root of the package trie, it is named Package, not
+// PackageType.
+type SBOMDiffSbomDiffSBOMDiffRemovedPackagesPackage struct {
+	AllPkgTree `json:"-"`
+}
+
+// GetId returns SBOMDiffSbomDiffSBOMDiffRemovedPackagesPackage.Id, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffRemovedPackagesPackage) GetId() string { return v.AllPkgTree.Id }
+
+// GetType returns SBOMDiffSbomDiffSBOMDiffRemovedPackagesPackage.Type, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffRemovedPackagesPackage) GetType() string { return v.AllPkgTree.Type }
+
+// GetNamespaces returns SBOMDiffSbomDiffSBOMDiffRemovedPackagesPackage.Namespaces, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffRemovedPackagesPackage) GetNamespaces() []AllPkgTreeNamespacesPackageNamespace {
+	return v.AllPkgTree.Namespaces
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffRemovedPackagesPackage) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*SBOMDiffSbomDiffSBOMDiffRemovedPackagesPackage
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.SBOMDiffSbomDiffSBOMDiffRemovedPackagesPackage = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.AllPkgTree)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalSBOMDiffSbomDiffSBOMDiffRemovedPackagesPackage struct {
+	Id string `json:"id"`
+
+	Type string `json:"type"`
+
+	Namespaces []AllPkgTreeNamespacesPackageNamespace `json:"namespaces"`
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffRemovedPackagesPackage) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffRemovedPackagesPackage) __premarshalJSON() (*__premarshalSBOMDiffSbomDiffSBOMDiffRemovedPackagesPackage, error) {
+	var retval __premarshalSBOMDiffSbomDiffSBOMDiffRemovedPackagesPackage
+
+	retval.Id = v.AllPkgTree.Id
+	retval.Type = v.AllPkgTree.Type
+	retval.Namespaces = v.AllPkgTree.Namespaces
+	return &retval, nil
+}
+
+// SBOMDiffSbomDiffSBOMDiffTargetHasSBOM includes the requested fields of the GraphQL type HasSBOM.
+type SBOMDiffSbomDiffSBOMDiffTargetHasSBOM struct {
+	SBOMDiffHasSBOM `json:"-"`
+}
+
+// GetId returns SBOMDiffSbomDiffSBOMDiffTargetHasSBOM.Id, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffTargetHasSBOM) GetId() string { return v.SBOMDiffHasSBOM.Id }
+
+// GetUri returns SBOMDiffSbomDiffSBOMDiffTargetHasSBOM.Uri, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffTargetHasSBOM) GetUri() string { return v.SBOMDiffHasSBOM.Uri }
+
+// GetAlgorithm returns SBOMDiffSbomDiffSBOMDiffTargetHasSBOM.Algorithm, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffTargetHasSBOM) GetAlgorithm() string {
+	return v.SBOMDiffHasSBOM.Algorithm
+}
+
+// GetDigest returns SBOMDiffSbomDiffSBOMDiffTargetHasSBOM.Digest, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffTargetHasSBOM) GetDigest() string { return v.SBOMDiffHasSBOM.Digest }
+
+// GetKnownSince returns SBOMDiffSbomDiffSBOMDiffTargetHasSBOM.KnownSince, and is useful for accessing the field via an interface.
+func (v *SBOMDiffSbomDiffSBOMDiffTargetHasSBOM) GetKnownSince() time.Time {
+	return v.SBOMDiffHasSBOM.KnownSince
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffTargetHasSBOM) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*SBOMDiffSbomDiffSBOMDiffTargetHasSBOM
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.SBOMDiffSbomDiffSBOMDiffTargetHasSBOM = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.SBOMDiffHasSBOM)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalSBOMDiffSbomDiffSBOMDiffTargetHasSBOM struct {
+	Id string `json:"id"`
+
+	Uri string `json:"uri"`
+
+	Algorithm string `json:"algorithm"`
+
+	Digest string `json:"digest"`
+
+	KnownSince time.Time `json:"knownSince"`
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffTargetHasSBOM) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *SBOMDiffSbomDiffSBOMDiffTargetHasSBOM) __premarshalJSON() (*__premarshalSBOMDiffSbomDiffSBOMDiffTargetHasSBOM, error) {
+	var retval __premarshalSBOMDiffSbomDiffSBOMDiffTargetHasSBOM
+
+	retval.Id = v.SBOMDiffHasSBOM.Id
+	retval.Uri = v.SBOMDiffHasSBOM.Uri
+	retval.Algorithm = v.SBOMDiffHasSBOM.Algorithm
+	retval.Digest = v.SBOMDiffHasSBOM.Digest
+	retval.KnownSince = v.SBOMDiffHasSBOM.KnownSince
+	return &retval, nil
+}
+
+// SLSAInputSpec is the same as SLSA but for mutation input.
+type SLSAInputSpec struct {
+	BuildType     string                   `json:"buildType"`
+	SlsaPredicate []SLSAPredicateInputSpec `json:"slsaPredicate"`
+	SlsaVersion   string                   `json:"slsaVersion"`
+	StartedOn     *time.Time               `json:"startedOn"`
+	FinishedOn    *time.Time               `json:"finishedOn"`
+	Origin        string                   `json:"origin"`
+	Collector     string                   `json:"collector"`
+}
+
+// GetBuildType returns SLSAInputSpec.BuildType, and is useful for accessing the field via an interface.
+func (v *SLSAInputSpec) GetBuildType() string { return v.BuildType }
+
+// GetSlsaPredicate returns SLSAInputSpec.SlsaPredicate, and is useful for accessing the field via an interface.
+func (v *SLSAInputSpec) GetSlsaPredicate() []SLSAPredicateInputSpec { return v.SlsaPredicate }
+
+// GetSlsaVersion returns SLSAInputSpec.SlsaVersion, and is useful for accessing the field via an interface.
+func (v *SLSAInputSpec) GetSlsaVersion() string { return v.SlsaVersion }
+
+// GetStartedOn returns SLSAInputSpec.StartedOn, and is useful for accessing the field via an interface.
+func (v *SLSAInputSpec) GetStartedOn() *time.Time { return v.StartedOn }
+
+// GetFinishedOn returns SLSAInputSpec.FinishedOn, and is useful for accessing the field via an interface.
+func (v *SLSAInputSpec) GetFinishedOn() *time.Time { return v.FinishedOn }
+
+// GetOrigin returns SLSAInputSpec.Origin, and is useful for accessing the field via an interface.
+func (v *SLSAInputSpec) GetOrigin() string { return v.Origin }
+
+// GetCollector returns SLSAInputSpec.Collector, and is useful for accessing the field via an interface.
+func (v *SLSAInputSpec) GetCollector() string { return v.Collector }
+
+// SLSAPredicateInputSpec allows ingesting SLSAPredicateSpec.
+type SLSAPredicateInputSpec struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
 }
@@ -22284,12 +23900,223 @@ func (v *VulnerabilitiesVulnerabilitiesVulnerability) UnmarshalJSON(b []byte) er
 type __premarshalVulnerabilitiesVulnerabilitiesVulnerability struct {
 	Id string `json:"id"`
 
-	Type string `json:"type"`
+	Type string `json:"type"`
+
+	VulnerabilityIDs []AllVulnerabilityTreeVulnerabilityIDsVulnerabilityID `json:"vulnerabilityIDs"`
+}
+
+func (v *VulnerabilitiesVulnerabilitiesVulnerability) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *VulnerabilitiesVulnerabilitiesVulnerability) __premarshalJSON() (*__premarshalVulnerabilitiesVulnerabilitiesVulnerability, error) {
+	var retval __premarshalVulnerabilitiesVulnerabilitiesVulnerability
+
+	retval.Id = v.AllVulnerabilityTree.Id
+	retval.Type = v.AllVulnerabilityTree.Type
+	retval.VulnerabilityIDs = v.AllVulnerabilityTree.VulnerabilityIDs
+	return &retval, nil
+}
+
+// VulnInputSpec specifies a vulnerability for mutations.
+//
+// This is different than VulnSpec because we want to encode mandatory fields:
+// type and vulnerabilityID.
+type VulnerabilityInputSpec struct {
+	Type            string `json:"type"`
+	VulnerabilityID string `json:"vulnerabilityID"`
+}
+
+// GetType returns VulnerabilityInputSpec.Type, and is useful for accessing the field via an interface.
+func (v *VulnerabilityInputSpec) GetType() string { return v.Type }
+
+// GetVulnerabilityID returns VulnerabilityInputSpec.VulnerabilityID, and is useful for accessing the field via an interface.
+func (v *VulnerabilityInputSpec) GetVulnerabilityID() string { return v.VulnerabilityID }
+
+// VulnerabilityMetadataInputSpec represents the mutation input to ingest a vulnerability metadata.
+type VulnerabilityMetadataInputSpec struct {
+	ScoreType  VulnerabilityScoreType `json:"scoreType"`
+	ScoreValue float64                `json:"scoreValue"`
+	Timestamp  time.Time              `json:"timestamp"`
+	Origin     string                 `json:"origin"`
+	Collector  string                 `json:"collector"`
+}
+
+// GetScoreType returns VulnerabilityMetadataInputSpec.ScoreType, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataInputSpec) GetScoreType() VulnerabilityScoreType { return v.ScoreType }
+
+// GetScoreValue returns VulnerabilityMetadataInputSpec.ScoreValue, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataInputSpec) GetScoreValue() float64 { return v.ScoreValue }
+
+// GetTimestamp returns VulnerabilityMetadataInputSpec.Timestamp, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataInputSpec) GetTimestamp() time.Time { return v.Timestamp }
+
+// GetOrigin returns VulnerabilityMetadataInputSpec.Origin, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataInputSpec) GetOrigin() string { return v.Origin }
+
+// GetCollector returns VulnerabilityMetadataInputSpec.Collector, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataInputSpec) GetCollector() string { return v.Collector }
+
+// VulnerabilityMetadataResponse is returned by VulnerabilityMetadata on success.
+type VulnerabilityMetadataResponse struct {
+	// Returns all vulnerabilityMetadata attestations matching a filter.
+	VulnerabilityMetadata []VulnerabilityMetadataVulnerabilityMetadata `json:"vulnerabilityMetadata"`
+}
+
+// GetVulnerabilityMetadata returns VulnerabilityMetadataResponse.VulnerabilityMetadata, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataResponse) GetVulnerabilityMetadata() []VulnerabilityMetadataVulnerabilityMetadata {
+	return v.VulnerabilityMetadata
+}
+
+// VulnerabilityMetadataSpec allows filtering the list of VulnerabilityMetadata evidence
+// to return in a query.
+//
+// Comparator field is an enum that be set to filter the score and return a
+// range that matches. If the comparator is not specified, it will default to equal operation.
+//
+// Timestamp specified indicates filtering timestamps after the specified time
+type VulnerabilityMetadataSpec struct {
+	Id            *string                 `json:"id"`
+	Vulnerability *VulnerabilitySpec      `json:"vulnerability"`
+	ScoreType     *VulnerabilityScoreType `json:"scoreType"`
+	ScoreValue    *float64                `json:"scoreValue"`
+	Comparator    *Comparator             `json:"comparator"`
+	Timestamp     *time.Time              `json:"timestamp"`
+	Origin        *string                 `json:"origin"`
+	Collector     *string                 `json:"collector"`
+}
+
+// GetId returns VulnerabilityMetadataSpec.Id, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataSpec) GetId() *string { return v.Id }
+
+// GetVulnerability returns VulnerabilityMetadataSpec.Vulnerability, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataSpec) GetVulnerability() *VulnerabilitySpec { return v.Vulnerability }
+
+// GetScoreType returns VulnerabilityMetadataSpec.ScoreType, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataSpec) GetScoreType() *VulnerabilityScoreType { return v.ScoreType }
+
+// GetScoreValue returns VulnerabilityMetadataSpec.ScoreValue, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataSpec) GetScoreValue() *float64 { return v.ScoreValue }
+
+// GetComparator returns VulnerabilityMetadataSpec.Comparator, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataSpec) GetComparator() *Comparator { return v.Comparator }
+
+// GetTimestamp returns VulnerabilityMetadataSpec.Timestamp, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataSpec) GetTimestamp() *time.Time { return v.Timestamp }
+
+// GetOrigin returns VulnerabilityMetadataSpec.Origin, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataSpec) GetOrigin() *string { return v.Origin }
+
+// GetCollector returns VulnerabilityMetadataSpec.Collector, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataSpec) GetCollector() *string { return v.Collector }
+
+// VulnerabilityMetadataVulnerabilityMetadata includes the requested fields of the GraphQL type VulnerabilityMetadata.
+// The GraphQL type's documentation follows.
+//
+// VulnerabilityMetadata is an attestation that a vulnerability has a related score
+// associated with it.
+//
+// The intent of this evidence tree predicate is to allow extensibility of vulnerability
+// score (one-to-one mapping) with a specific vulnerability ID.
+//
+// A vulnerability ID can have a one-to-many relationship with the VulnerabilityMetadata
+// node as a vulnerability ID can have multiple scores (in various frameworks).
+//
+// Examples:
+//
+// scoreType: EPSSv1
+// scoreValue: 0.960760000
+//
+// scoreType: CVSSv2
+// scoreValue: 5.0
+//
+// scoreType: CVSSv3
+// scoreValue: 7.5
+//
+// The timestamp is used to determine when the score was evaluated for the specific vulnerability.
+type VulnerabilityMetadataVulnerabilityMetadata struct {
+	AllVulnMetadataTree `json:"-"`
+}
+
+// GetId returns VulnerabilityMetadataVulnerabilityMetadata.Id, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataVulnerabilityMetadata) GetId() string { return v.AllVulnMetadataTree.Id }
+
+// GetVulnerability returns VulnerabilityMetadataVulnerabilityMetadata.Vulnerability, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataVulnerabilityMetadata) GetVulnerability() AllVulnMetadataTreeVulnerability {
+	return v.AllVulnMetadataTree.Vulnerability
+}
+
+// GetScoreType returns VulnerabilityMetadataVulnerabilityMetadata.ScoreType, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataVulnerabilityMetadata) GetScoreType() VulnerabilityScoreType {
+	return v.AllVulnMetadataTree.ScoreType
+}
+
+// GetScoreValue returns VulnerabilityMetadataVulnerabilityMetadata.ScoreValue, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataVulnerabilityMetadata) GetScoreValue() float64 {
+	return v.AllVulnMetadataTree.ScoreValue
+}
+
+// GetTimestamp returns VulnerabilityMetadataVulnerabilityMetadata.Timestamp, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataVulnerabilityMetadata) GetTimestamp() time.Time {
+	return v.AllVulnMetadataTree.Timestamp
+}
+
+// GetOrigin returns VulnerabilityMetadataVulnerabilityMetadata.Origin, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataVulnerabilityMetadata) GetOrigin() string {
+	return v.AllVulnMetadataTree.Origin
+}
+
+// GetCollector returns VulnerabilityMetadataVulnerabilityMetadata.Collector, and is useful for accessing the field via an interface.
+func (v *VulnerabilityMetadataVulnerabilityMetadata) GetCollector() string {
+	return v.AllVulnMetadataTree.Collector
+}
+
+func (v *VulnerabilityMetadataVulnerabilityMetadata) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*VulnerabilityMetadataVulnerabilityMetadata
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.VulnerabilityMetadataVulnerabilityMetadata = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.AllVulnMetadataTree)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalVulnerabilityMetadataVulnerabilityMetadata struct {
+	Id string `json:"id"`
+
+	Vulnerability AllVulnMetadataTreeVulnerability `json:"vulnerability"`
+
+	ScoreType VulnerabilityScoreType `json:"scoreType"`
+
+	ScoreValue float64 `json:"scoreValue"`
+
+	Timestamp time.Time `json:"timestamp"`
+
+	Origin string `json:"origin"`
 
-	VulnerabilityIDs []AllVulnerabilityTreeVulnerabilityIDsVulnerabilityID `json:"vulnerabilityIDs"`
+	Collector string `json:"collector"`
 }
 
-func (v *VulnerabilitiesVulnerabilitiesVulnerability) MarshalJSON() ([]byte, error) {
+func (v *VulnerabilityMetadataVulnerabilityMetadata) MarshalJSON() ([]byte, error) {
 	premarshaled, err := v.__premarshalJSON()
 	if err != nil {
 		return nil, err
@@ -22297,54 +24124,19 @@ func (v *VulnerabilitiesVulnerabilitiesVulnerability) MarshalJSON() ([]byte, err
 	return json.Marshal(premarshaled)
 }
 
-func (v *VulnerabilitiesVulnerabilitiesVulnerability) __premarshalJSON() (*__premarshalVulnerabilitiesVulnerabilitiesVulnerability, error) {
-	var retval __premarshalVulnerabilitiesVulnerabilitiesVulnerability
+func (v *VulnerabilityMetadataVulnerabilityMetadata) __premarshalJSON() (*__premarshalVulnerabilityMetadataVulnerabilityMetadata, error) {
+	var retval __premarshalVulnerabilityMetadataVulnerabilityMetadata
 
-	retval.Id = v.AllVulnerabilityTree.Id
-	retval.Type = v.AllVulnerabilityTree.Type
-	retval.VulnerabilityIDs = v.AllVulnerabilityTree.VulnerabilityIDs
+	retval.Id = v.AllVulnMetadataTree.Id
+	retval.Vulnerability = v.AllVulnMetadataTree.Vulnerability
+	retval.ScoreType = v.AllVulnMetadataTree.ScoreType
+	retval.ScoreValue = v.AllVulnMetadataTree.ScoreValue
+	retval.Timestamp = v.AllVulnMetadataTree.Timestamp
+	retval.Origin = v.AllVulnMetadataTree.Origin
+	retval.Collector = v.AllVulnMetadataTree.Collector
 	return &retval, nil
 }
 
-// VulnInputSpec specifies a vulnerability for mutations.
-//
-// This is different than VulnSpec because we want to encode mandatory fields:
-// type and vulnerabilityID.
-type VulnerabilityInputSpec struct {
-	Type            string `json:"type"`
-	VulnerabilityID string `json:"vulnerabilityID"`
-}
-
-// GetType returns VulnerabilityInputSpec.Type, and is useful for accessing the field via an interface.
-func (v *VulnerabilityInputSpec) GetType() string { return v.Type }
-
-// GetVulnerabilityID returns VulnerabilityInputSpec.VulnerabilityID, and is useful for accessing the field via an interface.
-func (v *VulnerabilityInputSpec) GetVulnerabilityID() string { return v.VulnerabilityID }
-
-// VulnerabilityMetadataInputSpec represents the mutation input to ingest a vulnerability metadata.
-type VulnerabilityMetadataInputSpec struct {
-	ScoreType  VulnerabilityScoreType `json:"scoreType"`
-	ScoreValue float64                `json:"scoreValue"`
-	Timestamp  time.Time              `json:"timestamp"`
-	Origin     string                 `json:"origin"`
-	Collector  string                 `json:"collector"`
-}
-
-// GetScoreType returns VulnerabilityMetadataInputSpec.ScoreType, and is useful for accessing the field via an interface.
-func (v *VulnerabilityMetadataInputSpec) GetScoreType() VulnerabilityScoreType { return v.ScoreType }
-
-// GetScoreValue returns VulnerabilityMetadataInputSpec.ScoreValue, and is useful for accessing the field via an interface.
-func (v *VulnerabilityMetadataInputSpec) GetScoreValue() float64 { return v.ScoreValue }
-
-// GetTimestamp returns VulnerabilityMetadataInputSpec.Timestamp, and is useful for accessing the field via an interface.
-func (v *VulnerabilityMetadataInputSpec) GetTimestamp() time.Time { return v.Timestamp }
-
-// GetOrigin returns VulnerabilityMetadataInputSpec.Origin, and is useful for accessing the field via an interface.
-func (v *VulnerabilityMetadataInputSpec) GetOrigin() string { return v.Origin }
-
-// GetCollector returns VulnerabilityMetadataInputSpec.Collector, and is useful for accessing the field via an interface.
-func (v *VulnerabilityMetadataInputSpec) GetCollector() string { return v.Collector }
-
 // Records the type of the score being captured by the score node
 type VulnerabilityScoreType string
 
@@ -22357,6 +24149,14 @@ const (
 	VulnerabilityScoreTypeCvssv4  VulnerabilityScoreType = "CVSSv4"
 	VulnerabilityScoreTypeOwasp   VulnerabilityScoreType = "OWASP"
 	VulnerabilityScoreTypeSsvc    VulnerabilityScoreType = "SSVC"
+	VulnerabilityScoreTypeEpssv3  VulnerabilityScoreType = "EPSSv3"
+	VulnerabilityScoreTypeEpssv4  VulnerabilityScoreType = "EPSSv4"
+	// Listed in the CISA Known Exploited Vulnerabilities catalog, the score is 1
+	VulnerabilityScoreTypeKev VulnerabilityScoreType = "KEV"
+	// Qualitative severity rated by a source without a CVSS score, such as a distro
+	// feed: 0 for none or negligible, 1 for low, 2 for medium, 3 for high and 4 for
+	// critical
+	VulnerabilityScoreTypeSeverity VulnerabilityScoreType = "SEVERITY"
 )
 
 // VulnerabilitySpec allows filtering the list of vulnerabilities to return in a query.
@@ -22413,6 +24213,22 @@ type __CertifyLegalsInput struct {
 // GetFilter returns __CertifyLegalsInput.Filter, and is useful for accessing the field via an interface.
 func (v *__CertifyLegalsInput) GetFilter() CertifyLegalSpec { return v.Filter }
 
+// __CertifyVEXStatementsInput is used internally by genqlient
+type __CertifyVEXStatementsInput struct {
+	Filter CertifyVEXStatementSpec `json:"filter"`
+}
+
+// GetFilter returns __CertifyVEXStatementsInput.Filter, and is useful for accessing the field via an interface.
+func (v *__CertifyVEXStatementsInput) GetFilter() CertifyVEXStatementSpec { return v.Filter }
+
+// __CertifyVulnsInput is used internally by genqlient
+type __CertifyVulnsInput struct {
+	Filter CertifyVulnSpec `json:"filter"`
+}
+
+// GetFilter returns __CertifyVulnsInput.Filter, and is useful for accessing the field via an interface.
+func (v *__CertifyVulnsInput) GetFilter() CertifyVulnSpec { return v.Filter }
+
 // __DependenciesInput is used internally by genqlient
 type __DependenciesInput struct {
 	Filter IsDependencySpec `json:"filter"`
@@ -23603,6 +25419,18 @@ func (v *__PathInput) GetMaxPathLength() int { return v.MaxPathLength }
 // GetUsingOnly returns __PathInput.UsingOnly, and is useful for accessing the field via an interface.
 func (v *__PathInput) GetUsingOnly() []Edge { return v.UsingOnly }
 
+// __SBOMDiffInput is used internally by genqlient
+type __SBOMDiffInput struct {
+	Base   string `json:"base"`
+	Target string `json:"target"`
+}
+
+// GetBase returns __SBOMDiffInput.Base, and is useful for accessing the field via an interface.
+func (v *__SBOMDiffInput) GetBase() string { return v.Base }
+
+// GetTarget returns __SBOMDiffInput.Target, and is useful for accessing the field via an interface.
+func (v *__SBOMDiffInput) GetTarget() string { return v.Target }
+
 // __SourcesInput is used internally by genqlient
 type __SourcesInput struct {
 	Filter SourceSpec `json:"filter"`
@@ -23619,6 +25447,14 @@ type __VulnerabilitiesInput struct {
 // GetFilter returns __VulnerabilitiesInput.Filter, and is useful for accessing the field via an interface.
 func (v *__VulnerabilitiesInput) GetFilter() VulnerabilitySpec { return v.Filter }
 
+// __VulnerabilityMetadataInput is used internally by genqlient
+type __VulnerabilityMetadataInput struct {
+	Filter VulnerabilityMetadataSpec `json:"filter"`
+}
+
+// GetFilter returns __VulnerabilityMetadataInput.Filter, and is useful for accessing the field via an interface.
+func (v *__VulnerabilityMetadataInput) GetFilter() VulnerabilityMetadataSpec { return v.Filter }
+
 // The query or mutation executed by Artifacts.
 const Artifacts_Operation = `
 query Artifacts ($filter: ArtifactSpec!) {
@@ -23626,28 +25462,223 @@ query Artifacts ($filter: ArtifactSpec!) {
 		... AllArtifactTree
 	}
 }
-fragment AllArtifactTree on Artifact {
+fragment AllArtifactTree on Artifact {
+	id
+	algorithm
+	digest
+}
+`
+
+func Artifacts(
+	ctx context.Context,
+	client graphql.Client,
+	filter ArtifactSpec,
+) (*ArtifactsResponse, error) {
+	req := &graphql.Request{
+		OpName: "Artifacts",
+		Query:  Artifacts_Operation,
+		Variables: &__ArtifactsInput{
+			Filter: filter,
+		},
+	}
+	var err error
+
+	var data ArtifactsResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+// The query or mutation executed by CertifyBads.
+const CertifyBads_Operation = `
+query CertifyBads ($filter: CertifyBadSpec!) {
+	CertifyBad(certifyBadSpec: $filter) {
+		... AllCertifyBad
+	}
+}
+fragment AllCertifyBad on CertifyBad {
+	id
+	justification
+	knownSince
+	subject {
+		__typename
+		... on Package {
+			... AllPkgTree
+		}
+		... on Source {
+			... AllSourceTree
+		}
+		... on Artifact {
+			... AllArtifactTree
+		}
+	}
+	origin
+	collector
+}
+fragment AllPkgTree on Package {
+	id
+	type
+	namespaces {
+		id
+		namespace
+		names {
+			id
+			name
+			versions {
+				id
+				version
+				qualifiers {
+					key
+					value
+				}
+				subpath
+			}
+		}
+	}
+}
+fragment AllSourceTree on Source {
+	id
+	type
+	namespaces {
+		id
+		namespace
+		names {
+			id
+			name
+			tag
+			commit
+		}
+	}
+}
+fragment AllArtifactTree on Artifact {
+	id
+	algorithm
+	digest
+}
+`
+
+func CertifyBads(
+	ctx context.Context,
+	client graphql.Client,
+	filter CertifyBadSpec,
+) (*CertifyBadsResponse, error) {
+	req := &graphql.Request{
+		OpName: "CertifyBads",
+		Query:  CertifyBads_Operation,
+		Variables: &__CertifyBadsInput{
+			Filter: filter,
+		},
+	}
+	var err error
+
+	var data CertifyBadsResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+// The query or mutation executed by CertifyLegals.
+const CertifyLegals_Operation = `
+query CertifyLegals ($filter: CertifyLegalSpec!) {
+	CertifyLegal(certifyLegalSpec: $filter) {
+		... AllCertifyLegalTree
+	}
+}
+fragment AllCertifyLegalTree on CertifyLegal {
+	id
+	subject {
+		__typename
+		... on Package {
+			... AllPkgTree
+		}
+		... on Source {
+			... AllSourceTree
+		}
+	}
+	declaredLicense
+	declaredLicenses {
+		... AllLicenseTree
+	}
+	discoveredLicense
+	discoveredLicenses {
+		... AllLicenseTree
+	}
+	attribution
+	justification
+	timeScanned
+	origin
+	collector
+}
+fragment AllPkgTree on Package {
+	id
+	type
+	namespaces {
+		id
+		namespace
+		names {
+			id
+			name
+			versions {
+				id
+				version
+				qualifiers {
+					key
+					value
+				}
+				subpath
+			}
+		}
+	}
+}
+fragment AllSourceTree on Source {
+	id
+	type
+	namespaces {
+		id
+		namespace
+		names {
+			id
+			name
+			tag
+			commit
+		}
+	}
+}
+fragment AllLicenseTree on License {
 	id
-	algorithm
-	digest
+	name
+	inline
+	listVersion
 }
 `
 
-func Artifacts(
+func CertifyLegals(
 	ctx context.Context,
 	client graphql.Client,
-	filter ArtifactSpec,
-) (*ArtifactsResponse, error) {
+	filter CertifyLegalSpec,
+) (*CertifyLegalsResponse, error) {
 	req := &graphql.Request{
-		OpName: "Artifacts",
-		Query:  Artifacts_Operation,
-		Variables: &__ArtifactsInput{
+		OpName: "CertifyLegals",
+		Query:  CertifyLegals_Operation,
+		Variables: &__CertifyLegalsInput{
 			Filter: filter,
 		},
 	}
 	var err error
 
-	var data ArtifactsResponse
+	var data CertifyLegalsResponse
 	resp := &graphql.Response{Data: &data}
 
 	err = client.MakeRequest(
@@ -23659,29 +25690,32 @@ func Artifacts(
 	return &data, err
 }
 
-// The query or mutation executed by CertifyBads.
-const CertifyBads_Operation = `
-query CertifyBads ($filter: CertifyBadSpec!) {
-	CertifyBad(certifyBadSpec: $filter) {
-		... AllCertifyBad
+// The query or mutation executed by CertifyVEXStatements.
+const CertifyVEXStatements_Operation = `
+query CertifyVEXStatements ($filter: CertifyVEXStatementSpec!) {
+	CertifyVEXStatement(certifyVEXStatementSpec: $filter) {
+		... AllCertifyVEXStatement
 	}
 }
-fragment AllCertifyBad on CertifyBad {
+fragment AllCertifyVEXStatement on CertifyVEXStatement {
 	id
-	justification
-	knownSince
 	subject {
 		__typename
 		... on Package {
 			... AllPkgTree
 		}
-		... on Source {
-			... AllSourceTree
-		}
 		... on Artifact {
 			... AllArtifactTree
 		}
 	}
+	vulnerability {
+		... AllVulnerabilityTree
+	}
+	status
+	vexJustification
+	statement
+	statusNotes
+	knownSince
 	origin
 	collector
 }
@@ -23706,42 +25740,36 @@ fragment AllPkgTree on Package {
 		}
 	}
 }
-fragment AllSourceTree on Source {
-	id
-	type
-	namespaces {
-		id
-		namespace
-		names {
-			id
-			name
-			tag
-			commit
-		}
-	}
-}
 fragment AllArtifactTree on Artifact {
 	id
 	algorithm
 	digest
 }
+fragment AllVulnerabilityTree on Vulnerability {
+	id
+	type
+	vulnerabilityIDs {
+		id
+		vulnerabilityID
+	}
+}
 `
 
-func CertifyBads(
+func CertifyVEXStatements(
 	ctx context.Context,
 	client graphql.Client,
-	filter CertifyBadSpec,
-) (*CertifyBadsResponse, error) {
+	filter CertifyVEXStatementSpec,
+) (*CertifyVEXStatementsResponse, error) {
 	req := &graphql.Request{
-		OpName: "CertifyBads",
-		Query:  CertifyBads_Operation,
-		Variables: &__CertifyBadsInput{
+		OpName: "CertifyVEXStatements",
+		Query:  CertifyVEXStatements_Operation,
+		Variables: &__CertifyVEXStatementsInput{
 			Filter: filter,
 		},
 	}
 	var err error
 
-	var data CertifyBadsResponse
+	var data CertifyVEXStatementsResponse
 	resp := &graphql.Response{Data: &data}
 
 	err = client.MakeRequest(
@@ -23753,37 +25781,30 @@ func CertifyBads(
 	return &data, err
 }
 
-// The query or mutation executed by CertifyLegals.
-const CertifyLegals_Operation = `
-query CertifyLegals ($filter: CertifyLegalSpec!) {
-	CertifyLegal(certifyLegalSpec: $filter) {
-		... AllCertifyLegalTree
+// The query or mutation executed by CertifyVulns.
+const CertifyVulns_Operation = `
+query CertifyVulns ($filter: CertifyVulnSpec!) {
+	CertifyVuln(certifyVulnSpec: $filter) {
+		... AllCertifyVuln
 	}
 }
-fragment AllCertifyLegalTree on CertifyLegal {
+fragment AllCertifyVuln on CertifyVuln {
 	id
-	subject {
-		__typename
-		... on Package {
-			... AllPkgTree
-		}
-		... on Source {
-			... AllSourceTree
-		}
+	package {
+		... AllPkgTree
 	}
-	declaredLicense
-	declaredLicenses {
-		... AllLicenseTree
+	vulnerability {
+		... AllVulnerabilityTree
 	}
-	discoveredLicense
-	discoveredLicenses {
-		... AllLicenseTree
+	metadata {
+		dbUri
+		dbVersion
+		scannerUri
+		scannerVersion
+		timeScanned
+		origin
+		collector
 	}
-	attribution
-	justification
-	timeScanned
-	origin
-	collector
 }
 fragment AllPkgTree on Package {
 	id
@@ -23806,43 +25827,31 @@ fragment AllPkgTree on Package {
 		}
 	}
 }
-fragment AllSourceTree on Source {
+fragment AllVulnerabilityTree on Vulnerability {
 	id
 	type
-	namespaces {
+	vulnerabilityIDs {
 		id
-		namespace
-		names {
-			id
-			name
-			tag
-			commit
-		}
+		vulnerabilityID
 	}
 }
-fragment AllLicenseTree on License {
-	id
-	name
-	inline
-	listVersion
-}
 `
 
-func CertifyLegals(
+func CertifyVulns(
 	ctx context.Context,
 	client graphql.Client,
-	filter CertifyLegalSpec,
-) (*CertifyLegalsResponse, error) {
+	filter CertifyVulnSpec,
+) (*CertifyVulnsResponse, error) {
 	req := &graphql.Request{
-		OpName: "CertifyLegals",
-		Query:  CertifyLegals_Operation,
-		Variables: &__CertifyLegalsInput{
+		OpName: "CertifyVulns",
+		Query:  CertifyVulns_Operation,
+		Variables: &__CertifyVulnsInput{
 			Filter: filter,
 		},
 	}
 	var err error
 
-	var data CertifyLegalsResponse
+	var data CertifyVulnsResponse
 	resp := &graphql.Response{Data: &data}
 
 	err = client.MakeRequest(
@@ -28873,6 +30882,124 @@ func Path(
 	return &data, err
 }
 
+// The query or mutation executed by SBOMDiff.
+const SBOMDiff_Operation = `
+query SBOMDiff ($base: ID!, $target: ID!) {
+	sbomDiff(base: $base, target: $target) {
+		base {
+			... SBOMDiffHasSBOM
+		}
+		target {
+			... SBOMDiffHasSBOM
+		}
+		addedPackages {
+			... AllPkgTree
+		}
+		removedPackages {
+			... AllPkgTree
+		}
+		changedPackages {
+			base {
+				... AllPkgTree
+			}
+			target {
+				... AllPkgTree
+			}
+		}
+		newVulnerabilities {
+			vulnerability {
+				... AllVulnerabilityTree
+			}
+			package {
+				... AllPkgTree
+			}
+		}
+		fixedVulnerabilities {
+			vulnerability {
+				... AllVulnerabilityTree
+			}
+			package {
+				... AllPkgTree
+			}
+		}
+		licenseChanges {
+			base {
+				... AllPkgTree
+			}
+			target {
+				... AllPkgTree
+			}
+			baseLicense
+			targetLicense
+		}
+	}
+}
+fragment SBOMDiffHasSBOM on HasSBOM {
+	id
+	uri
+	algorithm
+	digest
+	knownSince
+}
+fragment AllPkgTree on Package {
+	id
+	type
+	namespaces {
+		id
+		namespace
+		names {
+			id
+			name
+			versions {
+				id
+				version
+				qualifiers {
+					key
+					value
+				}
+				subpath
+			}
+		}
+	}
+}
+fragment AllVulnerabilityTree on Vulnerability {
+	id
+	type
+	vulnerabilityIDs {
+		id
+		vulnerabilityID
+	}
+}
+`
+
+func SBOMDiff(
+	ctx context.Context,
+	client graphql.Client,
+	base string,
+	target string,
+) (*SBOMDiffResponse, error) {
+	req := &graphql.Request{
+		OpName: "SBOMDiff",
+		Query:  SBOMDiff_Operation,
+		Variables: &__SBOMDiffInput{
+			Base:   base,
+			Target: target,
+		},
+	}
+	var err error
+
+	var data SBOMDiffResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
 // The query or mutation executed by Sources.
 const Sources_Operation = `
 query Sources ($filter: SourceSpec!) {
@@ -28964,3 +31091,54 @@ func Vulnerabilities(
 
 	return &data, err
 }
+
+// The query or mutation executed by VulnerabilityMetadata.
+const VulnerabilityMetadata_Operation = `
+query VulnerabilityMetadata ($filter: VulnerabilityMetadataSpec!) {
+	vulnerabilityMetadata(vulnerabilityMetadataSpec: $filter) {
+		... AllVulnMetadataTree
+	}
+}
+fragment AllVulnMetadataTree on VulnerabilityMetadata {
+	id
+	vulnerability {
+		id
+		type
+		vulnerabilityIDs {
+			id
+			vulnerabilityID
+		}
+	}
+	scoreType
+	scoreValue
+	timestamp
+	origin
+	collector
+}
+`
+
+func VulnerabilityMetadata(
+	ctx context.Context,
+	client graphql.Client,
+	filter VulnerabilityMetadataSpec,
+) (*VulnerabilityMetadataResponse, error) {
+	req := &graphql.Request{
+		OpName: "VulnerabilityMetadata",
+		Query:  VulnerabilityMetadata_Operation,
+		Variables: &__VulnerabilityMetadataInput{
+			Filter: filter,
+		},
+	}
+	var err error
+
+	var data VulnerabilityMetadataResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
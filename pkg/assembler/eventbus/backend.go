@@ -0,0 +1,95 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventbus
+
+import (
+	"context"
+
+	"github.com/guacsec/guac/pkg/assembler/backends"
+	"github.com/guacsec/guac/pkg/assembler/graphql/model"
+)
+
+// eventBackend publishes an event for every evidence node ingested through
+// the wrapped backend
+type eventBackend struct {
+	backends.Backend
+	bus *Bus
+}
+
+// WithEvents wraps the backend so that ingesting CertifyVuln, CertifyBad and
+// CertifyVEXStatement nodes publishes events on the bus. This works for every
+// backend, as only the IDs returned by the ingest paths are needed.
+func WithEvents(backend backends.Backend, bus *Bus) backends.Backend {
+	return &eventBackend{Backend: backend, bus: bus}
+}
+
+func (b *eventBackend) publish(ctx context.Context, kind Kind, ids ...string) {
+	events := make([]Event, 0, len(ids))
+	for _, id := range ids {
+		if id != "" {
+			events = append(events, Event{Kind: kind, ID: id})
+		}
+	}
+	b.bus.Publish(ctx, events...)
+}
+
+func (b *eventBackend) IngestCertifyBad(ctx context.Context, subject model.PackageSourceOrArtifactInput, pkgMatchType *model.MatchFlags, certifyBad model.CertifyBadInputSpec) (string, error) {
+	id, err := b.Backend.IngestCertifyBad(ctx, subject, pkgMatchType, certifyBad)
+	if err == nil {
+		b.publish(ctx, CertifyBad, id)
+	}
+	return id, err
+}
+
+func (b *eventBackend) IngestCertifyBads(ctx context.Context, subjects model.PackageSourceOrArtifactInputs, pkgMatchType *model.MatchFlags, certifyBads []*model.CertifyBadInputSpec) ([]string, error) {
+	ids, err := b.Backend.IngestCertifyBads(ctx, subjects, pkgMatchType, certifyBads)
+	if err == nil {
+		b.publish(ctx, CertifyBad, ids...)
+	}
+	return ids, err
+}
+
+func (b *eventBackend) IngestCertifyVuln(ctx context.Context, pkg model.IDorPkgInput, vulnerability model.IDorVulnerabilityInput, certifyVuln model.ScanMetadataInput) (string, error) {
+	id, err := b.Backend.IngestCertifyVuln(ctx, pkg, vulnerability, certifyVuln)
+	if err == nil {
+		b.publish(ctx, CertifyVuln, id)
+	}
+	return id, err
+}
+
+func (b *eventBackend) IngestCertifyVulns(ctx context.Context, pkgs []*model.IDorPkgInput, vulnerabilities []*model.IDorVulnerabilityInput, certifyVulns []*model.ScanMetadataInput) ([]string, error) {
+	ids, err := b.Backend.IngestCertifyVulns(ctx, pkgs, vulnerabilities, certifyVulns)
+	if err == nil {
+		b.publish(ctx, CertifyVuln, ids...)
+	}
+	return ids, err
+}
+
+func (b *eventBackend) IngestVEXStatement(ctx context.Context, subject model.PackageOrArtifactInput, vulnerability model.IDorVulnerabilityInput, vexStatement model.VexStatementInputSpec) (string, error) {
+	id, err := b.Backend.IngestVEXStatement(ctx, subject, vulnerability, vexStatement)
+	if err == nil {
+		b.publish(ctx, CertifyVEXStatement, id)
+	}
+	return id, err
+}
+
+func (b *eventBackend) IngestVEXStatements(ctx context.Context, subjects model.PackageOrArtifactInputs, vulnerabilities []*model.IDorVulnerabilityInput, vexStatements []*model.VexStatementInputSpec) ([]string, error) {
+	ids, err := b.Backend.IngestVEXStatements(ctx, subjects, vulnerabilities, vexStatements)
+	if err == nil {
+		b.publish(ctx, CertifyVEXStatement, ids...)
+	}
+	return ids, err
+}
@@ -0,0 +1,124 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package eventbus distributes events about newly ingested evidence inside the
+// GraphQL server. Events are published by wrapping the backend with
+// WithEvents, and consumed by the GraphQL subscriptions and optionally
+// forwarded to a pubsub topic for other services.
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+// Kind is the type of the node an event is about
+type Kind string
+
+const (
+	CertifyVuln         Kind = "CertifyVuln"
+	CertifyBad          Kind = "CertifyBad"
+	CertifyVEXStatement Kind = "CertifyVEXStatement"
+)
+
+// Event reports that a node was ingested. Ingesting evidence that already
+// exists in the graph also publishes an event with the ID of the existing node.
+type Event struct {
+	Kind Kind   `json:"kind"`
+	ID   string `json:"id"`
+}
+
+// NATSSubject is the NATS subject events are forwarded to, kept apart from
+// the subject of collected documents
+const NATSSubject = "GUAC.ingested"
+
+// subscriberBuffer is the number of events buffered for each subscriber
+// before new events are dropped for it
+const subscriberBuffer = 256
+
+// Bus fans out published events to all current subscribers. Publishing never
+// blocks: events are dropped for subscribers that do not keep up.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[chan Event]struct{}
+}
+
+// New returns an empty event bus
+func New() *Bus {
+	return &Bus{subscribers: map[chan Event]struct{}{}}
+}
+
+// Subscribe returns a channel receiving the events published until ctx is
+// done, at which point the channel is closed
+func (b *Bus) Subscribe(ctx context.Context) <-chan Event {
+	ch := make(chan Event, subscriberBuffer)
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		delete(b.subscribers, ch)
+		close(ch)
+		b.mu.Unlock()
+	}()
+	return ch
+}
+
+// Publish sends the events to all subscribers
+func (b *Bus) Publish(ctx context.Context, events ...Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for ch := range b.subscribers {
+		for _, event := range events {
+			select {
+			case ch <- event:
+			default:
+				logging.FromContext(ctx).Warnf("dropping %s event %s for a slow subscriber", event.Kind, event.ID)
+			}
+		}
+	}
+}
+
+// Forward publishes every event of the bus as JSON with publish until ctx is
+// done. It is used to make the events available to other services through
+// the emitter pubsub.
+func Forward(ctx context.Context, b *Bus, publish func(context.Context, []byte) error) {
+	logger := logging.FromContext(ctx)
+	for event := range b.Subscribe(ctx) {
+		data, err := json.Marshal(event)
+		if err != nil {
+			logger.Errorf("failed to marshal %s event: %v", event.Kind, err)
+			continue
+		}
+		if err := publish(ctx, data); err != nil {
+			logger.Errorf("failed to forward %s event %s: %v", event.Kind, event.ID, err)
+		}
+	}
+}
+
+// Decode parses an event forwarded by Forward
+func Decode(data []byte) (*Event, error) {
+	var event Event
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+	return &event, nil
+}
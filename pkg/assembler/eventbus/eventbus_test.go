@@ -0,0 +1,148 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/guacsec/guac/internal/testing/mocks"
+	"github.com/guacsec/guac/pkg/assembler/graphql/model"
+)
+
+func receive(t *testing.T, ch <-chan Event, n int) []Event {
+	t.Helper()
+	var events []Event
+	for len(events) < n {
+		select {
+		case event := <-ch:
+			events = append(events, event)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out after receiving %d of %d events", len(events), n)
+		}
+	}
+	return events
+}
+
+func TestBus(t *testing.T) {
+	ctx := context.Background()
+	bus := New()
+
+	ctx1, cancel1 := context.WithCancel(ctx)
+	sub1 := bus.Subscribe(ctx1)
+	sub2 := bus.Subscribe(ctx)
+
+	events := []Event{{Kind: CertifyVuln, ID: "1"}, {Kind: CertifyBad, ID: "2"}}
+	bus.Publish(ctx, events...)
+	for i, sub := range []<-chan Event{sub1, sub2} {
+		if diff := cmp.Diff(events, receive(t, sub, 2)); diff != "" {
+			t.Errorf("subscriber %d: unexpected events (-want +got):\n%s", i, diff)
+		}
+	}
+
+	// a cancelled subscriber is closed and no longer receives events
+	cancel1()
+	for range sub1 {
+	}
+	bus.Publish(ctx, Event{Kind: CertifyVEXStatement, ID: "3"})
+	if diff := cmp.Diff([]Event{{Kind: CertifyVEXStatement, ID: "3"}}, receive(t, sub2, 1)); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+
+	// publishing does not block on subscribers that do not keep up
+	for i := 0; i < 2*subscriberBuffer; i++ {
+		bus.Publish(ctx, Event{Kind: CertifyVuln, ID: fmt.Sprint(i)})
+	}
+	if got := len(sub2); got != subscriberBuffer {
+		t.Errorf("expected %d buffered events, got %d", subscriberBuffer, got)
+	}
+}
+
+func TestWithEvents(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	b := mocks.NewMockBackend(ctrl)
+	bus := New()
+	backend := WithEvents(b, bus)
+	sub := bus.Subscribe(ctx)
+
+	b.EXPECT().IngestCertifyVulns(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{"v1", "", "v2"}, nil)
+	b.EXPECT().IngestCertifyBad(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return("b1", nil)
+	b.EXPECT().IngestVEXStatement(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return("", fmt.Errorf("failed"))
+	b.EXPECT().IngestVEXStatements(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return([]string{"x1"}, nil)
+	b.EXPECT().Artifacts(ctx, gomock.Any()).Return(nil, nil)
+
+	if _, err := backend.IngestCertifyVulns(ctx, nil, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := backend.IngestCertifyBad(ctx, model.PackageSourceOrArtifactInput{}, nil, model.CertifyBadInputSpec{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := backend.IngestVEXStatement(ctx, model.PackageOrArtifactInput{}, model.IDorVulnerabilityInput{}, model.VexStatementInputSpec{}); err == nil {
+		t.Fatalf("expected the error of the backend")
+	}
+	if _, err := backend.IngestVEXStatements(ctx, model.PackageOrArtifactInputs{}, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// other methods are passed through
+	if _, err := backend.Artifacts(ctx, &model.ArtifactSpec{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []Event{
+		{Kind: CertifyVuln, ID: "v1"},
+		{Kind: CertifyVuln, ID: "v2"},
+		{Kind: CertifyBad, ID: "b1"},
+		{Kind: CertifyVEXStatement, ID: "x1"},
+	}
+	if diff := cmp.Diff(want, receive(t, sub, len(want))); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+}
+
+func TestForward(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bus := New()
+
+	forwarded := make(chan []byte, 1)
+	go Forward(ctx, bus, func(_ context.Context, data []byte) error {
+		forwarded <- data
+		return nil
+	})
+
+	event := Event{Kind: CertifyBad, ID: "1"}
+	// the forwarder subscribes asynchronously, publish until it receives
+	var data []byte
+	for data == nil {
+		bus.Publish(ctx, event)
+		select {
+		case data = <-forwarded:
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	got, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode() error: %v", err)
+	}
+	if diff := cmp.Diff(&event, got); diff != "" {
+		t.Errorf("unexpected event (-want +got):\n%s", diff)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"sync/atomic"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/introspection"
@@ -36,6 +37,7 @@ type ResolverRoot interface {
 	Mutation() MutationResolver
 	Package() PackageResolver
 	Query() QueryResolver
+	Subscription() SubscriptionResolver
 }
 
 type DirectiveRoot struct {
@@ -189,6 +191,13 @@ type ComplexityRoot struct {
 		Name        func(childComplexity int) int
 	}
 
+	LicenseChange struct {
+		Base          func(childComplexity int) int
+		BaseLicense   func(childComplexity int) int
+		Target        func(childComplexity int) int
+		TargetLicense func(childComplexity int) int
+	}
+
 	Mutation struct {
 		IngestArtifact                  func(childComplexity int, artifact *model.IDorArtifactInput) int
 		IngestArtifacts                 func(childComplexity int, artifacts []*model.IDorArtifactInput) int
@@ -244,6 +253,11 @@ type ComplexityRoot struct {
 		Type       func(childComplexity int) int
 	}
 
+	PackageChange struct {
+		Base   func(childComplexity int) int
+		Target func(childComplexity int) int
+	}
+
 	PackageIDs struct {
 		PackageNameID      func(childComplexity int) int
 		PackageNamespaceID func(childComplexity int) int
@@ -312,13 +326,14 @@ type ComplexityRoot struct {
 		IsDependency          func(childComplexity int, isDependencySpec model.IsDependencySpec) int
 		IsOccurrence          func(childComplexity int, isOccurrenceSpec model.IsOccurrenceSpec) int
 		Licenses              func(childComplexity int, licenseSpec model.LicenseSpec) int
-		Neighbors             func(childComplexity int, node string, usingOnly []model.Edge) int
+		Neighbors             func(childComplexity int, node string, usingOnly []model.Edge, asOf *time.Time) int
 		Node                  func(childComplexity int, node string) int
 		Nodes                 func(childComplexity int, nodes []string) int
 		Packages              func(childComplexity int, pkgSpec model.PkgSpec) int
-		Path                  func(childComplexity int, subject string, target string, maxPathLength int, usingOnly []model.Edge) int
+		Path                  func(childComplexity int, subject string, target string, maxPathLength int, usingOnly []model.Edge, asOf *time.Time) int
 		PkgEqual              func(childComplexity int, pkgEqualSpec model.PkgEqualSpec) int
 		PointOfContact        func(childComplexity int, pointOfContactSpec model.PointOfContactSpec) int
+		SbomDiff              func(childComplexity int, base string, target string) int
 		Scorecards            func(childComplexity int, scorecardSpec model.CertifyScorecardSpec) int
 		Sources               func(childComplexity int, sourceSpec model.SourceSpec) int
 		VulnEqual             func(childComplexity int, vulnEqualSpec model.VulnEqualSpec) int
@@ -326,6 +341,17 @@ type ComplexityRoot struct {
 		VulnerabilityMetadata func(childComplexity int, vulnerabilityMetadataSpec model.VulnerabilityMetadataSpec) int
 	}
 
+	SBOMDiff struct {
+		AddedPackages        func(childComplexity int) int
+		Base                 func(childComplexity int) int
+		ChangedPackages      func(childComplexity int) int
+		FixedVulnerabilities func(childComplexity int) int
+		LicenseChanges       func(childComplexity int) int
+		NewVulnerabilities   func(childComplexity int) int
+		RemovedPackages      func(childComplexity int) int
+		Target               func(childComplexity int) int
+	}
+
 	SLSA struct {
 		BuildType     func(childComplexity int) int
 		BuiltBy       func(childComplexity int) int
@@ -393,6 +419,12 @@ type ComplexityRoot struct {
 		Namespace func(childComplexity int) int
 	}
 
+	Subscription struct {
+		CertifyBadIngested          func(childComplexity int, certifyBadSpec model.CertifyBadSpec) int
+		CertifyVEXStatementIngested func(childComplexity int, certifyVEXStatementSpec model.CertifyVEXStatementSpec) int
+		CertifyVulnIngested         func(childComplexity int, certifyVulnSpec model.CertifyVulnSpec) int
+	}
+
 	VulnEqual struct {
 		Collector       func(childComplexity int) int
 		ID              func(childComplexity int) int
@@ -407,6 +439,11 @@ type ComplexityRoot struct {
 		VulnerabilityIDs func(childComplexity int) int
 	}
 
+	VulnerabilityChange struct {
+		Package       func(childComplexity int) int
+		Vulnerability func(childComplexity int) int
+	}
+
 	VulnerabilityID struct {
 		ID              func(childComplexity int) int
 		VulnerabilityID func(childComplexity int) int
@@ -1133,6 +1170,34 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.License.Name(childComplexity), true
 
+	case "LicenseChange.base":
+		if e.complexity.LicenseChange.Base == nil {
+			break
+		}
+
+		return e.complexity.LicenseChange.Base(childComplexity), true
+
+	case "LicenseChange.baseLicense":
+		if e.complexity.LicenseChange.BaseLicense == nil {
+			break
+		}
+
+		return e.complexity.LicenseChange.BaseLicense(childComplexity), true
+
+	case "LicenseChange.target":
+		if e.complexity.LicenseChange.Target == nil {
+			break
+		}
+
+		return e.complexity.LicenseChange.Target(childComplexity), true
+
+	case "LicenseChange.targetLicense":
+		if e.complexity.LicenseChange.TargetLicense == nil {
+			break
+		}
+
+		return e.complexity.LicenseChange.TargetLicense(childComplexity), true
+
 	case "Mutation.ingestArtifact":
 		if e.complexity.Mutation.IngestArtifact == nil {
 			break
@@ -1706,6 +1771,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Package.Type(childComplexity), true
 
+	case "PackageChange.base":
+		if e.complexity.PackageChange.Base == nil {
+			break
+		}
+
+		return e.complexity.PackageChange.Base(childComplexity), true
+
+	case "PackageChange.target":
+		if e.complexity.PackageChange.Target == nil {
+			break
+		}
+
+		return e.complexity.PackageChange.Target(childComplexity), true
+
 	case "PackageIDs.packageNameID":
 		if e.complexity.PackageIDs.PackageNameID == nil {
 			break
@@ -2118,7 +2197,7 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Query.Neighbors(childComplexity, args["node"].(string), args["usingOnly"].([]model.Edge)), true
+		return e.complexity.Query.Neighbors(childComplexity, args["node"].(string), args["usingOnly"].([]model.Edge), args["asOf"].(*time.Time)), true
 
 	case "Query.node":
 		if e.complexity.Query.Node == nil {
@@ -2166,7 +2245,7 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Query.Path(childComplexity, args["subject"].(string), args["target"].(string), args["maxPathLength"].(int), args["usingOnly"].([]model.Edge)), true
+		return e.complexity.Query.Path(childComplexity, args["subject"].(string), args["target"].(string), args["maxPathLength"].(int), args["usingOnly"].([]model.Edge), args["asOf"].(*time.Time)), true
 
 	case "Query.PkgEqual":
 		if e.complexity.Query.PkgEqual == nil {
@@ -2192,6 +2271,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.PointOfContact(childComplexity, args["pointOfContactSpec"].(model.PointOfContactSpec)), true
 
+	case "Query.sbomDiff":
+		if e.complexity.Query.SbomDiff == nil {
+			break
+		}
+
+		args, err := ec.field_Query_sbomDiff_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.SbomDiff(childComplexity, args["base"].(string), args["target"].(string)), true
+
 	case "Query.scorecards":
 		if e.complexity.Query.Scorecards == nil {
 			break
@@ -2252,6 +2343,62 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.VulnerabilityMetadata(childComplexity, args["vulnerabilityMetadataSpec"].(model.VulnerabilityMetadataSpec)), true
 
+	case "SBOMDiff.addedPackages":
+		if e.complexity.SBOMDiff.AddedPackages == nil {
+			break
+		}
+
+		return e.complexity.SBOMDiff.AddedPackages(childComplexity), true
+
+	case "SBOMDiff.base":
+		if e.complexity.SBOMDiff.Base == nil {
+			break
+		}
+
+		return e.complexity.SBOMDiff.Base(childComplexity), true
+
+	case "SBOMDiff.changedPackages":
+		if e.complexity.SBOMDiff.ChangedPackages == nil {
+			break
+		}
+
+		return e.complexity.SBOMDiff.ChangedPackages(childComplexity), true
+
+	case "SBOMDiff.fixedVulnerabilities":
+		if e.complexity.SBOMDiff.FixedVulnerabilities == nil {
+			break
+		}
+
+		return e.complexity.SBOMDiff.FixedVulnerabilities(childComplexity), true
+
+	case "SBOMDiff.licenseChanges":
+		if e.complexity.SBOMDiff.LicenseChanges == nil {
+			break
+		}
+
+		return e.complexity.SBOMDiff.LicenseChanges(childComplexity), true
+
+	case "SBOMDiff.newVulnerabilities":
+		if e.complexity.SBOMDiff.NewVulnerabilities == nil {
+			break
+		}
+
+		return e.complexity.SBOMDiff.NewVulnerabilities(childComplexity), true
+
+	case "SBOMDiff.removedPackages":
+		if e.complexity.SBOMDiff.RemovedPackages == nil {
+			break
+		}
+
+		return e.complexity.SBOMDiff.RemovedPackages(childComplexity), true
+
+	case "SBOMDiff.target":
+		if e.complexity.SBOMDiff.Target == nil {
+			break
+		}
+
+		return e.complexity.SBOMDiff.Target(childComplexity), true
+
 	case "SLSA.buildType":
 		if e.complexity.SLSA.BuildType == nil {
 			break
@@ -2532,6 +2679,42 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.SourceNamespace.Namespace(childComplexity), true
 
+	case "Subscription.certifyBadIngested":
+		if e.complexity.Subscription.CertifyBadIngested == nil {
+			break
+		}
+
+		args, err := ec.field_Subscription_certifyBadIngested_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Subscription.CertifyBadIngested(childComplexity, args["certifyBadSpec"].(model.CertifyBadSpec)), true
+
+	case "Subscription.certifyVEXStatementIngested":
+		if e.complexity.Subscription.CertifyVEXStatementIngested == nil {
+			break
+		}
+
+		args, err := ec.field_Subscription_certifyVEXStatementIngested_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Subscription.CertifyVEXStatementIngested(childComplexity, args["certifyVEXStatementSpec"].(model.CertifyVEXStatementSpec)), true
+
+	case "Subscription.certifyVulnIngested":
+		if e.complexity.Subscription.CertifyVulnIngested == nil {
+			break
+		}
+
+		args, err := ec.field_Subscription_certifyVulnIngested_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Subscription.CertifyVulnIngested(childComplexity, args["certifyVulnSpec"].(model.CertifyVulnSpec)), true
+
 	case "VulnEqual.collector":
 		if e.complexity.VulnEqual.Collector == nil {
 			break
@@ -2588,6 +2771,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Vulnerability.VulnerabilityIDs(childComplexity), true
 
+	case "VulnerabilityChange.package":
+		if e.complexity.VulnerabilityChange.Package == nil {
+			break
+		}
+
+		return e.complexity.VulnerabilityChange.Package(childComplexity), true
+
+	case "VulnerabilityChange.vulnerability":
+		if e.complexity.VulnerabilityChange.Vulnerability == nil {
+			break
+		}
+
+		return e.complexity.VulnerabilityChange.Vulnerability(childComplexity), true
+
 	case "VulnerabilityID.id":
 		if e.complexity.VulnerabilityID.ID == nil {
 			break
@@ -2787,6 +2984,23 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 			var buf bytes.Buffer
 			data.MarshalGQL(&buf)
 
+			return &graphql.Response{
+				Data: buf.Bytes(),
+			}
+		}
+	case ast.Subscription:
+		next := ec._Subscription(ctx, rc.Operation.SelectionSet)
+
+		var buf bytes.Buffer
+		return func(ctx context.Context) *graphql.Response {
+			buf.Reset()
+			data := next(ctx)
+
+			if data == nil {
+				return nil
+			}
+			data.MarshalGQL(&buf)
+
 			return &graphql.Response{
 				Data: buf.Bytes(),
 			}
@@ -3129,6 +3343,14 @@ extend type Mutation {
     certifyBads: [CertifyBadInputSpec!]!
   ): [ID!]!
 }
+
+extend type Subscription {
+  """
+  Streams the CertifyBad attestations matching the filter as they are
+  ingested. Re-ingesting an existing attestation streams it again.
+  """
+  certifyBadIngested(certifyBadSpec: CertifyBadSpec!): CertifyBad!
+}
 `, BuiltIn: false},
 	{Name: "../schema/certifyGood.graphql", Input: `#
 # Copyright 2023 The GUAC Authors.
@@ -3582,6 +3804,12 @@ input CertifyVEXStatementSpec {
   knownSince: Time
   origin: String
   collector: String
+  """
+  asOf returns the VEX statements as they were known at the given time. For
+  each subject and vulnerability, only the most recent statement known at or
+  before asOf is returned, as newer statements supersede older ones.
+  """
+  asOf: Time
 }
 
 "VexStatementInputSpec represents the input to ingest VEX statements."
@@ -3616,6 +3844,16 @@ extend type Mutation {
     vexStatements: [VexStatementInputSpec!]!
   ): [ID!]!
 }
+
+extend type Subscription {
+  """
+  Streams the VEX certifications matching the input filter as they are
+  ingested. Re-ingesting an existing certification streams it again.
+  """
+  certifyVEXStatementIngested(
+    certifyVEXStatementSpec: CertifyVEXStatementSpec!
+  ): CertifyVEXStatement!
+}
 `, BuiltIn: false},
 	{Name: "../schema/certifyVuln.graphql", Input: `#
 # Copyright 2023 The GUAC Authors.
@@ -3696,6 +3934,11 @@ input CertifyVulnSpec {
   scannerVersion: String
   origin: String
   collector: String
+  """
+  asOf returns the certifications as they were known at the given time. Only
+  scans performed at or before asOf are returned.
+  """
+  asOf: Time
 }
 
 """
@@ -3731,6 +3974,14 @@ extend type Mutation {
     certifyVulns: [ScanMetadataInput!]!
   ): [ID!]!
 }
+
+extend type Subscription {
+  """
+  Streams the vulnerability certifications matching the input filter as they
+  are ingested. Re-ingesting an existing certification streams it again.
+  """
+  certifyVulnIngested(certifyVulnSpec: CertifyVulnSpec!): CertifyVuln!
+}
 `, BuiltIn: false},
 	{Name: "../schema/contact.graphql", Input: `#
 # Copyright 2023 The GUAC Authors.
@@ -3910,6 +4161,11 @@ input HasSBOMSpec {
   includedSoftware: [PackageOrArtifactSpec!]
   includedDependencies: [IsDependencySpec!]
   includedOccurrences: [IsOccurrenceSpec!]
+  """
+  asOf returns the SBOMs as they were known at the given time. Only SBOMs known
+  at or before asOf are returned.
+  """
+  asOf: Time
 }
 
 input HasSBOMIncludesInputSpec {
@@ -5041,12 +5297,18 @@ extend type Query {
 
   Specifying any Edge value in ` + "`" + `usingOnly` + "`" + ` will make the path only contain the
   corresponding GUAC evidence trees (GUAC verbs).
+
+  Specifying ` + "`" + `asOf` + "`" + ` returns the path as it was known at the given time. The
+  graph is traversed through the neighbors of each node as returned by
+  ` + "`" + `neighbors` + "`" + ` with the same asOf, so the path does not go through evidence
+  recorded later or VEX statements superseded by then.
   """
   path(
     subject: ID!
     target: ID!
     maxPathLength: Int!
     usingOnly: [Edge!]!
+    asOf: Time
   ): [Node!]!
 
   """
@@ -5056,8 +5318,12 @@ extend type Query {
 
   Specifying any Edge value in ` + "`" + `usingOnly` + "`" + ` will make the neighbors list only
   contain the corresponding GUAC evidence trees (GUAC verbs).
+
+  Specifying ` + "`" + `asOf` + "`" + ` returns the neighbors as they were known at the given time.
+  Evidence trees recorded after asOf are excluded and, for each subject and
+  vulnerability, only the most recent VEX statement known at asOf is kept.
   """
-  neighbors(node: ID!, usingOnly: [Edge!]!): [Node!]!
+  neighbors(node: ID!, usingOnly: [Edge!]!, asOf: Time): [Node!]!
 
   """
   node returns a single node, regardless of type.
@@ -5147,6 +5413,94 @@ extend type Mutation {
     pkgEquals: [PkgEqualInputSpec!]!
   ): [ID!]!
 }
+`, BuiltIn: false},
+	{Name: "../schema/sbomDiff.graphql", Input: `#
+# Copyright 2024 The GUAC Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# NOTE: This is experimental and might change in the future!
+
+# Defines a GraphQL schema for comparing two SBOMs
+
+"""
+SBOMDiff is the difference between a base and a target SBOM.
+
+The packages of an SBOM are collected from its includedSoftware,
+includedDependencies and includedOccurrences. Packages of both SBOMs are
+matched by type, namespace and name.
+"""
+type SBOMDiff {
+  "SBOM that is compared against"
+  base: HasSBOM!
+  "SBOM that is compared to the base"
+  target: HasSBOM!
+  "Package versions whose package is only included in the target SBOM"
+  addedPackages: [Package!]!
+  "Package versions whose package is only included in the base SBOM"
+  removedPackages: [Package!]!
+  "Packages included in both SBOMs at a different version"
+  changedPackages: [PackageChange!]!
+  "Vulnerabilities that only affect packages of the target SBOM"
+  newVulnerabilities: [VulnerabilityChange!]!
+  "Vulnerabilities that only affect packages of the base SBOM"
+  fixedVulnerabilities: [VulnerabilityChange!]!
+  "Packages included in both SBOMs with a different license"
+  licenseChanges: [LicenseChange!]!
+}
+
+"PackageChange is a package that is included in both SBOMs at a different version."
+type PackageChange {
+  "Package version in the base SBOM"
+  base: Package!
+  "Package version in the target SBOM"
+  target: Package!
+}
+
+"VulnerabilityChange is a vulnerability that is only found in one of the SBOMs."
+type VulnerabilityChange {
+  vulnerability: Vulnerability!
+  "Package version of the SBOM that is affected by the vulnerability"
+  package: Package!
+}
+
+"""
+LicenseChange is a package that is included in both SBOMs with a different
+license.
+
+The license of a package version is the declared license of its most recent
+CertifyLegal, or the discovered license if none was declared.
+"""
+type LicenseChange {
+  "Package version in the base SBOM"
+  base: Package!
+  "Package version in the target SBOM"
+  target: Package!
+  "License of the package version in the base SBOM"
+  baseLicense: String!
+  "License of the package version in the target SBOM"
+  targetLicense: String!
+}
+
+extend type Query {
+  """
+  sbomDiff compares the target SBOM to the base SBOM.
+
+  Both base and target are either HasSBOM IDs or packageVersion IDs. For a
+  packageVersion, its most recent HasSBOM is used.
+  """
+  sbomDiff(base: ID!, target: ID!): SBOMDiff!
+}
 `, BuiltIn: false},
 	{Name: "../schema/search.graphql", Input: `#
 # Copyright 2023 The GUAC Authors.
@@ -5428,6 +5782,16 @@ enum VulnerabilityScoreType {
   CVSSv4
   OWASP
   SSVC
+  EPSSv3
+  EPSSv4
+  "Listed in the CISA Known Exploited Vulnerabilities catalog, the score is 1"
+  KEV
+  """
+  Qualitative severity rated by a source without a CVSS score, such as a distro
+  feed: 0 for none or negligible, 1 for low, 2 for medium, 3 for high and 4 for
+  critical
+  """
+  SEVERITY
 }
 
 "The Comparator is used by the vulnerability score filter on ranges"
@@ -0,0 +1,227 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package limits protects the GraphQL server from expensive queries. It
+// estimates the complexity of operations from the schema, limits the depth of
+// operations, bounds the time spent answering queries and rate limits clients.
+package limits
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Config holds the limits applied to the GraphQL server. A zero value
+// disables the corresponding limit.
+type Config struct {
+	// MaxComplexity is the maximum estimated complexity of an operation
+	MaxComplexity int
+	// MaxDepth is the maximum nesting of fields in an operation
+	MaxDepth int
+	// Timeout is the deadline for answering a query. Backend work is
+	// cancelled through the context once it passes. Mutations are not bounded,
+	// as bulk ingestion may legitimately take longer and must not be aborted
+	// part way.
+	Timeout time.Duration
+	// RateLimit is the number of requests per second allowed for each client
+	RateLimit float64
+	// RateBurst is the number of requests a client may make at once
+	RateBurst int
+}
+
+// Apply adds the complexity, depth and timeout limits of the config to the
+// server. The server must have been created with an executable schema wrapped
+// by WithComplexity for complexity estimates to account for lists.
+func Apply(srv *handler.Server, config Config) {
+	if config.MaxComplexity > 0 {
+		srv.Use(extension.FixedComplexityLimit(config.MaxComplexity))
+	}
+	if config.MaxDepth > 0 {
+		srv.Use(DepthLimit(config.MaxDepth))
+	}
+	if config.Timeout > 0 {
+		srv.AroundResponses(Timeout(config.Timeout))
+	}
+}
+
+const (
+	// ListMultiplier is the number of elements assumed to be returned by a
+	// field of list type when estimating the complexity of an operation
+	ListMultiplier = 5
+	// maxPathLengthArg is the argument bounding the search done by the path
+	// query, whose cost grows with the length of the paths searched
+	maxPathLengthArg = "maxPathLength"
+)
+
+type complexitySchema struct {
+	graphql.ExecutableSchema
+}
+
+// WithComplexity wraps the schema so that the estimated complexity of fields
+// of list type is their child complexity times ListMultiplier, and the
+// complexity of fields taking a maxPathLength argument is further multiplied by
+// that length. Other fields keep the default complexity of their child
+// complexity plus one.
+func WithComplexity(es graphql.ExecutableSchema) graphql.ExecutableSchema {
+	return complexitySchema{ExecutableSchema: es}
+}
+
+func (s complexitySchema) Complexity(typeName, fieldName string, childComplexity int, args map[string]interface{}) (int, bool) {
+	if complexity, ok := s.ExecutableSchema.Complexity(typeName, fieldName, childComplexity, args); ok {
+		return complexity, ok
+	}
+	def := s.Schema().Types[typeName]
+	if def == nil {
+		return 0, false
+	}
+	field := def.Fields.ForName(fieldName)
+	if field == nil {
+		return 0, false
+	}
+
+	complexity := childComplexity
+	if field.Type.Elem != nil {
+		complexity = safeMultiply(complexity, ListMultiplier)
+	}
+	if length, ok := intArg(args[maxPathLengthArg]); ok && length > 1 {
+		complexity = safeMultiply(complexity, length)
+	}
+	return safeAdd(complexity, 1), true
+}
+
+func intArg(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int64:
+		if n > math.MaxInt32 {
+			return math.MaxInt32, true
+		}
+		return int(n), true
+	case float64:
+		if n > math.MaxInt32 {
+			return math.MaxInt32, true
+		}
+		return int(n), true
+	}
+	return 0, false
+}
+
+func safeMultiply(a, b int) int {
+	if a != 0 && b > math.MaxInt/a {
+		return math.MaxInt
+	}
+	return a * b
+}
+
+func safeAdd(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+const errDepthLimit = "DEPTH_LIMIT_EXCEEDED"
+
+type depthLimit struct {
+	limit int
+}
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = depthLimit{}
+
+// DepthLimit returns an extension rejecting operations whose fields are nested
+// deeper than limit. Fragments count towards the depth of the fields they are
+// spread into and introspection fields are not counted.
+func DepthLimit(limit int) graphql.HandlerExtension {
+	return depthLimit{limit: limit}
+}
+
+func (d depthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func (d depthLimit) Validate(_ graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (d depthLimit) MutateOperationContext(_ context.Context, rc *graphql.OperationContext) *gqlerror.Error {
+	op := rc.Doc.Operations.ForName(rc.OperationName)
+	if op == nil {
+		return nil
+	}
+	if depth := selectionSetDepth(op.SelectionSet, d.limit); depth > d.limit {
+		err := gqlerror.Errorf("operation exceeds the depth limit of %d", d.limit)
+		errcode.Set(err, errDepthLimit)
+		return err
+	}
+	return nil
+}
+
+// selectionSetDepth returns the depth of the selection set, stopping once it
+// exceeds limit so that deeply nested fragments are not walked in full
+func selectionSetDepth(selectionSet ast.SelectionSet, limit int) int {
+	if limit < 0 {
+		return 0
+	}
+	depth := 0
+	for _, selection := range selectionSet {
+		var d int
+		switch s := selection.(type) {
+		case *ast.Field:
+			if s.Name == "__schema" || s.Name == "__type" {
+				continue
+			}
+			d = 1 + selectionSetDepth(s.SelectionSet, limit-1)
+		case *ast.FragmentSpread:
+			if s.Definition != nil {
+				d = selectionSetDepth(s.Definition.SelectionSet, limit)
+			}
+		case *ast.InlineFragment:
+			d = selectionSetDepth(s.SelectionSet, limit)
+		}
+		if d > depth {
+			depth = d
+		}
+		if depth > limit {
+			break
+		}
+	}
+	return depth
+}
+
+// Timeout returns a response middleware that cancels the context of queries
+// after timeout. Mutations, such as bulk ingestion, and long lived
+// subscriptions are not bounded.
+func Timeout(timeout time.Duration) graphql.ResponseMiddleware {
+	return func(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+		if rc := graphql.GetOperationContext(ctx); rc.Operation == nil || rc.Operation.Operation != ast.Query {
+			return next(ctx)
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return next(ctx)
+	}
+}
@@ -0,0 +1,252 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package limits
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/complexity"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/golang/mock/gomock"
+	"github.com/guacsec/guac/internal/testing/mocks"
+	"github.com/guacsec/guac/pkg/assembler/graphql/generated"
+	"github.com/guacsec/guac/pkg/assembler/graphql/model"
+	"github.com/guacsec/guac/pkg/assembler/graphql/resolvers"
+	"github.com/guacsec/guac/pkg/auth"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+// the defaults of the gql-max-complexity and gql-max-depth flags
+const (
+	defaultMaxComplexity = 1000000
+	defaultMaxDepth      = 15
+)
+
+func newSchema(backend *mocks.MockBackend) *generated.Config {
+	config := generated.Config{Resolvers: &resolvers.Resolver{Backend: backend}}
+	config.Directives.Filter = resolvers.Filter
+	return &config
+}
+
+// TestClientOperations checks that the operations used by the GUAC clients
+// are accepted with the default limits
+func TestClientOperations(t *testing.T) {
+	es := WithComplexity(generated.NewExecutableSchema(*newSchema(nil)))
+
+	files, err := filepath.Glob("../../clients/operations/*.graphql")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("failed to find client operations: %v", err)
+	}
+	var operations strings.Builder
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		operations.Write(data)
+		operations.WriteString("\n")
+	}
+	doc, parseErr := parser.ParseQuery(&ast.Source{Input: operations.String()})
+	if parseErr != nil {
+		t.Fatalf("failed to parse client operations: %v", parseErr)
+	}
+	if errs := validator.Validate(es.Schema(), doc); len(errs) > 0 {
+		t.Fatalf("failed to validate client operations: %v", errs)
+	}
+
+	for _, op := range doc.Operations {
+		// the complexity of path grows with its length, assume a short one
+		vars := map[string]interface{}{maxPathLengthArg: int64(2)}
+		if got := complexity.Calculate(es, op, vars); got > defaultMaxComplexity {
+			t.Errorf("operation %s has complexity %d over the default limit", op.Name, got)
+		}
+		if got := selectionSetDepth(op.SelectionSet, defaultMaxDepth); got > defaultMaxDepth {
+			t.Errorf("operation %s has depth %d over the default limit", op.Name, got)
+		}
+	}
+}
+
+type gqlResponse struct {
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func query(t *testing.T, handler http.Handler, body string) gqlResponse {
+	t.Helper()
+	r := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	var response gqlResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response %q: %v", w.Body.String(), err)
+	}
+	return response
+}
+
+func TestLimits(t *testing.T) {
+	const (
+		artifacts   = `{"query": "{ artifacts(artifactSpec: {}) { id } }"}`
+		deepSBOM    = `{"query": "{ HasSBOM(hasSBOMSpec: {}) { includedDependencies { package { namespaces { names { versions { id } } } } } } }"}`
+		longPath    = `{"query": "{ path(subject: \"a\", target: \"b\", maxPathLength: 1000000, usingOnly: []) { __typename } }"}`
+		slowPath    = `{"query": "{ path(subject: \"a\", target: \"b\", maxPathLength: 2, usingOnly: []) { __typename } }"}`
+		slowIngest  = `{"query": "mutation { ingestArtifact(artifact: {artifactInput: {algorithm: \"sha256\", digest: \"abc\"}}) }"}`
+		introspect  = `{"query": "{ __schema { types { name fields { name type { name ofType { name ofType { name ofType { name } } } } } } } }"}`
+		deadlineErr = "context deadline exceeded"
+	)
+
+	tests := []struct {
+		name      string
+		config    Config
+		body      string
+		expect    func(b *mocks.MockBackendMockRecorder)
+		wantError string
+	}{
+		{
+			name:   "within limits",
+			config: Config{MaxComplexity: 100, MaxDepth: 3},
+			body:   artifacts,
+			expect: func(b *mocks.MockBackendMockRecorder) {
+				b.Artifacts(gomock.Any(), gomock.Any()).Return([]*model.Artifact{}, nil)
+			},
+		},
+		{
+			name:      "too deep",
+			config:    Config{MaxDepth: 5},
+			body:      deepSBOM,
+			wantError: "operation exceeds the depth limit of 5",
+		},
+		{
+			name:      "too complex",
+			config:    Config{MaxComplexity: 1000},
+			body:      deepSBOM,
+			wantError: "which exceeds the limit of 1000",
+		},
+		{
+			name:      "path length counts towards complexity",
+			config:    Config{MaxComplexity: defaultMaxComplexity},
+			body:      longPath,
+			wantError: "exceeds the limit",
+		},
+		{
+			name:   "introspection does not count towards depth",
+			config: Config{MaxDepth: 2},
+			body:   introspect,
+		},
+		{
+			name:   "backend work is cancelled after the timeout",
+			config: Config{Timeout: 50 * time.Millisecond},
+			body:   slowPath,
+			expect: func(b *mocks.MockBackendMockRecorder) {
+				b.Path(gomock.Any(), "a", "b", 2, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, _, _ string, _ int, _ []model.Edge) ([]model.Node, error) {
+						<-ctx.Done()
+						return nil, ctx.Err()
+					})
+			},
+			wantError: deadlineErr,
+		},
+		{
+			name:   "mutations are not bounded by the timeout",
+			config: Config{Timeout: 10 * time.Millisecond},
+			body:   slowIngest,
+			expect: func(b *mocks.MockBackendMockRecorder) {
+				b.IngestArtifact(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, _ *model.IDorArtifactInput) (string, error) {
+						time.Sleep(50 * time.Millisecond)
+						return "1", ctx.Err()
+					})
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			b := mocks.NewMockBackend(ctrl)
+			if test.expect != nil {
+				test.expect(b.EXPECT())
+			}
+			srv := handler.NewDefaultServer(WithComplexity(generated.NewExecutableSchema(*newSchema(b))))
+			Apply(srv, test.config)
+
+			response := query(t, srv, test.body)
+			if test.wantError == "" {
+				if len(response.Errors) > 0 {
+					t.Errorf("unexpected errors: %v", response.Errors)
+				}
+				return
+			}
+			if len(response.Errors) == 0 || !strings.Contains(response.Errors[0].Message, test.wantError) {
+				t.Errorf("expected error containing %q, got %v", test.wantError, response.Errors)
+			}
+		})
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(1, 2)
+	limiter.now = func() time.Time { return now }
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	handler := limiter.Middleware(ok)
+
+	request := func(remoteAddr string, principal *auth.Principal) int {
+		r := httptest.NewRequest(http.MethodPost, "/query", nil)
+		r.RemoteAddr = remoteAddr
+		if principal != nil {
+			r = r.WithContext(auth.WithPrincipal(r.Context(), principal))
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	alice := &auth.Principal{Subject: "alice", Method: "token"}
+	for i, want := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+		if got := request("10.0.0.1:1234", alice); got != want {
+			t.Errorf("request %d for alice: got status %d, want %d", i, got, want)
+		}
+	}
+	// other clients have their own limits, even from the same address
+	if got := request("10.0.0.1:1234", nil); got != http.StatusOK {
+		t.Errorf("unauthenticated request: got status %d, want %d", got, http.StatusOK)
+	}
+	if got := request("10.0.0.2:1234", &auth.Principal{Subject: "bob", Method: "token"}); got != http.StatusOK {
+		t.Errorf("request for bob: got status %d, want %d", got, http.StatusOK)
+	}
+
+	now = now.Add(time.Second)
+	if got := request("10.0.0.1:1234", alice); got != http.StatusOK {
+		t.Errorf("request for alice after a second: got status %d, want %d", got, http.StatusOK)
+	}
+
+	now = now.Add(2 * idleClientExpiry)
+	request("10.0.0.3:1234", nil)
+	if len(limiter.clients) != 1 {
+		t.Errorf("expected idle clients to be dropped, got %d clients", len(limiter.clients))
+	}
+}
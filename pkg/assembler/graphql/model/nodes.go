@@ -332,6 +332,10 @@ type CertifyVEXStatementSpec struct {
 	KnownSince       *time.Time             `json:"knownSince,omitempty"`
 	Origin           *string                `json:"origin,omitempty"`
 	Collector        *string                `json:"collector,omitempty"`
+	// asOf returns the VEX statements as they were known at the given time. For
+	// each subject and vulnerability, only the most recent statement known at or
+	// before asOf is returned, as newer statements supersede older ones.
+	AsOf *time.Time `json:"asOf,omitempty"`
 }
 
 // CertifyVuln is an attestation to attach vulnerability information to a package.
@@ -370,6 +374,9 @@ type CertifyVulnSpec struct {
 	ScannerVersion *string            `json:"scannerVersion,omitempty"`
 	Origin         *string            `json:"origin,omitempty"`
 	Collector      *string            `json:"collector,omitempty"`
+	// asOf returns the certifications as they were known at the given time. Only
+	// scans performed at or before asOf are returned.
+	AsOf *time.Time `json:"asOf,omitempty"`
 }
 
 // HasMetadata is an attestation that a package, source, or artifact has a certain
@@ -496,6 +503,9 @@ type HasSBOMSpec struct {
 	IncludedSoftware     []*PackageOrArtifactSpec `json:"includedSoftware,omitempty"`
 	IncludedDependencies []*IsDependencySpec      `json:"includedDependencies,omitempty"`
 	IncludedOccurrences  []*IsOccurrenceSpec      `json:"includedOccurrences,omitempty"`
+	// asOf returns the SBOMs as they were known at the given time. Only SBOMs known
+	// at or before asOf are returned.
+	AsOf *time.Time `json:"asOf,omitempty"`
 }
 
 // HasSLSA records that a subject node has a SLSA attestation.
@@ -782,6 +792,22 @@ type License struct {
 
 func (License) IsNode() {}
 
+// LicenseChange is a package that is included in both SBOMs with a different
+// license.
+//
+// The license of a package version is the declared license of its most recent
+// CertifyLegal, or the discovered license if none was declared.
+type LicenseChange struct {
+	// Package version in the base SBOM
+	Base *Package `json:"base"`
+	// Package version in the target SBOM
+	Target *Package `json:"target"`
+	// License of the package version in the base SBOM
+	BaseLicense string `json:"baseLicense"`
+	// License of the package version in the target SBOM
+	TargetLicense string `json:"targetLicense"`
+}
+
 // LicenseInputSpec specifies an license for mutations. One of inline or
 // listVersion should be empty or missing.
 type LicenseInputSpec struct {
@@ -834,6 +860,14 @@ func (Package) IsPackageOrSource() {}
 
 func (Package) IsNode() {}
 
+// PackageChange is a package that is included in both SBOMs at a different version.
+type PackageChange struct {
+	// Package version in the base SBOM
+	Base *Package `json:"base"`
+	// Package version in the target SBOM
+	Target *Package `json:"target"`
+}
+
 // The IDs of the ingested package
 type PackageIDs struct {
 	PackageTypeID      string `json:"packageTypeID"`
@@ -1150,6 +1184,30 @@ type PointOfContactSpec struct {
 type Query struct {
 }
 
+// SBOMDiff is the difference between a base and a target SBOM.
+//
+// The packages of an SBOM are collected from its includedSoftware,
+// includedDependencies and includedOccurrences. Packages of both SBOMs are
+// matched by type, namespace and name.
+type SBOMDiff struct {
+	// SBOM that is compared against
+	Base *HasSbom `json:"base"`
+	// SBOM that is compared to the base
+	Target *HasSbom `json:"target"`
+	// Package versions whose package is only included in the target SBOM
+	AddedPackages []*Package `json:"addedPackages"`
+	// Package versions whose package is only included in the base SBOM
+	RemovedPackages []*Package `json:"removedPackages"`
+	// Packages included in both SBOMs at a different version
+	ChangedPackages []*PackageChange `json:"changedPackages"`
+	// Vulnerabilities that only affect packages of the target SBOM
+	NewVulnerabilities []*VulnerabilityChange `json:"newVulnerabilities"`
+	// Vulnerabilities that only affect packages of the base SBOM
+	FixedVulnerabilities []*VulnerabilityChange `json:"fixedVulnerabilities"`
+	// Packages included in both SBOMs with a different license
+	LicenseChanges []*LicenseChange `json:"licenseChanges"`
+}
+
 // SLSA contains all of the fields present in a SLSA attestation.
 //
 // The materials and builders are objects of the HasSLSA predicate, everything
@@ -1419,6 +1477,9 @@ type SourceSpec struct {
 	Commit    *string `json:"commit,omitempty"`
 }
 
+type Subscription struct {
+}
+
 // VexStatementInputSpec represents the input to ingest VEX statements.
 type VexStatementInputSpec struct {
 	Status           VexStatus        `json:"status"`
@@ -1497,6 +1558,13 @@ type Vulnerability struct {
 
 func (Vulnerability) IsNode() {}
 
+// VulnerabilityChange is a vulnerability that is only found in one of the SBOMs.
+type VulnerabilityChange struct {
+	Vulnerability *Vulnerability `json:"vulnerability"`
+	// Package version of the SBOM that is affected by the vulnerability
+	Package *Package `json:"package"`
+}
+
 // VulnerabilityID is a specific vulnerability ID associated with the type of the vulnerability.
 //
 // This will be enforced to be all lowercase.
@@ -2107,6 +2175,14 @@ const (
 	VulnerabilityScoreTypeCVSSv4  VulnerabilityScoreType = "CVSSv4"
 	VulnerabilityScoreTypeOwasp   VulnerabilityScoreType = "OWASP"
 	VulnerabilityScoreTypeSsvc    VulnerabilityScoreType = "SSVC"
+	VulnerabilityScoreTypeEPSSv3  VulnerabilityScoreType = "EPSSv3"
+	VulnerabilityScoreTypeEPSSv4  VulnerabilityScoreType = "EPSSv4"
+	// Listed in the CISA Known Exploited Vulnerabilities catalog, the score is 1
+	VulnerabilityScoreTypeKev VulnerabilityScoreType = "KEV"
+	// Qualitative severity rated by a source without a CVSS score, such as a distro
+	// feed: 0 for none or negligible, 1 for low, 2 for medium, 3 for high and 4 for
+	// critical
+	VulnerabilityScoreTypeSeverity VulnerabilityScoreType = "SEVERITY"
 )
 
 var AllVulnerabilityScoreType = []VulnerabilityScoreType{
@@ -2118,11 +2194,15 @@ var AllVulnerabilityScoreType = []VulnerabilityScoreType{
 	VulnerabilityScoreTypeCVSSv4,
 	VulnerabilityScoreTypeOwasp,
 	VulnerabilityScoreTypeSsvc,
+	VulnerabilityScoreTypeEPSSv3,
+	VulnerabilityScoreTypeEPSSv4,
+	VulnerabilityScoreTypeKev,
+	VulnerabilityScoreTypeSeverity,
 }
 
 func (e VulnerabilityScoreType) IsValid() bool {
 	switch e {
-	case VulnerabilityScoreTypeCVSSv2, VulnerabilityScoreTypeCVSSv3, VulnerabilityScoreTypeEPSSv1, VulnerabilityScoreTypeEPSSv2, VulnerabilityScoreTypeCVSSv31, VulnerabilityScoreTypeCVSSv4, VulnerabilityScoreTypeOwasp, VulnerabilityScoreTypeSsvc:
+	case VulnerabilityScoreTypeCVSSv2, VulnerabilityScoreTypeCVSSv3, VulnerabilityScoreTypeEPSSv1, VulnerabilityScoreTypeEPSSv2, VulnerabilityScoreTypeCVSSv31, VulnerabilityScoreTypeCVSSv4, VulnerabilityScoreTypeOwasp, VulnerabilityScoreTypeSsvc, VulnerabilityScoreTypeEPSSv3, VulnerabilityScoreTypeEPSSv4, VulnerabilityScoreTypeKev, VulnerabilityScoreTypeSeverity:
 		return true
 	}
 	return false
@@ -0,0 +1,321 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/guacsec/guac/pkg/assembler/graphql/model"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// asOf queries are answered by filtering the results of the backend, so that
+// every backend supports them without changes.
+
+// knownAt returns whether the evidence node was recorded at or before asOf.
+// Nodes that do not carry a timestamp are always known.
+func knownAt(node model.Node, asOf time.Time) bool {
+	var recorded *time.Time
+	switch n := node.(type) {
+	case *model.CertifyVuln:
+		if n.Metadata != nil {
+			recorded = &n.Metadata.TimeScanned
+		}
+	case *model.CertifyVEXStatement:
+		recorded = &n.KnownSince
+	case *model.HasSbom:
+		recorded = &n.KnownSince
+	case *model.CertifyBad:
+		recorded = &n.KnownSince
+	case *model.CertifyGood:
+		recorded = &n.KnownSince
+	case *model.CertifyLegal:
+		recorded = &n.TimeScanned
+	case *model.CertifyScorecard:
+		if n.Scorecard != nil {
+			recorded = &n.Scorecard.TimeScanned
+		}
+	case *model.HasSourceAt:
+		recorded = &n.KnownSince
+	case *model.HasMetadata:
+		recorded = &n.Timestamp
+	case *model.PointOfContact:
+		recorded = &n.Since
+	case *model.VulnerabilityMetadata:
+		recorded = &n.Timestamp
+	case *model.HasSlsa:
+		if n.Slsa != nil {
+			recorded = n.Slsa.FinishedOn
+		}
+	}
+	return recorded == nil || !recorded.After(asOf)
+}
+
+func certifyVulnsAsOf(certifyVulns []*model.CertifyVuln, asOf *time.Time) []*model.CertifyVuln {
+	if asOf == nil {
+		return certifyVulns
+	}
+	result := []*model.CertifyVuln{}
+	for _, certifyVuln := range certifyVulns {
+		if knownAt(certifyVuln, *asOf) {
+			result = append(result, certifyVuln)
+		}
+	}
+	return result
+}
+
+func hasSBOMsAsOf(hasSBOMs []*model.HasSbom, asOf *time.Time) []*model.HasSbom {
+	if asOf == nil {
+		return hasSBOMs
+	}
+	result := []*model.HasSbom{}
+	for _, hasSBOM := range hasSBOMs {
+		if knownAt(hasSBOM, *asOf) {
+			result = append(result, hasSBOM)
+		}
+	}
+	return result
+}
+
+// vexStatementsAsOf returns the VEX statements known at asOf, keeping only the
+// most recent statement for each subject and vulnerability
+func vexStatementsAsOf(statements []*model.CertifyVEXStatement, asOf *time.Time) []*model.CertifyVEXStatement {
+	if asOf == nil {
+		return statements
+	}
+	latest := map[string]*model.CertifyVEXStatement{}
+	var keys []string
+	for _, statement := range statements {
+		if !knownAt(statement, *asOf) {
+			continue
+		}
+		key := vexStatementKey(statement)
+		current, ok := latest[key]
+		if !ok {
+			keys = append(keys, key)
+		}
+		if !ok || statement.KnownSince.After(current.KnownSince) {
+			latest[key] = statement
+		}
+	}
+
+	// keep the order returned by the backend for the remaining statements
+	result := []*model.CertifyVEXStatement{}
+	for _, key := range keys {
+		result = append(result, latest[key])
+	}
+	return result
+}
+
+// certifyVEXStatementAsOf queries the VEX statements matching the filter as
+// known at asOf. A statement is superseded by a newer one whatever their
+// content, so the statements are fetched without the filters on their content
+// and only filtered on it once the superseded statements are dropped.
+func (r *queryResolver) certifyVEXStatementAsOf(ctx context.Context, filter *model.CertifyVEXStatementSpec, asOf *time.Time) ([]*model.CertifyVEXStatement, error) {
+	if asOf == nil {
+		return r.Backend.CertifyVEXStatement(ctx, filter)
+	}
+	unfiltered := *filter
+	unfiltered.Status = nil
+	unfiltered.VexJustification = nil
+	unfiltered.Statement = nil
+	unfiltered.StatusNotes = nil
+	statements, err := r.Backend.CertifyVEXStatement(ctx, &unfiltered)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*model.CertifyVEXStatement{}
+	for _, statement := range vexStatementsAsOf(statements, asOf) {
+		if matchesVEXStatementContent(statement, filter) {
+			result = append(result, statement)
+		}
+	}
+	return result, nil
+}
+
+// matchesVEXStatementContent reports whether the statement matches the
+// filters on the content of a statement
+func matchesVEXStatementContent(statement *model.CertifyVEXStatement, filter *model.CertifyVEXStatementSpec) bool {
+	return (filter.Status == nil || statement.Status == *filter.Status) &&
+		(filter.VexJustification == nil || statement.VexJustification == *filter.VexJustification) &&
+		(filter.Statement == nil || statement.Statement == *filter.Statement) &&
+		(filter.StatusNotes == nil || statement.StatusNotes == *filter.StatusNotes)
+}
+
+func vexStatementKey(statement *model.CertifyVEXStatement) string {
+	var subject string
+	switch s := statement.Subject.(type) {
+	case *model.Package:
+		if len(s.Namespaces) > 0 && len(s.Namespaces[0].Names) > 0 && len(s.Namespaces[0].Names[0].Versions) > 0 {
+			subject = s.Namespaces[0].Names[0].Versions[0].ID
+		}
+	case *model.Artifact:
+		subject = s.ID
+	}
+
+	var vulnerability string
+	if statement.Vulnerability != nil && len(statement.Vulnerability.VulnerabilityIDs) > 0 {
+		vulnerability = statement.Vulnerability.VulnerabilityIDs[0].ID
+	}
+	return subject + "," + vulnerability
+}
+
+// neighborsAsOf drops the evidence nodes that were not known at asOf and the
+// VEX statements that were superseded by then
+func neighborsAsOf(nodes []model.Node, asOf *time.Time) []model.Node {
+	if asOf == nil {
+		return nodes
+	}
+
+	var statements []*model.CertifyVEXStatement
+	for _, node := range nodes {
+		if statement, ok := node.(*model.CertifyVEXStatement); ok {
+			statements = append(statements, statement)
+		}
+	}
+	current := map[*model.CertifyVEXStatement]bool{}
+	for _, statement := range vexStatementsAsOf(statements, asOf) {
+		current[statement] = true
+	}
+
+	result := []model.Node{}
+	for _, node := range nodes {
+		if statement, ok := node.(*model.CertifyVEXStatement); ok {
+			if current[statement] {
+				result = append(result, node)
+			}
+			continue
+		}
+		if knownAt(node, *asOf) {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+// pathAsOf returns a shortest path between subject and target as known at
+// asOf. The graph is traversed through the neighbors of each node as known at
+// asOf, so that the path never goes through evidence recorded later or through
+// a VEX statement superseded by then, even when that is the shortest path
+// today.
+func (r *queryResolver) pathAsOf(ctx context.Context, subject string, target string, maxPathLength int, usingOnly []model.Edge, asOf *time.Time) ([]model.Node, error) {
+	parents := map[string]string{}
+	visited := map[string]bool{subject: true}
+	queue := []string{subject}
+	for depth := 0; !visited[target] && len(queue) > 0 && depth < maxPathLength; depth++ {
+		var next []string
+		for _, id := range queue {
+			neighbors, err := r.Backend.Neighbors(ctx, id, usingOnly)
+			if err != nil {
+				return nil, err
+			}
+			for _, neighbor := range neighborsAsOf(neighbors, asOf) {
+				neighborID := nodeID(neighbor)
+				if visited[neighborID] {
+					continue
+				}
+				visited[neighborID] = true
+				parents[neighborID] = id
+				next = append(next, neighborID)
+			}
+		}
+		queue = next
+	}
+	if !visited[target] {
+		return nil, gqlerror.Errorf("Path :: no path found up to specified length as of %v", *asOf)
+	}
+
+	path := []string{target}
+	for id := target; id != subject; {
+		id = parents[id]
+		path = append([]string{id}, path...)
+	}
+	return r.Backend.Nodes(ctx, path)
+}
+
+// nodeID returns the ID of a node. Packages, sources and vulnerabilities are
+// returned as tries, whose deepest level is the node itself.
+func nodeID(node model.Node) string {
+	switch n := node.(type) {
+	case *model.Package:
+		if len(n.Namespaces) == 0 {
+			return n.ID
+		}
+		if len(n.Namespaces[0].Names) == 0 {
+			return n.Namespaces[0].ID
+		}
+		if name := n.Namespaces[0].Names[0]; len(name.Versions) > 0 {
+			return name.Versions[0].ID
+		}
+		return n.Namespaces[0].Names[0].ID
+	case *model.Source:
+		if len(n.Namespaces) == 0 {
+			return n.ID
+		}
+		if len(n.Namespaces[0].Names) == 0 {
+			return n.Namespaces[0].ID
+		}
+		return n.Namespaces[0].Names[0].ID
+	case *model.Vulnerability:
+		if len(n.VulnerabilityIDs) == 0 {
+			return n.ID
+		}
+		return n.VulnerabilityIDs[0].ID
+	case *model.Artifact:
+		return n.ID
+	case *model.Builder:
+		return n.ID
+	case *model.License:
+		return n.ID
+	case *model.CertifyBad:
+		return n.ID
+	case *model.CertifyGood:
+		return n.ID
+	case *model.CertifyLegal:
+		return n.ID
+	case *model.CertifyScorecard:
+		return n.ID
+	case *model.CertifyVEXStatement:
+		return n.ID
+	case *model.CertifyVuln:
+		return n.ID
+	case *model.HasMetadata:
+		return n.ID
+	case *model.HasSbom:
+		return n.ID
+	case *model.HasSlsa:
+		return n.ID
+	case *model.HasSourceAt:
+		return n.ID
+	case *model.HashEqual:
+		return n.ID
+	case *model.IsDependency:
+		return n.ID
+	case *model.IsOccurrence:
+		return n.ID
+	case *model.PkgEqual:
+		return n.ID
+	case *model.PointOfContact:
+		return n.ID
+	case *model.VulnEqual:
+		return n.ID
+	case *model.VulnerabilityMetadata:
+		return n.ID
+	}
+	return ""
+}
@@ -0,0 +1,341 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/guacsec/guac/internal/testing/mocks"
+	"github.com/guacsec/guac/internal/testing/ptrfrom"
+	"github.com/guacsec/guac/pkg/assembler/graphql/model"
+	"github.com/guacsec/guac/pkg/assembler/graphql/resolvers"
+)
+
+var (
+	jan = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	feb = time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+	mar = time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	asOfArtifact = &model.Artifact{ID: "artifact", Algorithm: "sha256", Digest: "abc"}
+	asOfVuln     = &model.Vulnerability{ID: "vulnType", Type: "cve", VulnerabilityIDs: []*model.VulnerabilityID{{ID: "vuln", VulnerabilityID: "cve-2024-0001"}}}
+	otherVuln    = &model.Vulnerability{ID: "vulnType", Type: "cve", VulnerabilityIDs: []*model.VulnerabilityID{{ID: "other", VulnerabilityID: "cve-2024-0002"}}}
+
+	affectedJan    = &model.CertifyVEXStatement{ID: "affectedJan", Subject: asOfArtifact, Vulnerability: asOfVuln, Status: model.VexStatusAffected, KnownSince: jan}
+	notAffectedFeb = &model.CertifyVEXStatement{ID: "notAffectedFeb", Subject: asOfArtifact, Vulnerability: asOfVuln, Status: model.VexStatusNotAffected, KnownSince: feb}
+	fixedMar       = &model.CertifyVEXStatement{ID: "fixedMar", Subject: asOfArtifact, Vulnerability: asOfVuln, Status: model.VexStatusFixed, KnownSince: mar}
+	otherJan       = &model.CertifyVEXStatement{ID: "otherJan", Subject: asOfArtifact, Vulnerability: otherVuln, Status: model.VexStatusAffected, KnownSince: jan}
+
+	vexStatements = []*model.CertifyVEXStatement{affectedJan, notAffectedFeb, fixedMar, otherJan}
+)
+
+func TestCertifyVEXStatementAsOf(t *testing.T) {
+	tests := []struct {
+		Name string
+		AsOf *time.Time
+		Want []*model.CertifyVEXStatement
+	}{
+		{
+			Name: "no asOf returns the full history",
+			Want: vexStatements,
+		},
+		{
+			Name: "newer statements supersede older ones",
+			AsOf: &feb,
+			Want: []*model.CertifyVEXStatement{notAffectedFeb, otherJan},
+		},
+		{
+			Name: "statements after asOf are excluded",
+			AsOf: &jan,
+			Want: []*model.CertifyVEXStatement{affectedJan, otherJan},
+		},
+		{
+			Name: "before any statement",
+			AsOf: ptrfrom.Time(jan.Add(-time.Hour)),
+			Want: []*model.CertifyVEXStatement{},
+		},
+	}
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			b := mocks.NewMockBackend(ctrl)
+			r := resolvers.Resolver{Backend: b}
+			b.
+				EXPECT().
+				CertifyVEXStatement(ctx, gomock.Any()).
+				Return(vexStatements, nil)
+			got, err := r.Query().CertifyVEXStatement(ctx, model.CertifyVEXStatementSpec{AsOf: test.AsOf})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.Want, got); diff != "" {
+				t.Errorf("Unexpected results. (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCertifyVEXStatementAsOfStatus(t *testing.T) {
+	tests := []struct {
+		Name   string
+		AsOf   *time.Time
+		Status model.VexStatus
+		Want   []*model.CertifyVEXStatement
+	}{
+		{
+			Name:   "statement with the status is the latest",
+			AsOf:   &feb,
+			Status: model.VexStatusNotAffected,
+			Want:   []*model.CertifyVEXStatement{notAffectedFeb},
+		},
+		{
+			Name:   "statement with the status is superseded",
+			AsOf:   &mar,
+			Status: model.VexStatusNotAffected,
+			Want:   []*model.CertifyVEXStatement{},
+		},
+		{
+			Name:   "latest statements with the status",
+			AsOf:   &mar,
+			Status: model.VexStatusAffected,
+			Want:   []*model.CertifyVEXStatement{otherJan},
+		},
+	}
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			b := mocks.NewMockBackend(ctrl)
+			r := resolvers.Resolver{Backend: b}
+			// the backend filters the statements by status, as the backends do
+			b.
+				EXPECT().
+				CertifyVEXStatement(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, spec *model.CertifyVEXStatementSpec) ([]*model.CertifyVEXStatement, error) {
+					result := []*model.CertifyVEXStatement{}
+					for _, statement := range vexStatements {
+						if spec.Status == nil || statement.Status == *spec.Status {
+							result = append(result, statement)
+						}
+					}
+					return result, nil
+				})
+			status := test.Status
+			got, err := r.Query().CertifyVEXStatement(ctx, model.CertifyVEXStatementSpec{Status: &status, AsOf: test.AsOf})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.Want, got); diff != "" {
+				t.Errorf("Unexpected results. (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCertifyVulnAsOf(t *testing.T) {
+	scannedJan := &model.CertifyVuln{ID: "jan", Vulnerability: asOfVuln, Metadata: &model.ScanMetadata{TimeScanned: jan}}
+	scannedMar := &model.CertifyVuln{ID: "mar", Vulnerability: otherVuln, Metadata: &model.ScanMetadata{TimeScanned: mar}}
+
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	b := mocks.NewMockBackend(ctrl)
+	r := resolvers.Resolver{Backend: b}
+	b.
+		EXPECT().
+		CertifyVuln(ctx, gomock.Any()).
+		Return([]*model.CertifyVuln{scannedJan, scannedMar}, nil)
+
+	got, err := r.Query().CertifyVuln(ctx, model.CertifyVulnSpec{AsOf: &feb})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]*model.CertifyVuln{scannedJan}, got); diff != "" {
+		t.Errorf("Unexpected results. (-want +got):\n%s", diff)
+	}
+}
+
+func TestHasSbomAsOf(t *testing.T) {
+	sbomJan := &model.HasSbom{ID: "jan", Subject: asOfArtifact, KnownSince: jan}
+	sbomMar := &model.HasSbom{ID: "mar", Subject: asOfArtifact, KnownSince: mar}
+
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	b := mocks.NewMockBackend(ctrl)
+	r := resolvers.Resolver{Backend: b}
+	b.
+		EXPECT().
+		HasSBOM(ctx, gomock.Any()).
+		Return([]*model.HasSbom{sbomJan, sbomMar}, nil)
+
+	got, err := r.Query().HasSbom(ctx, model.HasSBOMSpec{AsOf: &feb})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]*model.HasSbom{sbomJan}, got); diff != "" {
+		t.Errorf("Unexpected results. (-want +got):\n%s", diff)
+	}
+}
+
+func TestNeighborsAsOf(t *testing.T) {
+	badMar := &model.CertifyBad{ID: "badMar", Subject: asOfArtifact, KnownSince: mar}
+	occurrence := &model.IsOccurrence{ID: "occurrence", Artifact: asOfArtifact}
+	neighbors := []model.Node{affectedJan, notAffectedFeb, fixedMar, badMar, occurrence}
+
+	tests := []struct {
+		Name string
+		AsOf *time.Time
+		Want []model.Node
+	}{
+		{
+			Name: "no asOf",
+			Want: neighbors,
+		},
+		{
+			Name: "feb",
+			AsOf: &feb,
+			Want: []model.Node{notAffectedFeb, occurrence},
+		},
+		{
+			Name: "mar",
+			AsOf: &mar,
+			Want: []model.Node{fixedMar, badMar, occurrence},
+		},
+	}
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			b := mocks.NewMockBackend(ctrl)
+			r := resolvers.Resolver{Backend: b}
+			b.
+				EXPECT().
+				Neighbors(ctx, "artifact", gomock.Any()).
+				Return(neighbors, nil)
+			got, err := r.Query().Neighbors(ctx, "artifact", []model.Edge{}, test.AsOf)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.Want, got); diff != "" {
+				t.Errorf("Unexpected results. (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPathAsOf(t *testing.T) {
+	// the artifact is linked to the package by a CertifyBad recorded in
+	// March, and through its source by a HasSourceAt recorded in January
+	pkg := &model.Package{ID: "type", Namespaces: []*model.PackageNamespace{{ID: "namespace", Names: []*model.PackageName{{ID: "name", Versions: []*model.PackageVersion{{ID: "version"}}}}}}}
+	src := &model.Source{ID: "srcType", Namespaces: []*model.SourceNamespace{{ID: "srcNamespace", Names: []*model.SourceName{{ID: "srcName"}}}}}
+	badMar := &model.CertifyBad{ID: "badMar", Subject: asOfArtifact, KnownSince: mar}
+	occurrence := &model.IsOccurrence{ID: "occurrence", Artifact: asOfArtifact, Subject: src}
+	sourceJan := &model.HasSourceAt{ID: "sourceJan", Package: pkg, Source: src, KnownSince: jan}
+	nodes := map[string]model.Node{
+		"artifact": asOfArtifact, "badMar": badMar, "version": pkg,
+		"occurrence": occurrence, "srcName": src, "sourceJan": sourceJan,
+	}
+	neighbors := map[string][]model.Node{
+		"artifact":   {badMar, occurrence},
+		"badMar":     {asOfArtifact, pkg},
+		"occurrence": {asOfArtifact, src},
+		"srcName":    {occurrence, sourceJan},
+		"sourceJan":  {src, pkg},
+		"version":    {badMar, sourceJan},
+	}
+	shortest := []model.Node{asOfArtifact, badMar, pkg}
+	throughSource := []model.Node{asOfArtifact, occurrence, src, sourceJan, pkg}
+
+	dec := time.Date(2023, time.December, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		Name          string
+		AsOf          *time.Time
+		MaxPathLength int
+		Want          []model.Node
+		WantErr       bool
+	}{
+		{
+			Name:          "no asOf",
+			MaxPathLength: 4,
+			Want:          shortest,
+		},
+		{
+			Name:          "shortest path known at asOf",
+			AsOf:          &mar,
+			MaxPathLength: 4,
+			Want:          shortest,
+		},
+		{
+			Name:          "longer path known at asOf",
+			AsOf:          &feb,
+			MaxPathLength: 4,
+			Want:          throughSource,
+		},
+		{
+			Name:          "path known at asOf is too long",
+			AsOf:          &feb,
+			MaxPathLength: 3,
+			WantErr:       true,
+		},
+		{
+			Name:          "no path known at asOf",
+			AsOf:          &dec,
+			MaxPathLength: 4,
+			WantErr:       true,
+		},
+	}
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			b := mocks.NewMockBackend(ctrl)
+			r := resolvers.Resolver{Backend: b}
+			b.
+				EXPECT().
+				Path(ctx, "artifact", "version", test.MaxPathLength, gomock.Any()).
+				Return(shortest, nil).
+				AnyTimes()
+			b.
+				EXPECT().
+				Neighbors(ctx, gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, node string, _ []model.Edge) ([]model.Node, error) {
+					return neighbors[node], nil
+				}).
+				AnyTimes()
+			b.
+				EXPECT().
+				Nodes(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, ids []string) ([]model.Node, error) {
+					var result []model.Node
+					for _, id := range ids {
+						result = append(result, nodes[id])
+					}
+					return result, nil
+				}).
+				AnyTimes()
+			got, err := r.Query().Path(ctx, "artifact", "version", test.MaxPathLength, []model.Edge{}, test.AsOf)
+			if (err != nil) != test.WantErr {
+				t.Fatalf("did not get expected error, want: %v, got: %v", test.WantErr, err)
+			}
+			if diff := cmp.Diff(test.Want, got); diff != "" {
+				t.Errorf("Unexpected results. (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
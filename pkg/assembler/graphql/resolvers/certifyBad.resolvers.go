@@ -8,6 +8,8 @@ import (
 	"context"
 
 	"github.com/guacsec/guac/pkg/assembler/backends/helper"
+	"github.com/guacsec/guac/pkg/assembler/eventbus"
+	"github.com/guacsec/guac/pkg/assembler/graphql/generated"
 	"github.com/guacsec/guac/pkg/assembler/graphql/model"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 )
@@ -67,3 +69,20 @@ func (r *queryResolver) CertifyBad(ctx context.Context, certifyBadSpec model.Cer
 	}
 	return r.Backend.CertifyBad(ctx, &certifyBadSpec)
 }
+
+// CertifyBadIngested is the resolver for the certifyBadIngested field.
+func (r *subscriptionResolver) CertifyBadIngested(ctx context.Context, certifyBadSpec model.CertifyBadSpec) (<-chan *model.CertifyBad, error) {
+	return subscribe(ctx, r.Events, eventbus.CertifyBad, func(ctx context.Context, id string) (*model.CertifyBad, error) {
+		if certifyBadSpec.ID != nil && *certifyBadSpec.ID != id {
+			return nil, nil
+		}
+		spec := certifyBadSpec
+		spec.ID = &id
+		return first(r.Query().CertifyBad(ctx, spec))
+	})
+}
+
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+
+type subscriptionResolver struct{ *Resolver }
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/guacsec/guac/pkg/assembler/backends/helper"
+	"github.com/guacsec/guac/pkg/assembler/eventbus"
 	"github.com/guacsec/guac/pkg/assembler/graphql/model"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 )
@@ -135,8 +136,20 @@ func (r *queryResolver) CertifyVEXStatement(ctx context.Context, certifyVEXState
 			Origin:           certifyVEXStatementSpec.Origin,
 			Collector:        certifyVEXStatementSpec.Collector,
 		}
-		return r.Backend.CertifyVEXStatement(ctx, lowercaseCertifyVexFilter)
+		return r.certifyVEXStatementAsOf(ctx, lowercaseCertifyVexFilter, certifyVEXStatementSpec.AsOf)
 	} else {
-		return r.Backend.CertifyVEXStatement(ctx, &certifyVEXStatementSpec)
+		return r.certifyVEXStatementAsOf(ctx, &certifyVEXStatementSpec, certifyVEXStatementSpec.AsOf)
 	}
 }
+
+// CertifyVEXStatementIngested is the resolver for the certifyVEXStatementIngested field.
+func (r *subscriptionResolver) CertifyVEXStatementIngested(ctx context.Context, certifyVEXStatementSpec model.CertifyVEXStatementSpec) (<-chan *model.CertifyVEXStatement, error) {
+	return subscribe(ctx, r.Events, eventbus.CertifyVEXStatement, func(ctx context.Context, id string) (*model.CertifyVEXStatement, error) {
+		if certifyVEXStatementSpec.ID != nil && *certifyVEXStatementSpec.ID != id {
+			return nil, nil
+		}
+		spec := certifyVEXStatementSpec
+		spec.ID = &id
+		return first(r.Query().CertifyVEXStatement(ctx, spec))
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/guacsec/guac/pkg/assembler/backends/helper"
+	"github.com/guacsec/guac/pkg/assembler/eventbus"
 	"github.com/guacsec/guac/pkg/assembler/graphql/model"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 )
@@ -111,8 +112,28 @@ func (r *queryResolver) CertifyVuln(ctx context.Context, certifyVulnSpec model.C
 			Origin:         certifyVulnSpec.Origin,
 			Collector:      certifyVulnSpec.Collector,
 		}
-		return r.Backend.CertifyVuln(ctx, &lowercaseCertifyVulnFilter)
+		certifyVulns, err := r.Backend.CertifyVuln(ctx, &lowercaseCertifyVulnFilter)
+		if err != nil {
+			return nil, err
+		}
+		return certifyVulnsAsOf(certifyVulns, certifyVulnSpec.AsOf), nil
 	} else {
-		return r.Backend.CertifyVuln(ctx, &certifyVulnSpec)
+		certifyVulns, err := r.Backend.CertifyVuln(ctx, &certifyVulnSpec)
+		if err != nil {
+			return nil, err
+		}
+		return certifyVulnsAsOf(certifyVulns, certifyVulnSpec.AsOf), nil
 	}
 }
+
+// CertifyVulnIngested is the resolver for the certifyVulnIngested field.
+func (r *subscriptionResolver) CertifyVulnIngested(ctx context.Context, certifyVulnSpec model.CertifyVulnSpec) (<-chan *model.CertifyVuln, error) {
+	return subscribe(ctx, r.Events, eventbus.CertifyVuln, func(ctx context.Context, id string) (*model.CertifyVuln, error) {
+		if certifyVulnSpec.ID != nil && *certifyVulnSpec.ID != id {
+			return nil, nil
+		}
+		spec := certifyVulnSpec
+		spec.ID = &id
+		return first(r.Query().CertifyVuln(ctx, spec))
+	})
+}
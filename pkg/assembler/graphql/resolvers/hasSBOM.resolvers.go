@@ -63,5 +63,9 @@ func (r *queryResolver) HasSbom(ctx context.Context, hasSBOMSpec model.HasSBOMSp
 	if err := helper.ValidatePackageOrArtifactQueryFilter(hasSBOMSpec.Subject); err != nil {
 		return nil, gqlerror.Errorf("%v :: %s", "HasSBOM", err)
 	}
-	return r.Backend.HasSBOM(ctx, &hasSBOMSpec)
+	hasSBOMs, err := r.Backend.HasSBOM(ctx, &hasSBOMSpec)
+	if err != nil {
+		return nil, err
+	}
+	return hasSBOMsAsOf(hasSBOMs, hasSBOMSpec.AsOf), nil
 }
@@ -6,23 +6,31 @@ package resolvers
 
 import (
 	"context"
+	"time"
 
 	"github.com/guacsec/guac/pkg/assembler/graphql/model"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
 // Path is the resolver for the path field.
-func (r *queryResolver) Path(ctx context.Context, subject string, target string, maxPathLength int, usingOnly []model.Edge) ([]model.Node, error) {
+func (r *queryResolver) Path(ctx context.Context, subject string, target string, maxPathLength int, usingOnly []model.Edge, asOf *time.Time) ([]model.Node, error) {
 	if maxPathLength <= 0 {
 		return nil, gqlerror.Errorf("Path :: maxPathLength argument must be positive, got %d", maxPathLength)
 	}
 
+	if asOf != nil {
+		return r.pathAsOf(ctx, subject, target, maxPathLength, usingOnly, asOf)
+	}
 	return r.Backend.Path(ctx, subject, target, maxPathLength, usingOnly)
 }
 
 // Neighbors is the resolver for the neighbors field.
-func (r *queryResolver) Neighbors(ctx context.Context, node string, usingOnly []model.Edge) ([]model.Node, error) {
-	return r.Backend.Neighbors(ctx, node, usingOnly)
+func (r *queryResolver) Neighbors(ctx context.Context, node string, usingOnly []model.Edge, asOf *time.Time) ([]model.Node, error) {
+	neighbors, err := r.Backend.Neighbors(ctx, node, usingOnly)
+	if err != nil {
+		return nil, err
+	}
+	return neighborsAsOf(neighbors, asOf), nil
 }
 
 // Node is the resolver for the node field.
@@ -77,7 +77,7 @@ func TestPath(t *testing.T) {
 					Path(ctx, o.subject, o.target, o.maxPathLength, o.usingOnly).
 					Return([]model.Node{}, nil).
 					Times(times)
-				_, err := r.Query().Path(ctx, o.subject, o.target, o.maxPathLength, o.usingOnly)
+				_, err := r.Query().Path(ctx, o.subject, o.target, o.maxPathLength, o.usingOnly, nil)
 				if (err != nil) != test.ExpIngestErr {
 					t.Fatalf("did not get expected ingest error, want: %v, got: %v", test.ExpIngestErr, err)
 				}
@@ -21,8 +21,13 @@ package resolvers
 
 import (
 	"github.com/guacsec/guac/pkg/assembler/backends"
+	"github.com/guacsec/guac/pkg/assembler/eventbus"
 )
 
 type Resolver struct {
 	Backend backends.Backend
+	// Events receives the ingestion events streamed by subscriptions. The
+	// backend must be wrapped with eventbus.WithEvents to publish them.
+	// Subscriptions are disabled if nil.
+	Events *eventbus.Bus
 }
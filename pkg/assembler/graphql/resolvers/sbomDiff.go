@@ -0,0 +1,314 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/guacsec/guac/pkg/assembler/backends"
+	"github.com/guacsec/guac/pkg/assembler/graphql/model"
+	"github.com/guacsec/guac/pkg/assembler/helpers"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const noVulnType string = "novuln"
+
+// sbomPackage is a package version included in an SBOM
+type sbomPackage struct {
+	pkg     *model.Package
+	name    string // purl without version, used to match packages between SBOMs
+	purl    string
+	version string
+}
+
+// sbomContents are the package versions included in an SBOM, grouped by package name
+type sbomContents map[string][]*sbomPackage
+
+func diffSBOMs(ctx context.Context, b backends.Backend, baseID, targetID string) (*model.SBOMDiff, error) {
+	baseSBOM, err := findSBOM(ctx, b, baseID)
+	if err != nil {
+		return nil, err
+	}
+	targetSBOM, err := findSBOM(ctx, b, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	base := collectSBOMPackages(baseSBOM)
+	target := collectSBOMPackages(targetSBOM)
+
+	diff := &model.SBOMDiff{
+		Base:                 baseSBOM,
+		Target:               targetSBOM,
+		AddedPackages:        []*model.Package{},
+		RemovedPackages:      []*model.Package{},
+		ChangedPackages:      []*model.PackageChange{},
+		NewVulnerabilities:   []*model.VulnerabilityChange{},
+		FixedVulnerabilities: []*model.VulnerabilityChange{},
+		LicenseChanges:       []*model.LicenseChange{},
+	}
+
+	for _, name := range sortedNames(base, target) {
+		basePkgs, targetPkgs := base[name], target[name]
+		removed := versionsNotIn(basePkgs, targetPkgs)
+		added := versionsNotIn(targetPkgs, basePkgs)
+
+		// versions that are replaced are paired up in order, the rest are added or removed
+		for len(removed) > 0 && len(added) > 0 {
+			diff.ChangedPackages = append(diff.ChangedPackages, &model.PackageChange{Base: removed[0].pkg, Target: added[0].pkg})
+			removed, added = removed[1:], added[1:]
+		}
+		for _, p := range removed {
+			diff.RemovedPackages = append(diff.RemovedPackages, p.pkg)
+		}
+		for _, p := range added {
+			diff.AddedPackages = append(diff.AddedPackages, p.pkg)
+		}
+
+		if len(basePkgs) > 0 && len(targetPkgs) > 0 {
+			baseLicense, err := packagesLicense(ctx, b, basePkgs)
+			if err != nil {
+				return nil, err
+			}
+			targetLicense, err := packagesLicense(ctx, b, targetPkgs)
+			if err != nil {
+				return nil, err
+			}
+			if baseLicense != targetLicense {
+				diff.LicenseChanges = append(diff.LicenseChanges, &model.LicenseChange{
+					Base:          basePkgs[0].pkg,
+					Target:        targetPkgs[0].pkg,
+					BaseLicense:   baseLicense,
+					TargetLicense: targetLicense,
+				})
+			}
+		}
+	}
+
+	baseVulns, err := collectVulnerabilities(ctx, b, base)
+	if err != nil {
+		return nil, err
+	}
+	targetVulns, err := collectVulnerabilities(ctx, b, target)
+	if err != nil {
+		return nil, err
+	}
+	diff.NewVulnerabilities = vulnerabilitiesNotIn(targetVulns, baseVulns)
+	diff.FixedVulnerabilities = vulnerabilitiesNotIn(baseVulns, targetVulns)
+
+	return diff, nil
+}
+
+// findSBOM returns the HasSBOM with the given ID, or the most recent HasSBOM
+// of the packageVersion with the given ID
+func findSBOM(ctx context.Context, b backends.Backend, id string) (*model.HasSbom, error) {
+	node, err := b.Node(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node %s: %w", id, err)
+	}
+
+	switch node := node.(type) {
+	case *model.HasSbom:
+		return node, nil
+	case *model.Package:
+		if len(node.Namespaces) == 0 || len(node.Namespaces[0].Names) == 0 || len(node.Namespaces[0].Names[0].Versions) == 0 {
+			return nil, gqlerror.Errorf("SbomDiff :: %s is a package name, expected a packageVersion or HasSBOM ID", id)
+		}
+		sboms, err := b.HasSBOM(ctx, &model.HasSBOMSpec{
+			Subject: &model.PackageOrArtifactSpec{Package: &model.PkgSpec{ID: &id}},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get SBOMs of package %s: %w", id, err)
+		}
+		var latest *model.HasSbom
+		for _, sbom := range sboms {
+			if latest == nil || sbom.KnownSince.After(latest.KnownSince) {
+				latest = sbom
+			}
+		}
+		if latest == nil {
+			return nil, gqlerror.Errorf("SbomDiff :: no SBOM found for packageVersion %s", id)
+		}
+		return latest, nil
+	}
+	return nil, gqlerror.Errorf("SbomDiff :: %s is not a HasSBOM or packageVersion ID", id)
+}
+
+func collectSBOMPackages(sbom *model.HasSbom) sbomContents {
+	contents := sbomContents{}
+	seen := map[string]bool{}
+	add := func(pkg *model.Package) {
+		if pkg == nil || len(pkg.Namespaces) == 0 || len(pkg.Namespaces[0].Names) == 0 {
+			return
+		}
+		namespace := pkg.Namespaces[0]
+		name := namespace.Names[0]
+		// dependencies on a package name do not name a version that can be compared
+		if len(name.Versions) == 0 || seen[name.Versions[0].ID] {
+			return
+		}
+		version := name.Versions[0]
+		seen[version.ID] = true
+
+		var qualifiers []string
+		for _, qualifier := range version.Qualifiers {
+			qualifiers = append(qualifiers, qualifier.Key, qualifier.Value)
+		}
+		nameKey := helpers.PkgToPurl(pkg.Type, namespace.Namespace, name.Name, "", version.Subpath, qualifiers)
+		contents[nameKey] = append(contents[nameKey], &sbomPackage{
+			pkg:     pkg,
+			name:    nameKey,
+			purl:    helpers.PkgToPurl(pkg.Type, namespace.Namespace, name.Name, version.Version, version.Subpath, qualifiers),
+			version: version.Version,
+		})
+	}
+
+	for _, software := range sbom.IncludedSoftware {
+		if pkg, ok := software.(*model.Package); ok {
+			add(pkg)
+		}
+	}
+	for _, dependency := range sbom.IncludedDependencies {
+		add(dependency.Package)
+		add(dependency.DependencyPackage)
+	}
+	for _, occurrence := range sbom.IncludedOccurrences {
+		if pkg, ok := occurrence.Subject.(*model.Package); ok {
+			add(pkg)
+		}
+	}
+
+	for _, pkgs := range contents {
+		sort.Slice(pkgs, func(i, j int) bool { return pkgs[i].purl < pkgs[j].purl })
+	}
+	return contents
+}
+
+func sortedNames(base, target sbomContents) []string {
+	var names []string
+	for name := range base {
+		names = append(names, name)
+	}
+	for name := range target {
+		if _, ok := base[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func versionsNotIn(pkgs, other []*sbomPackage) []*sbomPackage {
+	var result []*sbomPackage
+	for _, p := range pkgs {
+		found := false
+		for _, o := range other {
+			if p.purl == o.purl {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// packagesLicense returns the licenses of the package versions of a package name
+func packagesLicense(ctx context.Context, b backends.Backend, pkgs []*sbomPackage) (string, error) {
+	var licenses []string
+	for _, p := range pkgs {
+		versionID := p.pkg.Namespaces[0].Names[0].Versions[0].ID
+		legals, err := b.CertifyLegal(ctx, &model.CertifyLegalSpec{
+			Subject: &model.PackageOrSourceSpec{Package: &model.PkgSpec{ID: &versionID}},
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to get licenses of package %s: %w", p.purl, err)
+		}
+		var latest *model.CertifyLegal
+		for _, legal := range legals {
+			if latest == nil || legal.TimeScanned.After(latest.TimeScanned) {
+				latest = legal
+			}
+		}
+		if latest == nil {
+			continue
+		}
+		license := latest.DeclaredLicense
+		if license == "" {
+			license = latest.DiscoveredLicense
+		}
+		if license != "" {
+			licenses = append(licenses, license)
+		}
+	}
+	sort.Strings(licenses)
+	return strings.Join(licenses, ", "), nil
+}
+
+// collectVulnerabilities returns the vulnerabilities affecting the packages of
+// an SBOM keyed by vulnerability ID
+func collectVulnerabilities(ctx context.Context, b backends.Backend, contents sbomContents) (map[string]*model.VulnerabilityChange, error) {
+	vulns := map[string]*model.VulnerabilityChange{}
+	for _, pkgs := range contents {
+		for _, p := range pkgs {
+			versionID := p.pkg.Namespaces[0].Names[0].Versions[0].ID
+			certifyVulns, err := b.CertifyVuln(ctx, &model.CertifyVulnSpec{Package: &model.PkgSpec{ID: &versionID}})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get vulnerabilities of package %s: %w", p.purl, err)
+			}
+			for _, certifyVuln := range certifyVulns {
+				if certifyVuln.Vulnerability.Type == noVulnType {
+					continue
+				}
+				for _, vulnID := range certifyVuln.Vulnerability.VulnerabilityIDs {
+					key := vulnID.VulnerabilityID + " " + p.purl
+					if _, ok := vulns[key]; !ok {
+						vulns[key] = &model.VulnerabilityChange{Vulnerability: certifyVuln.Vulnerability, Package: p.pkg}
+					}
+				}
+			}
+		}
+	}
+	return vulns, nil
+}
+
+// vulnerabilitiesNotIn returns the vulnerabilities of vulns whose vulnerability
+// ID is not found in other, regardless of the package it affects
+func vulnerabilitiesNotIn(vulns, other map[string]*model.VulnerabilityChange) []*model.VulnerabilityChange {
+	otherIDs := map[string]bool{}
+	for key := range other {
+		otherIDs[strings.SplitN(key, " ", 2)[0]] = true
+	}
+
+	var keys []string
+	for key := range vulns {
+		if !otherIDs[strings.SplitN(key, " ", 2)[0]] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	result := []*model.VulnerabilityChange{}
+	for _, key := range keys {
+		result = append(result, vulns[key])
+	}
+	return result
+}
@@ -0,0 +1,16 @@
+package resolvers
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.44
+
+import (
+	"context"
+
+	"github.com/guacsec/guac/pkg/assembler/graphql/model"
+)
+
+// SbomDiff is the resolver for the sbomDiff field.
+func (r *queryResolver) SbomDiff(ctx context.Context, base string, target string) (*model.SBOMDiff, error) {
+	return diffSBOMs(ctx, r.Backend, base, target)
+}
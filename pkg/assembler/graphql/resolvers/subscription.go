@@ -0,0 +1,67 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+
+	"github.com/guacsec/guac/pkg/assembler/eventbus"
+	"github.com/guacsec/guac/pkg/logging"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// subscribe streams the nodes of the events of kind published on the bus
+// until ctx is done. Each event is resolved by lookup, which returns nil for
+// nodes that do not match the filter of the subscription. Looking the nodes up
+// through the queries makes the filters of subscriptions behave exactly like
+// the ones of the queries.
+func subscribe[T any](ctx context.Context, bus *eventbus.Bus, kind eventbus.Kind, lookup func(context.Context, string) (*T, error)) (<-chan *T, error) {
+	if bus == nil {
+		return nil, gqlerror.Errorf("subscriptions are not enabled on this server")
+	}
+	events := bus.Subscribe(ctx)
+	nodes := make(chan *T, 1)
+	go func() {
+		defer close(nodes)
+		for event := range events {
+			if event.Kind != kind {
+				continue
+			}
+			node, err := lookup(ctx, event.ID)
+			if err != nil {
+				logging.FromContext(ctx).Warnf("failed to look up %s %s for subscription: %v", event.Kind, event.ID, err)
+				continue
+			}
+			if node == nil {
+				continue
+			}
+			select {
+			case nodes <- node:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nodes, nil
+}
+
+// first returns the first result of a query, or nil if there are none
+func first[T any](results []*T, err error) (*T, error) {
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return results[0], nil
+}
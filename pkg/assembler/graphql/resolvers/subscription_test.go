@@ -0,0 +1,111 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/guacsec/guac/pkg/assembler/backends"
+	_ "github.com/guacsec/guac/pkg/assembler/backends/keyvalue"
+	"github.com/guacsec/guac/pkg/assembler/eventbus"
+	"github.com/guacsec/guac/pkg/assembler/graphql/generated"
+	"github.com/guacsec/guac/pkg/assembler/graphql/model"
+	"github.com/guacsec/guac/pkg/assembler/graphql/resolvers"
+)
+
+func TestCertifyBadIngestedSubscription(t *testing.T) {
+	ctx := context.Background()
+	backend, err := backends.Get("keyvalue", ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to create backend: %v", err)
+	}
+	bus := eventbus.New()
+	config := generated.Config{Resolvers: &resolvers.Resolver{Backend: eventbus.WithEvents(backend, bus), Events: bus}}
+	config.Directives.Filter = resolvers.Filter
+	c := client.New(handler.NewDefaultServer(generated.NewExecutableSchema(config)))
+
+	for _, digest := range []string{"abc", "def"} {
+		var resp struct{ IngestArtifact string }
+		c.MustPost(`mutation($digest: String!) { ingestArtifact(artifact: {artifactInput: {algorithm: "sha256", digest: $digest}}) }`,
+			&resp, client.Var("digest", digest))
+	}
+
+	sub := c.Websocket(`subscription {
+		certifyBadIngested(certifyBadSpec: {subject: {artifact: {digest: "abc"}}}) {
+			justification
+			subject { ... on Artifact { digest } }
+		}
+	}`)
+	defer sub.Close()
+
+	type event struct {
+		CertifyBadIngested struct {
+			Justification string
+			Subject       struct{ Digest string }
+		}
+	}
+	received := make(chan event, 1)
+	errs := make(chan error, 1)
+	go func() {
+		var e event
+		if err := sub.Next(&e); err != nil {
+			errs <- err
+			return
+		}
+		received <- e
+	}()
+
+	ingest := func(digest string) {
+		var resp struct{ IngestCertifyBad string }
+		c.MustPost(`mutation($digest: String!) {
+			ingestCertifyBad(
+				subject: {artifact: {artifactInput: {algorithm: "sha256", digest: $digest}}},
+				pkgMatchType: {pkg: SPECIFIC_VERSION},
+				certifyBad: {justification: $digest, knownSince: "2024-01-01T00:00:00Z", origin: "test", collector: "test"})
+		}`, &resp, client.Var("digest", digest))
+	}
+
+	// the subscription starts asynchronously, so keep ingesting until the
+	// first event matching the filter is received
+	timeout := time.After(10 * time.Second)
+	for {
+		ingest("def")
+		ingest("abc")
+		select {
+		case e := <-received:
+			if e.CertifyBadIngested.Justification != "abc" || e.CertifyBadIngested.Subject.Digest != "abc" {
+				t.Errorf("unexpected event %+v, expected the certifyBad of artifact abc", e)
+			}
+			return
+		case err := <-errs:
+			t.Fatalf("subscription failed: %v", err)
+		case <-timeout:
+			t.Fatalf("timed out waiting for the subscription event")
+		case <-time.After(20 * time.Millisecond):
+		}
+	}
+}
+
+func TestSubscriptionsDisabled(t *testing.T) {
+	r := resolvers.Resolver{}
+	if _, err := r.Subscription().CertifyVulnIngested(context.Background(), model.CertifyVulnSpec{}); err == nil {
+		t.Errorf("expected an error when subscriptions are not enabled")
+	}
+}
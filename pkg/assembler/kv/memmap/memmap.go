@@ -35,7 +35,12 @@ func GetStore() kv.Store {
 	}
 }
 
-func (s *store) Get(_ context.Context, c, k string, v any) error {
+func (s *store) Get(ctx context.Context, c, k string, v any) error {
+	// lookups are cheap, so checking for cancellation here is what stops long
+	// running queries once the request deadline has passed
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	col, ok := s.m[c]
 	if !ok {
 		return fmt.Errorf("%w : Collection %q", kv.NotFoundError, c)
@@ -89,7 +94,10 @@ type scanner struct {
 	store      *store
 }
 
-func (s *scanner) Scan(_ context.Context) ([]string, bool, error) {
+func (s *scanner) Scan(ctx context.Context) ([]string, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
 	if s.done {
 		return nil, true, nil
 	}
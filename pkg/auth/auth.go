@@ -0,0 +1,162 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auth provides the authentication and authorization HTTP middleware
+// shared by the GUAC GraphQL and REST servers. Clients are authenticated with
+// static bearer tokens, mTLS client certificates or OIDC JWTs, and each
+// authenticated client is given a role that decides what it may do.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+// Role is the level of access granted to an authenticated client
+type Role string
+
+const (
+	// RoleReadOnly may query the graph but not call mutations
+	RoleReadOnly Role = "read-only"
+	// RoleReadWrite may query the graph and call mutations
+	RoleReadWrite Role = "read-write"
+)
+
+// ParseRole returns the role named by s
+func ParseRole(s string) (Role, error) {
+	switch Role(s) {
+	case RoleReadOnly, RoleReadWrite:
+		return Role(s), nil
+	default:
+		return "", fmt.Errorf("unknown role %q, expected %s or %s", s, RoleReadOnly, RoleReadWrite)
+	}
+}
+
+// CanWrite returns whether the role may call mutations
+func (r Role) CanWrite() bool {
+	return r == RoleReadWrite
+}
+
+// Principal is an authenticated client
+type Principal struct {
+	// Subject identifies the client, e.g. the token name, the common name of
+	// the client certificate or the sub claim of the JWT
+	Subject string
+	Role    Role
+	// Method is the authentication method that accepted the client
+	Method string
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the principal
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal of the request, or nil if
+// authentication is disabled
+func PrincipalFromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalKey{}).(*Principal)
+	return p
+}
+
+// ErrInvalidCredentials is returned by an Authenticator when the request
+// carries credentials for its method that are not valid
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// Authenticator authenticates a request with a single method. It returns a nil
+// principal and a nil error when the request carries no credentials it
+// recognizes, so that the next authenticator can be tried.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+// Config selects the authentication methods. Every method that is configured
+// is enabled, and authentication is disabled when none are.
+type Config struct {
+	// TokenFile is the path to a yaml file of static bearer tokens
+	TokenFile string
+	// ClientCAFile is the path to the PEM encoded CAs used to verify mTLS
+	// client certificates
+	ClientCAFile string
+	// OIDC configures the validation of JWTs issued by an OIDC provider
+	OIDC OIDCConfig
+}
+
+// Enabled returns whether any authentication method is configured
+func (c Config) Enabled() bool {
+	return c.TokenFile != "" || c.ClientCAFile != "" || c.OIDC.JWKS != ""
+}
+
+// NewAuthenticators returns the authenticators of all methods enabled by the
+// config, in the order they are tried
+func NewAuthenticators(ctx context.Context, c Config) ([]Authenticator, error) {
+	var authenticators []Authenticator
+	if c.ClientCAFile != "" {
+		authenticators = append(authenticators, &CertificateAuthenticator{})
+	}
+	if c.TokenFile != "" {
+		tokens, err := LoadTokenFile(c.TokenFile)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, tokens)
+	}
+	if c.OIDC.JWKS != "" {
+		oidc, err := NewOIDCAuthenticator(ctx, c.OIDC)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, oidc)
+	}
+	return authenticators, nil
+}
+
+// Middleware returns HTTP middleware that rejects requests which none of the
+// authenticators accept, and stores the principal of accepted requests in the
+// request context. With no authenticators every request is let through.
+func Middleware(authenticators []Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if len(authenticators) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger := logging.FromContext(r.Context())
+			for _, authenticator := range authenticators {
+				principal, err := authenticator.Authenticate(r)
+				if err != nil {
+					logger.Debugf("rejected request from %s: %v", r.RemoteAddr, err)
+					unauthorized(w)
+					return
+				}
+				if principal != nil {
+					next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+					return
+				}
+			}
+			unauthorized(w)
+		})
+	}
+}
+
+func unauthorized(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+}
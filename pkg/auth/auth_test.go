@@ -0,0 +1,454 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/google/go-cmp/cmp"
+	"github.com/guacsec/guac/pkg/assembler/backends"
+	_ "github.com/guacsec/guac/pkg/assembler/backends/keyvalue"
+	"github.com/guacsec/guac/pkg/assembler/graphql/generated"
+	"github.com/guacsec/guac/pkg/assembler/graphql/resolvers"
+	"github.com/guacsec/guac/pkg/auth"
+	jose "gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+)
+
+// principalHandler responds with the principal of the request
+var principalHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(auth.PrincipalFromContext(r.Context()))
+})
+
+// authenticate sends a request with the bearer token through the middleware
+// and returns the principal, or nil if the request was rejected
+func authenticate(t *testing.T, authenticators []auth.Authenticator, r *http.Request) *auth.Principal {
+	t.Helper()
+	w := httptest.NewRecorder()
+	auth.Middleware(authenticators)(principalHandler).ServeHTTP(w, r)
+	if w.Code == http.StatusUnauthorized {
+		return nil
+	}
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d", w.Code)
+	}
+	var p *auth.Principal
+	if err := json.NewDecoder(w.Body).Decode(&p); err != nil {
+		t.Fatalf("failed to decode principal: %v", err)
+	}
+	return p
+}
+
+func bearerRequest(token string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/query", nil)
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	return r
+}
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+const tokenFile = `
+tokens:
+  - name: ci
+    token: writer-token
+    role: read-write
+  - name: dashboard
+    token: reader-token
+    role: read-only
+`
+
+func TestTokenAuthenticator(t *testing.T) {
+	authenticators, err := auth.NewAuthenticators(context.Background(), auth.Config{TokenFile: writeFile(t, "tokens.yaml", []byte(tokenFile))})
+	if err != nil {
+		t.Fatalf("NewAuthenticators() error: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  *auth.Principal
+	}{
+		{
+			name:  "read-write token",
+			token: "writer-token",
+			want:  &auth.Principal{Subject: "ci", Role: auth.RoleReadWrite, Method: "token"},
+		},
+		{
+			name:  "read-only token",
+			token: "reader-token",
+			want:  &auth.Principal{Subject: "dashboard", Role: auth.RoleReadOnly, Method: "token"},
+		},
+		{
+			name:  "unknown token",
+			token: "writer-token2",
+		},
+		{
+			name: "no token",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := authenticate(t, authenticators, bearerRequest(test.token))
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("unexpected principal (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTokenFileErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		tokens []auth.StaticToken
+	}{
+		{
+			name:   "unknown role",
+			tokens: []auth.StaticToken{{Name: "admin", Token: "a", Role: "admin"}},
+		},
+		{
+			name:   "empty token",
+			tokens: []auth.StaticToken{{Name: "ci", Role: "read-only"}},
+		},
+		{
+			name: "duplicate token",
+			tokens: []auth.StaticToken{
+				{Name: "ci", Token: "a", Role: "read-only"},
+				{Name: "dashboard", Token: "a", Role: "read-write"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := auth.NewTokenAuthenticator(test.tokens); err == nil {
+				t.Errorf("NewTokenAuthenticator() expected an error")
+			}
+		})
+	}
+}
+
+func TestMiddlewareDisabled(t *testing.T) {
+	authenticators, err := auth.NewAuthenticators(context.Background(), auth.Config{})
+	if err != nil {
+		t.Fatalf("NewAuthenticators() error: %v", err)
+	}
+	if got := authenticate(t, authenticators, bearerRequest("")); got != nil {
+		t.Errorf("expected no principal, got %v", got)
+	}
+}
+
+func TestCertificateAuthenticator(t *testing.T) {
+	tests := []struct {
+		name  string
+		units []string
+		want  *auth.Principal
+	}{
+		{
+			name: "no role defaults to read-only",
+			want: &auth.Principal{Subject: "client", Role: auth.RoleReadOnly, Method: "mtls"},
+		},
+		{
+			name:  "role from organizational unit",
+			units: []string{"engineering", "read-write"},
+			want:  &auth.Principal{Subject: "client", Role: auth.RoleReadWrite, Method: "mtls"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cert := &x509.Certificate{Subject: pkix.Name{CommonName: "client", OrganizationalUnit: test.units}}
+			r := bearerRequest("")
+			r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+			got := authenticate(t, []auth.Authenticator{auth.CertificateAuthenticator{}}, r)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("unexpected principal (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if got := authenticate(t, []auth.Authenticator{auth.CertificateAuthenticator{}}, bearerRequest("")); got != nil {
+		t.Errorf("expected a request without a client certificate to be rejected, got %v", got)
+	}
+}
+
+type oidcClaims struct {
+	jwt.Claims
+	Roles interface{} `json:"roles,omitempty"`
+}
+
+func signToken(t *testing.T, key *rsa.PrivateKey, kid string, claims oidcClaims) string {
+	t.Helper()
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).WithHeader("kid", kid))
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+	token, err := jwt.Signed(signer).Claims(claims).CompactSerialize()
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+func jwks(t *testing.T, key *rsa.PrivateKey, kid string) []byte {
+	t.Helper()
+	data, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: key.Public(), KeyID: kid, Algorithm: string(jose.RS256), Use: "sig"},
+	}})
+	if err != nil {
+		t.Fatalf("failed to marshal JWKS: %v", err)
+	}
+	return data
+}
+
+func TestOIDCAuthenticator(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	config := auth.Config{
+		TokenFile: writeFile(t, "tokens.yaml", []byte(tokenFile)),
+		OIDC: auth.OIDCConfig{
+			JWKS:     writeFile(t, "jwks.json", jwks(t, key, "key1")),
+			Issuer:   "https://issuer.example.com",
+			Audience: "guac",
+		},
+	}
+	authenticators, err := auth.NewAuthenticators(context.Background(), config)
+	if err != nil {
+		t.Fatalf("NewAuthenticators() error: %v", err)
+	}
+
+	now := time.Now()
+	valid := func(roles interface{}) oidcClaims {
+		return oidcClaims{
+			Claims: jwt.Claims{
+				Subject:  "alice",
+				Issuer:   "https://issuer.example.com",
+				Audience: jwt.Audience{"guac"},
+				IssuedAt: jwt.NewNumericDate(now),
+				Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+			},
+			Roles: roles,
+		}
+	}
+	expired := valid(nil)
+	expired.Expiry = jwt.NewNumericDate(now.Add(-time.Hour))
+	noExpiry := valid(nil)
+	noExpiry.Expiry = nil
+	wrongIssuer := valid(nil)
+	wrongIssuer.Issuer = "https://other.example.com"
+	wrongAudience := valid(nil)
+	wrongAudience.Audience = jwt.Audience{"other"}
+
+	tests := []struct {
+		name  string
+		token string
+		want  *auth.Principal
+	}{
+		{
+			name:  "no roles",
+			token: signToken(t, key, "key1", valid(nil)),
+			want:  &auth.Principal{Subject: "alice", Role: auth.RoleReadOnly, Method: "oidc"},
+		},
+		{
+			name:  "single role",
+			token: signToken(t, key, "key1", valid("read-write")),
+			want:  &auth.Principal{Subject: "alice", Role: auth.RoleReadWrite, Method: "oidc"},
+		},
+		{
+			name:  "list of roles",
+			token: signToken(t, key, "key1", valid([]string{"read-only", "read-write"})),
+			want:  &auth.Principal{Subject: "alice", Role: auth.RoleReadWrite, Method: "oidc"},
+		},
+		{
+			name:  "static tokens are still accepted",
+			token: "reader-token",
+			want:  &auth.Principal{Subject: "dashboard", Role: auth.RoleReadOnly, Method: "token"},
+		},
+		{
+			name:  "expired",
+			token: signToken(t, key, "key1", expired),
+		},
+		{
+			name:  "no expiry",
+			token: signToken(t, key, "key1", noExpiry),
+		},
+		{
+			name:  "wrong issuer",
+			token: signToken(t, key, "key1", wrongIssuer),
+		},
+		{
+			name:  "wrong audience",
+			token: signToken(t, key, "key1", wrongAudience),
+		},
+		{
+			name:  "signed by an unknown key",
+			token: signToken(t, otherKey, "key1", valid(nil)),
+		},
+		{
+			name:  "unknown key ID",
+			token: signToken(t, key, "key2", valid(nil)),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := authenticate(t, authenticators, bearerRequest(test.token))
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("unexpected principal (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOIDCAuthenticatorURL(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	keySet := jwks(t, key, "key1")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(keySet)
+	}))
+	defer server.Close()
+
+	authenticator, err := auth.NewOIDCAuthenticator(context.Background(), auth.OIDCConfig{JWKS: server.URL})
+	if err != nil {
+		t.Fatalf("NewOIDCAuthenticator() error: %v", err)
+	}
+	token := signToken(t, key, "key1", oidcClaims{Claims: jwt.Claims{Subject: "bob", Expiry: jwt.NewNumericDate(time.Now().Add(time.Hour))}})
+	want := &auth.Principal{Subject: "bob", Role: auth.RoleReadOnly, Method: "oidc"}
+	got := authenticate(t, []auth.Authenticator{authenticator}, bearerRequest(token))
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected principal (-want +got):\n%s", diff)
+	}
+}
+
+func TestAuthorizeOperation(t *testing.T) {
+	ctx := context.Background()
+	backend, err := backends.Get("keyvalue", ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to create backend: %v", err)
+	}
+	config := generated.Config{Resolvers: &resolvers.Resolver{Backend: backend}}
+	config.Directives.Filter = resolvers.Filter
+	srv := handler.NewDefaultServer(generated.NewExecutableSchema(config))
+	srv.AroundOperations(auth.AuthorizeOperation)
+
+	authenticators, err := auth.NewAuthenticators(ctx, auth.Config{TokenFile: writeFile(t, "tokens.yaml", []byte(tokenFile))})
+	if err != nil {
+		t.Fatalf("NewAuthenticators() error: %v", err)
+	}
+	server := httptest.NewServer(auth.Middleware(authenticators)(srv))
+	defer server.Close()
+
+	const (
+		query    = `{"query": "query { artifacts(artifactSpec: {}) { id } }"}`
+		mutation = `{"query": "mutation { ingestArtifact(artifact: {artifactInput: {algorithm: \"sha256\", digest: \"abc\"}}) }"}`
+	)
+	tests := []struct {
+		name      string
+		token     string
+		body      string
+		wantCode  int
+		wantError bool
+	}{
+		{
+			name:     "unauthenticated",
+			body:     query,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "read-only query",
+			token:    "reader-token",
+			body:     query,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:      "read-only mutation",
+			token:     "reader-token",
+			body:      mutation,
+			wantCode:  http.StatusOK,
+			wantError: true,
+		},
+		{
+			name:     "read-write mutation",
+			token:    "writer-token",
+			body:     mutation,
+			wantCode: http.StatusOK,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(test.body))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			r.Header.Set("Content-Type", "application/json")
+			if test.token != "" {
+				r.Header.Set("Authorization", "Bearer "+test.token)
+			}
+			resp, err := server.Client().Do(r)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != test.wantCode {
+				t.Fatalf("unexpected status %d, want %d", resp.StatusCode, test.wantCode)
+			}
+			if resp.StatusCode != http.StatusOK {
+				return
+			}
+			var body struct {
+				Errors []struct {
+					Message string `json:"message"`
+				} `json:"errors"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if gotError := len(body.Errors) > 0; gotError != test.wantError {
+				t.Errorf("unexpected errors %v, want errors: %v", body.Errors, test.wantError)
+			}
+		})
+	}
+}
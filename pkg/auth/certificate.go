@@ -0,0 +1,66 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// ClientCertTLSConfig returns the server TLS config that verifies client
+// certificates against the CAs in caFile. Clients without a certificate are
+// still accepted by the TLS handshake, so that they can authenticate with a
+// bearer token instead.
+func ClientCertTLSConfig(caFile string) (*tls.Config, error) {
+	data, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM certificates found in client CA file %s", caFile)
+	}
+	return &tls.Config{
+		ClientCAs:  pool,
+		ClientAuth: tls.VerifyClientCertIfGiven,
+		MinVersion: tls.VersionTLS12,
+	}, nil
+}
+
+// CertificateAuthenticator authenticates clients by the certificate verified
+// during the TLS handshake. The subject is the common name of the certificate
+// and the role is the first organizational unit naming a role, read-only if
+// there is none.
+type CertificateAuthenticator struct{}
+
+// Authenticate implements Authenticator
+func (CertificateAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil, nil
+	}
+	cert := r.TLS.VerifiedChains[0][0]
+	role := RoleReadOnly
+	for _, unit := range cert.Subject.OrganizationalUnit {
+		if parsed, err := ParseRole(unit); err == nil {
+			role = parsed
+			break
+		}
+	}
+	return &Principal{Subject: cert.Subject.CommonName, Role: role, Method: "mtls"}, nil
+}
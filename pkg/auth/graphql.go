@@ -0,0 +1,35 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// AuthorizeOperation is a gqlgen operation middleware that rejects mutations
+// from principals whose role cannot write. Operations without a principal are
+// allowed, as authentication is then disabled.
+func AuthorizeOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	principal := PrincipalFromContext(ctx)
+	operation := graphql.GetOperationContext(ctx).Operation
+	if principal != nil && operation != nil && operation.Operation == ast.Mutation && !principal.Role.CanWrite() {
+		return graphql.OneShot(graphql.ErrorResponse(ctx, "forbidden: role %s cannot call mutations", principal.Role))
+	}
+	return next(ctx)
+}
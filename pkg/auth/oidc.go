@@ -0,0 +1,217 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/guacsec/guac/pkg/logging"
+	jose "gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+)
+
+const (
+	defaultRolesClaim = "roles"
+	// jwksRefreshInterval limits how often a JWKS URL is fetched again when a
+	// token is signed by an unknown key
+	jwksRefreshInterval = time.Minute
+	// clockSkew is the leeway allowed when checking the expiry of a JWT
+	clockSkew = time.Minute
+)
+
+// OIDCConfig configures the validation of JWTs issued by an OIDC provider
+type OIDCConfig struct {
+	// JWKS is the path or the http(s) URL of the JSON Web Key Set used to
+	// verify the signature of tokens
+	JWKS string
+	// Issuer is the expected iss claim, not checked if empty
+	Issuer string
+	// Audience is the expected aud claim, not checked if empty
+	Audience string
+	// RolesClaim is the claim holding the role or list of roles of the
+	// client, "roles" if empty. Clients without a known role are read-only.
+	RolesClaim string
+}
+
+// OIDCAuthenticator authenticates JWT bearer tokens
+type OIDCAuthenticator struct {
+	config OIDCConfig
+	client *http.Client
+
+	mu          sync.Mutex
+	keys        *jose.JSONWebKeySet
+	lastFetched time.Time
+}
+
+// NewOIDCAuthenticator loads the key set of the config and returns an
+// authenticator for tokens signed by it
+func NewOIDCAuthenticator(ctx context.Context, config OIDCConfig) (*OIDCAuthenticator, error) {
+	if config.RolesClaim == "" {
+		config.RolesClaim = defaultRolesClaim
+	}
+	a := &OIDCAuthenticator{config: config, client: &http.Client{Timeout: 30 * time.Second}}
+	keys, err := a.loadKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	a.keys = keys
+	a.lastFetched = time.Now()
+	return a, nil
+}
+
+func (a *OIDCAuthenticator) isURL() bool {
+	return strings.HasPrefix(a.config.JWKS, "https://") || strings.HasPrefix(a.config.JWKS, "http://")
+}
+
+func (a *OIDCAuthenticator) loadKeys(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	var data []byte
+	if a.isURL() {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.config.JWKS, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create JWKS request: %w", err)
+		}
+		resp, err := a.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch JWKS from %s: status %d", a.config.JWKS, resp.StatusCode)
+		}
+		data, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWKS: %w", err)
+		}
+	} else {
+		var err error
+		data, err = os.ReadFile(a.config.JWKS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+		}
+	}
+
+	var keys jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+	if len(keys.Keys) == 0 {
+		return nil, fmt.Errorf("JWKS %s has no keys", a.config.JWKS)
+	}
+	return &keys, nil
+}
+
+// verificationKeys returns the keys that may have signed a token with the key
+// ID. A JWKS URL is fetched again if none are known, as the provider may have
+// rotated its keys.
+func (a *OIDCAuthenticator) verificationKeys(ctx context.Context, kid string) []jose.JSONWebKey {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	keys := matchingKeys(a.keys, kid)
+	if len(keys) > 0 || !a.isURL() || time.Since(a.lastFetched) < jwksRefreshInterval {
+		return keys
+	}
+	a.lastFetched = time.Now()
+	refreshed, err := a.loadKeys(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Warnf("failed to refresh JWKS: %v", err)
+		return nil
+	}
+	a.keys = refreshed
+	return matchingKeys(a.keys, kid)
+}
+
+func matchingKeys(set *jose.JSONWebKeySet, kid string) []jose.JSONWebKey {
+	var keys []jose.JSONWebKey
+	for _, key := range set.Keys {
+		if key.Use == "enc" || !key.IsPublic() {
+			continue
+		}
+		if kid == "" || key.KeyID == kid {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Authenticate implements Authenticator. Bearer tokens that are not JWTs are
+// left to the other authenticators.
+func (a *OIDCAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	raw, ok := bearerToken(r)
+	if !ok {
+		return nil, nil
+	}
+	token, err := jwt.ParseSigned(raw)
+	if err != nil {
+		return nil, nil
+	}
+	if len(token.Headers) != 1 {
+		return nil, fmt.Errorf("%w: expected a single JWT signature", ErrInvalidCredentials)
+	}
+
+	var claims jwt.Claims
+	custom := map[string]interface{}{}
+	verified := false
+	for _, key := range a.verificationKeys(r.Context(), token.Headers[0].KeyID) {
+		if err := token.Claims(key.Key, &claims, &custom); err == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, fmt.Errorf("%w: JWT signature could not be verified", ErrInvalidCredentials)
+	}
+
+	if claims.Expiry == nil {
+		return nil, fmt.Errorf("%w: JWT has no expiry", ErrInvalidCredentials)
+	}
+	expected := jwt.Expected{Issuer: a.config.Issuer, Time: time.Now()}
+	if a.config.Audience != "" {
+		expected.Audience = jwt.Audience{a.config.Audience}
+	}
+	if err := claims.ValidateWithLeeway(expected, clockSkew); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+
+	return &Principal{Subject: claims.Subject, Role: rolesClaim(custom[a.config.RolesClaim]), Method: "oidc"}, nil
+}
+
+// rolesClaim returns the highest known role in the claim, which is either a
+// single role or a list of roles
+func rolesClaim(claim interface{}) Role {
+	var values []interface{}
+	switch c := claim.(type) {
+	case string:
+		values = []interface{}{c}
+	case []interface{}:
+		values = c
+	}
+	role := RoleReadOnly
+	for _, value := range values {
+		if s, ok := value.(string); ok && Role(s).CanWrite() {
+			role = RoleReadWrite
+		}
+	}
+	return role
+}
@@ -0,0 +1,126 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TokenFile is the format of the static bearer token file:
+//
+//	tokens:
+//	  - name: ci
+//	    token: s3cr3t
+//	    role: read-write
+//	  - name: dashboard
+//	    token: an0th3r
+//	    role: read-only
+type TokenFile struct {
+	Tokens []StaticToken `yaml:"tokens"`
+}
+
+// StaticToken is a bearer token and the client it identifies
+type StaticToken struct {
+	Name  string `yaml:"name"`
+	Token string `yaml:"token"`
+	Role  string `yaml:"role"`
+}
+
+// TokenAuthenticator authenticates static bearer tokens
+type TokenAuthenticator struct {
+	// tokens are keyed by their hash, so that looking up a token does not
+	// leak how much of it matches through timing
+	tokens map[[sha256.Size]byte]*Principal
+}
+
+// LoadTokenFile reads a TokenFile and returns an authenticator for its tokens
+func LoadTokenFile(path string) (*TokenAuthenticator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+	var file TokenFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse token file %s: %w", path, err)
+	}
+	return NewTokenAuthenticator(file.Tokens)
+}
+
+// NewTokenAuthenticator returns an authenticator for the tokens
+func NewTokenAuthenticator(tokens []StaticToken) (*TokenAuthenticator, error) {
+	a := &TokenAuthenticator{tokens: map[[sha256.Size]byte]*Principal{}}
+	for i, token := range tokens {
+		if token.Token == "" {
+			return nil, fmt.Errorf("token %d (%s) is empty", i, token.Name)
+		}
+		role, err := ParseRole(token.Role)
+		if err != nil {
+			return nil, fmt.Errorf("token %d (%s): %w", i, token.Name, err)
+		}
+		hash := sha256.Sum256([]byte(token.Token))
+		if _, ok := a.tokens[hash]; ok {
+			return nil, fmt.Errorf("token %d (%s) is a duplicate", i, token.Name)
+		}
+		a.tokens[hash] = &Principal{Subject: token.Name, Role: role, Method: "token"}
+	}
+	return a, nil
+}
+
+// Authenticate implements Authenticator. Unknown tokens are left to the
+// other authenticators, as they may be JWTs.
+func (a *TokenAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, nil
+	}
+	return a.tokens[sha256.Sum256([]byte(token))], nil
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// BearerTransport is an http.RoundTripper that adds a bearer token to every
+// request, for clients of a server that requires authentication
+type BearerTransport struct {
+	Token string
+	// Base is the transport used to send the requests, http.DefaultTransport
+	// if nil
+	Base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *BearerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	r = r.Clone(r.Context())
+	r.Header.Set("Authorization", "Bearer "+t.Token)
+	return base.RoundTrip(r)
+}
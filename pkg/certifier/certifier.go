@@ -47,6 +47,8 @@ type ErrHandler func(error) bool
 type CertifierType string
 
 const (
-	CertifierOSV       CertifierType = "OSV"
-	CertifierScorecard CertifierType = "scorecard"
+	CertifierOSV            CertifierType = "OSV"
+	CertifierScorecard      CertifierType = "scorecard"
+	CertifierClearlyDefined CertifierType = "ClearlyDefined"
+	CertifierExploitability CertifierType = "exploitability"
 )
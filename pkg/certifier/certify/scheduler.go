@@ -0,0 +1,404 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/guacsec/guac/pkg/certifier"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/logging"
+	"github.com/guacsec/guac/pkg/metrics"
+)
+
+const (
+	prometheusPrefix = "certifier"
+
+	runsCounter       = "runs_total"
+	componentsCounter = "components_total"
+	documentsCounter  = "documents_total"
+	errorsCounter     = "errors_total"
+	lastRunGauge      = "last_run_timestamp_seconds"
+	runningGauge      = "running"
+	runDuration       = "run_duration_seconds"
+)
+
+var (
+	metricsOnce      sync.Once
+	schedulerMetrics metrics.MetricCollector
+	errMetrics       error
+)
+
+// CertifierConfig configures a certifier run by the Scheduler
+type CertifierConfig struct {
+	Type certifier.CertifierType
+	// Query gets the components to certify
+	Query certifier.QueryComponents
+	// NewCertifier returns the certifier of a batch of components
+	NewCertifier func() certifier.Certifier
+	// Interval is the time between the start of two runs, 0 runs once
+	Interval time.Duration
+	// Concurrency is the number of batches of components certified at once,
+	// defaults to 1
+	Concurrency int
+	// RateLimit is the number of batches of components certified per second,
+	// 0 is unlimited
+	RateLimit float64
+}
+
+// Status is the status of a certifier run by the Scheduler
+type Status struct {
+	Type                certifier.CertifierType `json:"type"`
+	Running             bool                    `json:"running"`
+	Runs                int64                   `json:"runs"`
+	LastRunStart        *time.Time              `json:"lastRunStart,omitempty"`
+	LastRunEnd          *time.Time              `json:"lastRunEnd,omitempty"`
+	LastRunError        string                  `json:"lastRunError,omitempty"`
+	NextRun             *time.Time              `json:"nextRun,omitempty"`
+	ComponentsProcessed int64                   `json:"componentsProcessed"`
+	DocumentsEmitted    int64                   `json:"documentsEmitted"`
+	Errors              int64                   `json:"errors"`
+}
+
+type scheduled struct {
+	config  CertifierConfig
+	limiter *rate.Limiter
+
+	mu     sync.Mutex
+	status Status
+}
+
+// Scheduler runs multiple certifiers concurrently, each with its own
+// interval, concurrency and rate limit. The failure of a certifier, or of one
+// of its runs, does not stop the others.
+type Scheduler struct {
+	emitter certifier.Emitter
+	metrics metrics.MetricCollector
+
+	mu         sync.Mutex
+	certifiers []*scheduled
+}
+
+// NewScheduler returns a scheduler emitting the documents of the certifiers
+// to the emitter, which must be safe for concurrent use
+func NewScheduler(ctx context.Context, emitter certifier.Emitter) (*Scheduler, error) {
+	m, err := registerMetricsOnce(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Scheduler{emitter: emitter, metrics: m}, nil
+}
+
+// Register adds a certifier to run
+func (s *Scheduler) Register(config CertifierConfig) error {
+	if config.Query == nil || config.NewCertifier == nil {
+		return fmt.Errorf("certifier %s requires a query and a certifier", config.Type)
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = 1
+	}
+	if config.Interval < 0 || config.RateLimit < 0 {
+		return fmt.Errorf("certifier %s interval and rate limit must not be negative", config.Type)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.certifiers {
+		if c.config.Type == config.Type {
+			return certifierTypeOverwriteError(config.Type)
+		}
+	}
+	c := &scheduled{config: config, status: Status{Type: config.Type}}
+	if config.RateLimit > 0 {
+		burst := int(config.RateLimit)
+		if burst < 1 {
+			burst = 1
+		}
+		c.limiter = rate.NewLimiter(rate.Limit(config.RateLimit), burst)
+	}
+	s.certifiers = append(s.certifiers, c)
+	return nil
+}
+
+// Run runs the registered certifiers until the context is canceled, or until
+// they all ran once when none has an interval
+func (s *Scheduler) Run(ctx context.Context) error {
+	s.mu.Lock()
+	certifiers := append([]*scheduled{}, s.certifiers...)
+	s.mu.Unlock()
+	if len(certifiers) == 0 {
+		return fmt.Errorf("no certifier registered")
+	}
+
+	var wg sync.WaitGroup
+	for _, c := range certifiers {
+		wg.Add(1)
+		go func(c *scheduled) {
+			defer wg.Done()
+			s.schedule(ctx, c)
+		}(c)
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// Status returns the status of the registered certifiers
+func (s *Scheduler) Status() []Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	statuses := make([]Status, 0, len(s.certifiers))
+	for _, c := range s.certifiers {
+		c.mu.Lock()
+		statuses = append(statuses, c.status)
+		c.mu.Unlock()
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Type < statuses[j].Type })
+	return statuses
+}
+
+// StatusHandler returns a http.Handler serving the status of the certifiers
+// as JSON
+func (s *Scheduler) StatusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(s.Status()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// MetricsHandler returns a http.Handler serving the prometheus metrics
+func (s *Scheduler) MetricsHandler() http.Handler {
+	return s.metrics.MetricsHandler()
+}
+
+// schedule runs the certifier at its interval until the context is canceled
+func (s *Scheduler) schedule(ctx context.Context, c *scheduled) {
+	logger := logging.FromContext(ctx)
+	for {
+		start := time.Now()
+		if err := s.run(ctx, c); err != nil && !errors.Is(err, context.Canceled) {
+			logger.Errorf("certifier %s run failed: %v", c.config.Type, err)
+		}
+		if c.config.Interval == 0 {
+			return
+		}
+		next := start.Add(c.config.Interval)
+		c.mu.Lock()
+		c.status.NextRun = &next
+		c.mu.Unlock()
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// run certifies all the components once. Errors of the certifier are
+// recorded and do not stop the run, an error of the query fails it. A run
+// stopped by canceling the context is not an error.
+func (s *Scheduler) run(ctx context.Context, c *scheduled) (err error) {
+	logger := logging.FromContext(ctx)
+	certifierType := string(c.config.Type)
+	start := time.Now()
+	c.mu.Lock()
+	c.status.Running = true
+	c.status.LastRunStart = &start
+	c.status.NextRun = nil
+	c.mu.Unlock()
+	s.setGauge(ctx, runningGauge, 1, certifierType)
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("run panicked: %v", r)
+		}
+		end := time.Now()
+		result := "success"
+		c.mu.Lock()
+		c.status.Running = false
+		c.status.Runs++
+		c.status.LastRunEnd = &end
+		c.status.LastRunError = ""
+		failed := err != nil && !errors.Is(err, context.Canceled)
+		switch {
+		case failed:
+			result = "failure"
+			c.status.LastRunError = err.Error()
+			c.status.Errors++
+		case err != nil:
+			result = "canceled"
+		}
+		c.mu.Unlock()
+		if failed {
+			s.addCounter(ctx, errorsCounter, 1, certifierType)
+		}
+		s.addCounter(ctx, runsCounter, 1, certifierType, result)
+		s.setGauge(ctx, runningGauge, 0, certifierType)
+		s.setGauge(ctx, lastRunGauge, float64(end.Unix()), certifierType)
+		s.observeHistogram(ctx, runDuration, end.Sub(start).Seconds(), certifierType)
+	}()
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// compChan to collect query components
+	compChan := make(chan interface{}, c.config.Concurrency)
+	// queryErr to receive error from the query
+	queryErr := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				queryErr <- fmt.Errorf("query panicked: %v", r)
+			}
+			close(compChan)
+		}()
+		queryErr <- c.config.Query.GetComponents(runCtx, compChan)
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < c.config.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for component := range compChan {
+				if c.limiter != nil {
+					if err := c.limiter.Wait(runCtx); err != nil {
+						continue
+					}
+				}
+				if err := s.certify(runCtx, c, component); err != nil && !errors.Is(err, context.Canceled) {
+					logger.Errorf("certifier %s failed: %v", c.config.Type, err)
+					c.mu.Lock()
+					c.status.Errors++
+					c.mu.Unlock()
+					s.addCounter(ctx, errorsCounter, 1, certifierType)
+				}
+				c.mu.Lock()
+				c.status.ComponentsProcessed++
+				c.mu.Unlock()
+				s.addCounter(ctx, componentsCounter, 1, certifierType)
+			}
+		}()
+	}
+	wg.Wait()
+	if err := <-queryErr; err != nil {
+		return fmt.Errorf("failed to get components: %w", err)
+	}
+	return ctx.Err()
+}
+
+// certify runs a new certifier on a batch of components and emits its
+// documents
+func (s *Scheduler) certify(ctx context.Context, c *scheduled, component interface{}) (err error) {
+	logger := logging.FromContext(ctx)
+	certifierType := string(c.config.Type)
+	// docChan to collect the documents
+	docChan := make(chan *processor.Document, BufferChannelSize)
+	// errChan to receive error from the certifier
+	errChan := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				errChan <- fmt.Errorf("certifier panicked: %v", r)
+			}
+		}()
+		errChan <- c.config.NewCertifier().CertifyComponent(ctx, component, docChan)
+	}()
+
+	var emitted int64
+	emit := func(d *processor.Document) {
+		if err := s.emitter(d); err != nil {
+			logger.Errorf("certifier %s emit error: %v", c.config.Type, err)
+			return
+		}
+		emitted++
+	}
+	defer func() {
+		c.mu.Lock()
+		c.status.DocumentsEmitted += emitted
+		c.mu.Unlock()
+		s.addCounter(ctx, documentsCounter, float64(emitted), certifierType)
+	}()
+	for {
+		select {
+		case d := <-docChan:
+			emit(d)
+		case err := <-errChan:
+			for len(docChan) > 0 {
+				emit(<-docChan)
+			}
+			return err
+		}
+	}
+}
+
+func (s *Scheduler) addCounter(ctx context.Context, name string, value float64, labels ...string) {
+	if err := s.metrics.AddCounter(ctx, name, value, labels...); err != nil {
+		logging.FromContext(ctx).Debugf("failed to add counter: %v", err)
+	}
+}
+
+func (s *Scheduler) setGauge(ctx context.Context, name string, value float64, labels ...string) {
+	if err := s.metrics.SetGauge(ctx, name, value, labels...); err != nil {
+		logging.FromContext(ctx).Debugf("failed to set gauge: %v", err)
+	}
+}
+
+func (s *Scheduler) observeHistogram(ctx context.Context, name string, value float64, labels ...string) {
+	if err := s.metrics.ObserveHistogram(ctx, name, value, labels...); err != nil {
+		logging.FromContext(ctx).Debugf("failed to observe histogram: %v", err)
+	}
+}
+
+// registerMetricsOnce registers the metrics of the certifiers once, as
+// prometheus metrics are global
+func registerMetricsOnce(ctx context.Context) (metrics.MetricCollector, error) {
+	metricsOnce.Do(func() {
+		m := metrics.NewPrometheus(prometheusPrefix)
+		for name, labels := range map[string][]string{
+			runsCounter:       {"certifier", "result"},
+			componentsCounter: {"certifier"},
+			documentsCounter:  {"certifier"},
+			errorsCounter:     {"certifier"},
+		} {
+			if _, err := m.RegisterCounter(ctx, name, labels...); err != nil {
+				errMetrics = fmt.Errorf("failed to register counter %s: %w", name, err)
+				return
+			}
+		}
+		for _, name := range []string{lastRunGauge, runningGauge} {
+			if _, err := m.RegisterGauge(ctx, name, "certifier"); err != nil {
+				errMetrics = fmt.Errorf("failed to register gauge %s: %w", name, err)
+				return
+			}
+		}
+		if _, err := m.RegisterHistogram(ctx, runDuration, "certifier"); err != nil {
+			errMetrics = fmt.Errorf("failed to register histogram %s: %w", runDuration, err)
+			return
+		}
+		schedulerMetrics = m
+	})
+	return schedulerMetrics, errMetrics
+}
@@ -0,0 +1,192 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/guacsec/guac/pkg/certifier"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+// batchQuery sends numbered batches of components
+type batchQuery struct {
+	batches int
+	err     error
+}
+
+func (q *batchQuery) GetComponents(ctx context.Context, compChan chan<- interface{}) error {
+	for i := 0; i < q.batches; i++ {
+		compChan <- i
+	}
+	return q.err
+}
+
+// funcCertifier certifies a component with a function
+type funcCertifier func(component interface{}, docChannel chan<- *processor.Document) error
+
+func (f funcCertifier) CertifyComponent(_ context.Context, component interface{}, docChannel chan<- *processor.Document) error {
+	return f(component, docChannel)
+}
+
+func TestScheduler(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	var mu sync.Mutex
+	emitted := map[string]int{}
+	s, err := NewScheduler(ctx, func(d *processor.Document) error {
+		mu.Lock()
+		defer mu.Unlock()
+		emitted[d.SourceInformation.Collector]++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("NewScheduler() error = %v", err)
+	}
+
+	var inFlight, maxInFlight int32
+	emit := func(collector string) func() certifier.Certifier {
+		return func() certifier.Certifier {
+			return funcCertifier(func(component interface{}, docChannel chan<- *processor.Document) error {
+				n := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					max := atomic.LoadInt32(&maxInFlight)
+					if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				docChannel <- &processor.Document{SourceInformation: processor.SourceInformation{Collector: collector}}
+				return nil
+			})
+		}
+	}
+	configs := []CertifierConfig{{
+		Type:         "concurrent",
+		Query:        &batchQuery{batches: 8},
+		NewCertifier: emit("concurrent"),
+		Concurrency:  4,
+	}, {
+		Type:  "failing",
+		Query: &batchQuery{batches: 3},
+		NewCertifier: func() certifier.Certifier {
+			return funcCertifier(func(component interface{}, docChannel chan<- *processor.Document) error {
+				switch component.(int) {
+				case 0:
+					return errors.New("certifier failed")
+				case 1:
+					panic("certifier panicked")
+				}
+				docChannel <- &processor.Document{SourceInformation: processor.SourceInformation{Collector: "failing"}}
+				return nil
+			})
+		},
+	}, {
+		Type:         "broken query",
+		Query:        &batchQuery{batches: 1, err: errors.New("query failed")},
+		NewCertifier: emit("broken query"),
+	}}
+	for _, config := range configs {
+		if err := s.Register(config); err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+	}
+	if err := s.Register(configs[0]); !errors.Is(err, errCertifierOverwrite) {
+		t.Errorf("Register() error = %v, want %v", err, errCertifierOverwrite)
+	}
+
+	if err := s.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if emitted["concurrent"] != 8 || emitted["failing"] != 1 || emitted["broken query"] != 1 {
+		t.Errorf("unexpected emitted documents %v", emitted)
+	}
+	if maxInFlight < 2 {
+		t.Errorf("certified at most %d batches at once, want concurrent batches", maxInFlight)
+	}
+	statuses := map[certifier.CertifierType]Status{}
+	for _, status := range s.Status() {
+		statuses[status.Type] = status
+	}
+	if status := statuses["concurrent"]; status.Runs != 1 || status.ComponentsProcessed != 8 || status.DocumentsEmitted != 8 || status.Errors != 0 || status.LastRunEnd == nil {
+		t.Errorf("unexpected status %+v", status)
+	}
+	if status := statuses["failing"]; status.ComponentsProcessed != 3 || status.DocumentsEmitted != 1 || status.Errors != 2 || status.LastRunError != "" {
+		t.Errorf("unexpected status %+v", status)
+	}
+	if status := statuses["broken query"]; status.Errors != 1 || !strings.Contains(status.LastRunError, "query failed") {
+		t.Errorf("unexpected status %+v", status)
+	}
+
+	rec := httptest.NewRecorder()
+	s.StatusHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/status", nil))
+	if body := rec.Body.String(); !strings.Contains(body, `"type":"failing"`) || !strings.Contains(body, `"componentsProcessed":3`) {
+		t.Errorf("unexpected status response %s", body)
+	}
+	rec = httptest.NewRecorder()
+	s.MetricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	metrics, _ := io.ReadAll(rec.Body)
+	if !strings.Contains(string(metrics), `guac_certifier_documents_total{certifier="concurrent"} 8`) {
+		t.Errorf("missing documents metric in %s", metrics)
+	}
+}
+
+func TestScheduler_Interval(t *testing.T) {
+	ctx, cancel := context.WithCancel(logging.WithLogger(context.Background()))
+	defer cancel()
+	var runs int32
+	s, err := NewScheduler(ctx, func(d *processor.Document) error { return nil })
+	if err != nil {
+		t.Fatalf("NewScheduler() error = %v", err)
+	}
+	err = s.Register(CertifierConfig{
+		Type:  "polling",
+		Query: &batchQuery{batches: 1},
+		NewCertifier: func() certifier.Certifier {
+			return funcCertifier(func(component interface{}, docChannel chan<- *processor.Document) error {
+				if atomic.AddInt32(&runs, 1) == 3 {
+					cancel()
+				}
+				return nil
+			})
+		},
+		Interval:  10 * time.Millisecond,
+		RateLimit: 100,
+	})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := s.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want %v", err, context.Canceled)
+	}
+	if got := atomic.LoadInt32(&runs); got != 3 {
+		t.Errorf("got %d runs, want 3", got)
+	}
+	// the last run was stopped by the cancellation, which is not an error
+	if status := s.Status()[0]; status.Runs != 3 || status.Errors != 0 || status.LastRunError != "" {
+		t.Errorf("unexpected status %+v", status)
+	}
+}
@@ -0,0 +1,168 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clearlydefined certifies the licenses of packages with the curated
+// declared and discovered licenses and attribution of ClearlyDefined
+// (https://clearlydefined.io), from its API or a local dump of harvested
+// definitions.
+package clearlydefined
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/guacsec/guac/pkg/assembler"
+	"github.com/guacsec/guac/pkg/assembler/clients/generated"
+	"github.com/guacsec/guac/pkg/assembler/helpers"
+	"github.com/guacsec/guac/pkg/certifier"
+	"github.com/guacsec/guac/pkg/certifier/components/root_package"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/ingestor/parser/common"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+const (
+	COLLECTOR     string = "clearlydefined"
+	JUSTIFICATION string = "Retrieved from ClearlyDefined"
+	// definitionsBatch is the maximum number of coordinates looked up at once
+	definitionsBatch = 500
+)
+
+var ErrClearlyDefinedComponentTypeMismatch error = errors.New("rootComponent type is not []*root_package.PackageNode")
+
+type clearlyDefinedCertifier struct {
+	definitions Definitions
+}
+
+// NewClearlyDefinedCertifier initializes the ClearlyDefined certifier with
+// the source of the definitions
+func NewClearlyDefinedCertifier(definitions Definitions) certifier.Certifier {
+	return &clearlyDefinedCertifier{definitions: definitions}
+}
+
+// CertifyComponent looks up the definitions of the package versions and emits
+// their certify legal. Packages not harvested by ClearlyDefined are skipped.
+func (c *clearlyDefinedCertifier) CertifyComponent(ctx context.Context, rootComponent interface{}, docChannel chan<- *processor.Document) error {
+	logger := logging.FromContext(ctx)
+	packageNodes, ok := rootComponent.([]*root_package.PackageNode)
+	if !ok {
+		return ErrClearlyDefinedComponentTypeMismatch
+	}
+
+	var coordinates []Coordinates
+	purls := map[string][]string{}
+	for _, node := range packageNodes {
+		coordinate, ok, err := PurlToCoordinates(node.Purl)
+		if err != nil {
+			logger.Warnf("skipping package: %v", err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		key := coordinate.String()
+		if _, ok := purls[key]; !ok {
+			coordinates = append(coordinates, coordinate)
+		}
+		purls[key] = append(purls[key], node.Purl)
+	}
+
+	for start := 0; start < len(coordinates); start += definitionsBatch {
+		end := start + definitionsBatch
+		if end > len(coordinates) {
+			end = len(coordinates)
+		}
+		definitions, err := c.definitions.Definitions(ctx, coordinates[start:end])
+		if err != nil {
+			return fmt.Errorf("could not get ClearlyDefined definitions: %w", err)
+		}
+		preds := &assembler.IngestPredicates{}
+		scannedOn := time.Now().UTC()
+		for _, coordinate := range coordinates[start:end] {
+			definition, ok := definitions[coordinate.String()]
+			if !ok || definition == nil || definition.isEmpty() {
+				continue
+			}
+			for _, purl := range purls[coordinate.String()] {
+				pkg, err := helpers.PurlToPkg(purl)
+				if err != nil {
+					logger.Warnf("skipping package: %v", err)
+					continue
+				}
+				preds.CertifyLegal = append(preds.CertifyLegal, c.certifyLegal(pkg, definition, scannedOn))
+			}
+		}
+		if len(preds.CertifyLegal) == 0 {
+			continue
+		}
+		payload, err := json.Marshal(preds)
+		if err != nil {
+			return fmt.Errorf("unable to marshal predicates: %w", err)
+		}
+		docChannel <- &processor.Document{
+			Blob:   payload,
+			Type:   processor.DocumentIngestPredicates,
+			Format: processor.FormatJSON,
+			SourceInformation: processor.SourceInformation{
+				Collector: COLLECTOR,
+				Source:    c.definitions.Origin(),
+			},
+		}
+	}
+	return nil
+}
+
+func (c *clearlyDefinedCertifier) certifyLegal(pkg *generated.PkgInputSpec, definition *Definition, scannedOn time.Time) assembler.CertifyLegalIngest {
+	declared := definition.Licensed.Declared
+	discovered := strings.Join(definition.Licensed.Facets.Core.Discovered.Expressions, " AND ")
+	return assembler.CertifyLegalIngest{
+		Pkg:        pkg,
+		Declared:   licenses(declared),
+		Discovered: licenses(discovered),
+		CertifyLegal: &generated.CertifyLegalInputSpec{
+			DeclaredLicense:   declared,
+			DiscoveredLicense: discovered,
+			Attribution:       strings.Join(definition.Licensed.Facets.Core.Attribution.Parties, "\n"),
+			Justification:     JUSTIFICATION,
+			TimeScanned:       scannedOn,
+			Origin:            c.definitions.Origin(),
+			Collector:         COLLECTOR,
+		},
+	}
+}
+
+// licenses returns the licenses of an SPDX expression, without the
+// NOASSERTION, NONE and OTHER placeholders of ClearlyDefined
+func licenses(expression string) []generated.LicenseInputSpec {
+	var rv []generated.LicenseInputSpec
+	for _, license := range common.ParseLicenses(expression, "") {
+		switch license.Name {
+		case "", "NOASSERTION", "NONE", "OTHER":
+			continue
+		}
+		if strings.HasPrefix(license.Name, "LicenseRef-") {
+			license.ListVersion = nil
+		}
+		rv = append(rv, license)
+	}
+	return rv
+}
@@ -0,0 +1,226 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clearlydefined
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/guacsec/guac/internal/testing/ptrfrom"
+	"github.com/guacsec/guac/pkg/assembler"
+	"github.com/guacsec/guac/pkg/assembler/clients/generated"
+	"github.com/guacsec/guac/pkg/certifier/components/root_package"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+const (
+	lodashDefinition = `{
+  "coordinates": {"type": "npm", "provider": "npmjs", "name": "lodash", "revision": "4.17.21"},
+  "licensed": {
+    "declared": "MIT",
+    "facets": {"core": {
+      "discovered": {"expressions": ["MIT", "CC0-1.0"]},
+      "attribution": {"parties": ["Copyright OpenJS Foundation and other contributors"]}
+    }}
+  }
+}`
+	muxDefinition   = `{"coordinates": {"type": "go", "provider": "golang", "namespace": "github.com%2fgorilla", "name": "mux", "revision": "v1.8.0"}, "licensed": {"declared": "BSD-3-Clause OR LicenseRef-scancode-unknown"}}`
+	emptyDefinition = `{"coordinates": {"type": "pypi", "provider": "pypi", "name": "unknown", "revision": "1.0"}, "licensed": {}}`
+)
+
+func TestPurlToCoordinates(t *testing.T) {
+	tests := []struct {
+		purl    string
+		want    string
+		wantOK  bool
+		wantErr bool
+	}{
+		{purl: "pkg:npm/lodash@4.17.21", want: "npm/npmjs/-/lodash/4.17.21", wantOK: true},
+		{purl: "pkg:npm/%40babel/core@7.0.0", want: "npm/npmjs/@babel/core/7.0.0", wantOK: true},
+		{purl: "pkg:maven/org.apache.commons/commons-text@1.9", want: "maven/mavencentral/org.apache.commons/commons-text/1.9", wantOK: true},
+		{purl: "pkg:pypi/Django_Rest@3.0", want: "pypi/pypi/-/django-rest/3.0", wantOK: true},
+		{purl: "pkg:golang/github.com/gorilla/mux@v1.8.0", want: "go/golang/github.com%2Fgorilla/mux/v1.8.0", wantOK: true},
+		{purl: "pkg:cargo/serde@1.0.0", want: "crate/cratesio/-/serde/1.0.0", wantOK: true},
+		{purl: "pkg:deb/debian/curl@7.64.0"},
+		{purl: "pkg:npm/lodash"},
+		{purl: "lodash", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.purl, func(t *testing.T) {
+			got, ok, err := PurlToCoordinates(test.purl)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("PurlToCoordinates() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if ok != test.wantOK {
+				t.Fatalf("PurlToCoordinates() ok = %v, want %v", ok, test.wantOK)
+			}
+			if ok && got.String() != test.want {
+				t.Errorf("PurlToCoordinates() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func certify(t *testing.T, definitions Definitions, purls ...string) []*assembler.IngestPredicates {
+	ctx := logging.WithLogger(context.Background())
+	var nodes []*root_package.PackageNode
+	for _, purl := range purls {
+		nodes = append(nodes, &root_package.PackageNode{Purl: purl})
+	}
+	docChan := make(chan *processor.Document, 10)
+	if err := NewClearlyDefinedCertifier(definitions).CertifyComponent(ctx, nodes, docChan); err != nil {
+		t.Fatalf("CertifyComponent() error = %v", err)
+	}
+	close(docChan)
+	var preds []*assembler.IngestPredicates
+	for doc := range docChan {
+		if doc.Type != processor.DocumentIngestPredicates || doc.SourceInformation.Collector != COLLECTOR {
+			t.Errorf("unexpected document %+v", doc)
+		}
+		p := &assembler.IngestPredicates{}
+		if err := json.Unmarshal(doc.Blob, p); err != nil {
+			t.Fatalf("invalid predicates: %v", err)
+		}
+		preds = append(preds, p)
+	}
+	return preds
+}
+
+func TestCertifyComponent_Dump(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"npm/lodash.json":   lodashDefinition,
+		"definitions.jsonl": muxDefinition + "\n" + emptyDefinition + "\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	definitions, err := NewDump(dir)
+	if err != nil {
+		t.Fatalf("NewDump() error = %v", err)
+	}
+
+	preds := certify(t, definitions,
+		"pkg:npm/lodash@4.17.21",
+		"pkg:npm/lodash@4.17.21?repository_url=https://registry.example.com",
+		"pkg:golang/github.com/gorilla/mux@v1.8.0",
+		"pkg:pypi/unknown@1.0",
+		"pkg:deb/debian/curl@7.64.0",
+		"not-a-purl",
+	)
+	if len(preds) != 1 {
+		t.Fatalf("got %d documents, want 1", len(preds))
+	}
+	origin := "file://" + dir
+	want := []assembler.CertifyLegalIngest{{
+		Pkg:        &generated.PkgInputSpec{Type: "npm", Namespace: ptrfrom.String(""), Name: "lodash", Version: ptrfrom.String("4.17.21"), Subpath: ptrfrom.String("")},
+		Declared:   []generated.LicenseInputSpec{{Name: "MIT", ListVersion: ptrfrom.String("")}},
+		Discovered: []generated.LicenseInputSpec{{Name: "MIT", ListVersion: ptrfrom.String("")}, {Name: "CC0-1.0", ListVersion: ptrfrom.String("")}},
+		CertifyLegal: &generated.CertifyLegalInputSpec{
+			DeclaredLicense:   "MIT",
+			DiscoveredLicense: "MIT AND CC0-1.0",
+			Attribution:       "Copyright OpenJS Foundation and other contributors",
+			Justification:     JUSTIFICATION,
+			Origin:            origin,
+			Collector:         COLLECTOR,
+		},
+	}, {
+		Pkg: &generated.PkgInputSpec{Type: "npm", Namespace: ptrfrom.String(""), Name: "lodash", Version: ptrfrom.String("4.17.21"), Subpath: ptrfrom.String(""),
+			Qualifiers: []generated.PackageQualifierInputSpec{{Key: "repository_url", Value: "https://registry.example.com"}}},
+		Declared:   []generated.LicenseInputSpec{{Name: "MIT", ListVersion: ptrfrom.String("")}},
+		Discovered: []generated.LicenseInputSpec{{Name: "MIT", ListVersion: ptrfrom.String("")}, {Name: "CC0-1.0", ListVersion: ptrfrom.String("")}},
+		CertifyLegal: &generated.CertifyLegalInputSpec{
+			DeclaredLicense:   "MIT",
+			DiscoveredLicense: "MIT AND CC0-1.0",
+			Attribution:       "Copyright OpenJS Foundation and other contributors",
+			Justification:     JUSTIFICATION,
+			Origin:            origin,
+			Collector:         COLLECTOR,
+		},
+	}, {
+		Pkg:      &generated.PkgInputSpec{Type: "golang", Namespace: ptrfrom.String("github.com/gorilla"), Name: "mux", Version: ptrfrom.String("v1.8.0"), Subpath: ptrfrom.String("")},
+		Declared: []generated.LicenseInputSpec{{Name: "BSD-3-Clause", ListVersion: ptrfrom.String("")}, {Name: "LicenseRef-scancode-unknown"}},
+		CertifyLegal: &generated.CertifyLegalInputSpec{
+			DeclaredLicense: "BSD-3-Clause OR LicenseRef-scancode-unknown",
+			Justification:   JUSTIFICATION,
+			Origin:          origin,
+			Collector:       COLLECTOR,
+		},
+	}}
+	ignoreTime := cmpopts.IgnoreFields(generated.CertifyLegalInputSpec{}, "TimeScanned")
+	if diff := cmp.Diff(want, preds[0].CertifyLegal, ignoreTime, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("unexpected certify legal (-want +got):\n%s", diff)
+	}
+	for _, cl := range preds[0].CertifyLegal {
+		if cl.CertifyLegal.TimeScanned.IsZero() {
+			t.Error("timeScanned is not set")
+		}
+	}
+}
+
+func TestCertifyComponent_API(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/definitions" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&requested); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"npm/npmjs/-/lodash/4.17.21": ` + lodashDefinition + `, "npm/npmjs/-/left-pad/1.0.0": {"licensed": {}}}`))
+	}))
+	defer server.Close()
+
+	preds := certify(t, NewAPI(server.URL+"/", server.Client()), "pkg:npm/lodash@4.17.21", "pkg:npm/left-pad@1.0.0")
+	if diff := cmp.Diff([]string{"npm/npmjs/-/lodash/4.17.21", "npm/npmjs/-/left-pad/1.0.0"}, requested); diff != "" {
+		t.Errorf("unexpected coordinates (-want +got):\n%s", diff)
+	}
+	if len(preds) != 1 || len(preds[0].CertifyLegal) != 1 {
+		t.Fatalf("got %v, want the certify legal of lodash", preds)
+	}
+	if got := preds[0].CertifyLegal[0].CertifyLegal.Origin; got != server.URL {
+		t.Errorf("origin = %s, want %s", got, server.URL)
+	}
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	err := NewClearlyDefinedCertifier(NewAPI(server.URL, server.Client())).CertifyComponent(context.Background(),
+		[]*root_package.PackageNode{{Purl: "pkg:npm/lodash@4.17.21"}}, make(chan *processor.Document, 1))
+	if err == nil {
+		t.Error("CertifyComponent() expected an error")
+	}
+	err = NewClearlyDefinedCertifier(NewAPI(server.URL, server.Client())).CertifyComponent(context.Background(), "", nil)
+	if !errors.Is(err, ErrClearlyDefinedComponentTypeMismatch) {
+		t.Errorf("CertifyComponent() error = %v, want %v", err, ErrClearlyDefinedComponentTypeMismatch)
+	}
+}
@@ -0,0 +1,254 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clearlydefined
+
+import (
+	"bytes"
+	"context"
+	stdjson "encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
+// DefaultURL is the public ClearlyDefined API
+const DefaultURL = "https://api.clearlydefined.io"
+
+// Coordinates identify a component in ClearlyDefined, as
+// type/provider/namespace/name/revision
+type Coordinates struct {
+	Type      string `json:"type"`
+	Provider  string `json:"provider"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Revision  string `json:"revision"`
+}
+
+// String returns the coordinates in their path form, with "-" for a missing
+// namespace
+func (c Coordinates) String() string {
+	namespace := c.Namespace
+	if namespace == "" {
+		namespace = "-"
+	}
+	return strings.Join([]string{c.Type, c.Provider, namespace, c.Name, c.Revision}, "/")
+}
+
+// providers maps the purl types to the ClearlyDefined type and provider
+var providers = map[string][2]string{
+	packageurl.TypeNPM:       {"npm", "npmjs"},
+	packageurl.TypeMaven:     {"maven", "mavencentral"},
+	packageurl.TypePyPi:      {"pypi", "pypi"},
+	packageurl.TypeGem:       {"gem", "rubygems"},
+	packageurl.TypeNuget:     {"nuget", "nuget"},
+	packageurl.TypeCargo:     {"crate", "cratesio"},
+	packageurl.TypeGolang:    {"go", "golang"},
+	packageurl.TypeComposer:  {"composer", "packagist"},
+	packageurl.TypeCocoapods: {"pod", "cocoapods"},
+	packageurl.TypeGithub:    {"git", "github"},
+}
+
+// PurlToCoordinates returns the ClearlyDefined coordinates of a package
+// version, or false if the package type is not harvested by ClearlyDefined
+func PurlToCoordinates(purl string) (Coordinates, bool, error) {
+	p, err := packageurl.FromString(purl)
+	if err != nil {
+		return Coordinates{}, false, fmt.Errorf("invalid purl %s: %w", purl, err)
+	}
+	provider, ok := providers[p.Type]
+	if !ok || p.Version == "" {
+		return Coordinates{}, false, nil
+	}
+	c := Coordinates{Type: provider[0], Provider: provider[1], Namespace: p.Namespace, Name: p.Name, Revision: p.Version}
+	switch p.Type {
+	case packageurl.TypeGolang:
+		// go module paths are a single url-encoded namespace
+		c.Namespace = url.PathEscape(p.Namespace)
+	case packageurl.TypePyPi:
+		c.Name = strings.ToLower(strings.ReplaceAll(p.Name, "_", "-"))
+	}
+	return c, true, nil
+}
+
+// Definition is the licensing part of a ClearlyDefined definition
+type Definition struct {
+	Coordinates Coordinates `json:"coordinates"`
+	Licensed    struct {
+		Declared string `json:"declared"`
+		Facets   struct {
+			Core struct {
+				Discovered struct {
+					Expressions []string `json:"expressions"`
+				} `json:"discovered"`
+				Attribution struct {
+					Parties []string `json:"parties"`
+				} `json:"attribution"`
+			} `json:"core"`
+		} `json:"facets"`
+	} `json:"licensed"`
+}
+
+// isEmpty reports whether ClearlyDefined has no licensing data, which is the
+// case of components that were never harvested
+func (d *Definition) isEmpty() bool {
+	return d.Licensed.Declared == "" && len(d.Licensed.Facets.Core.Discovered.Expressions) == 0 &&
+		len(d.Licensed.Facets.Core.Attribution.Parties) == 0
+}
+
+// Definitions looks up ClearlyDefined definitions
+type Definitions interface {
+	// Definitions returns the definitions of the coordinates, by their
+	// String() form. Unknown coordinates are missing or have empty
+	// definitions.
+	Definitions(ctx context.Context, coordinates []Coordinates) (map[string]*Definition, error)
+	// Origin identifies the source of the definitions
+	Origin() string
+}
+
+type api struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewAPI returns the definitions of a ClearlyDefined-compatible API, which
+// serves POST <baseURL>/definitions with a JSON array of coordinates
+func NewAPI(baseURL string, client *http.Client) Definitions {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &api{baseURL: strings.TrimSuffix(baseURL, "/"), client: client}
+}
+
+func (a *api) Origin() string {
+	return a.baseURL
+}
+
+func (a *api) Definitions(ctx context.Context, coordinates []Coordinates) (map[string]*Definition, error) {
+	paths := make([]string, 0, len(coordinates))
+	for _, c := range coordinates {
+		paths = append(paths, c.String())
+	}
+	body, err := json.Marshal(paths)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.baseURL+"/definitions", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ClearlyDefined definitions request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ClearlyDefined definitions request failed with status %s", resp.Status)
+	}
+	definitions := map[string]*Definition{}
+	if err := json.NewDecoder(resp.Body).Decode(&definitions); err != nil {
+		return nil, fmt.Errorf("invalid ClearlyDefined definitions: %w", err)
+	}
+	return definitions, nil
+}
+
+type dump struct {
+	origin      string
+	definitions map[string]*Definition
+}
+
+// NewDump loads a local dump of harvested definitions: JSON files of one or
+// an array of definitions, or NDJSON files. Directories are searched
+// recursively for .json, .ndjson and .jsonl files.
+func NewDump(paths ...string) (Definitions, error) {
+	d := &dump{origin: "file://" + strings.Join(paths, ","), definitions: map[string]*Definition{}}
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				return nil
+			}
+			switch strings.ToLower(filepath.Ext(path)) {
+			case ".json", ".ndjson", ".jsonl":
+				return d.load(path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load ClearlyDefined definitions from %s: %w", path, err)
+		}
+	}
+	return d, nil
+}
+
+func (d *dump) load(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	// jsoniter does not reliably stream concatenated documents
+	decoder := stdjson.NewDecoder(file)
+	for {
+		var raw stdjson.RawMessage
+		if err := decoder.Decode(&raw); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("invalid JSON in %s: %w", path, err)
+		}
+		var definitions []*Definition
+		if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+			err = json.Unmarshal(raw, &definitions)
+		} else {
+			definition := &Definition{}
+			err = json.Unmarshal(raw, definition)
+			definitions = append(definitions, definition)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid ClearlyDefined definition in %s: %w", path, err)
+		}
+		for _, definition := range definitions {
+			if definition.Coordinates.Name == "" {
+				return fmt.Errorf("invalid ClearlyDefined definition in %s: missing coordinates", path)
+			}
+			d.definitions[strings.ToLower(definition.Coordinates.String())] = definition
+		}
+	}
+}
+
+func (d *dump) Origin() string {
+	return d.origin
+}
+
+func (d *dump) Definitions(_ context.Context, coordinates []Coordinates) (map[string]*Definition, error) {
+	definitions := map[string]*Definition{}
+	for _, c := range coordinates {
+		if definition, ok := d.definitions[strings.ToLower(c.String())]; ok {
+			definitions[c.String()] = definition
+		}
+	}
+	return definitions, nil
+}
@@ -0,0 +1,175 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package legal_package
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/guacsec/guac/pkg/assembler/clients/generated"
+	"github.com/guacsec/guac/pkg/assembler/helpers"
+	"github.com/guacsec/guac/pkg/certifier"
+	"github.com/guacsec/guac/pkg/certifier/components/root_package"
+)
+
+const (
+	guacType string = "guac"
+	// batchSize is the maximum number of packages sent at once to the
+	// certifier
+	batchSize = 1000
+)
+
+type legalQuery struct {
+	client            graphql.Client
+	daysSinceLastScan int
+}
+
+var getPackages func(ctx context.Context, client graphql.Client, filter generated.PkgSpec) (*generated.PackagesResponse, error)
+var getNeighbors func(ctx context.Context, client graphql.Client, node string, usingOnly []generated.Edge) (*generated.NeighborsResponse, error)
+
+// NewLegalQuery initializes the legalQuery to query the packages lacking a
+// certify legal from the graph database. The components are batches of
+// []*root_package.PackageNode.
+func NewLegalQuery(client graphql.Client, daysSinceLastScan int) certifier.QueryComponents {
+	getPackages = generated.Packages
+	getNeighbors = generated.Neighbors
+	return &legalQuery{
+		client:            client,
+		daysSinceLastScan: daysSinceLastScan,
+	}
+}
+
+// GetComponents get all the packages that do not have a certify legal attached or last scanned is more than daysSinceLastScan
+func (l *legalQuery) GetComponents(ctx context.Context, compChan chan<- interface{}) error {
+	if compChan == nil {
+		return fmt.Errorf("compChan cannot be nil")
+	}
+
+	tickInterval := 5 * time.Second
+
+	// nodeChan to receive components
+	nodeChan := make(chan *root_package.PackageNode, 10000)
+	// errChan to receive error from collectors
+	errChan := make(chan error, 1)
+
+	response, err := getPackages(ctx, l.client, generated.PkgSpec{})
+	if err != nil {
+		return fmt.Errorf("failed packages query: %w", err)
+	}
+
+	go func() {
+		errChan <- l.getPackageNodes(ctx, response, nodeChan)
+	}()
+
+	packNodes := []*root_package.PackageNode{}
+	add := func(d *root_package.PackageNode) {
+		packNodes = append(packNodes, d)
+		if len(packNodes) >= batchSize {
+			compChan <- packNodes
+			packNodes = []*root_package.PackageNode{}
+		}
+	}
+	componentsCaptured := false
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+	for !componentsCaptured {
+		select {
+		case <-ticker.C:
+			if len(packNodes) > 0 {
+				compChan <- packNodes
+				packNodes = []*root_package.PackageNode{}
+			}
+		case d := <-nodeChan:
+			add(d)
+		case err := <-errChan:
+			if err != nil {
+				return err
+			}
+			componentsCaptured = true
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	for len(nodeChan) > 0 {
+		add(<-nodeChan)
+	}
+
+	if len(packNodes) > 0 {
+		compChan <- packNodes
+	}
+
+	return nil
+}
+
+func (l *legalQuery) getPackageNodes(ctx context.Context, response *generated.PackagesResponse, nodeChan chan<- *root_package.PackageNode) error {
+	packages := response.GetPackages()
+	for _, pkgType := range packages {
+		if pkgType.Type == guacType {
+			continue
+		}
+		for _, namespace := range pkgType.Namespaces {
+			for _, name := range namespace.Names {
+				for _, version := range name.Versions {
+					response, err := getNeighbors(ctx, l.client, version.Id, []generated.Edge{generated.EdgePackageCertifyLegal})
+					if err != nil {
+						return fmt.Errorf("failed neighbors query: %w", err)
+					}
+					if l.hasLegal(response.Neighbors) {
+						continue
+					}
+					qualifiersMap := map[string]string{}
+					keys := []string{}
+					for _, kv := range version.Qualifiers {
+						qualifiersMap[kv.Key] = kv.Value
+						keys = append(keys, kv.Key)
+					}
+					sort.Strings(keys)
+					qualifiers := []string{}
+					for _, k := range keys {
+						qualifiers = append(qualifiers, k, qualifiersMap[k])
+					}
+					purl := helpers.PkgToPurl(pkgType.Type, namespace.Namespace, name.Name, version.Version, version.Subpath, qualifiers)
+					nodeChan <- &root_package.PackageNode{Purl: purl}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// hasLegal reports whether a certify legal was scanned within
+// daysSinceLastScan, or exists at all when daysSinceLastScan is 0
+func (l *legalQuery) hasLegal(neighbors []generated.NeighborsNeighborsNode) bool {
+	for _, neighbor := range neighbors {
+		certifyLegal, ok := neighbor.(*generated.NeighborsNeighborsCertifyLegal)
+		if !ok {
+			continue
+		}
+		if l.daysSinceLastScan == 0 {
+			return true
+		}
+		difference := certifyLegal.TimeScanned.Sub(time.Now())
+		if math.Abs(difference.Hours()) < float64(l.daysSinceLastScan*24) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package legal_package
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/guacsec/guac/pkg/assembler/clients/generated"
+	"github.com/guacsec/guac/pkg/certifier/components/root_package"
+)
+
+func Test_legalQuery_GetComponents(t *testing.T) {
+	tm, _ := time.Parse(time.RFC3339, "2022-11-21T17:45:50.52Z")
+	testPypiPackage := generated.PackagesPackagesPackage{}
+	testPypiPackage.Type = "pypi"
+	testPypiPackage.Namespaces = append(testPypiPackage.Namespaces, generated.AllPkgTreeNamespacesPackageNamespace{
+		Names: []generated.AllPkgTreeNamespacesPackageNamespaceNamesPackageName{{
+			Name: "django",
+			Versions: []generated.AllPkgTreeNamespacesPackageNamespaceNamesPackageNameVersionsPackageVersion{{
+				Version: "1.11.1",
+				Qualifiers: []generated.AllPkgTreeNamespacesPackageNamespaceNamesPackageNameVersionsPackageVersionQualifiersPackageQualifier{
+					{Key: "repository_url", Value: "https://pypi.example.com"},
+				},
+			}},
+		}},
+	})
+
+	testGuacPackage := generated.PackagesPackagesPackage{}
+	testGuacPackage.Type = guacType
+	testGuacPackage.Namespaces = append(testGuacPackage.Namespaces, generated.AllPkgTreeNamespacesPackageNamespace{
+		Namespace: "files",
+		Names: []generated.AllPkgTreeNamespacesPackageNamespaceNamesPackageName{{
+			Name:     "sha256:abc",
+			Versions: []generated.AllPkgTreeNamespacesPackageNamespaceNamesPackageNameVersionsPackageVersion{{}},
+		}},
+	})
+
+	neighborCertifyLegalTimeStamp := generated.NeighborsNeighborsCertifyLegal{}
+	neighborCertifyLegalTimeStamp.TimeScanned = tm.UTC()
+
+	neighborCertifyLegalTimeNow := generated.NeighborsNeighborsCertifyLegal{}
+	neighborCertifyLegalTimeNow.TimeScanned = time.Now().UTC()
+
+	packages := func(ctx context.Context, client graphql.Client, filter generated.PkgSpec) (*generated.PackagesResponse, error) {
+		return &generated.PackagesResponse{
+			Packages: []generated.PackagesPackagesPackage{testPypiPackage, testGuacPackage},
+		}, nil
+	}
+	neighbors := func(nodes ...generated.NeighborsNeighborsNode) func(ctx context.Context, client graphql.Client, node string, usingOnly []generated.Edge) (*generated.NeighborsResponse, error) {
+		return func(ctx context.Context, client graphql.Client, node string, usingOnly []generated.Edge) (*generated.NeighborsResponse, error) {
+			return &generated.NeighborsResponse{Neighbors: nodes}, nil
+		}
+	}
+	django := []*root_package.PackageNode{{Purl: "pkg:pypi/django@1.11.1?repository_url=https%3A%2F%2Fpypi.example.com"}}
+
+	tests := []struct {
+		name              string
+		daysSinceLastScan int
+		getNeighbors      func(ctx context.Context, client graphql.Client, node string, usingOnly []generated.Edge) (*generated.NeighborsResponse, error)
+		wantPackNode      []*root_package.PackageNode
+	}{{
+		name:         "django without certifyLegal",
+		getNeighbors: neighbors(),
+		wantPackNode: django,
+	}, {
+		name:         "django with certifyLegal",
+		getNeighbors: neighbors(&neighborCertifyLegalTimeStamp),
+		wantPackNode: []*root_package.PackageNode{},
+	}, {
+		name:              "django with certifyLegal, daysSinceLastScan=30",
+		daysSinceLastScan: 30,
+		getNeighbors:      neighbors(&neighborCertifyLegalTimeStamp),
+		wantPackNode:      django,
+	}, {
+		name:              "django with certifyLegal, timestamp: time now, daysSinceLastScan=30",
+		daysSinceLastScan: 30,
+		getNeighbors:      neighbors(&neighborCertifyLegalTimeNow),
+		wantPackNode:      []*root_package.PackageNode{},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &legalQuery{daysSinceLastScan: tt.daysSinceLastScan}
+			getPackages = packages
+			getNeighbors = tt.getNeighbors
+
+			compChan := make(chan interface{}, 10)
+			if err := l.GetComponents(context.Background(), compChan); err != nil {
+				t.Fatalf("legalQuery.GetComponents() error = %v", err)
+			}
+			close(compChan)
+			pnList := []*root_package.PackageNode{}
+			for d := range compChan {
+				component, ok := d.([]*root_package.PackageNode)
+				if !ok {
+					t.Fatalf("unexpected component %T", d)
+				}
+				pnList = append(pnList, component...)
+			}
+			if !reflect.DeepEqual(pnList, tt.wantPackNode) {
+				t.Errorf("legalQuery.GetComponents() got = %v, want %v", pnList, tt.wantPackNode)
+			}
+		})
+	}
+}
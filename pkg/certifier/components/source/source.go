@@ -31,6 +31,9 @@ import (
 type sourceQuery struct {
 	client            graphql.Client
 	daysSinceLastScan int
+	// checkDaysSinceLastScan is the freshness of individual checks, by
+	// check name
+	checkDaysSinceLastScan map[string]int
 }
 
 type SourceNode struct {
@@ -42,7 +45,8 @@ type SourceNode struct {
 var getSources func(ctx context.Context, client graphql.Client, filter generated.SourceSpec) (*generated.SourcesResponse, error)
 var getNeighbors func(ctx context.Context, client graphql.Client, node string, usingOnly []generated.Edge) (*generated.NeighborsResponse, error)
 
-// GetComponents get all the sources that do not have a certify scorecard attached or last scanned is more than daysSinceLastScan
+// GetComponents get all the sources that do not have a certify scorecard attached or last scanned is more than daysSinceLastScan,
+// or whose checks of checkDaysSinceLastScan were last scanned more than their number of days ago
 func (s sourceQuery) GetComponents(ctx context.Context, compChan chan<- interface{}) error {
 	if compChan == nil {
 		return fmt.Errorf("compChan cannot be nil")
@@ -61,28 +65,13 @@ func (s sourceQuery) GetComponents(ctx context.Context, compChan chan<- interfac
 					return fmt.Errorf("failed neighbors query: %w", err)
 				}
 				scorecardList := []*generated.NeighborsNeighborsCertifyScorecard{}
-				scoreCardFound := false
 				for _, neighbor := range response.Neighbors {
 					scorecardNode, ok := neighbor.(*generated.NeighborsNeighborsCertifyScorecard)
 					if ok {
 						scorecardList = append(scorecardList, scorecardNode)
 					}
 				}
-				// collect all scorecardNodes and then check timestamp else if not checking timestamp,
-				// if a scorecard is found break out
-				for _, scorecardNode := range scorecardList {
-					if s.daysSinceLastScan != 0 {
-						now := time.Now()
-						difference := scorecardNode.Scorecard.TimeScanned.Sub(now)
-						if math.Abs(difference.Hours()) < float64(s.daysSinceLastScan*24) {
-							scoreCardFound = true
-						}
-					} else {
-						scoreCardFound = true
-						break
-					}
-				}
-				if !scoreCardFound {
+				if !s.isFresh(scorecardList) {
 					sourceNode := SourceNode{
 						Repo:   path.Join(namespace.Namespace, names.Name),
 						Commit: trimAlgorithm(nilOrEmpty(names.Commit)),
@@ -96,6 +85,49 @@ func (s sourceQuery) GetComponents(ctx context.Context, compChan chan<- interfac
 	return nil
 }
 
+// isFresh reports whether the source has a scorecard scanned within
+// daysSinceLastScan, or any scorecard if it is 0, and whether each check of
+// checkDaysSinceLastScan was scanned within its own number of days
+func (s sourceQuery) isFresh(scorecardList []*generated.NeighborsNeighborsCertifyScorecard) bool {
+	scoreCardFound := false
+	for _, scorecardNode := range scorecardList {
+		if s.daysSinceLastScan == 0 || scannedWithin(scorecardNode.Scorecard.TimeScanned, s.daysSinceLastScan) {
+			scoreCardFound = true
+			break
+		}
+	}
+	if !scoreCardFound {
+		return false
+	}
+	for check, days := range s.checkDaysSinceLastScan {
+		checkFound := false
+		for _, scorecardNode := range scorecardList {
+			if hasCheck(scorecardNode, check) && (days == 0 || scannedWithin(scorecardNode.Scorecard.TimeScanned, days)) {
+				checkFound = true
+				break
+			}
+		}
+		if !checkFound {
+			return false
+		}
+	}
+	return true
+}
+
+func scannedWithin(timeScanned time.Time, days int) bool {
+	difference := timeScanned.Sub(time.Now())
+	return math.Abs(difference.Hours()) < float64(days*24)
+}
+
+func hasCheck(scorecardNode *generated.NeighborsNeighborsCertifyScorecard, check string) bool {
+	for _, c := range scorecardNode.Scorecard.Checks {
+		if strings.EqualFold(c.Check, check) {
+			return true
+		}
+	}
+	return false
+}
+
 func nilOrEmpty(value *string) string {
 	if value != nil {
 		return *value
@@ -120,13 +152,22 @@ func trimAlgorithm(commit string) string {
 
 // NewCertifier returns a new sourceArtifacts certifier
 func NewCertifier(client graphql.Client, daysSinceLastScan int) (certifier.QueryComponents, error) {
+	return NewCertifierWithCheckFreshness(client, daysSinceLastScan, nil)
+}
+
+// NewCertifierWithCheckFreshness returns a new sourceArtifacts certifier that
+// also rescans the sources whose checks of checkDaysSinceLastScan were not
+// scanned within their number of days, e.g. {"Vulnerabilities": 1} rescans
+// every day for new vulnerabilities whatever daysSinceLastScan is
+func NewCertifierWithCheckFreshness(client graphql.Client, daysSinceLastScan int, checkDaysSinceLastScan map[string]int) (certifier.QueryComponents, error) {
 	if client == nil {
 		return nil, fmt.Errorf("client cannot be nil")
 	}
 	getSources = generated.Sources
 	getNeighbors = generated.Neighbors
 	return &sourceQuery{
-		client:            client,
-		daysSinceLastScan: daysSinceLastScan,
+		client:                 client,
+		daysSinceLastScan:      daysSinceLastScan,
+		checkDaysSinceLastScan: checkDaysSinceLastScan,
 	}, nil
 }
@@ -135,13 +135,31 @@ func Test_sourceArtifacts_GetComponents(t *testing.T) {
 		TimeScanned: time.Now().UTC(),
 	}
 
+	neighborCertifyScorecardChecksTimeStamp := generated.NeighborsNeighborsCertifyScorecard{}
+	neighborCertifyScorecardChecksTimeStamp.Scorecard = generated.AllCertifyScorecardScorecard{
+		TimeScanned: tm.UTC(),
+		Checks: []generated.AllCertifyScorecardScorecardChecksScorecardCheck{
+			{Check: "License", Score: 10},
+			{Check: "Vulnerabilities", Score: 10},
+		},
+	}
+
+	neighborCertifyScorecardChecksTimeNow := generated.NeighborsNeighborsCertifyScorecard{}
+	neighborCertifyScorecardChecksTimeNow.Scorecard = generated.AllCertifyScorecardScorecard{
+		TimeScanned: time.Now().UTC(),
+		Checks: []generated.AllCertifyScorecardScorecardChecksScorecardCheck{
+			{Check: "Vulnerabilities", Score: 10},
+		},
+	}
+
 	tests := []struct {
-		name              string
-		daysSinceLastScan int
-		getSources        func(ctx context.Context, client graphql.Client, filter generated.SourceSpec) (*generated.SourcesResponse, error)
-		getNeighbors      func(ctx context.Context, client graphql.Client, node string, usingOnly []generated.Edge) (*generated.NeighborsResponse, error)
-		wantSourceNode    []*SourceNode
-		wantErr           bool
+		name                   string
+		daysSinceLastScan      int
+		checkDaysSinceLastScan map[string]int
+		getSources             func(ctx context.Context, client graphql.Client, filter generated.SourceSpec) (*generated.SourcesResponse, error)
+		getNeighbors           func(ctx context.Context, client graphql.Client, node string, usingOnly []generated.Edge) (*generated.NeighborsResponse, error)
+		wantSourceNode         []*SourceNode
+		wantErr                bool
 	}{
 		{
 			name:              "django: daysSinceLastScan=0, tag specified",
@@ -257,6 +275,66 @@ func Test_sourceArtifacts_GetComponents(t *testing.T) {
 			},
 			wantSourceNode: []*SourceNode{},
 			wantErr:        false,
+		}, {
+			name:                   "django with scorecard, check scanned now, checkDaysSinceLastScan=1",
+			daysSinceLastScan:      0,
+			checkDaysSinceLastScan: map[string]int{"vulnerabilities": 1},
+			getSources: func(ctx context.Context, client graphql.Client, filter generated.SourceSpec) (*generated.SourcesResponse, error) {
+				return &generated.SourcesResponse{
+					Sources: []generated.SourcesSourcesSource{testSourceDjangoTag},
+				}, nil
+			},
+			getNeighbors: func(ctx context.Context, client graphql.Client, node string, usingOnly []generated.Edge) (*generated.NeighborsResponse, error) {
+				return &generated.NeighborsResponse{
+					Neighbors: []generated.NeighborsNeighborsNode{&neighborCertifyScorecardChecksTimeStamp, &neighborCertifyScorecardChecksTimeNow},
+				}, nil
+			},
+			wantSourceNode: []*SourceNode{},
+			wantErr:        false,
+		}, {
+			name:                   "django with scorecard, check scanned in the past, checkDaysSinceLastScan=30",
+			daysSinceLastScan:      0,
+			checkDaysSinceLastScan: map[string]int{"Vulnerabilities": 1, "License": 30},
+			getSources: func(ctx context.Context, client graphql.Client, filter generated.SourceSpec) (*generated.SourcesResponse, error) {
+				return &generated.SourcesResponse{
+					Sources: []generated.SourcesSourcesSource{testSourceDjangoTag},
+				}, nil
+			},
+			getNeighbors: func(ctx context.Context, client graphql.Client, node string, usingOnly []generated.Edge) (*generated.NeighborsResponse, error) {
+				return &generated.NeighborsResponse{
+					Neighbors: []generated.NeighborsNeighborsNode{&neighborCertifyScorecardChecksTimeStamp, &neighborCertifyScorecardChecksTimeNow},
+				}, nil
+			},
+			wantSourceNode: []*SourceNode{
+				{
+					Repo:   "github.com/django/django",
+					Commit: "",
+					Tag:    "1.11.1",
+				},
+			},
+			wantErr: false,
+		}, {
+			name:                   "django with scorecard, check never scanned",
+			daysSinceLastScan:      0,
+			checkDaysSinceLastScan: map[string]int{"Fuzzing": 0},
+			getSources: func(ctx context.Context, client graphql.Client, filter generated.SourceSpec) (*generated.SourcesResponse, error) {
+				return &generated.SourcesResponse{
+					Sources: []generated.SourcesSourcesSource{testSourceDjangoTag},
+				}, nil
+			},
+			getNeighbors: func(ctx context.Context, client graphql.Client, node string, usingOnly []generated.Edge) (*generated.NeighborsResponse, error) {
+				return &generated.NeighborsResponse{
+					Neighbors: []generated.NeighborsNeighborsNode{&neighborCertifyScorecardChecksTimeNow},
+				}, nil
+			},
+			wantSourceNode: []*SourceNode{
+				{
+					Repo:   "github.com/django/django",
+					Commit: "",
+					Tag:    "1.11.1",
+				},
+			},
+			wantErr: false,
 		}, {
 			name:              "multiple packages",
 			daysSinceLastScan: 0,
@@ -291,8 +369,9 @@ func Test_sourceArtifacts_GetComponents(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
 			p := &sourceQuery{
-				client:            nil,
-				daysSinceLastScan: tt.daysSinceLastScan,
+				client:                 nil,
+				daysSinceLastScan:      tt.daysSinceLastScan,
+				checkDaysSinceLastScan: tt.checkDaysSinceLastScan,
 			}
 			getSources = tt.getSources
 			getNeighbors = tt.getNeighbors
@@ -0,0 +1,98 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnerability
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/guacsec/guac/pkg/assembler/clients/generated"
+	"github.com/guacsec/guac/pkg/certifier"
+)
+
+const (
+	noVulnType string = "novuln"
+	// batchSize is the maximum number of vulnerabilities sent at once to the
+	// certifier
+	batchSize = 1000
+)
+
+// VulnerabilityNode is a vulnerability of the graph database
+type VulnerabilityNode struct {
+	Type            string
+	VulnerabilityID string
+}
+
+type vulnerabilityQuery struct {
+	client   graphql.Client
+	vulnType string
+}
+
+var getVulnerabilities func(ctx context.Context, client graphql.Client, filter generated.VulnerabilitySpec) (*generated.VulnerabilitiesResponse, error)
+
+// NewVulnerabilityQuery initializes the vulnerabilityQuery to query the
+// vulnerabilities of a type, or of all types when vulnType is empty, from the
+// graph database. The components are batches of []*VulnerabilityNode.
+func NewVulnerabilityQuery(client graphql.Client, vulnType string) certifier.QueryComponents {
+	getVulnerabilities = generated.Vulnerabilities
+	return &vulnerabilityQuery{
+		client:   client,
+		vulnType: vulnType,
+	}
+}
+
+// GetComponents gets all the vulnerabilities, without the novuln placeholder
+func (v *vulnerabilityQuery) GetComponents(ctx context.Context, compChan chan<- interface{}) error {
+	if compChan == nil {
+		return fmt.Errorf("compChan cannot be nil")
+	}
+
+	filter := generated.VulnerabilitySpec{}
+	if v.vulnType != "" {
+		filter.Type = &v.vulnType
+	}
+	response, err := getVulnerabilities(ctx, v.client, filter)
+	if err != nil {
+		return fmt.Errorf("failed vulnerabilities query: %w", err)
+	}
+
+	vulnNodes := []*VulnerabilityNode{}
+	for _, vuln := range response.Vulnerabilities {
+		if vuln.Type == noVulnType {
+			continue
+		}
+		for _, id := range vuln.VulnerabilityIDs {
+			vulnNodes = append(vulnNodes, &VulnerabilityNode{Type: vuln.Type, VulnerabilityID: id.VulnerabilityID})
+			if len(vulnNodes) >= batchSize {
+				select {
+				case compChan <- vulnNodes:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				vulnNodes = []*VulnerabilityNode{}
+			}
+		}
+	}
+	if len(vulnNodes) > 0 {
+		select {
+		case compChan <- vulnNodes:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnerability
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/guacsec/guac/pkg/assembler/clients/generated"
+)
+
+func Test_vulnerabilityQuery_GetComponents(t *testing.T) {
+	vulns := []generated.VulnerabilitiesVulnerabilitiesVulnerability{}
+	for _, v := range []struct{ vulnType, id string }{
+		{"cve", "cve-2021-44228"},
+		{"ghsa", "ghsa-jfh8-c2jp-5v3q"},
+		{noVulnType, ""},
+	} {
+		vuln := generated.VulnerabilitiesVulnerabilitiesVulnerability{}
+		vuln.Type = v.vulnType
+		vuln.VulnerabilityIDs = []generated.AllVulnerabilityTreeVulnerabilityIDsVulnerabilityID{{VulnerabilityID: v.id}}
+		vulns = append(vulns, vuln)
+	}
+
+	tests := []struct {
+		name     string
+		vulnType string
+		want     []*VulnerabilityNode
+	}{{
+		name: "all vulnerabilities",
+		want: []*VulnerabilityNode{
+			{Type: "cve", VulnerabilityID: "cve-2021-44228"},
+			{Type: "ghsa", VulnerabilityID: "ghsa-jfh8-c2jp-5v3q"},
+		},
+	}, {
+		name:     "cve vulnerabilities",
+		vulnType: "cve",
+		want:     []*VulnerabilityNode{{Type: "cve", VulnerabilityID: "cve-2021-44228"}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &vulnerabilityQuery{vulnType: tt.vulnType}
+			getVulnerabilities = func(ctx context.Context, client graphql.Client, filter generated.VulnerabilitySpec) (*generated.VulnerabilitiesResponse, error) {
+				response := &generated.VulnerabilitiesResponse{}
+				for _, vuln := range vulns {
+					if filter.Type == nil || *filter.Type == vuln.Type {
+						response.Vulnerabilities = append(response.Vulnerabilities, vuln)
+					}
+				}
+				return response, nil
+			}
+
+			compChan := make(chan interface{}, 1)
+			if err := v.GetComponents(context.Background(), compChan); err != nil {
+				t.Fatalf("vulnerabilityQuery.GetComponents() error = %v", err)
+			}
+			close(compChan)
+			var got []*VulnerabilityNode
+			for d := range compChan {
+				got = append(got, d.([]*VulnerabilityNode)...)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("vulnerabilityQuery.GetComponents() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
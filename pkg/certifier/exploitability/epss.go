@@ -0,0 +1,171 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exploitability
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/guacsec/guac/pkg/assembler/clients/generated"
+)
+
+// DefaultEPSSURL is the daily export of the EPSS scores of all CVEs
+const DefaultEPSSURL = "https://epss.empiricalsecurity.com/epss_scores-current.csv.gz"
+
+// EPSSScore is the probability of exploitation of a CVE in the next 30 days
+type EPSSScore struct {
+	Score      float64
+	Percentile float64
+}
+
+// EPSS is an export of EPSS scores
+type EPSS struct {
+	// ModelVersion is the version of the EPSS model, such as v2023.03.01
+	ModelVersion string
+	// ScoreDate is the day the scores were computed
+	ScoreDate time.Time
+	// Origin is the location the scores were loaded from
+	Origin string
+	// Scores are keyed by lowercase CVE ID
+	Scores map[string]EPSSScore
+}
+
+// ScoreType returns the vulnerability score type of the EPSS model version
+func (e *EPSS) ScoreType() generated.VulnerabilityScoreType {
+	year, err := strconv.Atoi(strings.SplitN(strings.TrimPrefix(e.ModelVersion, "v"), ".", 2)[0])
+	switch {
+	case err != nil:
+		return generated.VulnerabilityScoreTypeEpssv4
+	case year < 2022:
+		return generated.VulnerabilityScoreTypeEpssv1
+	case year == 2022:
+		return generated.VulnerabilityScoreTypeEpssv2
+	case year < 2025:
+		return generated.VulnerabilityScoreTypeEpssv3
+	default:
+		return generated.VulnerabilityScoreTypeEpssv4
+	}
+}
+
+// LoadEPSS loads an EPSS CSV export, optionally gzipped, from a file or an
+// http(s) URL
+func LoadEPSS(ctx context.Context, client *http.Client, location string) (*EPSS, error) {
+	r, err := open(ctx, client, location)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	epss, err := ParseEPSS(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid EPSS scores in %s: %w", location, err)
+	}
+	epss.Origin = location
+	return epss, nil
+}
+
+// ParseEPSS parses an EPSS CSV export: an optional
+// #model_version:<version>,score_date:<date> comment followed by the
+// cve,epss,percentile columns
+func ParseEPSS(r io.Reader) (*EPSS, error) {
+	br := bufio.NewReader(r)
+	epss := &EPSS{Scores: map[string]EPSSScore{}}
+	if first, err := br.Peek(1); err == nil && first[0] == '#' {
+		line, err := br.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if err := epss.parseComment(strings.TrimSpace(strings.TrimPrefix(line, "#"))); err != nil {
+			return nil, err
+		}
+	}
+
+	reader := csv.NewReader(br)
+	reader.ReuseRecord = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("missing header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	cveColumn, ok := columns["cve"]
+	if !ok {
+		return nil, fmt.Errorf("missing cve column")
+	}
+	epssColumn, ok := columns["epss"]
+	if !ok {
+		return nil, fmt.Errorf("missing epss column")
+	}
+	percentileColumn, hasPercentile := columns["percentile"]
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var score EPSSScore
+		if score.Score, err = strconv.ParseFloat(record[epssColumn], 64); err != nil {
+			return nil, fmt.Errorf("invalid score of %s: %w", record[cveColumn], err)
+		}
+		if hasPercentile {
+			if score.Percentile, err = strconv.ParseFloat(record[percentileColumn], 64); err != nil {
+				return nil, fmt.Errorf("invalid percentile of %s: %w", record[cveColumn], err)
+			}
+		}
+		epss.Scores[strings.ToLower(record[cveColumn])] = score
+	}
+	return epss, nil
+}
+
+func (e *EPSS) parseComment(comment string) error {
+	for _, field := range strings.Split(comment, ",") {
+		key, value, _ := strings.Cut(field, ":")
+		switch strings.TrimSpace(key) {
+		case "model_version":
+			e.ModelVersion = strings.TrimSpace(value)
+		case "score_date":
+			date, err := parseTime(strings.TrimSpace(value))
+			if err != nil {
+				return fmt.Errorf("invalid score date: %w", err)
+			}
+			e.ScoreDate = date
+		}
+	}
+	return nil
+}
+
+// parseTime parses the timestamps of the EPSS and KEV feeds, which are not
+// consistently RFC 3339
+func parseTime(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05-0700", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported time %q", value)
+}
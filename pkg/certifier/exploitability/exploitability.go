@@ -0,0 +1,194 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package exploitability certifies the likelihood of exploitation of CVEs
+// with their EPSS scores (https://www.first.org/epss) and their listing in
+// the CISA Known Exploited Vulnerabilities catalog
+// (https://www.cisa.gov/known-exploited-vulnerabilities-catalog), as
+// vulnerability metadata.
+package exploitability
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/guacsec/guac/pkg/assembler"
+	"github.com/guacsec/guac/pkg/assembler/clients/generated"
+	"github.com/guacsec/guac/pkg/certifier"
+	"github.com/guacsec/guac/pkg/certifier/components/vulnerability"
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+const (
+	EPSSCollector string = "epss"
+	KEVCollector  string = "cisa-kev"
+	cveType       string = "cve"
+)
+
+var ErrExploitabilityComponentTypeMismatch error = errors.New("rootComponent type is not []*vulnerability.VulnerabilityNode")
+
+type exploitabilityCertifier struct {
+	epss *EPSS
+	kev  *KEV
+}
+
+// NewExploitabilityCertifier initializes the exploitability certifier with
+// the EPSS scores and the KEV catalog, either of which can be nil
+func NewExploitabilityCertifier(epss *EPSS, kev *KEV) certifier.Certifier {
+	return &exploitabilityCertifier{epss: epss, kev: kev}
+}
+
+// CertifyComponent emits the EPSS score and KEV listing of the CVEs as
+// vulnerability metadata. Vulnerabilities of other types are skipped.
+func (e *exploitabilityCertifier) CertifyComponent(ctx context.Context, rootComponent interface{}, docChannel chan<- *processor.Document) error {
+	vulnNodes, ok := rootComponent.([]*vulnerability.VulnerabilityNode)
+	if !ok {
+		return ErrExploitabilityComponentTypeMismatch
+	}
+
+	var cves []string
+	seen := map[string]bool{}
+	for _, node := range vulnNodes {
+		id := strings.ToLower(node.VulnerabilityID)
+		if !strings.HasPrefix(id, "cve-") || seen[id] {
+			continue
+		}
+		seen[id] = true
+		cves = append(cves, id)
+	}
+
+	if e.epss != nil {
+		preds := &assembler.IngestPredicates{}
+		scoreType := e.epss.ScoreType()
+		for _, cve := range cves {
+			score, ok := e.epss.Scores[cve]
+			if !ok {
+				continue
+			}
+			preds.VulnMetadata = append(preds.VulnMetadata, vulnMetadata(cve, &generated.VulnerabilityMetadataInputSpec{
+				ScoreType:  scoreType,
+				ScoreValue: score.Score,
+				Timestamp:  e.epss.ScoreDate,
+			}))
+		}
+		if err := emit(preds, EPSSCollector, e.epss.Origin, docChannel); err != nil {
+			return err
+		}
+	}
+
+	if e.kev != nil {
+		preds := &assembler.IngestPredicates{}
+		for _, cve := range cves {
+			entry, ok := e.kev.Get(cve)
+			if !ok {
+				continue
+			}
+			preds.VulnMetadata = append(preds.VulnMetadata, vulnMetadata(cve, &generated.VulnerabilityMetadataInputSpec{
+				ScoreType:  generated.VulnerabilityScoreTypeKev,
+				ScoreValue: 1,
+				Timestamp:  entry.Added(),
+			}))
+		}
+		if err := emit(preds, KEVCollector, e.kev.Origin, docChannel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func vulnMetadata(cve string, metadata *generated.VulnerabilityMetadataInputSpec) assembler.VulnMetadataIngest {
+	return assembler.VulnMetadataIngest{
+		Vulnerability: &generated.VulnerabilityInputSpec{Type: cveType, VulnerabilityID: cve},
+		VulnMetadata:  metadata,
+	}
+}
+
+// emit sends the predicates as a document, the collector and source are
+// recorded as the collector and origin of the metadata
+func emit(preds *assembler.IngestPredicates, collector, source string, docChannel chan<- *processor.Document) error {
+	if len(preds.VulnMetadata) == 0 {
+		return nil
+	}
+	payload, err := json.Marshal(preds)
+	if err != nil {
+		return fmt.Errorf("unable to marshal predicates: %w", err)
+	}
+	docChannel <- &processor.Document{
+		Blob:   payload,
+		Type:   processor.DocumentIngestPredicates,
+		Format: processor.FormatJSON,
+		SourceInformation: processor.SourceInformation{
+			Collector: collector,
+			Source:    source,
+		},
+	}
+	return nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// open opens a file or an http(s) URL, decompressing gzip content
+func open(ctx context.Context, client *http.Client, location string) (io.ReadCloser, error) {
+	var rc io.ReadCloser
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		if client == nil {
+			client = http.DefaultClient
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download %s: %w", location, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to download %s: %s", location, resp.Status)
+		}
+		rc = resp.Body
+	} else {
+		file, err := os.Open(location)
+		if err != nil {
+			return nil, err
+		}
+		rc = file
+	}
+
+	br := bufio.NewReader(rc)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			rc.Close()
+			return nil, fmt.Errorf("invalid gzip content in %s: %w", location, err)
+		}
+		return readCloser{Reader: gz, Closer: rc}, nil
+	}
+	return readCloser{Reader: br, Closer: rc}, nil
+}
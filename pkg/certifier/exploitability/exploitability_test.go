@@ -0,0 +1,198 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exploitability
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/guacsec/guac/pkg/assembler"
+	"github.com/guacsec/guac/pkg/assembler/clients/generated"
+	"github.com/guacsec/guac/pkg/certifier/components/vulnerability"
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+const (
+	testEPSS = `#model_version:v2023.03.01,score_date:2024-01-15T00:00:00+0000
+cve,epss,percentile
+CVE-2021-44228,0.97565,0.99996
+CVE-2023-0001,0.00045,0.12
+`
+	testKEV = `{
+  "title": "CISA Catalog of Known Exploited Vulnerabilities",
+  "catalogVersion": "2024.01.15",
+  "dateReleased": "2024-01-15T15:00:00.0000Z",
+  "count": 1,
+  "vulnerabilities": [{
+    "cveID": "CVE-2021-44228",
+    "vendorProject": "Apache",
+    "product": "Log4j2",
+    "vulnerabilityName": "Apache Log4j2 Remote Code Execution Vulnerability",
+    "dateAdded": "2021-12-10",
+    "shortDescription": "Apache Log4j2 contains a vulnerability where JNDI features do not protect against attacker-controlled JNDI-related endpoints.",
+    "requiredAction": "Apply updates per vendor instructions.",
+    "dueDate": "2021-12-24",
+    "knownRansomwareCampaignUse": "Known",
+    "notes": ""
+  }]
+}`
+)
+
+func TestParseEPSS(t *testing.T) {
+	epss, err := ParseEPSS(strings.NewReader(testEPSS))
+	if err != nil {
+		t.Fatalf("ParseEPSS() error = %v", err)
+	}
+	if epss.ModelVersion != "v2023.03.01" || !epss.ScoreDate.Equal(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ParseEPSS() model version = %s, score date = %v", epss.ModelVersion, epss.ScoreDate)
+	}
+	if diff := cmp.Diff(map[string]EPSSScore{
+		"cve-2021-44228": {Score: 0.97565, Percentile: 0.99996},
+		"cve-2023-0001":  {Score: 0.00045, Percentile: 0.12},
+	}, epss.Scores); diff != "" {
+		t.Errorf("ParseEPSS() scores (-want +got):\n%s", diff)
+	}
+
+	for version, want := range map[string]generated.VulnerabilityScoreType{
+		"v2021.04.14": generated.VulnerabilityScoreTypeEpssv1,
+		"v2022.01.01": generated.VulnerabilityScoreTypeEpssv2,
+		"v2023.03.01": generated.VulnerabilityScoreTypeEpssv3,
+		"v2025.03.14": generated.VulnerabilityScoreTypeEpssv4,
+		"":            generated.VulnerabilityScoreTypeEpssv4,
+	} {
+		if got := (&EPSS{ModelVersion: version}).ScoreType(); got != want {
+			t.Errorf("ScoreType(%q) = %s, want %s", version, got, want)
+		}
+	}
+
+	if _, err := ParseEPSS(strings.NewReader("cve,percentile\nCVE-2021-44228,0.9\n")); err == nil {
+		t.Error("ParseEPSS() expected an error for a missing epss column")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	ctx := context.Background()
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	_, _ = w.Write([]byte(testEPSS))
+	_ = w.Close()
+	path := filepath.Join(t.TempDir(), "epss_scores.csv.gz")
+	if err := os.WriteFile(path, gz.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	epss, err := LoadEPSS(ctx, nil, path)
+	if err != nil {
+		t.Fatalf("LoadEPSS() error = %v", err)
+	}
+	if len(epss.Scores) != 2 || epss.Origin != path {
+		t.Errorf("LoadEPSS() = %+v", epss)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/kev.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(testKEV))
+	}))
+	defer server.Close()
+	kev, err := LoadKEV(ctx, server.Client(), server.URL+"/kev.json")
+	if err != nil {
+		t.Fatalf("LoadKEV() error = %v", err)
+	}
+	entry, ok := kev.Get("cve-2021-44228")
+	if !ok || entry.Product != "Log4j2" || !entry.Added().Equal(time.Date(2021, 12, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("KEV.Get() = %+v, %v", entry, ok)
+	}
+	if kev.Origin != server.URL+"/kev.json" {
+		t.Errorf("LoadKEV() origin = %s", kev.Origin)
+	}
+	if _, err := LoadKEV(ctx, server.Client(), server.URL+"/missing.json"); err == nil {
+		t.Error("LoadKEV() expected an error")
+	}
+}
+
+func TestCertifyComponent(t *testing.T) {
+	epss, err := ParseEPSS(strings.NewReader(testEPSS))
+	if err != nil {
+		t.Fatal(err)
+	}
+	epss.Origin = "file:///epss.csv"
+	kev, err := ParseKEV(strings.NewReader(testKEV))
+	if err != nil {
+		t.Fatal(err)
+	}
+	kev.Origin = DefaultKEVURL
+
+	docChan := make(chan *processor.Document, 4)
+	err = NewExploitabilityCertifier(epss, kev).CertifyComponent(context.Background(), []*vulnerability.VulnerabilityNode{
+		{Type: "cve", VulnerabilityID: "cve-2021-44228"},
+		{Type: "osv", VulnerabilityID: "CVE-2021-44228"},
+		{Type: "cve", VulnerabilityID: "cve-2023-0001"},
+		{Type: "ghsa", VulnerabilityID: "ghsa-jfh8-c2jp-5v3q"},
+	}, docChan)
+	if err != nil {
+		t.Fatalf("CertifyComponent() error = %v", err)
+	}
+	close(docChan)
+
+	got := map[string][]assembler.VulnMetadataIngest{}
+	for doc := range docChan {
+		preds := &assembler.IngestPredicates{}
+		if err := json.Unmarshal(doc.Blob, preds); err != nil {
+			t.Fatal(err)
+		}
+		got[doc.SourceInformation.Collector+" "+doc.SourceInformation.Source] = preds.VulnMetadata
+	}
+	log4shell := &generated.VulnerabilityInputSpec{Type: "cve", VulnerabilityID: "cve-2021-44228"}
+	want := map[string][]assembler.VulnMetadataIngest{
+		EPSSCollector + " file:///epss.csv": {{
+			Vulnerability: log4shell,
+			VulnMetadata: &generated.VulnerabilityMetadataInputSpec{
+				ScoreType: generated.VulnerabilityScoreTypeEpssv3, ScoreValue: 0.97565, Timestamp: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			},
+		}, {
+			Vulnerability: &generated.VulnerabilityInputSpec{Type: "cve", VulnerabilityID: "cve-2023-0001"},
+			VulnMetadata: &generated.VulnerabilityMetadataInputSpec{
+				ScoreType: generated.VulnerabilityScoreTypeEpssv3, ScoreValue: 0.00045, Timestamp: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			},
+		}},
+		KEVCollector + " " + DefaultKEVURL: {{
+			Vulnerability: log4shell,
+			VulnMetadata: &generated.VulnerabilityMetadataInputSpec{
+				ScoreType: generated.VulnerabilityScoreTypeKev, ScoreValue: 1, Timestamp: time.Date(2021, 12, 10, 0, 0, 0, 0, time.UTC),
+			},
+		}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CertifyComponent() (-want +got):\n%s", diff)
+	}
+
+	err = NewExploitabilityCertifier(epss, nil).CertifyComponent(context.Background(), "", nil)
+	if !errors.Is(err, ErrExploitabilityComponentTypeMismatch) {
+		t.Errorf("CertifyComponent() error = %v, want %v", err, ErrExploitabilityComponentTypeMismatch)
+	}
+}
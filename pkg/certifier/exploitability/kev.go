@@ -0,0 +1,100 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exploitability
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultKEVURL is the CISA Known Exploited Vulnerabilities catalog
+const DefaultKEVURL = "https://www.cisa.gov/sites/default/files/feeds/known_exploited_vulnerabilities.json"
+
+// KEVEntry is a vulnerability of the Known Exploited Vulnerabilities catalog
+type KEVEntry struct {
+	CVEID                      string `json:"cveID"`
+	VendorProject              string `json:"vendorProject"`
+	Product                    string `json:"product"`
+	VulnerabilityName          string `json:"vulnerabilityName"`
+	DateAdded                  string `json:"dateAdded"`
+	ShortDescription           string `json:"shortDescription"`
+	RequiredAction             string `json:"requiredAction"`
+	DueDate                    string `json:"dueDate"`
+	KnownRansomwareCampaignUse string `json:"knownRansomwareCampaignUse"`
+
+	added time.Time
+}
+
+// Added returns the day the vulnerability was added to the catalog
+func (e *KEVEntry) Added() time.Time {
+	return e.added
+}
+
+// KEV is the Known Exploited Vulnerabilities catalog
+type KEV struct {
+	CatalogVersion  string      `json:"catalogVersion"`
+	DateReleased    string      `json:"dateReleased"`
+	Vulnerabilities []*KEVEntry `json:"vulnerabilities"`
+
+	// Origin is the location the catalog was loaded from
+	Origin string `json:"-"`
+	// entries are keyed by lowercase CVE ID
+	entries map[string]*KEVEntry
+}
+
+// Get returns the catalog entry of a CVE
+func (k *KEV) Get(cve string) (*KEVEntry, bool) {
+	entry, ok := k.entries[strings.ToLower(cve)]
+	return entry, ok
+}
+
+// LoadKEV loads the Known Exploited Vulnerabilities catalog JSON, optionally
+// gzipped, from a file or an http(s) URL
+func LoadKEV(ctx context.Context, client *http.Client, location string) (*KEV, error) {
+	r, err := open(ctx, client, location)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	kev, err := ParseKEV(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid KEV catalog in %s: %w", location, err)
+	}
+	kev.Origin = location
+	return kev, nil
+}
+
+// ParseKEV parses the Known Exploited Vulnerabilities catalog JSON
+func ParseKEV(r io.Reader) (*KEV, error) {
+	kev := &KEV{}
+	if err := json.NewDecoder(r).Decode(kev); err != nil {
+		return nil, err
+	}
+	kev.entries = map[string]*KEVEntry{}
+	for _, entry := range kev.Vulnerabilities {
+		added, err := parseTime(entry.DateAdded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date added of %s: %w", entry.CVEID, err)
+		}
+		entry.added = added
+		kev.entries[strings.ToLower(entry.CVEID)] = entry
+	}
+	return kev, nil
+}
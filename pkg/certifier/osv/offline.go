@@ -0,0 +1,76 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osv
+
+import (
+	"context"
+	"fmt"
+
+	osv_scanner "github.com/google/osv-scanner/pkg/osv"
+
+	"github.com/guacsec/guac/pkg/certifier"
+	attestation_vuln "github.com/guacsec/guac/pkg/certifier/attestation"
+	"github.com/guacsec/guac/pkg/certifier/components/root_package"
+	"github.com/guacsec/guac/pkg/certifier/osv/osvdb"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+type offlineOSVCertifier struct {
+	db *osvdb.DB
+}
+
+// NewOfflineOSVCertificationParser initializes an OSV certifier that matches
+// packages against a local OSV database instead of querying osv.dev
+func NewOfflineOSVCertificationParser(db *osvdb.DB) certifier.Certifier {
+	return &offlineOSVCertifier{db: db}
+}
+
+// CertifyComponent takes in the root component from the gauc database and
+// generates vulnerability attestations from the local OSV database
+func (o *offlineOSVCertifier) CertifyComponent(ctx context.Context, rootComponent interface{}, docChannel chan<- *processor.Document) error {
+	logger := logging.FromContext(ctx)
+	packageNodes, ok := rootComponent.([]*root_package.PackageNode)
+	if !ok {
+		return ErrOSVComponenetTypeMismatch
+	}
+
+	var purls []string
+	packMap := map[string][]*root_package.PackageNode{}
+	for _, node := range packageNodes {
+		if _, ok := packMap[node.Purl]; !ok {
+			purls = append(purls, node.Purl)
+		}
+		packMap[node.Purl] = append(packMap[node.Purl], node)
+	}
+
+	db := attestation_vuln.DB{Uri: URI, Version: o.db.Version()}
+	for _, purl := range purls {
+		matches, err := o.db.Query(purl)
+		if err != nil {
+			logger.Warnf("skipping package: %v", err)
+			continue
+		}
+		vulns := make([]osv_scanner.MinimalVulnerability, 0, len(matches))
+		for _, vuln := range matches {
+			vulns = append(vulns, osv_scanner.MinimalVulnerability{ID: vuln.ID})
+		}
+		if err := generateDocument(packMap[purl], vulns, db, docChannel); err != nil {
+			return fmt.Errorf("could not generate document from OSV results: %w", err)
+		}
+	}
+	return nil
+}
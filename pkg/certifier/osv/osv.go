@@ -83,17 +83,21 @@ func (o *osvCertifier) CertifyComponent(ctx context.Context, rootComponent inter
 	for i, query := range query.Queries {
 		response := resp.Results[i]
 		purl := query.Package.PURL
-		if err := generateDocument(packMap[purl], response.Vulns, docChannel); err != nil {
+		if err := generateDocument(packMap[purl], response.Vulns, attestation_vuln.DB{}, docChannel); err != nil {
 			return fmt.Errorf("could not generate document from OSV results: %w", err)
 		}
 	}
 	return nil
 }
 
-func generateDocument(packNodes []*root_package.PackageNode, vulns []osv_scanner.MinimalVulnerability, docChannel chan<- *processor.Document) error {
+// generateDocument emits a vulnerability attestation for each package node,
+// recording the scanner database when known
+func generateDocument(packNodes []*root_package.PackageNode, vulns []osv_scanner.MinimalVulnerability, db attestation_vuln.DB, docChannel chan<- *processor.Document) error {
 	currentTime := time.Now()
 	for _, node := range packNodes {
-		payload, err := json.Marshal(createAttestation(node, vulns, currentTime))
+		attestation := createAttestation(node, vulns, currentTime)
+		attestation.Predicate.Scanner.Database = db
+		payload, err := json.Marshal(attestation)
 		if err != nil {
 			return fmt.Errorf("unable to marshal attestation: %w", err)
 		}
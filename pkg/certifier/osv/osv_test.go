@@ -18,6 +18,8 @@ package osv
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -25,6 +27,7 @@ import (
 	osv_scanner "github.com/google/osv-scanner/pkg/osv"
 	attestation_vuln "github.com/guacsec/guac/pkg/certifier/attestation"
 	"github.com/guacsec/guac/pkg/certifier/components/root_package"
+	"github.com/guacsec/guac/pkg/certifier/osv/osvdb"
 	intoto "github.com/in-toto/in-toto-golang/in_toto"
 
 	"github.com/guacsec/guac/internal/testing/dochelper"
@@ -259,3 +262,63 @@ func deepEqualIgnoreTimestamp(a, b *attestation_vuln.VulnerabilityStatement) boo
 	// use DeepEqual to compare the copies
 	return reflect.DeepEqual(aCopy, bCopy)
 }
+
+func TestOfflineOSVCertifier_CertifyVulns(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	dir := t.TempDir()
+	record := `{
+  "id": "GHSA-599f-7c49-w659",
+  "modified": "2022-10-20T00:00:00Z",
+  "affected": [{
+    "package": {"ecosystem": "Maven", "name": "org.apache.commons:commons-text"},
+    "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "1.5"}, {"fixed": "1.10.0"}]}]
+  }]
+}`
+	if err := os.WriteFile(filepath.Join(dir, "GHSA-599f-7c49-w659.json"), []byte(record), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	db, err := osvdb.Load(dir)
+	if err != nil {
+		t.Fatalf("osvdb.Load() error = %v", err)
+	}
+
+	o := NewOfflineOSVCertificationParser(db)
+	rootComponent := []*root_package.PackageNode{
+		&testdata.Text4ShelPackage,
+		{Purl: "pkg:maven/org.apache.commons/commons-text@1.10.0"},
+		&testdata.Text4ShelPackage,
+		{Purl: "not-a-purl"},
+	}
+	docChan := make(chan *processor.Document, len(rootComponent))
+	if err := o.CertifyComponent(ctx, rootComponent, docChan); err != nil {
+		t.Fatalf("CertifyComponent() error = %v", err)
+	}
+	close(docChan)
+
+	want := map[string][]attestation_vuln.Result{
+		"pkg:maven/org.apache.commons/commons-text@1.9":    {{VulnerabilityId: "GHSA-599f-7c49-w659"}},
+		"pkg:maven/org.apache.commons/commons-text@1.10.0": nil,
+	}
+	var count int
+	for doc := range docChan {
+		count++
+		if doc.Type != processor.DocumentITE6Vul {
+			t.Errorf("unexpected document type %s", doc.Type)
+		}
+		var statement attestation_vuln.VulnerabilityStatement
+		if err := json.Unmarshal(doc.Blob, &statement); err != nil {
+			t.Fatalf("unable to unmarshal attestation: %v", err)
+		}
+		scanner := statement.Predicate.Scanner
+		if scanner.Database.Uri != URI || scanner.Database.Version != "2022-10-20T00:00:00Z" {
+			t.Errorf("unexpected scanner database %+v", scanner.Database)
+		}
+		purl := statement.Subject[0].Name
+		if !reflect.DeepEqual(scanner.Result, want[purl]) {
+			t.Errorf("results of %s = %+v, want %+v", purl, scanner.Result, want[purl])
+		}
+	}
+	if count != 3 {
+		t.Errorf("got %d documents, want 3", count)
+	}
+}
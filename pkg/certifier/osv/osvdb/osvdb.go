@@ -0,0 +1,256 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package osvdb is a local index of OSV vulnerabilities, loaded from the
+// ecosystem zip exports of osv.dev (e.g.
+// https://osv-vulnerabilities.storage.googleapis.com/PyPI/all.zip) or from
+// directories of OSV JSON records. It lets the OSV certifier run without
+// network access.
+package osvdb
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/guacsec/guac/pkg/misc/versioncmp"
+)
+
+// entry is a package affected by a vulnerability
+type entry struct {
+	vuln     *models.Vulnerability
+	affected *models.Affected
+}
+
+// DB indexes the affected packages of OSV vulnerabilities by ecosystem and
+// package name
+type DB struct {
+	index    map[models.Ecosystem]map[string][]entry
+	modified time.Time
+	count    int
+}
+
+// Load reads the OSV records of the paths, which are zip exports, JSON files
+// or directories searched recursively for both. Withdrawn vulnerabilities are
+// skipped.
+func Load(paths ...string) (*DB, error) {
+	db := &DB{index: map[models.Ecosystem]map[string][]entry{}}
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			switch strings.ToLower(filepath.Ext(path)) {
+			case ".zip":
+				return db.loadZip(path)
+			case ".json":
+				f, err := os.Open(path)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				return db.loadRecord(path, f)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load OSV records from %s: %w", path, err)
+		}
+	}
+	return db, nil
+}
+
+func (db *DB) loadZip(path string) error {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	for _, file := range r.File {
+		if file.FileInfo().IsDir() || !strings.EqualFold(filepath.Ext(file.Name), ".json") {
+			continue
+		}
+		f, err := file.Open()
+		if err != nil {
+			return err
+		}
+		err = db.loadRecord(path+"!"+file.Name, f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (db *DB) loadRecord(name string, r io.Reader) error {
+	vuln := &models.Vulnerability{}
+	if err := json.NewDecoder(r).Decode(vuln); err != nil {
+		return fmt.Errorf("invalid OSV record %s: %w", name, err)
+	}
+	if vuln.ID == "" {
+		return fmt.Errorf("invalid OSV record %s: missing id", name)
+	}
+	if vuln.Modified.After(db.modified) {
+		db.modified = vuln.Modified
+	}
+	if !vuln.Withdrawn.IsZero() {
+		return nil
+	}
+	db.count++
+	for i := range vuln.Affected {
+		affected := &vuln.Affected[i]
+		ecosystem := baseEcosystem(affected.Package.Ecosystem)
+		names, ok := db.index[ecosystem]
+		if !ok {
+			names = map[string][]entry{}
+			db.index[ecosystem] = names
+		}
+		name := normalizeName(ecosystem, affected.Package.Name)
+		names[name] = append(names[name], entry{vuln: vuln, affected: affected})
+	}
+	return nil
+}
+
+// Len returns the number of vulnerabilities of the database
+func (db *DB) Len() int {
+	return db.count
+}
+
+// Version identifies the content of the database by the most recent
+// modification time of its records
+func (db *DB) Version() string {
+	if db.modified.IsZero() {
+		return ""
+	}
+	return db.modified.UTC().Format(time.RFC3339)
+}
+
+// Query returns the vulnerabilities affecting the package version of the
+// purl, sorted by ID. A purl without version matches no vulnerability.
+func (db *DB) Query(purl string) ([]*models.Vulnerability, error) {
+	pkg, err := models.PURLToPackage(purl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid purl %s: %w", purl, err)
+	}
+	if pkg.Version == "" {
+		return nil, nil
+	}
+	ecosystem := models.Ecosystem(pkg.Ecosystem)
+	seen := map[string]bool{}
+	var vulns []*models.Vulnerability
+	for _, e := range db.index[ecosystem][normalizeName(ecosystem, pkg.Name)] {
+		if seen[e.vuln.ID] || !isAffected(ecosystem, e.affected, pkg.Version) {
+			continue
+		}
+		seen[e.vuln.ID] = true
+		vulns = append(vulns, e.vuln)
+	}
+	sort.Slice(vulns, func(i, j int) bool { return vulns[i].ID < vulns[j].ID })
+	return vulns, nil
+}
+
+// baseEcosystem drops the release of ecosystems such as Debian:11 or
+// Alpine:v3.18, so that a package matches the records of every release
+func baseEcosystem(ecosystem models.Ecosystem) models.Ecosystem {
+	base, _, _ := strings.Cut(string(ecosystem), ":")
+	return models.Ecosystem(base)
+}
+
+var pypiNameSeparators = regexp.MustCompile(`[-_.]+`)
+
+// normalizeName normalizes Python package names as defined by PEP 503, other
+// ecosystems have case sensitive names
+func normalizeName(ecosystem models.Ecosystem, name string) string {
+	if ecosystem == models.EcosystemPyPI {
+		return pypiNameSeparators.ReplaceAllString(strings.ToLower(name), "-")
+	}
+	return name
+}
+
+// isAffected reports whether the version is listed as affected or is within
+// one of the SEMVER or ECOSYSTEM ranges. GIT ranges are ignored as they
+// apply to commits.
+func isAffected(ecosystem models.Ecosystem, affected *models.Affected, version string) bool {
+	ecosystemCompare := comparator(ecosystem, models.RangeEcosystem)
+	for _, v := range affected.Versions {
+		if v == version || ecosystemCompare(v, version) == 0 {
+			return true
+		}
+	}
+	for _, r := range affected.Ranges {
+		if r.Type == models.RangeGit {
+			continue
+		}
+		if inRange(r.Events, version, comparator(ecosystem, r.Type)) {
+			return true
+		}
+	}
+	return false
+}
+
+// inRange evaluates the events of a range in version order, as specified by
+// the OSV schema: an introduced event at or below the version enters the
+// range, a fixed or limit event at or below it, or a last_affected event
+// below it, leaves the range
+func inRange(events []models.Event, version string, compare versioncmp.Func) bool {
+	sorted := make([]models.Event, len(events))
+	copy(sorted, events)
+	key := func(e models.Event) string {
+		return e.Introduced + e.Fixed + e.LastAffected + e.Limit
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ki, kj := key(sorted[i]), key(sorted[j])
+		if ki == "0" || kj == "0" {
+			return ki == "0" && kj != "0"
+		}
+		return compare(ki, kj) < 0
+	})
+
+	affected := false
+	for _, e := range sorted {
+		switch {
+		case e.Introduced != "":
+			if e.Introduced == "0" || compare(version, e.Introduced) >= 0 {
+				affected = true
+			}
+		case e.Fixed != "":
+			if compare(version, e.Fixed) >= 0 {
+				affected = false
+			}
+		case e.LastAffected != "":
+			if compare(version, e.LastAffected) > 0 {
+				affected = false
+			}
+		case e.Limit != "" && e.Limit != "*":
+			if compare(version, e.Limit) >= 0 {
+				affected = false
+			}
+		}
+	}
+	return affected
+}
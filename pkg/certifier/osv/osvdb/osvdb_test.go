@@ -0,0 +1,214 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osvdb
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var records = map[string]string{
+	"GHSA-jfh8-c2jp-5v3q.json": `{
+  "id": "GHSA-jfh8-c2jp-5v3q",
+  "modified": "2024-01-10T12:00:00Z",
+  "aliases": ["CVE-2021-44228"],
+  "affected": [{
+    "package": {"ecosystem": "Maven", "name": "org.apache.logging.log4j:log4j-core"},
+    "ranges": [{"type": "ECOSYSTEM", "events": [
+      {"introduced": "2.13.0"}, {"fixed": "2.15.0"},
+      {"introduced": "2.0-beta9"}, {"fixed": "2.12.2"}
+    ]}]
+  }]
+}`,
+	"PYSEC-2021-1.json": `{
+  "id": "PYSEC-2021-1",
+  "modified": "2024-02-01T08:30:00Z",
+  "affected": [{
+    "package": {"ecosystem": "PyPI", "name": "Django_Rest.Framework"},
+    "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"last_affected": "3.11.1"}]}],
+    "versions": ["3.12.0rc1"]
+  }]
+}`,
+	"DSA-1-1.json": `{
+  "id": "DSA-1-1",
+  "modified": "2023-06-01T00:00:00Z",
+  "affected": [{
+    "package": {"ecosystem": "Debian:11", "name": "openssl"},
+    "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "1.1.1n-0+deb11u1"}]}]
+  }]
+}`,
+	"GO-2020-1.json": `{
+  "id": "GO-2020-1",
+  "modified": "2023-01-01T00:00:00Z",
+  "affected": [{
+    "package": {"ecosystem": "Go", "name": "github.com/gin-gonic/gin"},
+    "ranges": [
+      {"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "1.6.0"}]},
+      {"type": "GIT", "repo": "https://github.com/gin-gonic/gin", "events": [{"introduced": "0"}, {"fixed": "abcdef"}]}
+    ]
+  }]
+}`,
+	"GHSA-withdrawn.json": `{
+  "id": "GHSA-withdrawn",
+  "modified": "2024-03-01T00:00:00Z",
+  "withdrawn": "2024-03-01T00:00:00Z",
+  "affected": [{
+    "package": {"ecosystem": "Go", "name": "github.com/gin-gonic/gin"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}]}]
+  }]
+}`,
+}
+
+// writeRecords writes the Maven and PyPI records to a directory and the
+// others to a zip export
+func writeRecords(t *testing.T) string {
+	dir := t.TempDir()
+	zf, err := os.Create(filepath.Join(dir, "all.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(zf)
+	for name, record := range records {
+		if name == "GHSA-jfh8-c2jp-5v3q.json" || name == "PYSEC-2021-1.json" {
+			if err := os.MkdirAll(filepath.Join(dir, "records"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "records", name), []byte(record), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(record)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := zf.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestQuery(t *testing.T) {
+	db, err := Load(writeRecords(t))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := db.Len(); got != 4 {
+		t.Errorf("Len() = %d, want 4", got)
+	}
+	// the withdrawn record is the most recent
+	if got, want := db.Version(), "2024-03-01T00:00:00Z"; got != want {
+		t.Errorf("Version() = %s, want %s", got, want)
+	}
+
+	tests := []struct {
+		name    string
+		purl    string
+		want    []string
+		wantErr bool
+	}{{
+		name: "maven in second range",
+		purl: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1",
+		want: []string{"GHSA-jfh8-c2jp-5v3q"},
+	}, {
+		name: "maven pre-release in first range",
+		purl: "pkg:maven/org.apache.logging.log4j/log4j-core@2.0-rc1",
+		want: []string{"GHSA-jfh8-c2jp-5v3q"},
+	}, {
+		name: "maven between ranges",
+		purl: "pkg:maven/org.apache.logging.log4j/log4j-core@2.12.4",
+	}, {
+		name: "maven fixed",
+		purl: "pkg:maven/org.apache.logging.log4j/log4j-core@2.15.0",
+	}, {
+		name: "maven before introduced",
+		purl: "pkg:maven/org.apache.logging.log4j/log4j-core@2.0-alpha1",
+	}, {
+		name: "pypi normalized name at last affected",
+		purl: "pkg:pypi/django-rest-framework@3.11.1",
+		want: []string{"PYSEC-2021-1"},
+	}, {
+		name: "pypi explicit version",
+		purl: "pkg:pypi/django-rest-framework@3.12.0rc1",
+		want: []string{"PYSEC-2021-1"},
+	}, {
+		name: "pypi after last affected",
+		purl: "pkg:pypi/django-rest-framework@3.11.2",
+	}, {
+		name: "debian release",
+		purl: "pkg:deb/debian/openssl@1.1.1k-1+deb11u1?arch=amd64",
+		want: []string{"DSA-1-1"},
+	}, {
+		name: "debian fixed",
+		purl: "pkg:deb/debian/openssl@1.1.1n-0+deb11u1",
+	}, {
+		name: "go semver",
+		purl: "pkg:golang/github.com/gin-gonic/gin@v1.5.0",
+		want: []string{"GO-2020-1"},
+	}, {
+		name: "go fixed, git range ignored",
+		purl: "pkg:golang/github.com/gin-gonic/gin@v1.6.0",
+	}, {
+		name: "no version",
+		purl: "pkg:golang/github.com/gin-gonic/gin",
+	}, {
+		name: "unknown package",
+		purl: "pkg:npm/left-pad@1.0.0",
+	}, {
+		name:    "invalid purl",
+		purl:    "not-a-purl",
+		wantErr: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vulns, err := db.Query(test.purl)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Query() error = %v, wantErr %v", err, test.wantErr)
+			}
+			var got []string
+			for _, vuln := range vulns {
+				got = append(got, vuln.ID)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("Query() unexpected results (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "bad.json"), []byte(`{"id": `), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir); err == nil {
+		t.Error("Load() expected an error for an invalid record")
+	}
+	if _, err := Load(filepath.Join(dir, "missing")); err == nil {
+		t.Error("Load() expected an error for a missing path")
+	}
+}
@@ -0,0 +1,56 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osvdb
+
+import (
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/guacsec/guac/pkg/misc/versioncmp"
+)
+
+// ecosystemComparators are the version semantics of the ecosystems whose
+// ECOSYSTEM ranges do not follow semver
+var ecosystemComparators = map[models.Ecosystem]versioncmp.Func{
+	models.EcosystemPyPI:   versioncmp.PEP440,
+	models.EcosystemMaven:  versioncmp.Maven,
+	models.EcosystemDebian: versioncmp.Debian,
+	// Ubuntu versions follow dpkg, like Debian
+	"Ubuntu": versioncmp.Debian,
+}
+
+// semverEcosystems use semver ordering for ECOSYSTEM ranges
+var semverEcosystems = map[models.Ecosystem]bool{
+	models.EcosystemGo:            true,
+	models.EcosystemNPM:           true,
+	models.EcosystemCratesIO:      true,
+	models.EcosystemHex:           true,
+	models.EcosystemPub:           true,
+	models.EcosystemBitnami:       true,
+	models.EcosystemSwiftURL:      true,
+	models.EcosystemGitHubActions: true,
+}
+
+// comparator returns the version semantics of a range of the ecosystem.
+// Ecosystems without specific semantics use a generic ordering of the
+// numeric and alphabetic parts of versions.
+func comparator(ecosystem models.Ecosystem, rangeType models.RangeType) versioncmp.Func {
+	if rangeType == models.RangeSemVer || semverEcosystems[ecosystem] {
+		return versioncmp.Semver
+	}
+	if compare, ok := ecosystemComparators[ecosystem]; ok {
+		return compare
+	}
+	return versioncmp.Generic
+}
@@ -0,0 +1,89 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osvdb
+
+import (
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		ecosystem models.Ecosystem
+		rangeType models.RangeType
+		a, b      string
+		want      int
+	}{
+		// semver
+		{models.EcosystemGo, models.RangeSemVer, "1.2.3", "1.2.3", 0},
+		{models.EcosystemGo, models.RangeSemVer, "v1.2.3", "1.2.3", 0},
+		{models.EcosystemGo, models.RangeSemVer, "1.2.3", "1.10.0", -1},
+		{models.EcosystemNPM, models.RangeSemVer, "1.0.0-alpha", "1.0.0", -1},
+		{models.EcosystemNPM, models.RangeSemVer, "1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{models.EcosystemNPM, models.RangeSemVer, "1.0.0-beta.11", "1.0.0-beta.2", 1},
+		{models.EcosystemNPM, models.RangeSemVer, "1.0.0+build.1", "1.0.0", 0},
+		{models.EcosystemCratesIO, models.RangeEcosystem, "0.9.0", "0.10.0", -1},
+		// PEP 440
+		{models.EcosystemPyPI, models.RangeEcosystem, "1.0", "1.0.0", 0},
+		{models.EcosystemPyPI, models.RangeEcosystem, "1.0.dev1", "1.0a1", -1},
+		{models.EcosystemPyPI, models.RangeEcosystem, "1.0a1", "1.0b1", -1},
+		{models.EcosystemPyPI, models.RangeEcosystem, "1.0rc1", "1.0", -1},
+		{models.EcosystemPyPI, models.RangeEcosystem, "1.0", "1.0.post1", -1},
+		{models.EcosystemPyPI, models.RangeEcosystem, "1.0.post1.dev1", "1.0.post1", -1},
+		{models.EcosystemPyPI, models.RangeEcosystem, "1.0", "1.0+local", -1},
+		{models.EcosystemPyPI, models.RangeEcosystem, "1!0.5", "2.0", 1},
+		{models.EcosystemPyPI, models.RangeEcosystem, "2.9", "2.10", -1},
+		// Maven
+		{models.EcosystemMaven, models.RangeEcosystem, "1.0", "1.0.0", 0},
+		{models.EcosystemMaven, models.RangeEcosystem, "1.0-alpha-1", "1.0-beta-1", -1},
+		{models.EcosystemMaven, models.RangeEcosystem, "1.0-rc1", "1.0", -1},
+		{models.EcosystemMaven, models.RangeEcosystem, "1.0-SNAPSHOT", "1.0", -1},
+		{models.EcosystemMaven, models.RangeEcosystem, "1.0", "1.0-sp1", -1},
+		{models.EcosystemMaven, models.RangeEcosystem, "1.0.final", "1.0", 0},
+		{models.EcosystemMaven, models.RangeEcosystem, "2.14.1", "2.15.0", -1},
+		{models.EcosystemMaven, models.RangeEcosystem, "2.9", "2.10", -1},
+		// Debian
+		{models.EcosystemDebian, models.RangeEcosystem, "1.0", "1.0", 0},
+		{models.EcosystemDebian, models.RangeEcosystem, "1.0~rc1", "1.0", -1},
+		{models.EcosystemDebian, models.RangeEcosystem, "1:0.9", "2.0", 1},
+		{models.EcosystemDebian, models.RangeEcosystem, "1.0-1", "1.0-2", -1},
+		{models.EcosystemDebian, models.RangeEcosystem, "1.0a", "1.0+", -1},
+		{models.EcosystemDebian, models.RangeEcosystem, "1.2.3-1+deb11u1", "1.2.3-1+deb11u2", -1},
+		{models.EcosystemDebian, models.RangeEcosystem, "2.9", "2.10", -1},
+	}
+	for _, test := range tests {
+		t.Run(string(test.ecosystem)+"/"+test.a+"/"+test.b, func(t *testing.T) {
+			compare := comparator(test.ecosystem, test.rangeType)
+			if got := sign(compare(test.a, test.b)); got != test.want {
+				t.Errorf("compare(%q, %q) = %d, want %d", test.a, test.b, got, test.want)
+			}
+			if got := sign(compare(test.b, test.a)); got != -test.want {
+				t.Errorf("compare(%q, %q) = %d, want %d", test.b, test.a, got, -test.want)
+			}
+		})
+	}
+}
+
+func sign(c int) int {
+	switch {
+	case c < 0:
+		return -1
+	case c > 0:
+		return 1
+	}
+	return 0
+}
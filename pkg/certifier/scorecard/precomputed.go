@@ -0,0 +1,141 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorecard
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/guacsec/guac/pkg/certifier"
+	"github.com/ossf/scorecard/v4/docs/checks"
+	sc "github.com/ossf/scorecard/v4/pkg"
+)
+
+// ErrNoResult is returned when no precomputed result matches a source
+var ErrNoResult = errors.New("no precomputed scorecard result")
+
+// ResultSource looks up precomputed Scorecard results, such as those of the
+// public BigQuery export, a dump of `scorecard --format json` results or an
+// HTTP API serving them
+type ResultSource interface {
+	// Results returns the results of the repository, e.g.
+	// github.com/ossf/scorecard, restricted to the commit unless it is empty
+	Results(ctx context.Context, repo, commit string) ([]*sc.ScorecardResult, error)
+}
+
+type precomputedScorecard struct {
+	ctx    context.Context
+	source ResultSource
+	docs   checks.Doc
+}
+
+// NewPrecomputedScorecard returns a Scorecard that reads the results of the
+// source instead of running the checks. Sources of the graph, typically
+// created by HasSourceAt, are matched on their repository and commit, and
+// a source without commit matches the most recent result of the repository.
+func NewPrecomputedScorecard(ctx context.Context, source ResultSource) (Scorecard, error) {
+	if source == nil {
+		return nil, fmt.Errorf("result source cannot be nil")
+	}
+	docs, err := checks.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error getting scorecard docs: %w", err)
+	}
+	return precomputedScorecard{ctx: ctx, source: source, docs: docs}, nil
+}
+
+func (p precomputedScorecard) GetScore(repoName, commitSHA, tag string) (*sc.ScorecardResult, error) {
+	commit := strings.ToLower(commitSHA)
+	if commit == "head" {
+		commit = ""
+	}
+	if commit == "" && tag != "" {
+		// precomputed results do not record tags, and the latest result may
+		// be for a later release
+		return nil, fmt.Errorf("%w for %s@%s: results are matched by commit", ErrNoResult, repoName, tag)
+	}
+	repo := normalizeRepo(repoName)
+	results, err := p.source.Results(p.ctx, repo, commit)
+	if err != nil {
+		return nil, fmt.Errorf("error, failed to get precomputed scorecard results of %s: %w", repo, err)
+	}
+	var latest *sc.ScorecardResult
+	for _, result := range results {
+		if commit != "" && !strings.EqualFold(result.Repo.CommitSHA, commit) {
+			continue
+		}
+		if latest == nil || result.Date.After(latest.Date) {
+			latest = result
+		}
+	}
+	if latest == nil {
+		if commit == "" {
+			return nil, fmt.Errorf("%w for %s", ErrNoResult, repo)
+		}
+		return nil, fmt.Errorf("%w for %s@%s", ErrNoResult, repo, commit)
+	}
+	return p.knownChecks(latest), nil
+}
+
+// knownChecks returns a copy of the result without the checks added by newer
+// Scorecard releases, that the scorecard documents cannot describe
+func (p precomputedScorecard) knownChecks(result *sc.ScorecardResult) *sc.ScorecardResult {
+	known := *result
+	known.Checks = nil
+	for _, check := range result.Checks {
+		if p.docs.CheckExists(check.Name) {
+			known.Checks = append(known.Checks, check)
+		}
+	}
+	return &known
+}
+
+// parseResult parses a result in the JSON format of `scorecard --format
+// json`, which is also the format of the BigQuery export and of the public
+// Scorecard API
+func parseResult(data []byte) (*sc.ScorecardResult, error) {
+	result, _, err := sc.ExperimentalFromJSON2(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid scorecard result: %w", err)
+	}
+	if result.Repo.Name == "" {
+		return nil, fmt.Errorf("invalid scorecard result: missing repo name")
+	}
+	return &result, nil
+}
+
+// normalizeRepo turns a repository URL or name into the name used by
+// Scorecard, e.g. github.com/ossf/scorecard
+func normalizeRepo(repo string) string {
+	repo = strings.ToLower(strings.TrimSpace(repo))
+	for _, prefix := range []string{"git+", "https://", "http://"} {
+		repo = strings.TrimPrefix(repo, prefix)
+	}
+	repo = strings.TrimSuffix(repo, "/")
+	return strings.TrimSuffix(repo, ".git")
+}
+
+// NewPrecomputedScorecardCertifier initializes the scorecard certifier with
+// precomputed results, which does not need a GitHub token
+func NewPrecomputedScorecardCertifier(sc Scorecard) (certifier.Certifier, error) {
+	if sc == nil {
+		return nil, fmt.Errorf("scorecard cannot be nil")
+	}
+	return &scorecard{scorecard: sc}, nil
+}
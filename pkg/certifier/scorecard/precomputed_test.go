@@ -0,0 +1,258 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorecard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/guacsec/guac/pkg/certifier/components/source"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"google.golang.org/api/option"
+)
+
+const (
+	resultOld   = `{"date":"2023-06-01","repo":{"name":"github.com/ossf/scorecard","commit":"1111111111111111111111111111111111111111"},"scorecard":{"version":"v4.10.5","commit":"aaaa"},"score":7.5,"checks":[{"name":"Binary-Artifacts","score":10,"reason":"no binaries found in the repo","details":null,"documentation":{"short":"","url":""}}],"metadata":null}`
+	resultNew   = `{"date":"2024-01-15T10:00:00Z","repo":{"name":"github.com/ossf/scorecard","commit":"2222222222222222222222222222222222222222"},"scorecard":{"version":"v4.13.1","commit":"bbbb"},"score":8.0,"checks":[{"name":"Binary-Artifacts","score":10,"reason":"no binaries found in the repo","details":["Info: no binaries"],"documentation":{"short":"","url":""}},{"name":"SBOM","score":0,"reason":"SBOM file not detected","details":null,"documentation":{"short":"","url":""}}],"metadata":null}`
+	resultOther = `[{"date":"2024-01-15","repo":{"name":"github.com/Django/Django","commit":"3333333333333333333333333333333333333333"},"scorecard":{"version":"v4.13.1","commit":"bbbb"},"score":6.0,"checks":[{"name":"Code-Review","score":6,"reason":"found 6 reviewed changesets","details":null,"documentation":{"short":"","url":""}}],"metadata":null}]`
+)
+
+func writeResults(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		"scorecard.ndjson":    resultOld + "\n" + resultNew + "\n",
+		"nested/django.json":  resultOther,
+		"nested/ignored.yaml": "not: scorecard",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestPrecomputedScorecard_GetScore(t *testing.T) {
+	results, err := NewFileSource(writeResults(t))
+	if err != nil {
+		t.Fatalf("NewFileSource() error = %v", err)
+	}
+	precomputed, err := NewPrecomputedScorecard(context.Background(), results)
+	if err != nil {
+		t.Fatalf("NewPrecomputedScorecard() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		repo       string
+		commit     string
+		tag        string
+		wantCommit string
+		wantChecks []string
+		wantNone   bool
+	}{{
+		name:       "latest result",
+		repo:       "github.com/ossf/scorecard",
+		commit:     "HEAD",
+		wantCommit: "2222222222222222222222222222222222222222",
+		wantChecks: []string{"Binary-Artifacts"},
+	}, {
+		name:       "result at commit",
+		repo:       "https://github.com/ossf/scorecard.git",
+		commit:     "1111111111111111111111111111111111111111",
+		wantCommit: "1111111111111111111111111111111111111111",
+		wantChecks: []string{"Binary-Artifacts"},
+	}, {
+		name:       "case insensitive repository",
+		repo:       "github.com/django/django",
+		commit:     "3333333333333333333333333333333333333333",
+		wantCommit: "3333333333333333333333333333333333333333",
+		wantChecks: []string{"Code-Review"},
+	}, {
+		name:     "unknown commit",
+		repo:     "github.com/ossf/scorecard",
+		commit:   "4444444444444444444444444444444444444444",
+		wantNone: true,
+	}, {
+		name:     "tag without commit",
+		repo:     "github.com/ossf/scorecard",
+		commit:   "HEAD",
+		tag:      "v4.13.1",
+		wantNone: true,
+	}, {
+		name:     "unknown repository",
+		repo:     "github.com/guacsec/guac",
+		wantNone: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := precomputed.GetScore(test.repo, test.commit, test.tag)
+			if test.wantNone {
+				if !errors.Is(err, ErrNoResult) {
+					t.Fatalf("GetScore() error = %v, want ErrNoResult", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetScore() error = %v", err)
+			}
+			if got.Repo.CommitSHA != test.wantCommit {
+				t.Errorf("GetScore() commit = %s, want %s", got.Repo.CommitSHA, test.wantCommit)
+			}
+			var checks []string
+			for _, check := range got.Checks {
+				checks = append(checks, check.Name)
+			}
+			if strings.Join(checks, ",") != strings.Join(test.wantChecks, ",") {
+				t.Errorf("GetScore() checks = %v, want %v", checks, test.wantChecks)
+			}
+		})
+	}
+}
+
+func TestPrecomputedScorecard_CertifyComponent(t *testing.T) {
+	ctx := context.Background()
+	results, err := NewFileSource(writeResults(t))
+	if err != nil {
+		t.Fatalf("NewFileSource() error = %v", err)
+	}
+	precomputed, err := NewPrecomputedScorecard(ctx, results)
+	if err != nil {
+		t.Fatalf("NewPrecomputedScorecard() error = %v", err)
+	}
+	t.Setenv("GITHUB_AUTH_TOKEN", "")
+	c, err := NewPrecomputedScorecardCertifier(precomputed)
+	if err != nil {
+		t.Fatalf("NewPrecomputedScorecardCertifier() error = %v", err)
+	}
+
+	docChan := make(chan *processor.Document, 2)
+	if err := c.CertifyComponent(ctx, &source.SourceNode{Repo: "github.com/ossf/scorecard"}, docChan); err != nil {
+		t.Fatalf("CertifyComponent() error = %v", err)
+	}
+	// sources without precomputed results are skipped
+	if err := c.CertifyComponent(ctx, &source.SourceNode{Repo: "github.com/guacsec/guac"}, docChan); err != nil {
+		t.Fatalf("CertifyComponent() error = %v", err)
+	}
+	close(docChan)
+
+	var docs []*processor.Document
+	for doc := range docChan {
+		docs = append(docs, doc)
+	}
+	if len(docs) != 1 {
+		t.Fatalf("got %d documents, want 1", len(docs))
+	}
+	var got struct {
+		Date string `json:"date"`
+		Repo struct {
+			Commit string `json:"commit"`
+		} `json:"repo"`
+	}
+	if err := json.Unmarshal(docs[0].Blob, &got); err != nil {
+		t.Fatalf("invalid scorecard document: %v", err)
+	}
+	if got.Date != "2024-01-15T10:00:00Z" || got.Repo.Commit != "2222222222222222222222222222222222222222" {
+		t.Errorf("unexpected scorecard document %s", docs[0].Blob)
+	}
+	if docs[0].Type != processor.DocumentScorecard {
+		t.Errorf("unexpected document type %s", docs[0].Type)
+	}
+}
+
+func TestHTTPSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/projects/github.com/ossf/scorecard" && r.URL.Query().Get("commit") == "":
+			_, _ = w.Write([]byte(resultNew))
+		case r.URL.Path == "/projects/github.com/broken/repo":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	results := NewHTTPSource(server.URL+"/", server.Client())
+	got, err := results.Results(context.Background(), "github.com/ossf/scorecard", "")
+	if err != nil {
+		t.Fatalf("Results() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Repo.CommitSHA != "2222222222222222222222222222222222222222" {
+		t.Errorf("Results() = %v, want the latest result", got)
+	}
+	got, err = results.Results(context.Background(), "github.com/ossf/scorecard", "1111111111111111111111111111111111111111")
+	if err != nil || len(got) != 0 {
+		t.Errorf("Results() = %v, %v, want no result", got, err)
+	}
+	if _, err := results.Results(context.Background(), "github.com/broken/repo", ""); err == nil {
+		t.Error("Results() expected an error")
+	}
+}
+
+func TestBigQuerySource(t *testing.T) {
+	var query map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/projects/my-project/queries") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resp := map[string]interface{}{
+			"jobComplete": true,
+			"rows":        []interface{}{map[string]interface{}{"f": []interface{}{map[string]interface{}{"v": resultOld}}}},
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	if _, err := NewBigQuerySource(ctx, "my-project", "table`; DROP"); err == nil {
+		t.Error("NewBigQuerySource() expected an error for an invalid table")
+	}
+	results, err := NewBigQuerySource(ctx, "my-project", DefaultBigQueryTable, option.WithEndpoint(server.URL), option.WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewBigQuerySource() error = %v", err)
+	}
+	got, err := results.Results(ctx, "github.com/ossf/scorecard", "1111111111111111111111111111111111111111")
+	if err != nil {
+		t.Fatalf("Results() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Repo.CommitSHA != "1111111111111111111111111111111111111111" {
+		t.Errorf("Results() = %v, want the result at the commit", got)
+	}
+	sql, _ := query["query"].(string)
+	if !strings.Contains(sql, "`"+DefaultBigQueryTable+"`") || !strings.Contains(sql, "@commit") {
+		t.Errorf("unexpected query %s", sql)
+	}
+	if params, _ := query["queryParameters"].([]interface{}); len(params) != 2 {
+		t.Errorf("unexpected query parameters %v", query["queryParameters"])
+	}
+}
@@ -0,0 +1,249 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorecard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	sc "github.com/ossf/scorecard/v4/pkg"
+	"google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
+)
+
+const (
+	// DefaultAPIURL is the public Scorecard API
+	DefaultAPIURL = "https://api.securityscorecards.dev"
+	// DefaultBigQueryTable is the public BigQuery export of the latest
+	// result of each repository. The openssf.scorecardcron.scorecard-v2
+	// table also has the previous results.
+	DefaultBigQueryTable = "openssf.scorecardcron.scorecard-v2_latest"
+)
+
+type fileSource struct {
+	results map[string][]*sc.ScorecardResult
+}
+
+// NewFileSource loads the results of JSON or NDJSON files, such as the
+// output of `scorecard --format json` or an export of the BigQuery table.
+// Directories are searched recursively for .json, .ndjson and .jsonl files.
+func NewFileSource(paths ...string) (ResultSource, error) {
+	f := &fileSource{results: map[string][]*sc.ScorecardResult{}}
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			switch strings.ToLower(filepath.Ext(path)) {
+			case ".json", ".ndjson", ".jsonl":
+				return f.load(path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load scorecard results from %s: %w", path, err)
+		}
+	}
+	return f, nil
+}
+
+// load reads a file of concatenated or newline delimited results, or of a
+// JSON array of results
+func (f *fileSource) load(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	decoder := json.NewDecoder(file)
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("invalid JSON in %s: %w", path, err)
+		}
+		records := []json.RawMessage{raw}
+		if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+			if err := json.Unmarshal(raw, &records); err != nil {
+				return fmt.Errorf("invalid JSON in %s: %w", path, err)
+			}
+		}
+		for _, record := range records {
+			result, err := parseResult(record)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			repo := normalizeRepo(result.Repo.Name)
+			f.results[repo] = append(f.results[repo], result)
+		}
+	}
+}
+
+func (f *fileSource) Results(_ context.Context, repo, commit string) ([]*sc.ScorecardResult, error) {
+	return f.results[repo], nil
+}
+
+type httpSource struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPSource returns a source querying an HTTP API compatible with the
+// public Scorecard API: GET <baseURL>/projects/<repo>?commit=<sha> returns
+// a result in the JSON format of Scorecard, or 404 when there is none
+func NewHTTPSource(baseURL string, client *http.Client) ResultSource {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &httpSource{baseURL: strings.TrimSuffix(baseURL, "/"), client: client}
+}
+
+func (h *httpSource) Results(ctx context.Context, repo, commit string) ([]*sc.ScorecardResult, error) {
+	u := h.baseURL + "/projects/" + repo
+	if commit != "" {
+		u += "?" + url.Values{"commit": {commit}}.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s from %s", resp.Status, u)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	result, err := parseResult(body)
+	if err != nil {
+		return nil, err
+	}
+	return []*sc.ScorecardResult{result}, nil
+}
+
+var bigQueryTable = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+type bigQuerySource struct {
+	service *bigquery.Service
+	project string
+	table   string
+}
+
+// NewBigQuerySource returns a source querying a BigQuery table with the
+// schema of the Scorecard export, such as DefaultBigQueryTable. The queries
+// are billed to the project, using the application default credentials
+// unless other client options are given.
+func NewBigQuerySource(ctx context.Context, project, table string, opts ...option.ClientOption) (ResultSource, error) {
+	if project == "" {
+		return nil, fmt.Errorf("a project is required to query BigQuery")
+	}
+	if !bigQueryTable.MatchString(table) {
+		return nil, fmt.Errorf("invalid BigQuery table %q", table)
+	}
+	service, err := bigquery.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the BigQuery client: %w", err)
+	}
+	return &bigQuerySource{service: service, project: project, table: table}, nil
+}
+
+func (b *bigQuerySource) Results(ctx context.Context, repo, commit string) ([]*sc.ScorecardResult, error) {
+	query := "SELECT TO_JSON_STRING(t) FROM `" + b.table + "` AS t WHERE LOWER(t.repo.name) = @repo"
+	params := []*bigquery.QueryParameter{stringParameter("repo", repo)}
+	if commit != "" {
+		query += " AND LOWER(t.repo.commit) = @commit"
+		params = append(params, stringParameter("commit", commit))
+	}
+	query += " ORDER BY t.date DESC LIMIT 1"
+
+	useLegacySQL := false
+	resp, err := b.service.Jobs.Query(b.project, &bigquery.QueryRequest{
+		Query:           query,
+		ParameterMode:   "NAMED",
+		QueryParameters: params,
+		UseLegacySql:    &useLegacySQL,
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("BigQuery query failed: %w", err)
+	}
+	rows, complete := resp.Rows, resp.JobComplete
+	for !complete {
+		if resp.JobReference == nil {
+			return nil, errors.New("BigQuery query did not complete")
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+		}
+		results, err := b.service.Jobs.GetQueryResults(b.project, resp.JobReference.JobId).
+			Location(resp.JobReference.Location).Context(ctx).Do()
+		if err != nil {
+			return nil, fmt.Errorf("BigQuery query failed: %w", err)
+		}
+		rows, complete = results.Rows, results.JobComplete
+	}
+
+	var scores []*sc.ScorecardResult
+	for _, row := range rows {
+		if len(row.F) == 0 {
+			continue
+		}
+		record, ok := row.F[0].V.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected BigQuery row %v", row.F[0].V)
+		}
+		result, err := parseResult([]byte(record))
+		if err != nil {
+			return nil, err
+		}
+		scores = append(scores, result)
+	}
+	return scores, nil
+}
+
+func stringParameter(name, value string) *bigquery.QueryParameter {
+	return &bigquery.QueryParameter{
+		Name:           name,
+		ParameterType:  &bigquery.QueryParameterType{Type: "STRING"},
+		ParameterValue: &bigquery.QueryParameterValue{Value: value},
+	}
+}
@@ -18,6 +18,7 @@ package scorecard
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/ossf/scorecard/v4/log"
 
 	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/logging"
 )
 
 // scorecard is a struct that implements the Certifier interface.
@@ -38,7 +40,7 @@ type scorecard struct {
 var ErrArtifactNodeTypeMismatch = fmt.Errorf("rootComponent type is not *source.SourceNode")
 
 // CertifyComponent is a certifier that generates scorecard attestations
-func (s scorecard) CertifyComponent(_ context.Context, rootComponent interface{}, docChannel chan<- *processor.Document) error {
+func (s scorecard) CertifyComponent(ctx context.Context, rootComponent interface{}, docChannel chan<- *processor.Document) error {
 	if docChannel == nil {
 		return fmt.Errorf("docChannel cannot be nil")
 	}
@@ -63,6 +65,11 @@ func (s scorecard) CertifyComponent(_ context.Context, rootComponent interface{}
 	}
 
 	score, err := s.scorecard.GetScore(sourceNode.Repo, sourceNode.Commit, sourceNode.Tag)
+	if errors.Is(err, ErrNoResult) {
+		// precomputed results do not cover every source
+		logging.FromContext(ctx).Debugf("skipping source: %v", err)
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("error getting scorecard result: %w", err)
 	}
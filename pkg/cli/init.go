@@ -24,6 +24,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/guacsec/guac/pkg/auth"
 	"github.com/guacsec/guac/pkg/logging"
 	"github.com/guacsec/guac/pkg/metrics"
 	"github.com/mitchellh/go-homedir"
@@ -107,3 +108,24 @@ func SetupPrometheus(ctx context.Context, logger *zap.SugaredLogger, name string
 	}()
 	return m, nil
 }
+
+// AuthFlags are the flags configuring the authentication of the graphql and
+// rest api servers
+var AuthFlags = []string{
+	"auth-token-file", "auth-client-ca-file",
+	"auth-oidc-jwks", "auth-oidc-issuer", "auth-oidc-audience", "auth-oidc-roles-claim",
+}
+
+// AuthConfig returns the authentication config set through AuthFlags
+func AuthConfig() auth.Config {
+	return auth.Config{
+		TokenFile:    viper.GetString("auth-token-file"),
+		ClientCAFile: viper.GetString("auth-client-ca-file"),
+		OIDC: auth.OIDCConfig{
+			JWKS:       viper.GetString("auth-oidc-jwks"),
+			Issuer:     viper.GetString("auth-oidc-issuer"),
+			Audience:   viper.GetString("auth-oidc-audience"),
+			RolesClaim: viper.GetString("auth-oidc-roles-claim"),
+		},
+	}
+}
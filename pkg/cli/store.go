@@ -18,6 +18,7 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/spf13/pflag"
 )
@@ -51,6 +52,12 @@ func init() {
 	set.String("gql-tls-key-file", "", "path to the TLS key in PEM format for graphql api server")
 	set.Bool("gql-debug", false, "debug flag which enables the graphQL playground")
 	set.Bool("gql-trace", false, "flag which enables tracing of graphQL requests and responses on the console")
+	set.Int("gql-max-complexity", 1000000, "maximum estimated complexity of a graphQL operation, 0 to disable")
+	set.Int("gql-max-depth", 15, "maximum nesting of fields in a graphQL operation, 0 to disable")
+	set.Duration("gql-request-timeout", 2*time.Minute, "deadline for answering a graphQL query, 0 to disable. Mutations and subscriptions are not bounded")
+	set.Float64("gql-rate-limit", 0, "number of graphQL requests per second allowed for each client, 0 to disable")
+	set.Int("gql-rate-burst", 0, "number of graphQL requests a client may make at once, defaults to the rate limit")
+	set.String("gql-events-pubsub-addr", "", "gocloud connection string of the pubsub topic the graphql server publishes ingestion events to, disabled if empty. It must differ from pubsub-addr, except for NATS where events use their own subject")
 
 	set.String("neo4j-addr", "neo4j://localhost:7687", "address to neo4j db")
 	set.String("neo4j-user", "", "neo4j user credential to connect to graph db")
@@ -80,13 +87,53 @@ func init() {
 	set.String("rest-api-server-port", "8081", "port to serve the REST API from")
 	set.String("rest-api-tls-cert-file", "", "path to the TLS certificate in PEM format for rest api server")
 	set.String("rest-api-tls-key-file", "", "path to the TLS key in PEM format for rest api server")
+	set.String("rest-api-gql-token", "", "bearer token used by the rest api server to authenticate to the graphql server")
+
+	set.Int("notify-listen-port", 8082, "port the guacnotify watch API listens on")
+	set.String("notify-tls-cert-file", "", "path to the TLS certificate in PEM format for the guacnotify watch API")
+	set.String("notify-tls-key-file", "", "path to the TLS key in PEM format for the guacnotify watch API")
+	set.String("notify-store-file", "guacnotify.json", "path to the file persisting the watches and delivered notifications of guacnotify")
+	set.Int("notify-max-depth", 10, "maximum number of dependency levels between a watched subject and a vulnerable package, 0 has no limit")
+	set.String("notify-gql-token", "", "bearer token used by guacnotify to authenticate to the graphql server")
+	set.String("notify-pubsub-addr", "", "gocloud connection string of a pubsub topic guacnotify also publishes notifications to, disabled if empty")
+
+	// authentication of the graphql and rest api servers, disabled unless one of the methods is configured
+	set.String("auth-token-file", "", "path to a yaml file of static bearer tokens and their roles (read-only | read-write)")
+	set.String("auth-client-ca-file", "", "path to the CA certificates in PEM format used to verify mTLS client certificates, requires TLS")
+	set.String("auth-oidc-jwks", "", "path or URL of the JSON Web Key Set used to verify OIDC JWT bearer tokens")
+	set.String("auth-oidc-issuer", "", "expected issuer of OIDC JWT bearer tokens")
+	set.String("auth-oidc-audience", "", "expected audience of OIDC JWT bearer tokens")
+	set.String("auth-oidc-roles-claim", "roles", "claim of OIDC JWT bearer tokens holding the roles of the client (read-only | read-write)")
 
 	set.String("verifier-key-path", "", "path to pem file to verify dsse")
 	set.String("verifier-key-id", "", "ID of the key to be stored")
+	set.String("verifier-trusted-root", "", "path to the Sigstore trusted_root.json used to verify keyless signatures of Sigstore bundles offline")
 
 	set.Bool("service-poll", true, "sets the collector or certifier to polling mode")
 	set.BoolP("poll", "p", false, "sets the collector or certifier to polling mode")
 
+	set.StringSlice("certifiers", []string{"osv"}, "certifiers scheduled by guacone certifier run: osv, scorecard, clearlydefined and exploitability")
+	set.StringSlice("certifier-interval", []string{}, "interval between the runs of a certifier, as certifier=duration, e.g. osv=6h, defaults to the polling interval")
+	set.StringSlice("certifier-concurrency", []string{}, "number of batches of components a certifier certifies at once, as certifier=number, e.g. osv=4, defaults to 1")
+	set.StringSlice("certifier-rate-limit", []string{}, "maximum number of batches of components a certifier certifies per second, as certifier=rate, e.g. scorecard=0.5, defaults to unlimited")
+
+	set.String("scorecard-source", "live", "source of the scorecard certifier results: live (run the checks against GitHub), file, api or bigquery")
+	set.StringSlice("scorecard-results", []string{}, "paths to JSON or NDJSON scorecard results, or directories of them, for the file scorecard source")
+	set.String("scorecard-api-url", "https://api.securityscorecards.dev", "base URL of the scorecard API for the api scorecard source")
+	set.String("scorecard-bigquery-project", "", "Google Cloud project billed for the queries of the bigquery scorecard source")
+	set.String("scorecard-bigquery-table", "openssf.scorecardcron.scorecard-v2_latest", "BigQuery table of scorecard results for the bigquery scorecard source")
+	set.Int("scorecard-days-since-last-scan", 0, "rescan sources whose scorecard is older than this number of days, 0 never rescans")
+	set.StringSlice("scorecard-check-days-since-last-scan", []string{}, "rescan sources whose check was scanned more than this number of days ago, as check=days, e.g. Vulnerabilities=1")
+
+	set.String("clearlydefined-url", "https://api.clearlydefined.io", "base URL of the ClearlyDefined-compatible API queried by the clearlydefined certifier")
+	set.StringSlice("clearlydefined-dump", []string{}, "paths to harvested ClearlyDefined definitions, as JSON or NDJSON files or directories of them, used by the clearlydefined certifier instead of the API")
+	set.Int("clearlydefined-days-since-last-scan", 0, "recertify packages whose certify legal is older than this number of days, 0 never recertifies")
+
+	set.String("epss-source", "https://epss.empiricalsecurity.com/epss_scores-current.csv.gz", "file or URL of the EPSS scores CSV, optionally gzipped, used by the exploitability certifier, empty to skip EPSS")
+	set.String("kev-source", "https://www.cisa.gov/sites/default/files/feeds/known_exploited_vulnerabilities.json", "file or URL of the CISA Known Exploited Vulnerabilities catalog JSON used by the exploitability certifier, empty to skip KEV")
+
+	set.StringSlice("osv-db", []string{}, "paths to osv.dev ecosystem zip exports or directories of OSV JSON records, used by the osv certifier instead of querying osv.dev")
+
 	set.Bool("retrieve-dependencies", true, "enable the deps.dev collector to retrieve package dependencies")
 
 	set.Bool("enable-prometheus", true, "enable prometheus metrics")
@@ -104,8 +151,13 @@ func init() {
 	set.Int("num-path", 0, "number of paths to return, 0 means all paths")
 	set.String("start-purl", "", "string input of purl with package to start search from")
 	set.String("stop-purl", "", "string input of purl with package to stop search at")
+	set.String("start-vuln", "", "vulnerability ID (e.g. cve-2023-1234) to start the patch plan search from")
+	set.String("start-source", "", "source repository VCS URI (e.g. git+https://github.com/org/repo) to start the patch plan search from")
+	set.String("start-artifact", "", "artifact digest in the form algorithm:digest to start the patch plan search from")
+	set.StringP("output", "o", "table", "output format for query results: [table | json | dot | mermaid]")
 	set.Bool("is-pkg-version-start", false, "for query path are you inputting a packageVersion to start the search from (if false then packageName)")
 	set.Bool("is-pkg-version-stop", false, "for query path are you inputting a packageVersion to stop the search at (if false then packageName)")
+	set.String("policy-file", "", "path to the policy file (yaml or json) to evaluate")
 
 	// Google Cloud platform flags
 	set.String("gcp-credentials-path", "", "Path to the Google Cloud service account credentials json file.\nAlternatively you can set GOOGLE_APPLICATION_CREDENTIALS=<path> in your environment.")
@@ -36,6 +36,10 @@ import (
 // EmitterPubSub stores the serviceURL such that the topic and subscription can be reopened
 type EmitterPubSub struct {
 	ServiceURL string
+	// Subject is the NATS subject messages are published to, the subject of
+	// collected documents if empty. Other providers select the topic through
+	// the ServiceURL.
+	Subject string
 }
 
 // DataFunc determines how the data return from NATS is transformed based on implementation per module
@@ -62,9 +66,12 @@ func NewEmitterPubSub(_ context.Context, serviceURL string) *EmitterPubSub {
 
 // buildTopicURL constructs the full URL for a topic.
 // If using NATS, additional parameters are needed for jetstream
-func buildTopicURL(serviceURL string) string {
+func buildTopicURL(serviceURL string, subject string) string {
+	if subject == "" {
+		subject = subjectNameDocCollected
+	}
 	if strings.HasPrefix(serviceURL, "nats://") {
-		return fmt.Sprintf("%s?subject=%s", serviceURL, subjectNameDocCollected)
+		return fmt.Sprintf("%s?subject=%s", serviceURL, subject)
 	} else {
 		return serviceURL
 	}
@@ -83,7 +90,7 @@ func buildSubscriptionURL(serviceURL string) string {
 // Publish publishes the data onto the pubsub stream for consumption by upstream services
 func (e *EmitterPubSub) Publish(ctx context.Context, data []byte) error {
 	// pubsub.OpenTopic creates a *pubsub.Topic from a URL.
-	topicURL := buildTopicURL(e.ServiceURL)
+	topicURL := buildTopicURL(e.ServiceURL, e.Subject)
 
 	// Initialize a topic
 	topic, err := pubsub.OpenTopic(ctx, topicURL)
@@ -0,0 +1,221 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package guacanalytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// PatchPlan is the machine-readable form of the blast radius subgraph found by the patch planning search
+type PatchPlan struct {
+	Nodes []PatchPlanNode `json:"nodes"`
+	Edges []PatchPlanEdge `json:"edges"`
+}
+
+// PatchPlanNode is a single node of the blast radius subgraph. FrontierLevel is only set for nodes that are
+// part of the blast radius.
+type PatchPlanNode struct {
+	ID                  string   `json:"id"`
+	Type                string   `json:"type"`
+	Label               string   `json:"label"`
+	Parents             []string `json:"parents"`
+	Depth               int      `json:"depth"`
+	FrontierLevel       *int     `json:"frontierLevel,omitempty"`
+	NotInBlastRadius    bool     `json:"notInBlastRadius"`
+	PointOfContactEmail string   `json:"pointOfContactEmail,omitempty"`
+}
+
+// PatchPlanEdge links a node (From) to one of its dependents (To)
+type PatchPlanEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+func (n NodeType) String() string {
+	switch n {
+	case PackageName:
+		return "packageName"
+	case PackageVersion:
+		return "packageVersion"
+	case SourceName:
+		return "sourceName"
+	case Artifact:
+		return "artifact"
+	}
+	return "unknown"
+}
+
+// NewPatchPlan builds a PatchPlan from the results of SearchDependentsFromStartNode and TopoSortFromBfsNodeMap.
+// labels maps node IDs to a human readable description; nodes without a label use their ID.
+func NewPatchPlan(nodeMap map[string]BfsNode, frontiers map[int][]string, labels map[string]string) PatchPlan {
+	frontierLevels := map[string]int{}
+	for level, ids := range frontiers {
+		for _, id := range ids {
+			frontierLevels[id] = level
+		}
+	}
+
+	plan := PatchPlan{
+		Nodes: []PatchPlanNode{},
+		Edges: []PatchPlanEdge{},
+	}
+
+	for id, node := range nodeMap {
+		label, ok := labels[id]
+		if !ok {
+			label = id
+		}
+
+		planNode := PatchPlanNode{
+			ID:                  id,
+			Type:                node.Type.String(),
+			Label:               label,
+			Parents:             []string{},
+			Depth:               node.Depth,
+			NotInBlastRadius:    node.NotInBlastRadius,
+			PointOfContactEmail: node.PointOfContact.Email,
+		}
+
+		if level, ok := frontierLevels[id]; ok {
+			level := level
+			planNode.FrontierLevel = &level
+		}
+
+		seenParents := map[string]bool{}
+		for _, parent := range node.Parents {
+			if _, ok := nodeMap[parent]; !ok || seenParents[parent] {
+				continue
+			}
+			seenParents[parent] = true
+			planNode.Parents = append(planNode.Parents, parent)
+			plan.Edges = append(plan.Edges, PatchPlanEdge{From: parent, To: id})
+		}
+		sort.Strings(planNode.Parents)
+
+		plan.Nodes = append(plan.Nodes, planNode)
+	}
+
+	sort.Slice(plan.Nodes, func(i, j int) bool {
+		return plan.Nodes[i].less(plan.Nodes[j])
+	})
+	sort.Slice(plan.Edges, func(i, j int) bool {
+		if plan.Edges[i].From != plan.Edges[j].From {
+			return plan.Edges[i].From < plan.Edges[j].From
+		}
+		return plan.Edges[i].To < plan.Edges[j].To
+	})
+
+	return plan
+}
+
+// less orders nodes by frontier level, with informational nodes last, and then by ID
+func (n PatchPlanNode) less(other PatchPlanNode) bool {
+	if (n.FrontierLevel == nil) != (other.FrontierLevel == nil) {
+		return n.FrontierLevel != nil
+	}
+	if n.FrontierLevel != nil && *n.FrontierLevel != *other.FrontierLevel {
+		return *n.FrontierLevel < *other.FrontierLevel
+	}
+	return n.ID < other.ID
+}
+
+// WriteJSON writes the patch plan as indented JSON
+func (p PatchPlan) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(p); err != nil {
+		return fmt.Errorf("failed to encode patch plan: %w", err)
+	}
+	return nil
+}
+
+// WriteDot writes the patch plan as a Graphviz digraph. Informational nodes that are not in the blast radius
+// are drawn dashed.
+func (p PatchPlan) WriteDot(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("digraph patchplan {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box];\n")
+	for _, node := range p.Nodes {
+		fmt.Fprintf(&sb, "  %s [label=%s", dotQuote(node.ID), dotQuote(node.description("\n")))
+		if node.NotInBlastRadius {
+			sb.WriteString(", style=dashed")
+		}
+		sb.WriteString("];\n")
+	}
+	for _, edge := range p.Edges {
+		fmt.Fprintf(&sb, "  %s -> %s;\n", dotQuote(edge.From), dotQuote(edge.To))
+	}
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteMermaid writes the patch plan as a mermaid flowchart. Informational nodes that are not in the blast
+// radius use the "info" class.
+func (p PatchPlan) WriteMermaid(w io.Writer) error {
+	mermaidIDs := map[string]string{}
+	for i, node := range p.Nodes {
+		mermaidIDs[node.ID] = fmt.Sprintf("n%d", i)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	var infoNodes []string
+	for _, node := range p.Nodes {
+		fmt.Fprintf(&sb, "  %s[\"%s\"]\n", mermaidIDs[node.ID], mermaidEscape(node.description("<br/>")))
+		if node.NotInBlastRadius {
+			infoNodes = append(infoNodes, mermaidIDs[node.ID])
+		}
+	}
+	for _, edge := range p.Edges {
+		fmt.Fprintf(&sb, "  %s --> %s\n", mermaidIDs[edge.From], mermaidIDs[edge.To])
+	}
+	if len(infoNodes) > 0 {
+		sb.WriteString("  classDef info stroke-dasharray: 5 5\n")
+		fmt.Fprintf(&sb, "  class %s info\n", strings.Join(infoNodes, ","))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func (n PatchPlanNode) description(separator string) string {
+	lines := []string{n.Label}
+	if n.FrontierLevel != nil {
+		lines = append(lines, fmt.Sprintf("frontier level %d", *n.FrontierLevel))
+	}
+	if n.PointOfContactEmail != "" {
+		lines = append(lines, "contact: "+n.PointOfContactEmail)
+	}
+	return strings.Join(lines, separator)
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
@@ -0,0 +1,184 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package guacanalytics
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+)
+
+var (
+	exportNodeMap = map[string]BfsNode{
+		"1": {Type: PackageVersion, Parents: []string{}},
+		"2": {Type: PackageName, Parents: []string{}},
+		"3": {Type: PackageVersion, Parents: []string{"1", "2", "1"}, Depth: 1,
+			PointOfContact: model.AllPointOfContact{Email: "owner@example.com"}},
+		"4": {Type: PackageVersion, Parents: []string{"3"}, Depth: 2, NotInBlastRadius: true},
+	}
+	exportFrontiers = map[int][]string{
+		0: {"2", "1"},
+		1: {"3"},
+	}
+	exportLabels = map[string]string{
+		"1": "pkg:conan/openssl.org/openssl@3.0.3",
+		"2": "pkg:conan/openssl.org/openssl",
+		"3": `pkg:deb/ubuntu/"dpkg"@1.19.0`,
+	}
+)
+
+func Test_NewPatchPlan(t *testing.T) {
+	zero, one := 0, 1
+	want := PatchPlan{
+		Nodes: []PatchPlanNode{
+			{ID: "1", Type: "packageVersion", Label: "pkg:conan/openssl.org/openssl@3.0.3", Parents: []string{}, FrontierLevel: &zero},
+			{ID: "2", Type: "packageName", Label: "pkg:conan/openssl.org/openssl", Parents: []string{}, FrontierLevel: &zero},
+			{ID: "3", Type: "packageVersion", Label: `pkg:deb/ubuntu/"dpkg"@1.19.0`, Parents: []string{"1", "2"}, Depth: 1,
+				FrontierLevel: &one, PointOfContactEmail: "owner@example.com"},
+			{ID: "4", Type: "packageVersion", Label: "4", Parents: []string{"3"}, Depth: 2, NotInBlastRadius: true},
+		},
+		Edges: []PatchPlanEdge{
+			{From: "1", To: "3"},
+			{From: "2", To: "3"},
+			{From: "3", To: "4"},
+		},
+	}
+
+	got := NewPatchPlan(exportNodeMap, exportFrontiers, exportLabels)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewPatchPlan (-want +got):\n%s", diff)
+	}
+}
+
+func Test_PatchPlanWriters(t *testing.T) {
+	plan := NewPatchPlan(exportNodeMap, exportFrontiers, exportLabels)
+
+	tests := []struct {
+		name  string
+		write func(p PatchPlan, b *bytes.Buffer) error
+		want  string
+	}{
+		{
+			name:  "dot",
+			write: func(p PatchPlan, b *bytes.Buffer) error { return p.WriteDot(b) },
+			want: `digraph patchplan {
+  rankdir=LR;
+  node [shape=box];
+  "1" [label="pkg:conan/openssl.org/openssl@3.0.3\nfrontier level 0"];
+  "2" [label="pkg:conan/openssl.org/openssl\nfrontier level 0"];
+  "3" [label="pkg:deb/ubuntu/\"dpkg\"@1.19.0\nfrontier level 1\ncontact: owner@example.com"];
+  "4" [label="4", style=dashed];
+  "1" -> "3";
+  "2" -> "3";
+  "3" -> "4";
+}
+`,
+		},
+		{
+			name:  "mermaid",
+			write: func(p PatchPlan, b *bytes.Buffer) error { return p.WriteMermaid(b) },
+			want: `flowchart LR
+  n0["pkg:conan/openssl.org/openssl@3.0.3<br/>frontier level 0"]
+  n1["pkg:conan/openssl.org/openssl<br/>frontier level 0"]
+  n2["pkg:deb/ubuntu/#quot;dpkg#quot;@1.19.0<br/>frontier level 1<br/>contact: owner@example.com"]
+  n3["4"]
+  n0 --> n2
+  n1 --> n2
+  n2 --> n3
+  classDef info stroke-dasharray: 5 5
+  class n3 info
+`,
+		},
+		{
+			name:  "json",
+			write: func(p PatchPlan, b *bytes.Buffer) error { return p.WriteJSON(b) },
+			want: `{
+  "nodes": [
+    {
+      "id": "1",
+      "type": "packageVersion",
+      "label": "pkg:conan/openssl.org/openssl@3.0.3",
+      "parents": [],
+      "depth": 0,
+      "frontierLevel": 0,
+      "notInBlastRadius": false
+    },
+    {
+      "id": "2",
+      "type": "packageName",
+      "label": "pkg:conan/openssl.org/openssl",
+      "parents": [],
+      "depth": 0,
+      "frontierLevel": 0,
+      "notInBlastRadius": false
+    },
+    {
+      "id": "3",
+      "type": "packageVersion",
+      "label": "pkg:deb/ubuntu/\"dpkg\"@1.19.0",
+      "parents": [
+        "1",
+        "2"
+      ],
+      "depth": 1,
+      "frontierLevel": 1,
+      "notInBlastRadius": false,
+      "pointOfContactEmail": "owner@example.com"
+    },
+    {
+      "id": "4",
+      "type": "packageVersion",
+      "label": "4",
+      "parents": [
+        "3"
+      ],
+      "depth": 2,
+      "notInBlastRadius": true
+    }
+  ],
+  "edges": [
+    {
+      "from": "1",
+      "to": "3"
+    },
+    {
+      "from": "2",
+      "to": "3"
+    },
+    {
+      "from": "3",
+      "to": "4"
+    }
+  ]
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := tt.write(plan, &b); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, b.String()); diff != "" {
+				t.Errorf("output (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package guacanalytics
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/Khan/genqlient/graphql"
 	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
@@ -46,10 +47,11 @@ type BfsNode struct {
 }
 
 type queueValues struct {
-	nodeMap map[string]BfsNode
-	now     *string
-	nowNode BfsNode
-	queue   []string
+	nodeMap     map[string]BfsNode
+	now         *string
+	nowNode     BfsNode
+	queue       []string
+	notAffected map[string]bool // packageVersions and artifacts pruned from the blast radius by a VEX statement
 }
 
 func SearchDependentsFromStartPackage(ctx context.Context, gqlClient graphql.Client, startID string, stopID *string, maxDepth int) (map[string]BfsNode, []string, error) {
@@ -65,52 +67,209 @@ func SearchDependentsFromStartPackage(ctx context.Context, gqlClient graphql.Cli
 		return nil, nil, fmt.Errorf("not a package")
 	}
 
-	q := queueValues{
-		queue:   make([]string, 0), // the queue of nodes in bfs
-		nodeMap: map[string]BfsNode{},
+	q := newQueueValues()
+
+	err = q.addStartPackage(ctx, gqlClient, nodePkg.AllPkgTree, startID)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// TODO: add functionality to start with other nodes?
-	if len(nodePkg.AllPkgTree.Namespaces) == 0 {
-		return nil, nil, fmt.Errorf("start by inputting a packageName or packageVersion node")
+	err = q.bfsOfDependents(ctx, gqlClient, stopID, maxDepth)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if len(nodePkg.AllPkgTree.Namespaces[0].Names) == 0 {
-		return nil, nil, fmt.Errorf("start by inputting a packageName or packageVersion node")
+	return q.nodeMap, path, nil
+
+}
+
+// SearchDependentsFromStartNode searches the blast radius starting from a packageName, packageVersion,
+// source name, artifact or vulnerability node. For a vulnerability, the affected packages and artifacts
+// are found through CertifyVuln and CertifyVEXStatement and subjects whose latest VEX statement is
+// NOT_AFFECTED are pruned from the blast radius, along with their dependents. FIXED subjects are kept,
+// as their dependents may still pin the vulnerable version.
+func SearchDependentsFromStartNode(ctx context.Context, gqlClient graphql.Client, startID string, stopID *string, maxDepth int) (map[string]BfsNode, []string, error) {
+	startNode, err := model.Node(ctx, gqlClient, startID)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed getting initial node with given ID:%w", err)
 	}
 
-	if len(nodePkg.AllPkgTree.Namespaces[0].Names[0].Versions) == 0 {
-		// TODO: handle case where there are circular dependents that introduce more versions to the version list on a node that requires revisiting
-		err := q.addNodesToQueueFromPackageName(ctx, gqlClient, nodePkg.AllPkgTree.Type, nodePkg.AllPkgTree.Namespaces[0].Namespace, nodePkg.AllPkgTree.Namespaces[0].Names[0].Name, startID)
+	q := newQueueValues()
 
-		if err != nil {
-			return nil, nil, err
+	switch node := startNode.Node.(type) {
+	case *model.NodeNodePackage:
+		err = q.addStartPackage(ctx, gqlClient, node.AllPkgTree, startID)
+	case *model.NodeNodeSource:
+		if len(node.Namespaces) == 0 || len(node.Namespaces[0].Names) == 0 {
+			return nil, nil, fmt.Errorf("start by inputting a sourceName node")
 		}
-	} else {
-		q.queue = append(q.queue, nodePkg.AllPkgTree.Namespaces[0].Names[0].Id)
+		q.addStartNode(SourceName, node.Namespaces[0].Names[0].Id)
+	case *model.NodeNodeArtifact:
+		q.addStartNode(Artifact, node.Id)
+	case *model.NodeNodeVulnerability:
+		err = q.addStartVulnerability(ctx, gqlClient, node.AllVulnerabilityTree)
+	default:
+		return nil, nil, fmt.Errorf("start by inputting a package, source, artifact or vulnerability node")
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
 
-		var versionsList []string
-		versionsList = append(versionsList, nodePkg.AllPkgTree.Namespaces[0].Names[0].Versions[0].Version)
-		q.nodeMap[startID] = BfsNode{
-			Type:    PackageVersion,
-			Parents: []string{},
+	err = q.bfsOfDependents(ctx, gqlClient, stopID, maxDepth)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return q.nodeMap, path, nil
+}
+
+func newQueueValues() *queueValues {
+	return &queueValues{
+		queue:       make([]string, 0), // the queue of nodes in bfs
+		nodeMap:     map[string]BfsNode{},
+		notAffected: map[string]bool{},
+	}
+}
+
+func (q *queueValues) addStartPackage(ctx context.Context, gqlClient graphql.Client, pkg model.AllPkgTree, startID string) error {
+	if len(pkg.Namespaces) == 0 {
+		return fmt.Errorf("start by inputting a packageName or packageVersion node")
+	}
+
+	if len(pkg.Namespaces[0].Names) == 0 {
+		return fmt.Errorf("start by inputting a packageName or packageVersion node")
+	}
+
+	if len(pkg.Namespaces[0].Names[0].Versions) == 0 {
+		// TODO: handle case where there are circular dependents that introduce more versions to the version list on a node that requires revisiting
+		return q.addNodesToQueueFromPackageName(ctx, gqlClient, pkg.Type, pkg.Namespaces[0].Namespace, pkg.Namespaces[0].Names[0].Name, startID)
+	}
+
+	q.addStartPackageVersion(startID, pkg.Namespaces[0].Names[0].Versions[0].Version, pkg.Namespaces[0].Names[0].Id)
+	return nil
+}
+
+// addStartPackageVersion adds a packageVersion and its packageName as roots of the search
+func (q *queueValues) addStartPackageVersion(versionID string, version string, nameID string) {
+	if _, seen := q.nodeMap[versionID]; seen {
+		return
+	}
+
+	if q.notAffected[versionID] {
+		q.nodeMap[versionID] = BfsNode{
+			Type:             PackageVersion,
+			Parents:          []string{},
+			NotInBlastRadius: true,
 		}
+		return
+	}
 
-		q.nodeMap[nodePkg.AllPkgTree.Namespaces[0].Names[0].Id] = BfsNode{
+	if nameNode, seen := q.nodeMap[nameID]; seen {
+		nameNode.nodeVersions = append(nameNode.nodeVersions, version)
+		q.nodeMap[nameID] = nameNode
+	} else {
+		q.queue = append(q.queue, nameID)
+		q.nodeMap[nameID] = BfsNode{
 			Type:         PackageName,
-			nodeVersions: versionsList,
+			nodeVersions: []string{version},
 			Parents:      []string{},
 		}
-		q.queue = append(q.queue, startID)
 	}
 
-	err = q.bfsOfDependents(ctx, gqlClient, stopID, maxDepth)
-	if err != nil {
-		return nil, nil, err
+	q.nodeMap[versionID] = BfsNode{
+		Type:    PackageVersion,
+		Parents: []string{},
 	}
+	q.queue = append(q.queue, versionID)
+}
 
-	return q.nodeMap, path, nil
+// addStartNode adds a source name or artifact as a root of the search
+func (q *queueValues) addStartNode(nodeType NodeType, id string) {
+	if _, seen := q.nodeMap[id]; seen {
+		return
+	}
 
+	q.nodeMap[id] = BfsNode{
+		Type:             nodeType,
+		Parents:          []string{},
+		NotInBlastRadius: q.notAffected[id],
+	}
+
+	if !q.notAffected[id] {
+		q.queue = append(q.queue, id)
+	}
+}
+
+// addStartVulnerability finds the packages and artifacts affected by the vulnerability and adds them as
+// roots of the search. Only the latest VEX statement for a subject is taken into account.
+func (q *queueValues) addStartVulnerability(ctx context.Context, gqlClient graphql.Client, vuln model.AllVulnerabilityTree) error {
+	type vexSubject struct {
+		status     model.VexStatus
+		knownSince time.Time
+	}
+
+	var certifyVulns []*model.NeighborsNeighborsCertifyVuln
+	var vexStatements []*model.NeighborsNeighborsCertifyVEXStatement
+	latestVex := map[string]vexSubject{}
+
+	edgeTypes := []model.Edge{model.EdgeVulnerabilityCertifyVuln, model.EdgeVulnerabilityCertifyVexStatement}
+	for _, vulnID := range vuln.VulnerabilityIDs {
+		path = append(path, vulnID.Id)
+
+		neighborsResponse, err := model.Neighbors(ctx, gqlClient, vulnID.Id, edgeTypes)
+		if err != nil {
+			return fmt.Errorf("failed getting vulnerability neighbors:%w", err)
+		}
+
+		for _, neighbor := range neighborsResponse.Neighbors {
+			switch neighbor := neighbor.(type) {
+			case *model.NeighborsNeighborsCertifyVuln:
+				certifyVulns = append(certifyVulns, neighbor)
+			case *model.NeighborsNeighborsCertifyVEXStatement:
+				vexStatements = append(vexStatements, neighbor)
+				subjectID := vexSubjectID(neighbor.Subject)
+				if latest, ok := latestVex[subjectID]; !ok || neighbor.KnownSince.After(latest.knownSince) {
+					latestVex[subjectID] = vexSubject{status: neighbor.Status, knownSince: neighbor.KnownSince}
+				}
+			}
+		}
+	}
+
+	for subjectID, vex := range latestVex {
+		if vex.status == model.VexStatusNotAffected {
+			q.notAffected[subjectID] = true
+		}
+	}
+
+	for _, certifyVuln := range certifyVulns {
+		path = append(path, certifyVuln.Id)
+		pkg := certifyVuln.Package
+		q.addStartPackageVersion(pkg.Namespaces[0].Names[0].Versions[0].Id, pkg.Namespaces[0].Names[0].Versions[0].Version, pkg.Namespaces[0].Names[0].Id)
+	}
+
+	for _, vex := range vexStatements {
+		path = append(path, vex.Id)
+		switch subject := vex.Subject.(type) {
+		case *model.AllCertifyVEXStatementSubjectPackage:
+			q.addStartPackageVersion(subject.Namespaces[0].Names[0].Versions[0].Id, subject.Namespaces[0].Names[0].Versions[0].Version, subject.Namespaces[0].Names[0].Id)
+		case *model.AllCertifyVEXStatementSubjectArtifact:
+			q.addStartNode(Artifact, subject.Id)
+		}
+	}
+
+	return nil
+}
+
+func vexSubjectID(subject model.AllCertifyVEXStatementSubjectPackageOrArtifact) string {
+	switch subject := subject.(type) {
+	case *model.AllCertifyVEXStatementSubjectPackage:
+		return subject.Namespaces[0].Names[0].Versions[0].Id
+	case *model.AllCertifyVEXStatementSubjectArtifact:
+		return subject.Id
+	}
+	return ""
 }
 
 // bfsOfDependents performs a breadth-first search on a graph to find dependencies
@@ -254,8 +413,7 @@ func exploreIsDependencyFromDepPkg(ctx context.Context, gqlClient graphql.Client
 		}
 	}
 
-	q.addNodeToQueue(PackageVersion, nil, isDependency.Package.Namespaces[0].Names[0].Versions[0].Id)
-	q.addNodeToQueue(PackageName, []string{isDependency.Package.Namespaces[0].Names[0].Versions[0].Version}, isDependency.Package.Namespaces[0].Names[0].Id)
+	q.addPackageVersionToQueue(isDependency.Package.Namespaces[0].Names[0].Versions[0].Id, isDependency.Package.Namespaces[0].Names[0].Versions[0].Version, isDependency.Package.Namespaces[0].Names[0].Id)
 	path = append(path, isDependency.Package.Namespaces[0].Id)
 
 	return nil
@@ -278,8 +436,7 @@ func exploreIsOccurrenceFromArtifact(ctx context.Context, gqlClient graphql.Clie
 	path = append(path, isOccurrence.Id)
 	switch subject := isOccurrence.Subject.(type) {
 	case *model.AllIsOccurrencesTreeSubjectPackage:
-		q.addNodeToQueue(PackageVersion, nil, subject.Namespaces[0].Names[0].Versions[0].Id)
-		q.addNodeToQueue(PackageName, []string{subject.Namespaces[0].Names[0].Versions[0].Version}, subject.Namespaces[0].Names[0].Id)
+		q.addPackageVersionToQueue(subject.Namespaces[0].Names[0].Versions[0].Id, subject.Namespaces[0].Names[0].Versions[0].Version, subject.Namespaces[0].Names[0].Id)
 		path = append(path, subject.Namespaces[0].Id)
 	case *model.AllIsOccurrencesTreeSubjectSource:
 		q.addNodeToQueue(SourceName, nil, subject.Namespaces[0].Names[0].Id)
@@ -296,8 +453,7 @@ func exploreHasSourceAtFromSource(ctx context.Context, gqlClient graphql.Client,
 			return err
 		}
 	} else {
-		q.addNodeToQueue(PackageVersion, nil, hasSourceAt.Package.Namespaces[0].Names[0].Versions[0].Id)
-		q.addNodeToQueue(PackageName, []string{hasSourceAt.Package.Namespaces[0].Names[0].Versions[0].Version}, hasSourceAt.Package.Namespaces[0].Names[0].Id)
+		q.addPackageVersionToQueue(hasSourceAt.Package.Namespaces[0].Names[0].Versions[0].Id, hasSourceAt.Package.Namespaces[0].Names[0].Versions[0].Version, hasSourceAt.Package.Namespaces[0].Names[0].Id)
 	}
 	return nil
 }
@@ -308,8 +464,7 @@ func explorePkgEqual(ctx context.Context, gqlClient graphql.Client, q *queueValu
 	for _, pkg := range pkgEqual.Packages {
 		if pkg.Namespaces[0].Names[0].Versions[0].Id != *q.now {
 			path = append(path, pkg.Namespaces[0].Id)
-			q.addNodeToQueue(PackageVersion, nil, pkg.Namespaces[0].Names[0].Versions[0].Id)
-			q.addNodeToQueue(PackageName, []string{pkg.Namespaces[0].Names[0].Versions[0].Version}, pkg.Namespaces[0].Names[0].Id)
+			q.addPackageVersionToQueue(pkg.Namespaces[0].Names[0].Versions[0].Id, pkg.Namespaces[0].Names[0].Versions[0].Version, pkg.Namespaces[0].Names[0].Id)
 		}
 	}
 }
@@ -490,6 +645,10 @@ func (q *queueValues) addNodesToQueueFromPackageName(ctx context.Context, gqlCli
 
 	var versionsList []string
 	for _, versionEntry := range pkgResponse.Packages[0].Namespaces[0].Names[0].Versions {
+		if q.notAffected[versionEntry.Id] {
+			q.pruneNode(PackageVersion, versionEntry.Id)
+			continue
+		}
 		versionsList = append(versionsList, versionEntry.Version)
 		if versionNode, seen := q.nodeMap[versionEntry.Id]; seen {
 			if !q.nodeMap[versionEntry.Id].Expanded {
@@ -547,7 +706,43 @@ func (q *queueValues) addNodesToQueueFromPackageName(ctx context.Context, gqlCli
 	return nil
 }
 
+// addPackageVersionToQueue adds a packageVersion and its packageName to the queue. If the packageVersion
+// has been pruned by a VEX statement, the packageName is not explored through it.
+func (q *queueValues) addPackageVersionToQueue(versionID string, version string, nameID string) {
+	q.addNodeToQueue(PackageVersion, nil, versionID)
+	if q.notAffected[versionID] {
+		return
+	}
+	q.addNodeToQueue(PackageName, []string{version}, nameID)
+}
+
+// pruneNode records a node that is not affected by the vulnerability as an informational node so that
+// neither it nor its dependents are part of the blast radius
+func (q *queueValues) pruneNode(nodeType NodeType, id string) {
+	node := q.nodeMap[id]
+
+	parents := node.Parents
+
+	if q.now != nil {
+		parents = append(parents, *q.now)
+	}
+
+	q.nodeMap[id] = BfsNode{
+		Parents:          parents,
+		Depth:            q.nowNode.Depth + 1,
+		Type:             nodeType,
+		PointOfContact:   node.PointOfContact,
+		NotInBlastRadius: true,
+		Expanded:         node.Expanded,
+	}
+}
+
 func (q *queueValues) addNodeToQueue(nodeType NodeType, versions []string, id string) {
+	if q.notAffected[id] {
+		q.pruneNode(nodeType, id)
+		return
+	}
+
 	node, seen := q.nodeMap[id]
 
 	var notInBlastRadius bool
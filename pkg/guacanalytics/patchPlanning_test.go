@@ -18,7 +18,9 @@ package guacanalytics
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
@@ -26,6 +28,7 @@ import (
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/Khan/genqlient/graphql"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/guacsec/guac/internal/testing/ptrfrom"
 	"github.com/guacsec/guac/pkg/assembler"
 	"github.com/guacsec/guac/pkg/assembler/backends"
@@ -1135,6 +1138,58 @@ func ingestPointOfContact(ctx context.Context, client graphql.Client, graph asse
 	return nil
 }
 
+func ingestCertifyVuln(ctx context.Context, client graphql.Client, graph assembler.IngestPredicates) error {
+	for _, ingest := range graph.CertifyVuln {
+		_, err := model.IngestPackage(ctx, client, model.IDorPkgInput{PackageInput: ingest.Pkg})
+		if err != nil {
+			return fmt.Errorf("error in ingesting Package for CertifyVuln: %v\n", err)
+		}
+
+		_, err = model.IngestVulnerability(ctx, client, model.IDorVulnerabilityInput{VulnerabilityInput: ingest.Vulnerability})
+		if err != nil {
+			return fmt.Errorf("error in ingesting Vulnerability for CertifyVuln: %v\n", err)
+		}
+
+		_, err = model.IngestCertifyVulnPkg(ctx, client, model.IDorPkgInput{PackageInput: ingest.Pkg}, model.IDorVulnerabilityInput{VulnerabilityInput: ingest.Vulnerability}, *ingest.VulnData)
+		if err != nil {
+			return fmt.Errorf("error in ingesting CertifyVuln: %v\n", err)
+		}
+	}
+	return nil
+}
+
+func ingestVex(ctx context.Context, client graphql.Client, graph assembler.IngestPredicates) error {
+	for _, ingest := range graph.Vex {
+		var err error
+
+		if ingest.Pkg != nil {
+			_, err = model.IngestPackage(ctx, client, model.IDorPkgInput{PackageInput: ingest.Pkg})
+		} else {
+			_, err = model.IngestArtifact(ctx, client, model.IDorArtifactInput{ArtifactInput: ingest.Artifact})
+		}
+
+		if err != nil {
+			return fmt.Errorf("error in ingesting pkg/artifact for Vex: %v\n", err)
+		}
+
+		_, err = model.IngestVulnerability(ctx, client, model.IDorVulnerabilityInput{VulnerabilityInput: ingest.Vulnerability})
+		if err != nil {
+			return fmt.Errorf("error in ingesting Vulnerability for Vex: %v\n", err)
+		}
+
+		if ingest.Pkg != nil {
+			_, err = model.IngestCertifyVexPkg(ctx, client, model.IDorPkgInput{PackageInput: ingest.Pkg}, model.IDorVulnerabilityInput{VulnerabilityInput: ingest.Vulnerability}, *ingest.VexData)
+		} else {
+			_, err = model.IngestCertifyVexArtifact(ctx, client, model.IDorArtifactInput{ArtifactInput: ingest.Artifact}, model.IDorVulnerabilityInput{VulnerabilityInput: ingest.Vulnerability}, *ingest.VexData)
+		}
+
+		if err != nil {
+			return fmt.Errorf("error in ingesting Vex: %v\n", err)
+		}
+	}
+	return nil
+}
+
 func ingestTestData(ctx context.Context, client graphql.Client, graph assembler.IngestPredicates) error {
 	if len(graph.IsDependency) > 0 {
 		err := ingestIsDependency(ctx, client, graph)
@@ -1192,6 +1247,20 @@ func ingestTestData(ctx context.Context, client graphql.Client, graph assembler.
 		}
 	}
 
+	if len(graph.CertifyVuln) > 0 {
+		err := ingestCertifyVuln(ctx, client, graph)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(graph.Vex) > 0 {
+		err := ingestVex(ctx, client, graph)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -1695,8 +1764,14 @@ func startTestServer() (*http.Server, error) {
 	server := &http.Server{Addr: fmt.Sprintf(":%d", 9090)}
 	logger.Info("starting server")
 
+	// listen before returning so that the first test case does not race the server start
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to listen on %s: %s", server.Addr, err)
+	}
+
 	go func() {
-		logger.Infof("server finished: %s", server.ListenAndServe())
+		logger.Infof("server finished: %s", server.Serve(listener))
 	}()
 	return server, nil
 }
@@ -1767,3 +1842,301 @@ func getReturnedPOCs(ctx context.Context, gqlClient graphql.Client, nodeMap map[
 
 	return found
 }
+
+var blastRadiusGraph = assembler.IngestPredicates{
+	IsDependency: []assembler.IsDependencyIngest{
+		{
+			Pkg: &model.PkgInputSpec{
+				Type:      "blastapp",
+				Namespace: ptrfrom.String("blastns"),
+				Name:      "app",
+				Version:   ptrfrom.String("2.0.0"),
+			},
+			DepPkg: &model.PkgInputSpec{
+				Type:      "blastlib",
+				Namespace: ptrfrom.String("blastns"),
+				Name:      "lib",
+				Version:   ptrfrom.String("1.1.0"),
+			},
+			DepPkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeAllVersions},
+			IsDependency: &model.IsDependencyInputSpec{
+				VersionRange:   ">=1.0.0",
+				DependencyType: model.DependencyTypeDirect,
+				Justification:  "test justification",
+				Origin:         "Demo ingestion",
+				Collector:      "Demo ingestion",
+			},
+		},
+		{
+			Pkg: &model.PkgInputSpec{
+				Type:      "blasttop",
+				Namespace: ptrfrom.String("blastns"),
+				Name:      "top",
+				Version:   ptrfrom.String("1.0.0"),
+			},
+			DepPkg: &model.PkgInputSpec{
+				Type:      "blastapp",
+				Namespace: ptrfrom.String("blastns"),
+				Name:      "app",
+				Version:   ptrfrom.String("2.0.0"),
+			},
+			DepPkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+			IsDependency: &model.IsDependencyInputSpec{
+				VersionRange:   "=2.0.0",
+				DependencyType: model.DependencyTypeDirect,
+				Justification:  "test justification",
+				Origin:         "Demo ingestion",
+				Collector:      "Demo ingestion",
+			},
+		},
+		{
+			Pkg: &model.PkgInputSpec{
+				Type:      "blastother",
+				Namespace: ptrfrom.String("blastns"),
+				Name:      "other",
+				Version:   ptrfrom.String("1.0.0"),
+			},
+			DepPkg: &model.PkgInputSpec{
+				Type:      "blastforked",
+				Namespace: ptrfrom.String("blastns"),
+				Name:      "forked",
+				Version:   ptrfrom.String("1.0.0"),
+			},
+			DepPkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+			IsDependency: &model.IsDependencyInputSpec{
+				VersionRange:   "=1.0.0",
+				DependencyType: model.DependencyTypeDirect,
+				Justification:  "test justification",
+				Origin:         "Demo ingestion",
+				Collector:      "Demo ingestion",
+			},
+		},
+	},
+	HasSourceAt: []assembler.HasSourceAtIngest{
+		{
+			Pkg: &model.PkgInputSpec{
+				Type:      "blastlib",
+				Namespace: ptrfrom.String("blastns"),
+				Name:      "lib",
+				Version:   ptrfrom.String("1.1.0"),
+			},
+			PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+			Src: &model.SourceInputSpec{
+				Type:      "blastsrc",
+				Namespace: "github.com/blastns",
+				Name:      "lib",
+			},
+			HasSourceAt: &model.HasSourceAtInputSpec{
+				KnownSince:    tm,
+				Justification: "test justification",
+				Origin:        "Demo ingestion",
+				Collector:     "Demo ingestion",
+			},
+		},
+	},
+	IsOccurrence: []assembler.IsOccurrenceIngest{
+		{
+			Pkg: &model.PkgInputSpec{
+				Type:      "blastapp",
+				Namespace: ptrfrom.String("blastns"),
+				Name:      "app",
+				Version:   ptrfrom.String("2.0.0"),
+			},
+			Artifact: &model.ArtifactInputSpec{
+				Algorithm: "blastalgorithm",
+				Digest:    "blastdigest",
+			},
+			IsOccurrence: &model.IsOccurrenceInputSpec{
+				Justification: "test justification",
+				Origin:        "Demo ingestion",
+				Collector:     "Demo ingestion",
+			},
+		},
+	},
+	CertifyVuln: []assembler.CertifyVulnIngest{
+		{
+			Pkg: &model.PkgInputSpec{
+				Type:      "blastlib",
+				Namespace: ptrfrom.String("blastns"),
+				Name:      "lib",
+				Version:   ptrfrom.String("1.1.0"),
+			},
+			Vulnerability: &model.VulnerabilityInputSpec{
+				Type:            "osv",
+				VulnerabilityID: "cve-2023-1111",
+			},
+			VulnData: &model.ScanMetadataInput{
+				TimeScanned: tm,
+				Origin:      "Demo ingestion",
+				Collector:   "Demo ingestion",
+			},
+		},
+		{
+			Pkg: &model.PkgInputSpec{
+				Type:      "blastforked",
+				Namespace: ptrfrom.String("blastns"),
+				Name:      "forked",
+				Version:   ptrfrom.String("1.0.0"),
+			},
+			Vulnerability: &model.VulnerabilityInputSpec{
+				Type:            "osv",
+				VulnerabilityID: "cve-2023-1111",
+			},
+			VulnData: &model.ScanMetadataInput{
+				TimeScanned: tm,
+				Origin:      "Demo ingestion",
+				Collector:   "Demo ingestion",
+			},
+		},
+	},
+	Vex: []assembler.VexIngest{
+		{
+			Pkg: &model.PkgInputSpec{
+				Type:      "blastfixed",
+				Namespace: ptrfrom.String("blastns"),
+				Name:      "fixed",
+				Version:   ptrfrom.String("1.0.0"),
+			},
+			Vulnerability: &model.VulnerabilityInputSpec{
+				Type:            "osv",
+				VulnerabilityID: "cve-2023-1111",
+			},
+			VexData: &model.VexStatementInputSpec{
+				Status:           model.VexStatusFixed,
+				VexJustification: model.VexJustificationNotProvided,
+				Statement:        "fixed in a later release",
+				KnownSince:       tm,
+				Origin:           "Demo ingestion",
+				Collector:        "Demo ingestion",
+			},
+		},
+		{
+			Pkg: &model.PkgInputSpec{
+				Type:      "blastforked",
+				Namespace: ptrfrom.String("blastns"),
+				Name:      "forked",
+				Version:   ptrfrom.String("1.0.0"),
+			},
+			Vulnerability: &model.VulnerabilityInputSpec{
+				Type:            "osv",
+				VulnerabilityID: "cve-2023-1111",
+			},
+			VexData: &model.VexStatementInputSpec{
+				Status:           model.VexStatusNotAffected,
+				VexJustification: model.VexJustificationVulnerableCodeNotInExecutePath,
+				KnownSince:       tm,
+				Origin:           "Demo ingestion",
+				Collector:        "Demo ingestion",
+			},
+		},
+	},
+}
+
+func Test_SearchDependentsFromStartNode(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+
+	srv, err := getGraphqlTestServer()
+	if err != nil {
+		t.Fatalf("unable to initialize graphql server: %s", err)
+	}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+
+	gqlClient := graphql.NewClient(server.URL, server.Client())
+
+	if err := ingestTestData(ctx, gqlClient, blastRadiusGraph); err != nil {
+		t.Fatalf("error ingesting test data: %s", err)
+	}
+
+	vulnResponse, err := model.Vulnerabilities(ctx, gqlClient, model.VulnerabilitySpec{VulnerabilityID: ptrfrom.String("cve-2023-1111")})
+	if err != nil || len(vulnResponse.Vulnerabilities) != 1 {
+		t.Fatalf("error finding vulnerability: %v", err)
+	}
+	vulnID := vulnResponse.Vulnerabilities[0].VulnerabilityIDs[0].Id
+
+	srcID, err := getSrcID(ctx, gqlClient, "blastsrc")
+	if err != nil {
+		t.Fatalf("error finding source: %s", err)
+	}
+
+	artifactIDs, err := getArtifactIDs(ctx, gqlClient, "blastalgorithm")
+	if err != nil {
+		t.Fatalf("error finding artifact: %s", err)
+	}
+
+	pkgIDs := func(pkgType string) []string {
+		ids, err := GetPackageIDs(ctx, gqlClient, &pkgType, "", "", nil, false, false)
+		if err != nil {
+			t.Fatalf("error finding package %s: %s", pkgType, err)
+		}
+		var found []string
+		for _, id := range ids {
+			found = append(found, *id)
+		}
+		return found
+	}
+
+	forkedVersionIDs, err := GetPackageIDs(ctx, gqlClient, ptrfrom.String("blastforked"), "blastns", "forked", ptrfrom.String("1.0.0"), true, false)
+	if err != nil {
+		t.Fatalf("error finding forked package: %s", err)
+	}
+
+	var radius []string
+	radius = append(radius, pkgIDs("blastlib")...)
+	radius = append(radius, pkgIDs("blastapp")...)
+	radius = append(radius, pkgIDs("blasttop")...)
+	radius = append(radius, srcID)
+	radius = append(radius, artifactIDs...)
+	// a fixed package stays in the blast radius of the vulnerability
+	vulnRadius := append(append([]string{}, radius...), pkgIDs("blastfixed")...)
+
+	testCases := []struct {
+		name                 string
+		startID              string
+		wantInBlastRadius    []string
+		wantNotInBlastRadius []string
+	}{
+		{
+			name:                 "vulnerability with VEX not affected and fixed packages",
+			startID:              vulnID,
+			wantInBlastRadius:    vulnRadius,
+			wantNotInBlastRadius: []string{*forkedVersionIDs[0]},
+		},
+		{
+			name:              "source",
+			startID:           srcID,
+			wantInBlastRadius: radius,
+		},
+		{
+			name:              "artifact",
+			startID:           artifactIDs[0],
+			wantInBlastRadius: append(append(pkgIDs("blastapp"), pkgIDs("blasttop")...), artifactIDs...),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			gotMap, _, err := SearchDependentsFromStartNode(ctx, gqlClient, tt.startID, nil, 10)
+			if err != nil {
+				t.Fatalf("got err from SearchDependentsFromStartNode: %s", err)
+			}
+
+			var gotInBlastRadius, gotNotInBlastRadius []string
+			for id, node := range gotMap {
+				if node.NotInBlastRadius {
+					gotNotInBlastRadius = append(gotNotInBlastRadius, id)
+				} else {
+					gotInBlastRadius = append(gotInBlastRadius, id)
+				}
+			}
+
+			sortStrings := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+			if diff := cmp.Diff(tt.wantInBlastRadius, gotInBlastRadius, sortStrings, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("nodes in blast radius (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantNotInBlastRadius, gotNotInBlastRadius, sortStrings, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("nodes not in blast radius (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,379 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package guacanalytics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/Khan/genqlient/graphql"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	"github.com/guacsec/guac/pkg/assembler/helpers"
+	"github.com/guacsec/guac/pkg/logging"
+	"github.com/guacsec/guac/pkg/misc/depversion"
+)
+
+const noVulnType string = "novuln"
+
+// Reachability is how a top-level package depends on a vulnerable package
+type Reachability string
+
+const (
+	ReachabilityDirect     Reachability = "direct"
+	ReachabilityTransitive Reachability = "transitive"
+)
+
+// VulnerabilityReachability is a vulnerability of a dependency of the
+// top-level package and how the dependency is reached
+type VulnerabilityReachability struct {
+	VulnerabilityID string       `json:"vulnerabilityID"`
+	Package         string       `json:"package"`
+	Reachability    Reachability `json:"reachability"`
+	// MinDepth is the fewest levels of dependencies between the top-level
+	// package and the vulnerable package, 1 for a direct dependency
+	MinDepth int `json:"minDepth"`
+	// Paths is the number of distinct dependency paths to the vulnerable
+	// package
+	Paths int `json:"paths"`
+	// Via are the dependencies of the top-level package the paths go through
+	Via []string `json:"via"`
+}
+
+// DependencyImpact is a dependency of the top-level package and the
+// vulnerabilities reached through it, which upgrading it may fix
+type DependencyImpact struct {
+	Package         string   `json:"package"`
+	Vulnerabilities []string `json:"vulnerabilities"`
+}
+
+// ReachabilityReport is the result of AnalyzeReachability
+type ReachabilityReport struct {
+	Package         string                      `json:"package"`
+	Vulnerabilities []VulnerabilityReachability `json:"vulnerabilities"`
+	// Dependencies are sorted by the number of vulnerabilities reached through
+	// them, most first
+	Dependencies []DependencyImpact `json:"dependencies"`
+}
+
+// dependencyEdge is a dependency of a package version on another, weighted by
+// the levels of dependencies it stands for
+type dependencyEdge struct {
+	to     string
+	weight int
+}
+
+type reachabilityGraph struct {
+	gqlClient graphql.Client
+	purls     map[string]string
+	edges     map[string][]dependencyEdge
+	parents   map[string][]string
+	// versions caches the version IDs of the package names of version range
+	// dependencies
+	versions map[string]map[string]string
+}
+
+// AnalyzeReachability finds the vulnerabilities of the dependencies of a
+// package version and classifies them as direct or transitive, with the
+// minimum depth and number of dependency paths to the vulnerable package.
+// The dependencies are followed through IsDependency up to maxDepth levels,
+// unless maxDepth is 0. A dependency on a package name is followed to the
+// versions in its version range. An indirect dependency recorded on the
+// top-level package, as in flattened SBOMs, counts as two levels since at
+// least one dependency lies in between.
+func AnalyzeReachability(ctx context.Context, gqlClient graphql.Client, topPkgVersionID string, maxDepth int) (*ReachabilityReport, error) {
+	topNode, err := model.Node(ctx, gqlClient, topPkgVersionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting initial node with given ID: %w", err)
+	}
+	topPkg, ok := topNode.Node.(*model.NodeNodePackage)
+	if !ok || len(topPkg.Namespaces) == 0 || len(topPkg.Namespaces[0].Names) == 0 || len(topPkg.Namespaces[0].Names[0].Versions) == 0 {
+		return nil, fmt.Errorf("start by inputting a packageVersion node")
+	}
+
+	g := &reachabilityGraph{
+		gqlClient: gqlClient,
+		purls:     map[string]string{topPkgVersionID: helpers.AllPkgTreeToPurl(&topPkg.AllPkgTree)},
+		edges:     map[string][]dependencyEdge{},
+		parents:   map[string][]string{},
+		versions:  map[string]map[string]string{},
+	}
+	if err := g.explore(ctx, topPkgVersionID, maxDepth); err != nil {
+		return nil, err
+	}
+	depths := g.minDepths(topPkgVersionID)
+
+	report := &ReachabilityReport{
+		Package:         g.purls[topPkgVersionID],
+		Vulnerabilities: []VulnerabilityReachability{},
+		Dependencies:    []DependencyImpact{},
+	}
+	paths := map[string]*reachedPaths{}
+	impact := map[string]map[string]bool{}
+	for id := range depths {
+		if id == topPkgVersionID {
+			continue
+		}
+		pkgID := id
+		vulnResponse, err := model.CertifyVulns(ctx, gqlClient, model.CertifyVulnSpec{Package: &model.PkgSpec{Id: &pkgID}})
+		if err != nil {
+			return nil, fmt.Errorf("error querying for the vulnerabilities of %s: %w", g.purls[id], err)
+		}
+		seen := map[string]bool{}
+		for _, certifyVuln := range vulnResponse.CertifyVuln {
+			if certifyVuln.Vulnerability.Type == noVulnType {
+				continue
+			}
+			vulnID := vulnerabilityIDs(certifyVuln.Vulnerability.AllVulnerabilityTree)
+			if seen[vulnID] {
+				continue
+			}
+			seen[vulnID] = true
+
+			reached := g.paths(topPkgVersionID, id, paths, map[string]bool{})
+			reachability := ReachabilityTransitive
+			if depths[id] == 1 {
+				reachability = ReachabilityDirect
+			}
+			v := VulnerabilityReachability{
+				VulnerabilityID: vulnID,
+				Package:         g.purls[id],
+				Reachability:    reachability,
+				MinDepth:        depths[id],
+				Paths:           reached.count,
+				Via:             []string{},
+			}
+			for via := range reached.via {
+				v.Via = append(v.Via, g.purls[via])
+				if impact[via] == nil {
+					impact[via] = map[string]bool{}
+				}
+				impact[via][vulnID] = true
+			}
+			sort.Strings(v.Via)
+			report.Vulnerabilities = append(report.Vulnerabilities, v)
+		}
+	}
+
+	sort.Slice(report.Vulnerabilities, func(i, j int) bool {
+		a, b := report.Vulnerabilities[i], report.Vulnerabilities[j]
+		if a.MinDepth != b.MinDepth {
+			return a.MinDepth < b.MinDepth
+		}
+		if a.VulnerabilityID != b.VulnerabilityID {
+			return a.VulnerabilityID < b.VulnerabilityID
+		}
+		return a.Package < b.Package
+	})
+	for via, vulns := range impact {
+		d := DependencyImpact{Package: g.purls[via], Vulnerabilities: []string{}}
+		for vulnID := range vulns {
+			d.Vulnerabilities = append(d.Vulnerabilities, vulnID)
+		}
+		sort.Strings(d.Vulnerabilities)
+		report.Dependencies = append(report.Dependencies, d)
+	}
+	sort.Slice(report.Dependencies, func(i, j int) bool {
+		a, b := report.Dependencies[i], report.Dependencies[j]
+		if len(a.Vulnerabilities) != len(b.Vulnerabilities) {
+			return len(a.Vulnerabilities) > len(b.Vulnerabilities)
+		}
+		return a.Package < b.Package
+	})
+	return report, nil
+}
+
+// explore records the dependencies of the package versions reachable from
+// the top-level package, breadth-first up to maxDepth levels
+func (g *reachabilityGraph) explore(ctx context.Context, topPkgVersionID string, maxDepth int) error {
+	queue := []string{topPkgVersionID}
+	levels := map[string]int{topPkgVersionID: 0}
+	for len(queue) > 0 {
+		now := queue[0]
+		queue = queue[1:]
+		if maxDepth != 0 && levels[now] >= maxDepth {
+			continue
+		}
+
+		pkgID := now
+		deps, err := model.Dependencies(ctx, g.gqlClient, model.IsDependencySpec{Package: &model.PkgSpec{Id: &pkgID}})
+		if err != nil {
+			return fmt.Errorf("error querying for the dependencies of %s: %w", g.purls[now], err)
+		}
+		weights := map[string]int{}
+		for i := range deps.IsDependency {
+			dep := &deps.IsDependency[i].AllIsDependencyTree
+			weight := 1
+			if dep.DependencyType == model.DependencyTypeIndirect && now == topPkgVersionID {
+				weight = 2
+			}
+			ids, err := g.dependencyVersions(ctx, dep)
+			if err != nil {
+				return err
+			}
+			for _, id := range ids {
+				if id == now {
+					continue
+				}
+				if w, ok := weights[id]; !ok || weight < w {
+					weights[id] = weight
+				}
+			}
+		}
+		for id, weight := range weights {
+			g.edges[now] = append(g.edges[now], dependencyEdge{to: id, weight: weight})
+			g.parents[id] = append(g.parents[id], now)
+			if _, seen := levels[id]; !seen {
+				levels[id] = levels[now] + 1
+				queue = append(queue, id)
+			}
+		}
+	}
+	return nil
+}
+
+// dependencyVersions returns the version IDs of the dependency package of
+// the IsDependency, in its version range for a dependency on a package name
+func (g *reachabilityGraph) dependencyVersions(ctx context.Context, dep *model.AllIsDependencyTree) ([]string, error) {
+	depPkg := &dep.DependencyPackage
+	name := depPkg.Namespaces[0].Names[0]
+	if len(name.Versions) > 0 {
+		g.purls[name.Versions[0].Id] = helpers.AllPkgTreeToPurl(&depPkg.AllPkgTree)
+		return []string{name.Versions[0].Id}, nil
+	}
+
+	versions, ok := g.versions[name.Id]
+	if !ok {
+		nameID := name.Id
+		pkgResponse, err := model.Packages(ctx, g.gqlClient, model.PkgSpec{Type: &depPkg.Type, Namespace: &depPkg.Namespaces[0].Namespace, Name: &name.Name})
+		if err != nil {
+			return nil, fmt.Errorf("error querying for the versions of dependency %s: %w", nameID, err)
+		}
+		versions = map[string]string{}
+		for i := range pkgResponse.Packages {
+			pkg := &pkgResponse.Packages[i].AllPkgTree
+			for _, ns := range pkg.Namespaces {
+				for _, n := range ns.Names {
+					for _, v := range n.Versions {
+						versions[v.Version] = v.Id
+						g.purls[v.Id] = helpers.PkgToPurl(pkg.Type, ns.Namespace, n.Name, v.Version, v.Subpath, qualifiers(v.Qualifiers))
+					}
+				}
+			}
+		}
+		g.versions[name.Id] = versions
+	}
+
+	var all []string
+	for version := range versions {
+		all = append(all, version)
+	}
+	matches, err := depversion.WhichVersionMatches(all, dep.VersionRange)
+	if err != nil {
+		// depversion does not handle all version ranges, the dependency
+		// cannot be followed
+		logging.FromContext(ctx).Debugf("unable to match the version range %q of dependency %s: %v", dep.VersionRange, dep.Id, err)
+		return nil, nil
+	}
+	var ids []string
+	for version := range matches {
+		ids = append(ids, versions[version])
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// minDepths returns the minimum weighted depth of the explored package
+// versions, visiting them in order of depth
+func (g *reachabilityGraph) minDepths(topPkgVersionID string) map[string]int {
+	depths := map[string]int{topPkgVersionID: 0}
+	buckets := map[int][]string{0: {topPkgVersionID}}
+	for depth := 0; len(buckets) > 0; depth++ {
+		for _, id := range buckets[depth] {
+			if depths[id] != depth {
+				continue
+			}
+			for _, edge := range g.edges[id] {
+				d := depth + edge.weight
+				if current, ok := depths[edge.to]; !ok || d < current {
+					depths[edge.to] = d
+					buckets[d] = append(buckets[d], edge.to)
+				}
+			}
+		}
+		delete(buckets, depth)
+	}
+	return depths
+}
+
+// reachedPaths are the paths from the top-level package to a package version
+type reachedPaths struct {
+	count int
+	// via are the dependencies of the top-level package the paths go through
+	via map[string]bool
+}
+
+// paths counts the paths from the top-level package to the package version
+// through its parents. Dependency cycles are not followed, so the paths of a
+// package version whose parents lead back to a package version still being
+// visited depend on where the search started, and are not memoized.
+func (g *reachabilityGraph) paths(topPkgVersionID string, id string, memo map[string]*reachedPaths, visiting map[string]bool) *reachedPaths {
+	reached, _ := g.visitPaths(topPkgVersionID, id, memo, visiting)
+	return reached
+}
+
+// visitPaths implements paths, also returning whether a cycle was cut while
+// counting the paths of id
+func (g *reachabilityGraph) visitPaths(topPkgVersionID string, id string, memo map[string]*reachedPaths, visiting map[string]bool) (*reachedPaths, bool) {
+	if reached, ok := memo[id]; ok {
+		return reached, false
+	}
+	reached := &reachedPaths{via: map[string]bool{}}
+	cut := false
+	visiting[id] = true
+	for _, parent := range g.parents[id] {
+		if parent == topPkgVersionID {
+			reached.count++
+			reached.via[id] = true
+			continue
+		}
+		if visiting[parent] {
+			cut = true
+			continue
+		}
+		parentPaths, parentCut := g.visitPaths(topPkgVersionID, parent, memo, visiting)
+		reached.count += parentPaths.count
+		for via := range parentPaths.via {
+			reached.via[via] = true
+		}
+		cut = cut || parentCut
+	}
+	delete(visiting, id)
+	if !cut {
+		memo[id] = reached
+	}
+	return reached, cut
+}
+
+// qualifiers flattens the qualifiers of a package version to the key value
+// list of helpers.PkgToPurl
+func qualifiers(pkgQualifiers []model.AllPkgTreeNamespacesPackageNamespaceNamesPackageNameVersionsPackageVersionQualifiersPackageQualifier) []string {
+	var q []string
+	for _, qualifier := range pkgQualifiers {
+		q = append(q, qualifier.Key, qualifier.Value)
+	}
+	return q
+}
@@ -0,0 +1,174 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package guacanalytics
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/guacsec/guac/internal/testing/ptrfrom"
+	"github.com/guacsec/guac/pkg/assembler"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	"github.com/guacsec/guac/pkg/assembler/clients/helpers"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+func reachPkg(name, version string) *model.PkgInputSpec {
+	pkg := &model.PkgInputSpec{Type: "guac", Namespace: ptrfrom.String("reach"), Name: name}
+	if version != "" {
+		pkg.Version = ptrfrom.String(version)
+	}
+	return pkg
+}
+
+func Test_AnalyzeReachability(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+
+	srv, err := getGraphqlTestServer()
+	if err != nil {
+		t.Fatalf("unable to initialize graphql server: %v", err)
+	}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+	gqlClient := graphql.NewClient(server.URL, server.Client())
+
+	app, a, b, c, d := reachPkg("app", "1.0.0"), reachPkg("a", "1.0.0"), reachPkg("b", "1.0.0"), reachPkg("c", "1.0.0"), reachPkg("d", "1.0.0")
+	e1, e3 := reachPkg("e", "1.0.0"), reachPkg("e", "3.0.0")
+	dependency := func(pkg, depPkg *model.PkgInputSpec, dependencyType model.DependencyType) assembler.IsDependencyIngest {
+		return assembler.IsDependencyIngest{
+			Pkg:             pkg,
+			DepPkg:          depPkg,
+			DepPkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+			IsDependency:    &model.IsDependencyInputSpec{DependencyType: dependencyType, Origin: "test", Collector: "test"},
+		}
+	}
+	vuln := func(pkg *model.PkgInputSpec, id string) assembler.CertifyVulnIngest {
+		return assembler.CertifyVulnIngest{
+			Pkg:           pkg,
+			Vulnerability: &model.VulnerabilityInputSpec{Type: "osv", VulnerabilityID: id},
+			VulnData:      &model.ScanMetadataInput{TimeScanned: tm, Origin: "test", Collector: "test"},
+		}
+	}
+	graph := assembler.IngestPredicates{
+		IsDependency: []assembler.IsDependencyIngest{
+			dependency(app, a, model.DependencyTypeDirect),
+			dependency(app, b, model.DependencyTypeUnknown),
+			// a flattened dependency of app on a dependency of d
+			dependency(app, d, model.DependencyTypeIndirect),
+			dependency(a, c, model.DependencyTypeDirect),
+			dependency(b, c, model.DependencyTypeDirect),
+			{
+				Pkg:             a,
+				DepPkg:          reachPkg("e", ""),
+				DepPkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeAllVersions},
+				IsDependency:    &model.IsDependencyInputSpec{VersionRange: "<2.0.0", DependencyType: model.DependencyTypeDirect, Origin: "test", Collector: "test"},
+			},
+		},
+		CertifyVuln: []assembler.CertifyVulnIngest{
+			vuln(a, "cve-2024-0001"),
+			vuln(c, "cve-2024-0002"),
+			vuln(d, "cve-2024-0003"),
+			vuln(e1, "cve-2024-0004"),
+			vuln(e3, "cve-2024-0005"),
+		},
+	}
+	if err := helpers.GetAssembler(ctx, gqlClient)([]assembler.IngestPredicates{graph}); err != nil {
+		t.Fatalf("error ingesting test data: %v", err)
+	}
+	appIDs, err := model.Packages(ctx, gqlClient, model.PkgSpec{Name: &app.Name, Version: app.Version})
+	if err != nil || len(appIDs.Packages) != 1 {
+		t.Fatalf("error querying for the top-level package: %v", err)
+	}
+	appID := appIDs.Packages[0].Namespaces[0].Names[0].Versions[0].Id
+
+	tests := []struct {
+		name     string
+		maxDepth int
+		want     *ReachabilityReport
+	}{{
+		name: "all dependencies",
+		want: &ReachabilityReport{
+			Package: "pkg:guac/reach/app@1.0.0",
+			Vulnerabilities: []VulnerabilityReachability{
+				{VulnerabilityID: "cve-2024-0001", Package: "pkg:guac/reach/a@1.0.0", Reachability: ReachabilityDirect, MinDepth: 1, Paths: 1, Via: []string{"pkg:guac/reach/a@1.0.0"}},
+				{VulnerabilityID: "cve-2024-0002", Package: "pkg:guac/reach/c@1.0.0", Reachability: ReachabilityTransitive, MinDepth: 2, Paths: 2, Via: []string{"pkg:guac/reach/a@1.0.0", "pkg:guac/reach/b@1.0.0"}},
+				{VulnerabilityID: "cve-2024-0003", Package: "pkg:guac/reach/d@1.0.0", Reachability: ReachabilityTransitive, MinDepth: 2, Paths: 1, Via: []string{"pkg:guac/reach/d@1.0.0"}},
+				{VulnerabilityID: "cve-2024-0004", Package: "pkg:guac/reach/e@1.0.0", Reachability: ReachabilityTransitive, MinDepth: 2, Paths: 1, Via: []string{"pkg:guac/reach/a@1.0.0"}},
+			},
+			Dependencies: []DependencyImpact{
+				{Package: "pkg:guac/reach/a@1.0.0", Vulnerabilities: []string{"cve-2024-0001", "cve-2024-0002", "cve-2024-0004"}},
+				{Package: "pkg:guac/reach/b@1.0.0", Vulnerabilities: []string{"cve-2024-0002"}},
+				{Package: "pkg:guac/reach/d@1.0.0", Vulnerabilities: []string{"cve-2024-0003"}},
+			},
+		},
+	}, {
+		name:     "max depth",
+		maxDepth: 1,
+		want: &ReachabilityReport{
+			Package: "pkg:guac/reach/app@1.0.0",
+			Vulnerabilities: []VulnerabilityReachability{
+				{VulnerabilityID: "cve-2024-0001", Package: "pkg:guac/reach/a@1.0.0", Reachability: ReachabilityDirect, MinDepth: 1, Paths: 1, Via: []string{"pkg:guac/reach/a@1.0.0"}},
+				{VulnerabilityID: "cve-2024-0003", Package: "pkg:guac/reach/d@1.0.0", Reachability: ReachabilityTransitive, MinDepth: 2, Paths: 1, Via: []string{"pkg:guac/reach/d@1.0.0"}},
+			},
+			Dependencies: []DependencyImpact{
+				{Package: "pkg:guac/reach/a@1.0.0", Vulnerabilities: []string{"cve-2024-0001"}},
+				{Package: "pkg:guac/reach/d@1.0.0", Vulnerabilities: []string{"cve-2024-0003"}},
+			},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AnalyzeReachability(ctx, gqlClient, appID, tt.maxDepth)
+			if err != nil {
+				t.Fatalf("AnalyzeReachability() error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("AnalyzeReachability() (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	nameID := appIDs.Packages[0].Namespaces[0].Names[0].Id
+	if _, err := AnalyzeReachability(ctx, gqlClient, nameID, 0); err == nil {
+		t.Errorf("AnalyzeReachability() expected an error for a package name")
+	}
+}
+
+func Test_reachabilityGraphPaths(t *testing.T) {
+	// app depends on a and b, which depend on each other, and c depends on
+	// both of them
+	g := &reachabilityGraph{parents: map[string][]string{
+		"a": {"app", "b"},
+		"b": {"app", "a"},
+		"c": {"a", "b"},
+	}}
+	want := map[string]int{"a": 2, "b": 2, "c": 4}
+	for _, order := range [][]string{{"a", "b", "c"}, {"b", "a", "c"}, {"c", "a", "b"}} {
+		memo := map[string]*reachedPaths{}
+		for _, id := range order {
+			got := g.paths("app", id, memo, map[string]bool{})
+			if got.count != want[id] {
+				t.Errorf("paths(%s) after %v = %d, want %d", id, order, got.count, want[id])
+			}
+			if !got.via["a"] || !got.via["b"] {
+				t.Errorf("paths(%s) after %v go via %v, want a and b", id, order, got.via)
+			}
+		}
+	}
+}
@@ -0,0 +1,192 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package guacanalytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	"github.com/guacsec/guac/pkg/assembler/helpers"
+)
+
+// SBOMDiff is the difference between a base and a target SBOM, with packages
+// identified by purl
+type SBOMDiff struct {
+	Base                 SBOMReference         `json:"base"`
+	Target               SBOMReference         `json:"target"`
+	AddedPackages        []string              `json:"addedPackages"`
+	RemovedPackages      []string              `json:"removedPackages"`
+	ChangedPackages      []PackageChange       `json:"changedPackages"`
+	NewVulnerabilities   []VulnerabilityChange `json:"newVulnerabilities"`
+	FixedVulnerabilities []VulnerabilityChange `json:"fixedVulnerabilities"`
+	LicenseChanges       []LicenseChange       `json:"licenseChanges"`
+}
+
+// SBOMReference identifies a HasSBOM node
+type SBOMReference struct {
+	ID     string `json:"id"`
+	URI    string `json:"uri"`
+	Digest string `json:"digest"`
+}
+
+// PackageChange is a package included in both SBOMs at a different version
+type PackageChange struct {
+	Base   string `json:"base"`
+	Target string `json:"target"`
+}
+
+// VulnerabilityChange is a vulnerability found in only one of the SBOMs and
+// the package it affects
+type VulnerabilityChange struct {
+	VulnerabilityID string `json:"vulnerabilityID"`
+	Package         string `json:"package"`
+}
+
+// LicenseChange is a package included in both SBOMs with a different license
+type LicenseChange struct {
+	Base          string `json:"base"`
+	Target        string `json:"target"`
+	BaseLicense   string `json:"baseLicense"`
+	TargetLicense string `json:"targetLicense"`
+}
+
+// DiffSBOMs compares the target SBOM to the base SBOM. Both are either a
+// HasSBOM ID or the purl of a package version, in which case the most recent
+// SBOM of the package version is used.
+func DiffSBOMs(ctx context.Context, gqlClient graphql.Client, base string, target string) (*SBOMDiff, error) {
+	baseID, err := sbomDiffInputID(ctx, gqlClient, base)
+	if err != nil {
+		return nil, err
+	}
+	targetID, err := sbomDiffInputID(ctx, gqlClient, target)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := model.SBOMDiff(ctx, gqlClient, baseID, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying for SBOM diff: %w", err)
+	}
+	d := response.SbomDiff
+
+	diff := &SBOMDiff{
+		Base:                 SBOMReference{ID: d.Base.Id, URI: d.Base.Uri, Digest: d.Base.Algorithm + ":" + d.Base.Digest},
+		Target:               SBOMReference{ID: d.Target.Id, URI: d.Target.Uri, Digest: d.Target.Algorithm + ":" + d.Target.Digest},
+		AddedPackages:        []string{},
+		RemovedPackages:      []string{},
+		ChangedPackages:      []PackageChange{},
+		NewVulnerabilities:   []VulnerabilityChange{},
+		FixedVulnerabilities: []VulnerabilityChange{},
+		LicenseChanges:       []LicenseChange{},
+	}
+	for _, pkg := range d.AddedPackages {
+		diff.AddedPackages = append(diff.AddedPackages, helpers.AllPkgTreeToPurl(&pkg.AllPkgTree))
+	}
+	for _, pkg := range d.RemovedPackages {
+		diff.RemovedPackages = append(diff.RemovedPackages, helpers.AllPkgTreeToPurl(&pkg.AllPkgTree))
+	}
+	for _, change := range d.ChangedPackages {
+		diff.ChangedPackages = append(diff.ChangedPackages, PackageChange{
+			Base:   helpers.AllPkgTreeToPurl(&change.Base.AllPkgTree),
+			Target: helpers.AllPkgTreeToPurl(&change.Target.AllPkgTree),
+		})
+	}
+	for _, change := range d.NewVulnerabilities {
+		diff.NewVulnerabilities = append(diff.NewVulnerabilities, VulnerabilityChange{
+			VulnerabilityID: vulnerabilityIDs(change.Vulnerability.AllVulnerabilityTree),
+			Package:         helpers.AllPkgTreeToPurl(&change.Package.AllPkgTree),
+		})
+	}
+	for _, change := range d.FixedVulnerabilities {
+		diff.FixedVulnerabilities = append(diff.FixedVulnerabilities, VulnerabilityChange{
+			VulnerabilityID: vulnerabilityIDs(change.Vulnerability.AllVulnerabilityTree),
+			Package:         helpers.AllPkgTreeToPurl(&change.Package.AllPkgTree),
+		})
+	}
+	for _, change := range d.LicenseChanges {
+		diff.LicenseChanges = append(diff.LicenseChanges, LicenseChange{
+			Base:          helpers.AllPkgTreeToPurl(&change.Base.AllPkgTree),
+			Target:        helpers.AllPkgTreeToPurl(&change.Target.AllPkgTree),
+			BaseLicense:   change.BaseLicense,
+			TargetLicense: change.TargetLicense,
+		})
+	}
+	return diff, nil
+}
+
+// sbomDiffInputID resolves a purl to its package version ID. Any other input
+// is assumed to be a HasSBOM ID.
+func sbomDiffInputID(ctx context.Context, gqlClient graphql.Client, input string) (string, error) {
+	if !strings.HasPrefix(input, "pkg:") {
+		return input, nil
+	}
+	return PackageVersionID(ctx, gqlClient, input)
+}
+
+var (
+	// ErrInvalidPurl is returned by PackageVersionID for purls that can't be
+	// parsed
+	ErrInvalidPurl = errors.New("invalid purl")
+	// ErrPackageNotFound is returned by PackageVersionID for purls that don't
+	// match a single package version
+	ErrPackageNotFound = errors.New("package version not found")
+)
+
+// PackageVersionID returns the ID of the package version of the purl
+func PackageVersionID(ctx context.Context, gqlClient graphql.Client, purl string) (string, error) {
+	pkgInput, err := helpers.PurlToPkg(purl)
+	if err != nil {
+		return "", fmt.Errorf("%w %s: %v", ErrInvalidPurl, purl, err)
+	}
+
+	pkgQualifierFilter := []model.PackageQualifierSpec{}
+	for _, qualifier := range pkgInput.Qualifiers {
+		// to prevent https://github.com/golang/go/discussions/56010
+		qualifier := qualifier
+		pkgQualifierFilter = append(pkgQualifierFilter, model.PackageQualifierSpec{
+			Key:   qualifier.Key,
+			Value: &qualifier.Value,
+		})
+	}
+
+	pkgResponse, err := model.Packages(ctx, gqlClient, model.PkgSpec{
+		Type:       &pkgInput.Type,
+		Namespace:  pkgInput.Namespace,
+		Name:       &pkgInput.Name,
+		Version:    pkgInput.Version,
+		Subpath:    pkgInput.Subpath,
+		Qualifiers: pkgQualifierFilter,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error querying for package: %w", err)
+	}
+	if len(pkgResponse.Packages) != 1 || len(pkgResponse.Packages[0].Namespaces[0].Names[0].Versions) != 1 {
+		return "", fmt.Errorf("%w: failed to locate a single package version for purl %s", ErrPackageNotFound, purl)
+	}
+	return pkgResponse.Packages[0].Namespaces[0].Names[0].Versions[0].Id, nil
+}
+
+func vulnerabilityIDs(vuln model.AllVulnerabilityTree) string {
+	var ids []string
+	for _, id := range vuln.VulnerabilityIDs {
+		ids = append(ids, id.VulnerabilityID)
+	}
+	return strings.Join(ids, ",")
+}
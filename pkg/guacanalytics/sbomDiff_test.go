@@ -0,0 +1,155 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package guacanalytics
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/guacsec/guac/internal/testing/ptrfrom"
+	"github.com/guacsec/guac/pkg/assembler"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	"github.com/guacsec/guac/pkg/assembler/clients/helpers"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+func diffPkg(name, version string) *model.PkgInputSpec {
+	return &model.PkgInputSpec{Type: "guac", Namespace: ptrfrom.String("diff"), Name: name, Version: ptrfrom.String(version)}
+}
+
+func diffSBOMGraph(app *model.PkgInputSpec, digest string, deps []*model.PkgInputSpec, vulns map[*model.PkgInputSpec]string, licenses map[*model.PkgInputSpec]string) assembler.IngestPredicates {
+	graph := assembler.IngestPredicates{
+		HasSBOM: []assembler.HasSBOMIngest{
+			{
+				Pkg: app,
+				HasSBOM: &model.HasSBOMInputSpec{
+					Uri:        "https://example.com/sbom/" + digest,
+					Algorithm:  "sha256",
+					Digest:     digest,
+					KnownSince: tm,
+					Origin:     "test",
+					Collector:  "test",
+				},
+			},
+		},
+	}
+	for _, dep := range deps {
+		graph.IsDependency = append(graph.IsDependency, assembler.IsDependencyIngest{
+			Pkg:             app,
+			DepPkg:          dep,
+			DepPkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+			IsDependency:    &model.IsDependencyInputSpec{DependencyType: model.DependencyTypeDirect, Origin: "test", Collector: "test"},
+		})
+	}
+	for pkg, vuln := range vulns {
+		graph.CertifyVuln = append(graph.CertifyVuln, assembler.CertifyVulnIngest{
+			Pkg:           pkg,
+			Vulnerability: &model.VulnerabilityInputSpec{Type: "osv", VulnerabilityID: vuln},
+			VulnData:      &model.ScanMetadataInput{TimeScanned: tm, Origin: "test", Collector: "test"},
+		})
+	}
+	for pkg, license := range licenses {
+		graph.CertifyLegal = append(graph.CertifyLegal, assembler.CertifyLegalIngest{
+			Pkg:          pkg,
+			Declared:     []model.LicenseInputSpec{{Name: license, ListVersion: ptrfrom.String("3.21")}},
+			Discovered:   []model.LicenseInputSpec{{Name: license, ListVersion: ptrfrom.String("3.21")}},
+			CertifyLegal: &model.CertifyLegalInputSpec{DeclaredLicense: license, TimeScanned: tm, Origin: "test", Collector: "test"},
+		})
+	}
+	return graph
+}
+
+func Test_DiffSBOMs(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+
+	srv, err := getGraphqlTestServer()
+	if err != nil {
+		t.Fatalf("unable to initialize graphql server: %v", err)
+	}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+	gqlClient := graphql.NewClient(server.URL, server.Client())
+
+	libV1, libV2 := diffPkg("lib", "1.0.0"), diffPkg("lib", "2.0.0")
+	old, added, same := diffPkg("old", "1.0.0"), diffPkg("new", "1.0.0"), diffPkg("same", "1.0.0")
+
+	graphs := []assembler.IngestPredicates{
+		diffSBOMGraph(diffPkg("app", "1.0.0"), "base123",
+			[]*model.PkgInputSpec{libV1, old, same},
+			map[*model.PkgInputSpec]string{libV1: "cve-2024-0001"},
+			map[*model.PkgInputSpec]string{libV1: "MIT", same: "MIT"}),
+		diffSBOMGraph(diffPkg("app", "2.0.0"), "target123",
+			[]*model.PkgInputSpec{libV2, added, same},
+			map[*model.PkgInputSpec]string{added: "cve-2024-0002"},
+			map[*model.PkgInputSpec]string{libV2: "Apache-2.0", same: "MIT"}),
+	}
+	if err := helpers.GetAssembler(ctx, gqlClient)(graphs); err != nil {
+		t.Fatalf("error ingesting test data: %v", err)
+	}
+
+	want := &SBOMDiff{
+		Base:            SBOMReference{URI: "https://example.com/sbom/base123", Digest: "sha256:base123"},
+		Target:          SBOMReference{URI: "https://example.com/sbom/target123", Digest: "sha256:target123"},
+		AddedPackages:   []string{"pkg:guac/diff/new@1.0.0"},
+		RemovedPackages: []string{"pkg:guac/diff/old@1.0.0"},
+		ChangedPackages: []PackageChange{
+			{Base: "pkg:guac/diff/app@1.0.0", Target: "pkg:guac/diff/app@2.0.0"},
+			{Base: "pkg:guac/diff/lib@1.0.0", Target: "pkg:guac/diff/lib@2.0.0"},
+		},
+		NewVulnerabilities:   []VulnerabilityChange{{VulnerabilityID: "cve-2024-0002", Package: "pkg:guac/diff/new@1.0.0"}},
+		FixedVulnerabilities: []VulnerabilityChange{{VulnerabilityID: "cve-2024-0001", Package: "pkg:guac/diff/lib@1.0.0"}},
+		LicenseChanges: []LicenseChange{
+			{Base: "pkg:guac/diff/lib@1.0.0", Target: "pkg:guac/diff/lib@2.0.0", BaseLicense: "MIT", TargetLicense: "Apache-2.0"},
+		},
+	}
+
+	got, err := DiffSBOMs(ctx, gqlClient, "pkg:guac/diff/app@1.0.0", "pkg:guac/diff/app@2.0.0")
+	if err != nil {
+		t.Fatalf("DiffSBOMs() error: %v", err)
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(SBOMReference{}, "ID")); diff != "" {
+		t.Errorf("DiffSBOMs() (-want +got):\n%s", diff)
+	}
+
+	// the reverse diff by HasSBOM ID swaps additions and removals
+	reverse, err := DiffSBOMs(ctx, gqlClient, got.Target.ID, got.Base.ID)
+	if err != nil {
+		t.Fatalf("DiffSBOMs() error: %v", err)
+	}
+	if diff := cmp.Diff(want.AddedPackages, reverse.RemovedPackages); diff != "" {
+		t.Errorf("reverse DiffSBOMs() removed packages (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want.NewVulnerabilities, reverse.FixedVulnerabilities); diff != "" {
+		t.Errorf("reverse DiffSBOMs() fixed vulnerabilities (-want +got):\n%s", diff)
+	}
+
+	if _, err := DiffSBOMs(ctx, gqlClient, "pkg:guac/diff/lib@1.0.0", got.Target.ID); err == nil {
+		t.Errorf("DiffSBOMs() expected an error for a package version without an SBOM")
+	}
+
+	// invalid and unknown purls are told apart from failed queries
+	if _, err := PackageVersionID(ctx, gqlClient, "not a purl"); !errors.Is(err, ErrInvalidPurl) {
+		t.Errorf("PackageVersionID() error = %v, want %v", err, ErrInvalidPurl)
+	}
+	if _, err := PackageVersionID(ctx, gqlClient, "pkg:guac/diff/missing@1.0.0"); !errors.Is(err, ErrPackageNotFound) {
+		t.Errorf("PackageVersionID() error = %v, want %v", err, ErrPackageNotFound)
+	}
+}
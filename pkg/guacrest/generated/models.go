@@ -3,20 +3,169 @@
 // Code generated by github.com/deepmap/oapi-codegen/v2 version v2.1.0 DO NOT EDIT.
 package generated
 
+// Defines values for PolicyRuleScope.
+const (
+	PolicyRuleScopeDirect     PolicyRuleScope = "direct"
+	PolicyRuleScopeSubject    PolicyRuleScope = "subject"
+	PolicyRuleScopeTransitive PolicyRuleScope = "transitive"
+)
+
+// Defines values for PolicyRuleType.
+const (
+	MinScorecard    PolicyRuleType = "minScorecard"
+	NoCertifyBad    PolicyRuleType = "noCertifyBad"
+	NoVulnerability PolicyRuleType = "noVulnerability"
+	SlsaBuilder     PolicyRuleType = "slsaBuilder"
+)
+
+// Defines values for ReachableVulnerabilityReachability.
+const (
+	ReachableVulnerabilityReachabilityDirect     ReachableVulnerabilityReachability = "direct"
+	ReachableVulnerabilityReachabilityTransitive ReachableVulnerabilityReachability = "transitive"
+)
+
 // Defines values for AnalyzeDependenciesParamsSort.
 const (
 	Frequency AnalyzeDependenciesParamsSort = "frequency"
 	Scorecard AnalyzeDependenciesParamsSort = "scorecard"
 )
 
+// DependencyImpact defines model for DependencyImpact.
+type DependencyImpact struct {
+	Package Purl `json:"package"`
+
+	// Vulnerabilities the vulnerabilities reached through the dependency
+	Vulnerabilities []string `json:"vulnerabilities"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	Message string `json:"message"`
 }
 
+// LicenseChange defines model for LicenseChange.
+type LicenseChange struct {
+	Base          Purl   `json:"base"`
+	BaseLicense   string `json:"baseLicense"`
+	Target        Purl   `json:"target"`
+	TargetLicense string `json:"targetLicense"`
+}
+
+// PackageChange defines model for PackageChange.
+type PackageChange struct {
+	Base   Purl `json:"base"`
+	Target Purl `json:"target"`
+}
+
+// Policy defines model for Policy.
+type Policy struct {
+	Name  *string      `json:"name,omitempty"`
+	Rules []PolicyRule `json:"rules"`
+}
+
+// PolicyEvaluation defines model for PolicyEvaluation.
+type PolicyEvaluation struct {
+	Passed  bool             `json:"passed"`
+	Policy  string           `json:"policy"`
+	Rules   []RuleEvaluation `json:"rules"`
+	Subject string           `json:"subject"`
+}
+
+// PolicyEvaluationRequest defines model for PolicyEvaluationRequest.
+type PolicyEvaluationRequest struct {
+	Policy Policy `json:"policy"`
+
+	// Subject the purl of a package version or the algorithm:digest of an artifact
+	Subject string `json:"subject"`
+}
+
+// PolicyRule defines model for PolicyRule.
+type PolicyRule struct {
+	AllowMissing *bool            `json:"allowMissing,omitempty"`
+	Builders     *[]string        `json:"builders,omitempty"`
+	MinScore     *float64         `json:"minScore,omitempty"`
+	Name         *string          `json:"name,omitempty"`
+	Scope        *PolicyRuleScope `json:"scope,omitempty"`
+	ScoreType    *string          `json:"scoreType,omitempty"`
+	Type         PolicyRuleType   `json:"type"`
+}
+
+// PolicyRuleScope defines model for PolicyRule.Scope.
+type PolicyRuleScope string
+
+// PolicyRuleType defines model for PolicyRule.Type.
+type PolicyRuleType string
+
+// PolicyViolation defines model for PolicyViolation.
+type PolicyViolation struct {
+	Message string `json:"message"`
+
+	// Path the packages and artifacts from the subject to the offending node
+	Path []string `json:"path"`
+}
+
 // Purl defines model for Purl.
 type Purl = string
 
+// ReachableVulnerability defines model for ReachableVulnerability.
+type ReachableVulnerability struct {
+	// MinDepth the fewest levels of dependencies to the vulnerable package
+	MinDepth int  `json:"minDepth"`
+	Package  Purl `json:"package"`
+
+	// Paths the number of distinct dependency paths to the vulnerable package
+	Paths        int                                `json:"paths"`
+	Reachability ReachableVulnerabilityReachability `json:"reachability"`
+
+	// Via the dependencies of the top-level package the paths go through
+	Via             []Purl `json:"via"`
+	VulnerabilityID string `json:"vulnerabilityID"`
+}
+
+// ReachableVulnerabilityReachability defines model for ReachableVulnerability.Reachability.
+type ReachableVulnerabilityReachability string
+
+// RuleEvaluation defines model for RuleEvaluation.
+type RuleEvaluation struct {
+	Name       string            `json:"name"`
+	Passed     bool              `json:"passed"`
+	Type       string            `json:"type"`
+	Violations []PolicyViolation `json:"violations"`
+}
+
+// SBOMDiffResult defines model for SBOMDiffResult.
+type SBOMDiffResult struct {
+	AddedPackages        []Purl                `json:"addedPackages"`
+	Base                 SBOMReference         `json:"base"`
+	ChangedPackages      []PackageChange       `json:"changedPackages"`
+	FixedVulnerabilities []VulnerabilityChange `json:"fixedVulnerabilities"`
+	LicenseChanges       []LicenseChange       `json:"licenseChanges"`
+	NewVulnerabilities   []VulnerabilityChange `json:"newVulnerabilities"`
+	RemovedPackages      []Purl                `json:"removedPackages"`
+	Target               SBOMReference         `json:"target"`
+}
+
+// SBOMReference defines model for SBOMReference.
+type SBOMReference struct {
+	Digest string `json:"digest"`
+	Id     string `json:"id"`
+	Uri    string `json:"uri"`
+}
+
+// VulnerabilityChange defines model for VulnerabilityChange.
+type VulnerabilityChange struct {
+	Package         Purl   `json:"package"`
+	VulnerabilityID string `json:"vulnerabilityID"`
+}
+
+// VulnerabilityReachabilityResult defines model for VulnerabilityReachabilityResult.
+type VulnerabilityReachabilityResult struct {
+	// Dependencies the dependencies of the package, most vulnerabilities reached first
+	Dependencies    []DependencyImpact       `json:"dependencies"`
+	Package         Purl                     `json:"package"`
+	Vulnerabilities []ReachableVulnerability `json:"vulnerabilities"`
+}
+
 // BadGateway defines model for BadGateway.
 type BadGateway = Error
 
@@ -26,9 +175,18 @@ type BadRequest = Error
 // InternalServerError defines model for InternalServerError.
 type InternalServerError = Error
 
+// PolicyResult defines model for PolicyResult.
+type PolicyResult = PolicyEvaluation
+
 // PurlList defines model for PurlList.
 type PurlList = []Purl
 
+// SBOMDiff defines model for SBOMDiff.
+type SBOMDiff = SBOMDiffResult
+
+// VulnerabilityReachability defines model for VulnerabilityReachability.
+type VulnerabilityReachability = VulnerabilityReachabilityResult
+
 // AnalyzeDependenciesParams defines parameters for AnalyzeDependencies.
 type AnalyzeDependenciesParams struct {
 	// Sort The sort order of the packages
@@ -40,8 +198,29 @@ type AnalyzeDependenciesParams struct {
 // AnalyzeDependenciesParamsSort defines parameters for AnalyzeDependencies.
 type AnalyzeDependenciesParamsSort string
 
+// DiffSBOMsParams defines parameters for DiffSBOMs.
+type DiffSBOMsParams struct {
+	// Base the HasSBOM ID or package version purl of the SBOM that is compared against
+	Base string `form:"base" json:"base"`
+
+	// Target the HasSBOM ID or package version purl of the SBOM that is compared to the base
+	Target string `form:"target" json:"target"`
+}
+
+// AnalyzeVulnerabilityReachabilityParams defines parameters for AnalyzeVulnerabilityReachability.
+type AnalyzeVulnerabilityReachabilityParams struct {
+	// Purl the purl of the top-level package version
+	Purl string `form:"purl" json:"purl"`
+
+	// MaxDepth the maximum number of levels of dependencies followed, all levels when 0
+	MaxDepth *int `form:"maxDepth,omitempty" json:"maxDepth,omitempty"`
+}
+
 // RetrieveDependenciesParams defines parameters for RetrieveDependencies.
 type RetrieveDependenciesParams struct {
 	// Purl the purl of the dependent package
 	Purl string `form:"purl" json:"purl"`
 }
+
+// EvaluatePolicyJSONRequestBody defines body for EvaluatePolicy for application/json ContentType.
+type EvaluatePolicyJSONRequestBody = PolicyEvaluationRequest
@@ -19,6 +19,15 @@ type ServerInterface interface {
 	// Identify the most important dependencies
 	// (GET /analysis/dependencies)
 	AnalyzeDependencies(w http.ResponseWriter, r *http.Request, params AnalyzeDependenciesParams)
+	// Evaluate a policy against a package or artifact
+	// (POST /analysis/policy)
+	EvaluatePolicy(w http.ResponseWriter, r *http.Request)
+	// Compare the packages, vulnerabilities and licenses of two SBOMs
+	// (GET /analysis/sbom-diff)
+	DiffSBOMs(w http.ResponseWriter, r *http.Request, params DiffSBOMsParams)
+	// Classify the vulnerabilities of the dependencies of a package as direct or transitive
+	// (GET /analysis/vulnerability-reachability)
+	AnalyzeVulnerabilityReachability(w http.ResponseWriter, r *http.Request, params AnalyzeVulnerabilityReachabilityParams)
 	// Health check the server
 	// (GET /healthz)
 	HealthCheck(w http.ResponseWriter, r *http.Request)
@@ -37,6 +46,24 @@ func (_ Unimplemented) AnalyzeDependencies(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Evaluate a policy against a package or artifact
+// (POST /analysis/policy)
+func (_ Unimplemented) EvaluatePolicy(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Compare the packages, vulnerabilities and licenses of two SBOMs
+// (GET /analysis/sbom-diff)
+func (_ Unimplemented) DiffSBOMs(w http.ResponseWriter, r *http.Request, params DiffSBOMsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Classify the vulnerabilities of the dependencies of a package as direct or transitive
+// (GET /analysis/vulnerability-reachability)
+func (_ Unimplemented) AnalyzeVulnerabilityReachability(w http.ResponseWriter, r *http.Request, params AnalyzeVulnerabilityReachabilityParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Health check the server
 // (GET /healthz)
 func (_ Unimplemented) HealthCheck(w http.ResponseWriter, r *http.Request) {
@@ -93,6 +120,114 @@ func (siw *ServerInterfaceWrapper) AnalyzeDependencies(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// EvaluatePolicy operation middleware
+func (siw *ServerInterfaceWrapper) EvaluatePolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.EvaluatePolicy(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DiffSBOMs operation middleware
+func (siw *ServerInterfaceWrapper) DiffSBOMs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DiffSBOMsParams
+
+	// ------------- Required query parameter "base" -------------
+
+	if paramValue := r.URL.Query().Get("base"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "base"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "base", r.URL.Query(), &params.Base)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "base", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "target" -------------
+
+	if paramValue := r.URL.Query().Get("target"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "target"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "target", r.URL.Query(), &params.Target)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "target", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DiffSBOMs(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// AnalyzeVulnerabilityReachability operation middleware
+func (siw *ServerInterfaceWrapper) AnalyzeVulnerabilityReachability(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AnalyzeVulnerabilityReachabilityParams
+
+	// ------------- Required query parameter "purl" -------------
+
+	if paramValue := r.URL.Query().Get("purl"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "purl"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "purl", r.URL.Query(), &params.Purl)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "purl", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "maxDepth" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "maxDepth", r.URL.Query(), &params.MaxDepth)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "maxDepth", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AnalyzeVulnerabilityReachability(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // HealthCheck operation middleware
 func (siw *ServerInterfaceWrapper) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -259,6 +394,15 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/analysis/dependencies", wrapper.AnalyzeDependencies)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/analysis/policy", wrapper.EvaluatePolicy)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/analysis/sbom-diff", wrapper.DiffSBOMs)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/analysis/vulnerability-reachability", wrapper.AnalyzeVulnerabilityReachability)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/healthz", wrapper.HealthCheck)
 	})
@@ -275,8 +419,14 @@ type BadRequestJSONResponse Error
 
 type InternalServerErrorJSONResponse Error
 
+type PolicyResultJSONResponse PolicyEvaluation
+
 type PurlListJSONResponse []Purl
 
+type SBOMDiffJSONResponse SBOMDiffResult
+
+type VulnerabilityReachabilityJSONResponse VulnerabilityReachabilityResult
+
 type AnalyzeDependenciesRequestObject struct {
 	Params AnalyzeDependenciesParams
 }
@@ -323,6 +473,146 @@ func (response AnalyzeDependencies502JSONResponse) VisitAnalyzeDependenciesRespo
 	return json.NewEncoder(w).Encode(response)
 }
 
+type EvaluatePolicyRequestObject struct {
+	Body *EvaluatePolicyJSONRequestBody
+}
+
+type EvaluatePolicyResponseObject interface {
+	VisitEvaluatePolicyResponse(w http.ResponseWriter) error
+}
+
+type EvaluatePolicy200JSONResponse struct{ PolicyResultJSONResponse }
+
+func (response EvaluatePolicy200JSONResponse) VisitEvaluatePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EvaluatePolicy400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response EvaluatePolicy400JSONResponse) VisitEvaluatePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EvaluatePolicy500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response EvaluatePolicy500JSONResponse) VisitEvaluatePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EvaluatePolicy502JSONResponse struct{ BadGatewayJSONResponse }
+
+func (response EvaluatePolicy502JSONResponse) VisitEvaluatePolicyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(502)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DiffSBOMsRequestObject struct {
+	Params DiffSBOMsParams
+}
+
+type DiffSBOMsResponseObject interface {
+	VisitDiffSBOMsResponse(w http.ResponseWriter) error
+}
+
+type DiffSBOMs200JSONResponse struct{ SBOMDiffJSONResponse }
+
+func (response DiffSBOMs200JSONResponse) VisitDiffSBOMsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DiffSBOMs400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response DiffSBOMs400JSONResponse) VisitDiffSBOMsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DiffSBOMs500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response DiffSBOMs500JSONResponse) VisitDiffSBOMsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DiffSBOMs502JSONResponse struct{ BadGatewayJSONResponse }
+
+func (response DiffSBOMs502JSONResponse) VisitDiffSBOMsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(502)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AnalyzeVulnerabilityReachabilityRequestObject struct {
+	Params AnalyzeVulnerabilityReachabilityParams
+}
+
+type AnalyzeVulnerabilityReachabilityResponseObject interface {
+	VisitAnalyzeVulnerabilityReachabilityResponse(w http.ResponseWriter) error
+}
+
+type AnalyzeVulnerabilityReachability200JSONResponse struct {
+	VulnerabilityReachabilityJSONResponse
+}
+
+func (response AnalyzeVulnerabilityReachability200JSONResponse) VisitAnalyzeVulnerabilityReachabilityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AnalyzeVulnerabilityReachability400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response AnalyzeVulnerabilityReachability400JSONResponse) VisitAnalyzeVulnerabilityReachabilityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AnalyzeVulnerabilityReachability500JSONResponse struct {
+	InternalServerErrorJSONResponse
+}
+
+func (response AnalyzeVulnerabilityReachability500JSONResponse) VisitAnalyzeVulnerabilityReachabilityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AnalyzeVulnerabilityReachability502JSONResponse struct{ BadGatewayJSONResponse }
+
+func (response AnalyzeVulnerabilityReachability502JSONResponse) VisitAnalyzeVulnerabilityReachabilityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(502)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type HealthCheckRequestObject struct {
 }
 
@@ -390,6 +680,15 @@ type StrictServerInterface interface {
 	// Identify the most important dependencies
 	// (GET /analysis/dependencies)
 	AnalyzeDependencies(ctx context.Context, request AnalyzeDependenciesRequestObject) (AnalyzeDependenciesResponseObject, error)
+	// Evaluate a policy against a package or artifact
+	// (POST /analysis/policy)
+	EvaluatePolicy(ctx context.Context, request EvaluatePolicyRequestObject) (EvaluatePolicyResponseObject, error)
+	// Compare the packages, vulnerabilities and licenses of two SBOMs
+	// (GET /analysis/sbom-diff)
+	DiffSBOMs(ctx context.Context, request DiffSBOMsRequestObject) (DiffSBOMsResponseObject, error)
+	// Classify the vulnerabilities of the dependencies of a package as direct or transitive
+	// (GET /analysis/vulnerability-reachability)
+	AnalyzeVulnerabilityReachability(ctx context.Context, request AnalyzeVulnerabilityReachabilityRequestObject) (AnalyzeVulnerabilityReachabilityResponseObject, error)
 	// Health check the server
 	// (GET /healthz)
 	HealthCheck(ctx context.Context, request HealthCheckRequestObject) (HealthCheckResponseObject, error)
@@ -453,6 +752,89 @@ func (sh *strictHandler) AnalyzeDependencies(w http.ResponseWriter, r *http.Requ
 	}
 }
 
+// EvaluatePolicy operation middleware
+func (sh *strictHandler) EvaluatePolicy(w http.ResponseWriter, r *http.Request) {
+	var request EvaluatePolicyRequestObject
+
+	var body EvaluatePolicyJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.EvaluatePolicy(ctx, request.(EvaluatePolicyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "EvaluatePolicy")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(EvaluatePolicyResponseObject); ok {
+		if err := validResponse.VisitEvaluatePolicyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DiffSBOMs operation middleware
+func (sh *strictHandler) DiffSBOMs(w http.ResponseWriter, r *http.Request, params DiffSBOMsParams) {
+	var request DiffSBOMsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DiffSBOMs(ctx, request.(DiffSBOMsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DiffSBOMs")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DiffSBOMsResponseObject); ok {
+		if err := validResponse.VisitDiffSBOMsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AnalyzeVulnerabilityReachability operation middleware
+func (sh *strictHandler) AnalyzeVulnerabilityReachability(w http.ResponseWriter, r *http.Request, params AnalyzeVulnerabilityReachabilityParams) {
+	var request AnalyzeVulnerabilityReachabilityRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AnalyzeVulnerabilityReachability(ctx, request.(AnalyzeVulnerabilityReachabilityRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AnalyzeVulnerabilityReachability")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AnalyzeVulnerabilityReachabilityResponseObject); ok {
+		if err := validResponse.VisitAnalyzeVulnerabilityReachabilityResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // HealthCheck operation middleware
 func (sh *strictHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	var request HealthCheckRequestObject
@@ -18,18 +18,37 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+RUT0/8Rgz9KpZb6SdVKVnR9pJb6V+kSq2AG3AYJt7NQOIZPBNQQPnulSebdEG77XLg",
-	"1NskM7afn9/zK1rfBc/EKWL1ikIxeI6UP85M/ZtJ9GwG/bKeE3HSowmhddYk57m8j571X7QNdUZPXwut",
-	"scKvyn9Sl9NtLH8R8YLjOBZYU7TigibBCq8agkjyRALE1vecSKgGw0AaAtYzk02ON5A8pIagNsnAnbEP",
-	"xDWOhaK9oMeeYvp8tGemBpmKFRB724CJsBbfgeMn07oavEDnYlS8wYjpKJFEhXmunbFpL3OzU4VPxzsX",
-	"hakqbB8W+Fcv7R/ug5S5RF38LyyaWSukIRBWaETMsA/aj9C6mMCvIfTSRtQX2xxaYiEoiA8kyU3S7ChG",
-	"syE9bgvEJI43OVwn44RqrK6Xh7cLEn93TzbNze/LMBboeO2x4r5tC/SB2ASHFX53sjpZYYHBpCbDKA2b",
-	"doguljUF4prYbgFuKBOpmDON57X2qq9f6Ofdt5pt0Ud1/brPF14SeKlJlCbVfjD2wWwo3jDAN/BlnbXI",
-	"dvgC38LVzj08u9TkiMZtGooJuO/upjwz4jRnidYLWSP14Sytf9Ykfwbiy8tfYYmYTjeMyhxW+NiTDFgg",
-	"my4z6yXh7lyS9FTsKIq473RaSyOot9vkO7NbRnRbvF1Vp6vVIUEu78pF7GOB3x8TsLNSxgJ/OCZkn71z",
-	"7OlR5eZ9m13Qd52RQe2rY3LrIc+g8zGB64KXZDjBG+FpWNmQaVPzclCFv+f7nxqyD7ifxqM3wR7nvF88",
-	"tUZTzNC3C95FmDC+73NCBlah7QRMbWVNHeezC0ri6OlDRsu26qWdLbbYY7bBAW1rzL9q+/8t3XkUbzjV",
-	"eSjPZuF2HMfx7wAAAP//2OEUMowIAAA=",
+	"H4sIAAAAAAAC/+RZS2/cOBL+KwR3gQEW8tiY3b30bWzP7hiYwQR2kEuSA1sqtZhQpEJS3VGC/u+LIqk3",
+	"5Vb7cQj21g+y+FXVR9bH4neaqrJSEqQ1dPOdajCVkgbcl2uW/ZdZOLAGv6VKWpAWP7KqEjxllit5+cko",
+	"ib+ZtICS4ae/a8jphv7tsjd96f81l79prTQ9Ho8JzcCkmldohG7o2wKIAb0HTUCmqpYWNGSESQI4haRK",
+	"SkgtlztiFbEFkIxZRrYs/Qwyo8cE0d7DlxqMfX201ywj2i+WEFOnBWGG5FqVhMs9EzwjSpOSG4N4K6ZZ",
+	"CRa0QZh36Jlk4sE561d4dbztosSvSsLAhL5RgqfNPZhavFzYvNHf9kzUbvpSvrVblqicQBgrd4SRyk13",
+	"6Got/uBnJpRbKM1JiLUWuIJtKqAbyrRmTQzmr0Rw4yBWtRYugQ/Xf/15y/P8xcLVGgxZWAhWxvMcNMgU",
+	"yBbsAUASe1AEJztY72ohQbMtF9w298DSInx+MZyLKzwOfD+YxsFgLN32hQpkBjINvzFSsfQz2wFhMiOF",
+	"OuCohjCNPGFpARlF+wEMYr1tLTR3ZcVS512lVQUa13HfvMW1ZJggxWljd2zEnQCO2EKreleMfWto0vMx",
+	"cM1YzeUuQr6E4pHCNWR0877DPof1sZuptp8gtWiqO0fGASjBmBCAyeqT1dqBMdt/8BSkgZuCSW9qvMaW",
+	"mdURxrHBXDwiTO/Art68bvSyvYmPDmi3xhjM1FgsDm98Rl4iDuf4+agXUZz+/JwBlKyMR13Xwo9Yd3T6",
+	"klELOMlhb3gZ46BERPauMWilw7tVSgCTOLvqPHymL+jFsE5N/UmoqT3mk+wKmPoZSetCckYcBgpmEo7O",
+	"5dO5meCen2FYy8Zn7h604UqibMEBTOyU5rYoNxnfgS+ATBKmLc+Z8+3xYAxi4BEt++6INHOXCaEOf3oB",
+	"FefAtuYiQ001zPWJIzahJZcPqdJuxVzpklm6oZmqtwJ6p2RdbsHJo8UtY1JVuX9A1uXY44xr/8FqJg23",
+	"fD88TkYmNLxtKngEeG9fqlH5pQmV6gbjlTfXDDlmhGHXPia09zNlOousPkmX+3c5Re+4Egu7dLnAIP1t",
+	"scA/zzrjSn1LqiCg8f8QzFbpqzwHmaE4lCqDp5fUFmyAFnUYj9yY4aB3BIzTMA8Il7dQLTmewwF3k4A9",
+	"CKd6RioouNvWe9EFqqcmlxZ2nptniht0eUHSeL47ONxYLlM7UDDETTwTm57oz5bFa/fGnrM41KlqxN+s",
+	"qi5cRLvTzHMMYe9UK8yGtDn/ZjBWYc3d7emCMJ2QDCTdKD5JT5o2TT4CMYJOKtb6Iv9YPbVLp9C+3fnn",
+	"yoP+yDi1KR3gMGZQMgcrx8IwuTPN60eWQRYEm3nmrTBZJe0Q0T2EKxpOSp1OfAKIkcyMoMn5V8jeze8q",
+	"q6yPjq/lNcRQ86+3Pr4qROxKOLwycg2l2r9g7tdJ9Un2T9w8xuycY56TJxq5BSrMsre0gXrAs/3jZV/0",
+	"TOBZ9Oda89NnIsetjSOTdoUYtlimX/Ju/8zj+yTkSG9kHuBBIVtf6gKEhJTK2MVWRM61sWvr3ayLEtkD",
+	"z++jrLuNxTXW0zslyTjM88ShKS5zRTeyFiKhqgLJKk439J8/X/18RQey6ZJJJhrDzeU0deF4wOy6inWX",
+	"0Q39FUd/g9vhWLTWtYI377/HWuBKW6J05uXYUCt/kIT8g/yUu7azTJufyAV5O9TSB25996nguwJV5kDW",
+	"BRS2tWLau8GyFaGcVP2rAvnw8B/SzfCfPkjkF6L+UoN21xEnPCg6QIf5sbqGZNBhbKVg5wgNV6Glq8rH",
+	"ZPwq8cvV1RKRunGXXef4mNB/rZkweD04JvTfa6bEOvlu7i+rlmufVlxXsy5Lphu6oXeYJp43Lgdul/Oy",
+	"UtoyaUcngpvWc7JvD1TKROgYJCO8absU4fXiWmXNq/X9u4COdywy4vikpA4fK360xLYZ6B44CNsxLo0d",
+	"dGGU7lss4/yarSovsvDqED1wUA37x4ATxwwS63dmcCy5u8U1p02gtj2EI90wWzBLuCHoMXPPch76whkQ",
+	"5M7yGTCrvK+BMdxYA5gYzk6PrUf6pMOoezH60Th742M5qkPJTHYwmZGgOL1Q6Z+lRhweKaqLaYvgsSq6",
+	"/La1gutDpsxbBYFPCwzBuc9ncsm+8rIuBwV5oQGUK4FlN0sIE6IddChAkqsFgCX72nYOelAll7ge3VzN",
+	"ezNPY/ByAn44SgtmTFtfz3+aNMS3sFyjvO9hOaIXwIQtvi2S+Xf3/00B6WcaT8LqKjylXeTBP8PZYHw/",
+	"1b/6c0M8xmlQPDKSIrTBBO+WY9w60XsPVnPYn6V6p1u006qDBuOLbM3/Lx3ZpuJxQiN1jv8LAAD//2xv",
+	"3S0EJAAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -17,9 +17,14 @@ package server
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+
 	"github.com/Khan/genqlient/graphql"
+	analysis "github.com/guacsec/guac/pkg/guacanalytics"
 	gen "github.com/guacsec/guac/pkg/guacrest/generated"
+	"github.com/guacsec/guac/pkg/policy"
 )
 
 // DefaultServer implements the API, backed by the GraphQL Server
@@ -48,3 +53,142 @@ func (s *DefaultServer) RetrieveDependencies(ctx context.Context, request gen.Re
 	//}, nil
 	return nil, fmt.Errorf("Unimplemented")
 }
+
+func (s *DefaultServer) EvaluatePolicy(ctx context.Context, request gen.EvaluatePolicyRequestObject) (gen.EvaluatePolicyResponseObject, error) {
+	// the request policy has the same JSON form as a policy file
+	policyJSON, err := json.Marshal(request.Body.Policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal policy: %w", err)
+	}
+	p, err := policy.ParsePolicy(policyJSON)
+	if err != nil {
+		return gen.EvaluatePolicy400JSONResponse{
+			BadRequestJSONResponse: gen.BadRequestJSONResponse{Message: err.Error()},
+		}, nil
+	}
+
+	subjectID, err := policy.FindSubject(ctx, s.gqlClient, request.Body.Subject)
+	if err != nil {
+		return gen.EvaluatePolicy400JSONResponse{
+			BadRequestJSONResponse: gen.BadRequestJSONResponse{Message: err.Error()},
+		}, nil
+	}
+
+	result, err := policy.Evaluate(ctx, s.gqlClient, p, subjectID)
+	if err != nil {
+		return gen.EvaluatePolicy502JSONResponse{
+			BadGatewayJSONResponse: gen.BadGatewayJSONResponse{Message: err.Error()},
+		}, nil
+	}
+
+	evaluation := gen.PolicyEvaluation{
+		Policy:  result.Policy,
+		Subject: result.Subject,
+		Passed:  result.Passed,
+		Rules:   []gen.RuleEvaluation{},
+	}
+	for _, rule := range result.Rules {
+		ruleEvaluation := gen.RuleEvaluation{
+			Name:       rule.Name,
+			Type:       string(rule.Type),
+			Passed:     rule.Passed,
+			Violations: []gen.PolicyViolation{},
+		}
+		for _, violation := range rule.Violations {
+			ruleEvaluation.Violations = append(ruleEvaluation.Violations, gen.PolicyViolation{
+				Message: violation.Message,
+				Path:    violation.Path,
+			})
+		}
+		evaluation.Rules = append(evaluation.Rules, ruleEvaluation)
+	}
+	return gen.EvaluatePolicy200JSONResponse{PolicyResultJSONResponse: gen.PolicyResultJSONResponse(evaluation)}, nil
+}
+
+func (s *DefaultServer) DiffSBOMs(ctx context.Context, request gen.DiffSBOMsRequestObject) (gen.DiffSBOMsResponseObject, error) {
+	diff, err := analysis.DiffSBOMs(ctx, s.gqlClient, request.Params.Base, request.Params.Target)
+	if err != nil {
+		return gen.DiffSBOMs400JSONResponse{
+			BadRequestJSONResponse: gen.BadRequestJSONResponse{Message: err.Error()},
+		}, nil
+	}
+
+	result := gen.SBOMDiffResult{
+		Base:                 gen.SBOMReference{Id: diff.Base.ID, Uri: diff.Base.URI, Digest: diff.Base.Digest},
+		Target:               gen.SBOMReference{Id: diff.Target.ID, Uri: diff.Target.URI, Digest: diff.Target.Digest},
+		AddedPackages:        diff.AddedPackages,
+		RemovedPackages:      diff.RemovedPackages,
+		ChangedPackages:      []gen.PackageChange{},
+		NewVulnerabilities:   []gen.VulnerabilityChange{},
+		FixedVulnerabilities: []gen.VulnerabilityChange{},
+		LicenseChanges:       []gen.LicenseChange{},
+	}
+	for _, change := range diff.ChangedPackages {
+		result.ChangedPackages = append(result.ChangedPackages, gen.PackageChange{Base: change.Base, Target: change.Target})
+	}
+	for _, change := range diff.NewVulnerabilities {
+		result.NewVulnerabilities = append(result.NewVulnerabilities, gen.VulnerabilityChange{VulnerabilityID: change.VulnerabilityID, Package: change.Package})
+	}
+	for _, change := range diff.FixedVulnerabilities {
+		result.FixedVulnerabilities = append(result.FixedVulnerabilities, gen.VulnerabilityChange{VulnerabilityID: change.VulnerabilityID, Package: change.Package})
+	}
+	for _, change := range diff.LicenseChanges {
+		result.LicenseChanges = append(result.LicenseChanges, gen.LicenseChange{
+			Base:          change.Base,
+			Target:        change.Target,
+			BaseLicense:   change.BaseLicense,
+			TargetLicense: change.TargetLicense,
+		})
+	}
+	return gen.DiffSBOMs200JSONResponse{SBOMDiffJSONResponse: gen.SBOMDiffJSONResponse(result)}, nil
+}
+
+func (s *DefaultServer) AnalyzeVulnerabilityReachability(ctx context.Context, request gen.AnalyzeVulnerabilityReachabilityRequestObject) (gen.AnalyzeVulnerabilityReachabilityResponseObject, error) {
+	maxDepth := 0
+	if request.Params.MaxDepth != nil {
+		maxDepth = *request.Params.MaxDepth
+	}
+	if maxDepth < 0 {
+		return gen.AnalyzeVulnerabilityReachability400JSONResponse{
+			BadRequestJSONResponse: gen.BadRequestJSONResponse{Message: "maxDepth must not be negative"},
+		}, nil
+	}
+	pkgID, err := analysis.PackageVersionID(ctx, s.gqlClient, request.Params.Purl)
+	if errors.Is(err, analysis.ErrInvalidPurl) || errors.Is(err, analysis.ErrPackageNotFound) {
+		return gen.AnalyzeVulnerabilityReachability400JSONResponse{
+			BadRequestJSONResponse: gen.BadRequestJSONResponse{Message: err.Error()},
+		}, nil
+	}
+	if err != nil {
+		return gen.AnalyzeVulnerabilityReachability502JSONResponse{
+			BadGatewayJSONResponse: gen.BadGatewayJSONResponse{Message: err.Error()},
+		}, nil
+	}
+
+	report, err := analysis.AnalyzeReachability(ctx, s.gqlClient, pkgID, maxDepth)
+	if err != nil {
+		return gen.AnalyzeVulnerabilityReachability502JSONResponse{
+			BadGatewayJSONResponse: gen.BadGatewayJSONResponse{Message: err.Error()},
+		}, nil
+	}
+
+	result := gen.VulnerabilityReachabilityResult{
+		Package:         report.Package,
+		Vulnerabilities: []gen.ReachableVulnerability{},
+		Dependencies:    []gen.DependencyImpact{},
+	}
+	for _, v := range report.Vulnerabilities {
+		result.Vulnerabilities = append(result.Vulnerabilities, gen.ReachableVulnerability{
+			VulnerabilityID: v.VulnerabilityID,
+			Package:         v.Package,
+			Reachability:    gen.ReachableVulnerabilityReachability(v.Reachability),
+			MinDepth:        v.MinDepth,
+			Paths:           v.Paths,
+			Via:             v.Via,
+		})
+	}
+	for _, d := range report.Dependencies {
+		result.Dependencies = append(result.Dependencies, gen.DependencyImpact{Package: d.Package, Vulnerabilities: d.Vulnerabilities})
+	}
+	return gen.AnalyzeVulnerabilityReachability200JSONResponse{VulnerabilityReachabilityJSONResponse: gen.VulnerabilityReachabilityJSONResponse(result)}, nil
+}
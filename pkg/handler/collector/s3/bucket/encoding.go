@@ -27,6 +27,8 @@ func ExtractEncoding(encoding string, filename string) processor.EncodingType {
 		return processor.EncodingBzip2
 	case "ZSTD":
 		return processor.EncodingZstd
+	case "GZIP":
+		return processor.EncodingGzip
 	default:
 		return FromFile(filename)
 	}
@@ -40,6 +42,8 @@ func FromFile(file string) processor.EncodingType {
 		return processor.EncodingBzip2
 	case "zst":
 		return processor.EncodingZstd
+	case "gz", "tgz":
+		return processor.EncodingGzip
 	default:
 		return processor.EncodingUnknown
 	}
@@ -0,0 +1,209 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+// SourceSeparator separates the source of an archive from the path of an
+// entry inside of it, e.g. sbom.tar.gz!sboms/app.spdx.json. The number of
+// separators in a source is the nesting depth of the archive.
+const SourceSeparator = "!"
+
+// Limits on the archives that are unpacked, to guard against archive bombs.
+// Archives exceeding them are rejected.
+var (
+	// MaxEntrySize is the maximum uncompressed size of an entry
+	MaxEntrySize int64 = 256 << 20
+	// MaxTotalSize is the maximum uncompressed size of all the entries of an
+	// archive
+	MaxTotalSize int64 = 1 << 30
+	// MaxEntries is the maximum number of files in an archive
+	MaxEntries = 10000
+	// MaxDepth is the maximum nesting depth of archives within archives
+	MaxDepth = 3
+)
+
+// TarProcessor unpacks tar archives. Compressed archives such as .tar.gz are
+// decoded before they reach the processor.
+type TarProcessor struct {
+}
+
+func (t *TarProcessor) ValidateSchema(i *processor.Document) error {
+	if i.Type != processor.DocumentTar {
+		return fmt.Errorf("expected document type: %v, actual document type: %v", processor.DocumentTar, i.Type)
+	}
+
+	_, err := tar.NewReader(bytes.NewReader(i.Blob)).Next()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid tar archive: %w", err)
+	}
+	return nil
+}
+
+// Unpack returns a document for each regular file of the archive
+func (t *TarProcessor) Unpack(i *processor.Document) ([]*processor.Document, error) {
+	if i.Type != processor.DocumentTar {
+		return nil, fmt.Errorf("expected document type: %v, actual document type: %v", processor.DocumentTar, i.Type)
+	}
+	if err := checkDepth(i); err != nil {
+		return nil, err
+	}
+
+	var documents []*processor.Document
+	var total int64
+	reader := tar.NewReader(bytes.NewReader(i.Blob))
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tar archive: %w", err)
+		}
+		if !header.FileInfo().Mode().IsRegular() {
+			continue
+		}
+		if len(documents) >= MaxEntries {
+			return nil, fmt.Errorf("archive has more than %d entries", MaxEntries)
+		}
+		if header.Size > MaxEntrySize {
+			return nil, fmt.Errorf("entry %s of %d bytes exceeds the limit of %d bytes", header.Name, header.Size, MaxEntrySize)
+		}
+		blob, err := readEntry(reader, header.Name)
+		if err != nil {
+			return nil, err
+		}
+		if total, err = addSize(total, blob); err != nil {
+			return nil, err
+		}
+		documents = append(documents, entryDocument(i, header.Name, blob))
+	}
+	return documents, nil
+}
+
+// ZipProcessor unpacks zip archives
+type ZipProcessor struct {
+}
+
+func (z *ZipProcessor) ValidateSchema(i *processor.Document) error {
+	if i.Type != processor.DocumentZip {
+		return fmt.Errorf("expected document type: %v, actual document type: %v", processor.DocumentZip, i.Type)
+	}
+
+	if _, err := zip.NewReader(bytes.NewReader(i.Blob), int64(len(i.Blob))); err != nil {
+		return fmt.Errorf("invalid zip archive: %w", err)
+	}
+	return nil
+}
+
+// Unpack returns a document for each regular file of the archive
+func (z *ZipProcessor) Unpack(i *processor.Document) ([]*processor.Document, error) {
+	if i.Type != processor.DocumentZip {
+		return nil, fmt.Errorf("expected document type: %v, actual document type: %v", processor.DocumentZip, i.Type)
+	}
+	if err := checkDepth(i); err != nil {
+		return nil, err
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(i.Blob), int64(len(i.Blob)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid zip archive: %w", err)
+	}
+
+	var documents []*processor.Document
+	var total int64
+	for _, file := range reader.File {
+		if !file.Mode().IsRegular() {
+			continue
+		}
+		if len(documents) >= MaxEntries {
+			return nil, fmt.Errorf("archive has more than %d entries", MaxEntries)
+		}
+		// the size in the header is checked up front, and enforced while
+		// reading as it can't be trusted
+		if file.UncompressedSize64 > uint64(MaxEntrySize) {
+			return nil, fmt.Errorf("entry %s of %d bytes exceeds the limit of %d bytes", file.Name, file.UncompressedSize64, MaxEntrySize)
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open entry %s: %w", file.Name, err)
+		}
+		blob, err := readEntry(rc, file.Name)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		if total, err = addSize(total, blob); err != nil {
+			return nil, err
+		}
+		documents = append(documents, entryDocument(i, file.Name, blob))
+	}
+	return documents, nil
+}
+
+// checkDepth rejects archives nested deeper than MaxDepth
+func checkDepth(i *processor.Document) error {
+	if depth := strings.Count(i.SourceInformation.Source, SourceSeparator); depth >= MaxDepth {
+		return fmt.Errorf("archive %s is nested deeper than %d archives", i.SourceInformation.Source, MaxDepth)
+	}
+	return nil
+}
+
+func readEntry(r io.Reader, name string) ([]byte, error) {
+	blob, err := io.ReadAll(io.LimitReader(r, MaxEntrySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read entry %s: %w", name, err)
+	}
+	if int64(len(blob)) > MaxEntrySize {
+		return nil, fmt.Errorf("entry %s exceeds the limit of %d bytes", name, MaxEntrySize)
+	}
+	return blob, nil
+}
+
+// addSize adds the size of an entry to the total size of the entries read so
+// far, rejecting archives whose entries exceed MaxTotalSize together
+func addSize(total int64, blob []byte) (int64, error) {
+	total += int64(len(blob))
+	if total > MaxTotalSize {
+		return total, fmt.Errorf("archive entries exceed the total limit of %d bytes", MaxTotalSize)
+	}
+	return total, nil
+}
+
+// entryDocument returns the document of an entry, whose encoding, format and
+// type are guessed when it is processed
+func entryDocument(i *processor.Document, name string, blob []byte) *processor.Document {
+	return &processor.Document{
+		Blob:   blob,
+		Type:   processor.DocumentUnknown,
+		Format: processor.FormatUnknown,
+		SourceInformation: processor.SourceInformation{
+			Collector: i.SourceInformation.Collector,
+			Source:    i.SourceInformation.Source + SourceSeparator + path.Clean(strings.TrimPrefix(name, "./")),
+		},
+	}
+}
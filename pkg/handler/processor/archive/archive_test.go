@@ -0,0 +1,186 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+type entry struct {
+	name string
+	blob string
+}
+
+func tarBlob(t *testing.T, entries ...entry) []byte {
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	if err := w.WriteHeader(&tar.Header{Name: "sboms/", Mode: 0o755, Typeflag: tar.TypeDir}); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if err := w.WriteHeader(&tar.Header{Name: e.name, Mode: 0o644, Size: int64(len(e.blob)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(e.blob)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func zipBlob(t *testing.T, entries ...entry) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	if _, err := w.Create("attestations/"); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		f, err := w.Create(e.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(e.blob)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func archiveDoc(docType processor.DocumentType, source string, blob []byte) *processor.Document {
+	return &processor.Document{
+		Blob:   blob,
+		Type:   docType,
+		Format: processor.FormatUnknown,
+		SourceInformation: processor.SourceInformation{
+			Collector: "TestCollector",
+			Source:    source,
+		},
+	}
+}
+
+func entryDoc(source string, blob string) *processor.Document {
+	return &processor.Document{
+		Blob:   []byte(blob),
+		Type:   processor.DocumentUnknown,
+		Format: processor.FormatUnknown,
+		SourceInformation: processor.SourceInformation{
+			Collector: "TestCollector",
+			Source:    source,
+		},
+	}
+}
+
+func TestArchiveProcessors_Unpack(t *testing.T) {
+	spdx := entry{"./sboms/app.spdx.json", `{"spdxVersion": "SPDX-2.3"}`}
+	intoto := entry{"attestations/app.intoto.jsonl", `{"payloadType": "application/vnd.in-toto+json"}`}
+
+	testCases := []struct {
+		name      string
+		processor processor.DocumentProcessor
+		doc       *processor.Document
+		expected  []*processor.Document
+		expectErr bool
+	}{{
+		name:      "tar",
+		processor: &TarProcessor{},
+		doc:       archiveDoc(processor.DocumentTar, "release/sbom.tar.gz", tarBlob(t, spdx)),
+		expected:  []*processor.Document{entryDoc("release/sbom.tar.gz!sboms/app.spdx.json", spdx.blob)},
+	}, {
+		name:      "zip",
+		processor: &ZipProcessor{},
+		doc:       archiveDoc(processor.DocumentZip, "release/attestations.zip", zipBlob(t, intoto, spdx)),
+		expected: []*processor.Document{
+			entryDoc("release/attestations.zip!attestations/app.intoto.jsonl", intoto.blob),
+			entryDoc("release/attestations.zip!sboms/app.spdx.json", spdx.blob),
+		},
+	}, {
+		name:      "zip nested in a tar",
+		processor: &ZipProcessor{},
+		doc:       archiveDoc(processor.DocumentZip, "release.tar!attestations.zip", zipBlob(t, intoto)),
+		expected:  []*processor.Document{entryDoc("release.tar!attestations.zip!attestations/app.intoto.jsonl", intoto.blob)},
+	}, {
+		name:      "nested too deep",
+		processor: &TarProcessor{},
+		doc:       archiveDoc(processor.DocumentTar, "a.tar!b.tar!c.tar!d.tar", tarBlob(t, spdx)),
+		expectErr: true,
+	}, {
+		name:      "entry too large",
+		processor: &ZipProcessor{},
+		doc:       archiveDoc(processor.DocumentZip, "bomb.zip", zipBlob(t, entry{"bomb.json", string(make([]byte, 2048))})),
+		expectErr: true,
+	}, {
+		name:      "entries too large together",
+		processor: &TarProcessor{},
+		doc: archiveDoc(processor.DocumentTar, "bomb.tar", tarBlob(t,
+			entry{"a.json", string(make([]byte, 1024))},
+			entry{"b.json", string(make([]byte, 1024))},
+			entry{"c.json", string(make([]byte, 1024))})),
+		expectErr: true,
+	}, {
+		name:      "too many entries",
+		processor: &TarProcessor{},
+		doc: archiveDoc(processor.DocumentTar, "many.tar", func() []byte {
+			var entries []entry
+			for i := 0; i < 11; i++ {
+				entries = append(entries, entry{fmt.Sprintf("sbom-%d.json", i), "{}"})
+			}
+			return tarBlob(t, entries...)
+		}()),
+		expectErr: true,
+	}, {
+		name:      "incorrect type",
+		processor: &TarProcessor{},
+		doc:       archiveDoc(processor.DocumentZip, "attestations.zip", zipBlob(t, intoto)),
+		expectErr: true,
+	}}
+
+	maxEntrySize, maxTotalSize, maxEntries := MaxEntrySize, MaxTotalSize, MaxEntries
+	MaxEntrySize, MaxTotalSize, MaxEntries = 1024, 2048, 10
+	defer func() {
+		MaxEntrySize, MaxTotalSize, MaxEntries = maxEntrySize, maxTotalSize, maxEntries
+	}()
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.processor.ValidateSchema(tt.doc); err != nil {
+				if tt.expectErr {
+					return
+				}
+				t.Fatalf("unexpected error validating: %v", err)
+			}
+			actual, err := tt.processor.Unpack(tt.doc)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("Unpack() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("Unpack() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
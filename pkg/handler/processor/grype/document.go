@@ -0,0 +1,100 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grype
+
+// Document is the subset of the native Grype JSON format that GUAC ingests,
+// see https://github.com/anchore/grype/tree/main/grype/presenter/models
+type Document struct {
+	Matches    []Match    `json:"matches"`
+	Descriptor Descriptor `json:"descriptor"`
+}
+
+// Match is a vulnerability matched against a package
+type Match struct {
+	Vulnerability          Vulnerability         `json:"vulnerability"`
+	RelatedVulnerabilities []VulnerabilityRecord `json:"relatedVulnerabilities"`
+	Artifact               Package               `json:"artifact"`
+}
+
+// VulnerabilityRecord is a vulnerability as recorded in a namespace of the
+// vulnerability database
+type VulnerabilityRecord struct {
+	ID         string `json:"id"`
+	DataSource string `json:"dataSource"`
+	Namespace  string `json:"namespace,omitempty"`
+	Severity   string `json:"severity,omitempty"`
+	CVSS       []CVSS `json:"cvss"`
+}
+
+// Vulnerability is the vulnerability matched, along with its fix
+type Vulnerability struct {
+	VulnerabilityRecord
+	Fix Fix `json:"fix"`
+}
+
+// Fix is the state of the fix of the vulnerability
+type Fix struct {
+	Versions []string `json:"versions"`
+	State    string   `json:"state"`
+}
+
+// CVSS is a score a source assigned to the vulnerability
+type CVSS struct {
+	Source  string      `json:"source,omitempty"`
+	Type    string      `json:"type,omitempty"`
+	Version string      `json:"version"`
+	Vector  string      `json:"vector"`
+	Metrics CVSSMetrics `json:"metrics"`
+}
+
+// CVSSMetrics are the scores of a CVSS vector
+type CVSSMetrics struct {
+	BaseScore float64 `json:"baseScore"`
+}
+
+// Package is the package the vulnerability was matched against
+type Package struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Type    string `json:"type"`
+	PURL    string `json:"purl"`
+}
+
+// Descriptor identifies the version of Grype and of its database that
+// created the document
+type Descriptor struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Timestamp string `json:"timestamp"`
+	DB        DB     `json:"db"`
+}
+
+// DB describes the vulnerability database. Grype versions using the v5
+// schema set the fields directly, later versions set the status.
+type DB struct {
+	Built         string      `json:"built,omitempty"`
+	SchemaVersion interface{} `json:"schemaVersion,omitempty"`
+	Location      string      `json:"location,omitempty"`
+	Status        *DBStatus   `json:"status,omitempty"`
+}
+
+// DBStatus is the status of the vulnerability database
+type DBStatus struct {
+	SchemaVersion string `json:"schemaVersion"`
+	From          string `json:"from"`
+	Built         string `json:"built"`
+}
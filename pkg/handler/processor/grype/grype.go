@@ -0,0 +1,80 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grype
+
+import (
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+type GrypeProcessor struct{}
+
+func (p *GrypeProcessor) ValidateSchema(d *processor.Document) error {
+	if d.Type != processor.DocumentGrype {
+		return fmt.Errorf("expected document type: %v, actual document type: %v", processor.DocumentGrype, d.Type)
+	}
+
+	switch d.Format {
+	case processor.FormatJSON:
+		_, err := ParseDocument(d.Blob)
+		return err
+	}
+
+	return fmt.Errorf("unable to support parsing of Grype document format: %v", d.Format)
+}
+
+func (p *GrypeProcessor) Unpack(d *processor.Document) ([]*processor.Document, error) {
+	if d.Type != processor.DocumentGrype {
+		return nil, fmt.Errorf("expected document type: %v, actual document type: %v", processor.DocumentGrype, d.Type)
+	}
+
+	return []*processor.Document{}, nil
+}
+
+// ParseDocument decodes a native Grype JSON document
+func ParseDocument(blob []byte) (*Document, error) {
+	var doc Document
+	if err := json.Unmarshal(blob, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Grype document: %w", err)
+	}
+	if doc.Matches == nil {
+		return nil, fmt.Errorf("no matches found in Grype document")
+	}
+	return &doc, nil
+}
+
+// DBVersion returns the version of the vulnerability database, which is the
+// time it was built, falling back to its schema version
+func (d DB) DBVersion() string {
+	if d.Status != nil {
+		if d.Status.Built != "" {
+			return d.Status.Built
+		}
+		return d.Status.SchemaVersion
+	}
+	if d.Built != "" {
+		return d.Built
+	}
+	if d.SchemaVersion != nil {
+		return fmt.Sprintf("%v", d.SchemaVersion)
+	}
+	return ""
+}
@@ -0,0 +1,148 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grype
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/guacsec/guac/internal/testing/testdata"
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+func TestGrypeProcessor_ValidateSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     *processor.Document
+		wantErr bool
+	}{
+		{
+			name: "valid Grype document",
+			doc: &processor.Document{
+				Blob:   testdata.GrypeExampleAlpine,
+				Type:   processor.DocumentGrype,
+				Format: processor.FormatJSON,
+			},
+		},
+		{
+			name: "incorrect type",
+			doc: &processor.Document{
+				Blob:   testdata.GrypeExampleAlpine,
+				Type:   processor.DocumentUnknown,
+				Format: processor.FormatJSON,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid Grype document",
+			doc: &processor.Document{
+				Blob:   []byte(`{"abc": "def"}`),
+				Type:   processor.DocumentGrype,
+				Format: processor.FormatJSON,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid Grype document format",
+			doc: &processor.Document{
+				Blob:   testdata.GrypeExampleAlpine,
+				Type:   processor.DocumentGrype,
+				Format: processor.FormatUnknown,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &GrypeProcessor{}
+			if err := p.ValidateSchema(tt.doc); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGrypeProcessor_Unpack(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     *processor.Document
+		want    []*processor.Document
+		wantErr bool
+	}{
+		{
+			name: "Grype document",
+			doc: &processor.Document{
+				Type: processor.DocumentGrype,
+			},
+			want: []*processor.Document{},
+		},
+		{
+			name: "incorrect type",
+			doc: &processor.Document{
+				Type: processor.DocumentUnknown,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &GrypeProcessor{}
+			got, err := p.Unpack(tt.doc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Unpack() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unpack() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_DBVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		db   string
+		want string
+	}{
+		{
+			name: "v5 database",
+			db:   `{"built": "2024-03-11T01:29:13Z", "schemaVersion": 5}`,
+			want: "2024-03-11T01:29:13Z",
+		},
+		{
+			name: "v5 database without build time",
+			db:   `{"schemaVersion": 5}`,
+			want: "5",
+		},
+		{
+			name: "v6 database status",
+			db:   `{"status": {"schemaVersion": "v6.0.2", "from": "https://grype.anchore.io/databases/v6/vulnerability-db_v6.0.2.tar.zst", "built": "2025-01-20T01:31:41Z"}}`,
+			want: "2025-01-20T01:31:41Z",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseDocument([]byte(`{"matches": [], "descriptor": {"db": ` + tt.db + `}}`))
+			if err != nil {
+				t.Fatalf("ParseDocument() error = %v", err)
+			}
+			if got := doc.Descriptor.DB.DBVersion(); got != tt.want {
+				t.Errorf("DBVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
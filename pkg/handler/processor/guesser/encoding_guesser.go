@@ -28,6 +28,7 @@ import (
 const (
 	bzipMimeType = "application/x-bzip2"
 	zstdMimeType = "application/zstd"
+	gzipMimeType = "application/x-gzip"
 	blankType    = ""
 )
 
@@ -47,6 +48,8 @@ func GuessEncoding(ctx context.Context, d *processor.Document) error {
 		d.Encoding = processor.EncodingBzip2
 	case zstdMimeType:
 		d.Encoding = processor.EncodingZstd
+	case gzipMimeType:
+		d.Encoding = processor.EncodingGzip
 	default:
 	}
 	if d.Encoding != "" {
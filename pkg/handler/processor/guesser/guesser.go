@@ -17,35 +17,92 @@ package guesser
 
 import (
 	"context"
+	"sort"
 
 	"github.com/guacsec/guac/pkg/handler/processor"
 	"github.com/guacsec/guac/pkg/logging"
 )
 
-func GuessDocument(ctx context.Context, d *processor.Document) (processor.DocumentType, processor.FormatType, error) {
+// Candidate is a document type a type guesser matched the document with
+type Candidate struct {
+	// Guesser is the name the type guesser was registered with
+	Guesser    string
+	Type       processor.DocumentType
+	Confidence int
+}
+
+// Guess is the outcome of guessing the format and type of a document
+type Guess struct {
+	Type   processor.DocumentType
+	Format processor.FormatType
+	// FormatGuesser is the name of the format guesser that recognized the
+	// format, empty if the format was already known or not recognized
+	FormatGuesser string
+	// Match is the candidate with the highest confidence, ties are broken by
+	// the name of the guesser. It is the zero value if the type was already
+	// known or no type guesser matched.
+	Match Candidate
+	// Alternatives are the other candidates, by descending confidence
+	Alternatives []Candidate
+}
+
+// GuessDocument guesses the format and the type of the document, unless they
+// are already set. Guessers are evaluated in the order of their names, and
+// all type guessers are evaluated so that the type matched with the highest
+// confidence is picked regardless of registration order.
+func GuessDocument(ctx context.Context, d *processor.Document) (*Guess, error) {
 	logger := logging.FromContext(ctx)
-	format := d.Format
+	guess := &Guess{
+		Type:   d.Type,
+		Format: d.Format,
+	}
 
-	if format == processor.FormatUnknown {
-		for name, g := range documentFormatGuessers {
-			if f := g.GuessFormat(d.Blob); f != processor.FormatUnknown {
-				format = f
+	if guess.Format == processor.FormatUnknown {
+		for _, name := range sortedNames(documentFormatGuessers) {
+			if f := documentFormatGuessers[name].GuessFormat(d.Blob); f != processor.FormatUnknown {
+				guess.Format = f
+				guess.FormatGuesser = name
 				logger.Debugf("Format guesser %v guessed document format %v", name, f)
 				break
 			}
 		}
 	}
 
-	documentType := d.Type
-	if documentType == processor.DocumentUnknown {
-		for name, g := range documentTypeGuessers {
-			if t := g.GuessDocumentType(d.Blob, format); t != processor.DocumentUnknown {
-				documentType = t
-				logger.Debugf("DocumentType guesser %v guessed document format %v", name, t)
-				break
+	if guess.Type == processor.DocumentUnknown {
+		var candidates []Candidate
+		for _, name := range sortedNames(documentTypeGuessers) {
+			g := documentTypeGuessers[name]
+			if t := g.GuessDocumentType(d.Blob, guess.Format); t != processor.DocumentUnknown {
+				candidates = append(candidates, Candidate{
+					Guesser:    name,
+					Type:       t,
+					Confidence: confidence(g, t),
+				})
+			}
+		}
+		// stable so that candidates of equal confidence stay in name order
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].Confidence > candidates[j].Confidence
+		})
+		if len(candidates) > 0 {
+			guess.Match = candidates[0]
+			guess.Alternatives = candidates[1:]
+			guess.Type = guess.Match.Type
+			logger.Debugf("DocumentType guesser %v guessed document type %v with confidence %d", guess.Match.Guesser, guess.Type, guess.Match.Confidence)
+			for _, alt := range guess.Alternatives {
+				logger.Debugf("DocumentType guesser %v also matched document type %v with confidence %d", alt.Guesser, alt.Type, alt.Confidence)
 			}
 		}
 	}
 
-	return documentType, format, nil
+	return guess, nil
+}
+
+func sortedNames[T any](guessers map[string]T) []string {
+	names := make([]string, 0, len(guessers))
+	for name := range guessers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
@@ -19,6 +19,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/guacsec/guac/internal/testing/testdata"
 	"github.com/guacsec/guac/pkg/handler/processor"
 )
@@ -69,6 +70,16 @@ func Test_GuessDocument(t *testing.T) {
 		},
 		expectedType:   processor.DocumentSPDX,
 		expectedFormat: processor.FormatJSON,
+	}, {
+		name: "valid spdx 3 Document",
+		document: &processor.Document{
+			Blob:              testdata.Spdx3Example,
+			Type:              processor.DocumentUnknown,
+			Format:            processor.FormatUnknown,
+			SourceInformation: processor.SourceInformation{},
+		},
+		expectedType:   processor.DocumentSPDX3,
+		expectedFormat: processor.FormatJSON,
 	}, {
 		name: "valid big spdx Document",
 		document: &processor.Document{
@@ -209,15 +220,92 @@ func Test_GuessDocument(t *testing.T) {
 		},
 		expectedType:   processor.DocumentCsaf,
 		expectedFormat: processor.FormatJSON,
+	}, {
+		name: "valid Syft Document",
+		document: &processor.Document{
+			Blob:              testdata.SyftExampleAlpine,
+			Type:              processor.DocumentUnknown,
+			Format:            processor.FormatUnknown,
+			SourceInformation: processor.SourceInformation{},
+		},
+		expectedType:   processor.DocumentSyft,
+		expectedFormat: processor.FormatJSON,
+	}, {
+		name: "valid Trivy Document",
+		document: &processor.Document{
+			Blob:              testdata.TrivyExampleAlpine,
+			Type:              processor.DocumentUnknown,
+			Format:            processor.FormatUnknown,
+			SourceInformation: processor.SourceInformation{},
+		},
+		expectedType:   processor.DocumentTrivy,
+		expectedFormat: processor.FormatJSON,
+	}, {
+		name: "valid Grype Document",
+		document: &processor.Document{
+			Blob:              testdata.GrypeExampleAlpine,
+			Type:              processor.DocumentUnknown,
+			Format:            processor.FormatUnknown,
+			SourceInformation: processor.SourceInformation{},
+		},
+		expectedType:   processor.DocumentGrype,
+		expectedFormat: processor.FormatJSON,
+	}, {
+		name: "valid SARIF Document",
+		document: &processor.Document{
+			Blob:              testdata.SARIFExampleCodeQLSemgrep,
+			Type:              processor.DocumentUnknown,
+			Format:            processor.FormatUnknown,
+			SourceInformation: processor.SourceInformation{},
+		},
+		expectedType:   processor.DocumentSARIF,
+		expectedFormat: processor.FormatJSON,
 	}}
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
-			documentType, documentFormat, err := GuessDocument(context.TODO(), tt.document)
+			guess, err := GuessDocument(context.TODO(), tt.document)
 			if err != nil {
 				t.Errorf("unexpected error: %v", err)
-			} else if documentType != tt.expectedType || documentFormat != tt.expectedFormat {
-				t.Errorf("document type, format: got %v, %v, expected %v, %v", documentType, documentFormat, tt.expectedType, tt.expectedFormat)
+			} else if guess.Type != tt.expectedType || guess.Format != tt.expectedFormat {
+				t.Errorf("document type, format: got %v, %v, expected %v, %v", guess.Type, guess.Format, tt.expectedType, tt.expectedFormat)
 			}
 		})
 	}
 }
+
+func Test_GuessDocumentAmbiguous(t *testing.T) {
+	// an in-toto statement that also has an ingest predicates field
+	blob := []byte(`{
+		"_type": "https://in-toto.io/Statement/v1",
+		"predicateType": "https://slsa.dev/provenance/v1",
+		"certifyBad": [{"certifyBad": {"justification": "bad"}}]
+	}`)
+	if err := RegisterDocumentTypeGuesser(&IngestPredicatesGuesser{}, "ingest_predicates"); err != nil {
+		t.Fatal(err)
+	}
+	defer delete(documentTypeGuessers, "ingest_predicates")
+
+	want := &Guess{
+		Type:          processor.DocumentITE6SLSA,
+		Format:        processor.FormatJSON,
+		FormatGuesser: "json",
+		Match:         Candidate{Guesser: "ite6", Type: processor.DocumentITE6SLSA, Confidence: ConfidenceDeclared},
+		Alternatives: []Candidate{
+			{Guesser: "ingest_predicates", Type: processor.DocumentIngestPredicates, Confidence: ConfidenceGeneric},
+		},
+	}
+	// the outcome must not depend on the iteration order of the guessers
+	for i := 0; i < 20; i++ {
+		guess, err := GuessDocument(context.TODO(), &processor.Document{
+			Blob:   blob,
+			Type:   processor.DocumentUnknown,
+			Format: processor.FormatUnknown,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if diff := cmp.Diff(want, guess); diff != "" {
+			t.Fatalf("GuessDocument() mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package guesser
+
+import (
+	"bytes"
+
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+var (
+	// zip archives start with a local file header, or with the end of
+	// central directory record if they are empty
+	zipMagic      = []byte("PK\x03\x04")
+	zipEmptyMagic = []byte("PK\x05\x06")
+	// POSIX and GNU tar headers have the ustar magic at offset 257
+	tarMagic       = []byte("ustar")
+	tarMagicOffset = 257
+)
+
+type archiveTypeGuesser struct{}
+
+func (_ *archiveTypeGuesser) GuessDocumentType(blob []byte, format processor.FormatType) processor.DocumentType {
+	if format != processor.FormatUnknown {
+		return processor.DocumentUnknown
+	}
+	if bytes.HasPrefix(blob, zipMagic) || bytes.HasPrefix(blob, zipEmptyMagic) {
+		return processor.DocumentZip
+	}
+	if len(blob) >= tarMagicOffset+len(tarMagic) && bytes.Equal(blob[tarMagicOffset:tarMagicOffset+len(tarMagic)], tarMagic) {
+		return processor.DocumentTar
+	}
+	return processor.DocumentUnknown
+}
+
+func (_ *archiveTypeGuesser) Confidence(documentType processor.DocumentType) int {
+	return ConfidenceDeclared
+}
@@ -0,0 +1,70 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package guesser
+
+import (
+	"testing"
+
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+func Test_ArchiveTypeGuesser(t *testing.T) {
+	tarHeader := make([]byte, 512)
+	copy(tarHeader, "sbom.json")
+	copy(tarHeader[257:], "ustar\x0000")
+
+	testCases := []struct {
+		name     string
+		blob     []byte
+		format   processor.FormatType
+		expected processor.DocumentType
+	}{{
+		name:     "JSON document",
+		blob:     []byte(`{ "abc": "def"}`),
+		format:   processor.FormatJSON,
+		expected: processor.DocumentUnknown,
+	}, {
+		name:     "zip archive",
+		blob:     []byte("PK\x03\x04\x14\x00\x08\x00"),
+		format:   processor.FormatUnknown,
+		expected: processor.DocumentZip,
+	}, {
+		name:     "empty zip archive",
+		blob:     []byte("PK\x05\x06\x00\x00\x00\x00"),
+		format:   processor.FormatUnknown,
+		expected: processor.DocumentZip,
+	}, {
+		name:     "tar archive",
+		blob:     tarHeader,
+		format:   processor.FormatUnknown,
+		expected: processor.DocumentTar,
+	}, {
+		name:     "unstructured text",
+		blob:     []byte("unstructured text"),
+		format:   processor.FormatUnknown,
+		expected: processor.DocumentUnknown,
+	}}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			guesser := &archiveTypeGuesser{}
+			f := guesser.GuessDocumentType(tt.blob, tt.format)
+			if f != tt.expected {
+				t.Errorf("got the wrong format, got %v, expected %v", f, tt.expected)
+			}
+		})
+	}
+}
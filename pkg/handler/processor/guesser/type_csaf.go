@@ -34,3 +34,7 @@ func (_ *csafTypeGuesser) GuessDocumentType(blob []byte, format processor.Format
 	}
 	return processor.DocumentUnknown
 }
+
+func (_ *csafTypeGuesser) Confidence(documentType processor.DocumentType) int {
+	return ConfidenceStructural
+}
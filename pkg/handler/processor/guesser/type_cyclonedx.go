@@ -50,3 +50,7 @@ func (_ *cycloneDXTypeGuesser) GuessDocumentType(blob []byte, format processor.F
 	}
 	return processor.DocumentUnknown
 }
+
+func (_ *cycloneDXTypeGuesser) Confidence(documentType processor.DocumentType) int {
+	return ConfidenceDeclared
+}
@@ -29,3 +29,7 @@ func (_ *depsDevTypeGuesser) GuessDocumentType(blob []byte, format processor.For
 	}
 	return processor.DocumentUnknown
 }
+
+func (_ *depsDevTypeGuesser) Confidence(documentType processor.DocumentType) int {
+	return ConfidenceStructural
+}
@@ -31,3 +31,7 @@ func (_ *dsseTypeGuesser) GuessDocumentType(blob []byte, format processor.Format
 	}
 	return processor.DocumentUnknown
 }
+
+func (_ *dsseTypeGuesser) Confidence(documentType processor.DocumentType) int {
+	return ConfidenceStructural
+}
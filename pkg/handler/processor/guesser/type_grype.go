@@ -0,0 +1,37 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package guesser
+
+import (
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+type grypeTypeGuesser struct{}
+
+func (_ *grypeTypeGuesser) GuessDocumentType(blob []byte, format processor.FormatType) processor.DocumentType {
+	switch format {
+	case processor.FormatJSON:
+		var doc anchoreDocument
+		if err := json.Unmarshal(blob, &doc); err == nil && doc.Matches != nil && doc.Descriptor.Name == "grype" {
+			return processor.DocumentGrype
+		}
+	}
+	return processor.DocumentUnknown
+}
+
+func (_ *grypeTypeGuesser) Confidence(documentType processor.DocumentType) int {
+	return ConfidenceDeclared
+}
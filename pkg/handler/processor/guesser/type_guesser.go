@@ -24,12 +24,19 @@ import (
 func init() {
 	_ = RegisterDocumentTypeGuesser(&ite6TypeGuesser{}, "ite6")
 	_ = RegisterDocumentTypeGuesser(&dsseTypeGuesser{}, "dsse")
+	_ = RegisterDocumentTypeGuesser(&sigstoreBundleTypeGuesser{}, "sigstore-bundle")
+	_ = RegisterDocumentTypeGuesser(&archiveTypeGuesser{}, "archive")
 	_ = RegisterDocumentTypeGuesser(&spdxTypeGuesser{}, "spdx")
+	_ = RegisterDocumentTypeGuesser(&spdx3TypeGuesser{}, "spdx3")
 	_ = RegisterDocumentTypeGuesser(&scorecardTypeGuesser{}, "scorecard")
 	_ = RegisterDocumentTypeGuesser(&cycloneDXTypeGuesser{}, "cyclonedx")
 	_ = RegisterDocumentTypeGuesser(&openVexTypeGuesser{}, "openvex")
 	_ = RegisterDocumentTypeGuesser(&depsDevTypeGuesser{}, "deps.dev")
 	_ = RegisterDocumentTypeGuesser(&csafTypeGuesser{}, "csaf")
+	_ = RegisterDocumentTypeGuesser(&syftTypeGuesser{}, "syft")
+	_ = RegisterDocumentTypeGuesser(&trivyTypeGuesser{}, "trivy")
+	_ = RegisterDocumentTypeGuesser(&grypeTypeGuesser{}, "grype")
+	_ = RegisterDocumentTypeGuesser(&sarifTypeGuesser{}, "sarif")
 }
 
 // DocumentTypeGuesser guesses the document type based on the blob and format given
@@ -39,6 +46,39 @@ type DocumentTypeGuesser interface {
 	GuessDocumentType(blob []byte, format processor.FormatType) processor.DocumentType
 }
 
+// Confidence levels of the document types guessed. A document matched by
+// several guessers is given the type guessed with the highest confidence.
+const (
+	// ConfidenceDeclared is for documents that declare their type, e.g.
+	// through a media type, a spec version or a predicate type
+	ConfidenceDeclared = 90
+	// ConfidenceStructural is for documents recognized by fields specific
+	// to the type
+	ConfidenceStructural = 60
+	// ConfidenceGeneric is for guesses that documents of other types may
+	// also satisfy
+	ConfidenceGeneric = 30
+	// DefaultConfidence is the confidence of guessers that don't implement
+	// ScoredDocumentTypeGuesser
+	DefaultConfidence = ConfidenceStructural
+)
+
+// ScoredDocumentTypeGuesser is a DocumentTypeGuesser that reports how
+// confident it is in the types it guesses
+type ScoredDocumentTypeGuesser interface {
+	DocumentTypeGuesser
+	// Confidence returns the confidence, between 0 and 100, that a document
+	// the guesser guessed to be of documentType is of that type
+	Confidence(documentType processor.DocumentType) int
+}
+
+func confidence(g DocumentTypeGuesser, documentType processor.DocumentType) int {
+	if scored, ok := g.(ScoredDocumentTypeGuesser); ok {
+		return scored.Confidence(documentType)
+	}
+	return DefaultConfidence
+}
+
 var (
 	documentTypeGuessers = map[string]DocumentTypeGuesser{}
 )
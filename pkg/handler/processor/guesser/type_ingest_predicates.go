@@ -35,3 +35,8 @@ func (_ *IngestPredicatesGuesser) GuessDocumentType(blob []byte, format processo
 	}
 	return processor.DocumentUnknown
 }
+
+func (_ *IngestPredicatesGuesser) Confidence(documentType processor.DocumentType) int {
+	// any JSON object with a field named after a predicate matches
+	return ConfidenceGeneric
+}
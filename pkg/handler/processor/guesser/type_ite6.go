@@ -40,9 +40,24 @@ func (_ *ite6TypeGuesser) GuessDocumentType(blob []byte, format processor.Format
 				return processor.DocumentITE6Generic
 			} else if strings.HasPrefix(statement.PredicateType, "https://in-toto.io/attestation/vuln/v0.1") {
 				return processor.DocumentITE6Vul
+			} else if strings.HasPrefix(statement.PredicateType, "https://in-toto.io/attestation/test-result/v0.1") ||
+				strings.HasPrefix(statement.PredicateType, "https://in-toto.io/attestation/link/v0.3") ||
+				strings.HasPrefix(statement.PredicateType, "https://in-toto.io/attestation/scai/attribute-report") ||
+				strings.HasPrefix(statement.PredicateType, "https://in-toto.io/attestation/release/v0.1") ||
+				strings.HasPrefix(statement.PredicateType, "https://in-toto.io/attestation/runtime-trace/v0.1") {
+				return processor.DocumentITE6Attestation
 			}
 			return processor.DocumentITE6Generic
 		}
 	}
 	return processor.DocumentUnknown
 }
+
+func (_ *ite6TypeGuesser) Confidence(documentType processor.DocumentType) int {
+	// statements of known predicate types declare their type, other
+	// statements are recognized by their statement type only
+	if documentType == processor.DocumentITE6Generic {
+		return ConfidenceStructural
+	}
+	return ConfidenceDeclared
+}
@@ -55,6 +55,14 @@ func Test_Ite6TypeGuesser(t *testing.T) {
 		name:     "valid Vuln ITE6 Document",
 		blob:     testdata.ITE6VulnExample,
 		expected: processor.DocumentITE6Vul,
+	}, {
+		name:     "valid test result ITE6 Document",
+		blob:     []byte(`{"_type": "https://in-toto.io/Statement/v1", "predicateType": "https://in-toto.io/attestation/test-result/v0.1"}`),
+		expected: processor.DocumentITE6Attestation,
+	}, {
+		name:     "valid SCAI ITE6 Document",
+		blob:     []byte(`{"_type": "https://in-toto.io/Statement/v1", "predicateType": "https://in-toto.io/attestation/scai/attribute-report/v0.2"}`),
+		expected: processor.DocumentITE6Attestation,
 	}}
 
 	for _, tt := range testCases {
@@ -35,3 +35,7 @@ func (_ *openVexTypeGuesser) GuessDocumentType(blob []byte, format processor.For
 	}
 	return processor.DocumentUnknown
 }
+
+func (_ *openVexTypeGuesser) Confidence(documentType processor.DocumentType) int {
+	return ConfidenceStructural
+}
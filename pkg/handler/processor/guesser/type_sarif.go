@@ -0,0 +1,50 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package guesser
+
+import (
+	"strings"
+
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+type sarifTypeGuesser struct{}
+
+type sarifDocument struct {
+	Schema  string         `json:"$schema"`
+	Version string         `json:"version"`
+	Runs    *[]interface{} `json:"runs"`
+}
+
+func (_ *sarifTypeGuesser) GuessDocumentType(blob []byte, format processor.FormatType) processor.DocumentType {
+	switch format {
+	case processor.FormatJSON:
+		var doc sarifDocument
+		if err := json.Unmarshal(blob, &doc); err != nil || doc.Runs == nil || doc.Version != "2.1.0" {
+			return processor.DocumentUnknown
+		}
+		// the schema is optional, tools that omit it are recognized by the
+		// SARIF version along with the runs
+		if doc.Schema == "" || strings.Contains(strings.ToLower(doc.Schema), "sarif") {
+			return processor.DocumentSARIF
+		}
+	}
+	return processor.DocumentUnknown
+}
+
+func (_ *sarifTypeGuesser) Confidence(documentType processor.DocumentType) int {
+	return ConfidenceDeclared
+}
@@ -0,0 +1,66 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package guesser
+
+import (
+	"testing"
+
+	"github.com/guacsec/guac/internal/testing/testdata"
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+func Test_sarifTypeGuesser_GuessDocumentType(t *testing.T) {
+	testCases := []struct {
+		name     string
+		blob     []byte
+		expected processor.DocumentType
+	}{{
+		name: "invalid SARIF Document",
+		blob: []byte(`{
+			"abc": "def"
+		}`),
+		expected: processor.DocumentUnknown,
+	}, {
+		name:     "valid SARIF Document",
+		blob:     testdata.SARIFExampleCodeQLSemgrep,
+		expected: processor.DocumentSARIF,
+	}, {
+		name:     "SARIF Document without a schema",
+		blob:     []byte(`{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "Semgrep OSS"}}, "results": []}]}`),
+		expected: processor.DocumentSARIF,
+	}, {
+		name:     "SARIF Document of an older version",
+		blob:     []byte(`{"version": "1.0.0", "runs": []}`),
+		expected: processor.DocumentUnknown,
+	}, {
+		name:     "CycloneDX Document",
+		blob:     testdata.CycloneDXExampleSmallDeps,
+		expected: processor.DocumentUnknown,
+	}, {
+		name:     "Syft Document",
+		blob:     testdata.SyftExampleAlpine,
+		expected: processor.DocumentUnknown,
+	}}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			guesser := &sarifTypeGuesser{}
+			f := guesser.GuessDocumentType(tt.blob, processor.FormatJSON)
+			if f != tt.expected {
+				t.Errorf("got the wrong format, got %v, expected %v", f, tt.expected)
+			}
+		})
+	}
+}
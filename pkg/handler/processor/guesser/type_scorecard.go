@@ -31,3 +31,7 @@ func (_ *scorecardTypeGuesser) GuessDocumentType(blob []byte, format processor.F
 	}
 	return processor.DocumentUnknown
 }
+
+func (_ *scorecardTypeGuesser) Confidence(documentType processor.DocumentType) int {
+	return ConfidenceStructural
+}
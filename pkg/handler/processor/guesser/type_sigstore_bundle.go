@@ -0,0 +1,46 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package guesser
+
+import (
+	"strings"
+
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+const sigstoreBundleMediaType = "application/vnd.dev.sigstore.bundle"
+
+type sigstoreBundleTypeGuesser struct{}
+
+func (_ *sigstoreBundleTypeGuesser) GuessDocumentType(blob []byte, format processor.FormatType) processor.DocumentType {
+	var bundle struct {
+		MediaType    string         `json:"mediaType"`
+		DSSEEnvelope *dsse.Envelope `json:"dsseEnvelope"`
+	}
+	if json.Unmarshal(blob, &bundle) == nil && format == processor.FormatJSON {
+		// the media type is followed by either a version parameter or
+		// a version suffix, e.g. application/vnd.dev.sigstore.bundle.v0.3+json
+		if strings.HasPrefix(bundle.MediaType, sigstoreBundleMediaType) && bundle.DSSEEnvelope != nil {
+			return processor.DocumentSigstoreBundle
+		}
+	}
+	return processor.DocumentUnknown
+}
+
+func (_ *sigstoreBundleTypeGuesser) Confidence(documentType processor.DocumentType) int {
+	return ConfidenceDeclared
+}
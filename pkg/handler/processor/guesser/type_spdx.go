@@ -40,3 +40,7 @@ func (_ *spdxTypeGuesser) GuessDocumentType(blob []byte, format processor.Format
 	}
 	return processor.DocumentUnknown
 }
+
+func (_ *spdxTypeGuesser) Confidence(documentType processor.DocumentType) int {
+	return ConfidenceDeclared
+}
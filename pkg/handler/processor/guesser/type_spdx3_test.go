@@ -0,0 +1,68 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package guesser
+
+import (
+	"testing"
+
+	"github.com/guacsec/guac/internal/testing/testdata"
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+func Test_spdx3TypeGuesser_GuessDocumentType(t *testing.T) {
+	testCases := []struct {
+		name     string
+		blob     []byte
+		expected processor.DocumentType
+	}{{
+		name: "invalid spdx 3 Document",
+		blob: []byte(`{
+			"abc": "def"
+		}`),
+		expected: processor.DocumentUnknown,
+	}, {
+		name:     "spdx 2 Document",
+		blob:     testdata.SpdxExampleSmall,
+		expected: processor.DocumentUnknown,
+	}, {
+		name: "other JSON-LD Document",
+		blob: []byte(`{
+			"@context": "https://schema.org",
+			"@graph": [{"@type": "Thing"}]
+		}`),
+		expected: processor.DocumentUnknown,
+	}, {
+		name: "spdx 3 Document with a list of contexts",
+		blob: []byte(`{
+			"@context": ["https://spdx.org/rdf/3.0.0/spdx-context.jsonld", {"ex": "https://example.com/"}],
+			"@graph": [{"type": "SpdxDocument"}]
+		}`),
+		expected: processor.DocumentSPDX3,
+	}, {
+		name:     "valid spdx 3 Document",
+		blob:     testdata.Spdx3Example,
+		expected: processor.DocumentSPDX3,
+	}}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			guesser := &spdx3TypeGuesser{}
+			f := guesser.GuessDocumentType(tt.blob, processor.FormatJSON)
+			if f != tt.expected {
+				t.Errorf("got the wrong format, got %v, expected %v", f, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package guesser
+
+import (
+	"strings"
+
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+type syftTypeGuesser struct{}
+
+// anchoreDocument holds the fields identifying the native JSON documents of
+// the Anchore tools, Syft and Grype
+type anchoreDocument struct {
+	Artifacts  *[]interface{} `json:"artifacts"`
+	Matches    *[]interface{} `json:"matches"`
+	Descriptor struct {
+		Name string `json:"name"`
+	} `json:"descriptor"`
+	Schema struct {
+		URL string `json:"url"`
+	} `json:"schema"`
+}
+
+func (_ *syftTypeGuesser) GuessDocumentType(blob []byte, format processor.FormatType) processor.DocumentType {
+	switch format {
+	case processor.FormatJSON:
+		var doc anchoreDocument
+		if err := json.Unmarshal(blob, &doc); err != nil || doc.Artifacts == nil {
+			return processor.DocumentUnknown
+		}
+		if doc.Descriptor.Name == "syft" || strings.Contains(doc.Schema.URL, "anchore/syft") {
+			return processor.DocumentSyft
+		}
+	}
+	return processor.DocumentUnknown
+}
+
+func (_ *syftTypeGuesser) Confidence(documentType processor.DocumentType) int {
+	return ConfidenceDeclared
+}
@@ -0,0 +1,43 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package guesser
+
+import (
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+type trivyTypeGuesser struct{}
+
+func (_ *trivyTypeGuesser) GuessDocumentType(blob []byte, format processor.FormatType) processor.DocumentType {
+	switch format {
+	case processor.FormatJSON:
+		// Trivy reports don't name the tool, but their capitalized fields
+		// are specific to it
+		var report struct {
+			SchemaVersion int    `json:"SchemaVersion"`
+			ArtifactName  string `json:"ArtifactName"`
+			ArtifactType  string `json:"ArtifactType"`
+		}
+		if err := json.Unmarshal(blob, &report); err == nil && report.SchemaVersion > 0 && report.ArtifactName != "" && report.ArtifactType != "" {
+			return processor.DocumentTrivy
+		}
+	}
+	return processor.DocumentUnknown
+}
+
+func (_ *trivyTypeGuesser) Confidence(documentType processor.DocumentType) int {
+	return ConfidenceStructural
+}
@@ -18,8 +18,10 @@ package process
 import (
 	"bytes"
 	"compress/bzip2"
+	"compress/gzip"
 	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"path/filepath"
@@ -31,15 +33,22 @@ import (
 	"github.com/guacsec/guac/pkg/events"
 	"github.com/guacsec/guac/pkg/handler/collector"
 	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/handler/processor/archive"
 	"github.com/guacsec/guac/pkg/handler/processor/csaf"
 	"github.com/guacsec/guac/pkg/handler/processor/cyclonedx"
 	"github.com/guacsec/guac/pkg/handler/processor/deps_dev"
 	"github.com/guacsec/guac/pkg/handler/processor/dsse"
+	"github.com/guacsec/guac/pkg/handler/processor/grype"
 	"github.com/guacsec/guac/pkg/handler/processor/guesser"
 	"github.com/guacsec/guac/pkg/handler/processor/ite6"
 	"github.com/guacsec/guac/pkg/handler/processor/open_vex"
+	"github.com/guacsec/guac/pkg/handler/processor/sarif"
 	"github.com/guacsec/guac/pkg/handler/processor/scorecard"
+	"github.com/guacsec/guac/pkg/handler/processor/sigstore_bundle"
 	"github.com/guacsec/guac/pkg/handler/processor/spdx"
+	"github.com/guacsec/guac/pkg/handler/processor/spdx3"
+	"github.com/guacsec/guac/pkg/handler/processor/syft"
+	"github.com/guacsec/guac/pkg/handler/processor/trivy"
 	"github.com/guacsec/guac/pkg/logging"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/klauspost/compress/zstd"
@@ -50,17 +59,53 @@ var (
 	json               = jsoniter.ConfigCompatibleWithStandardLibrary
 )
 
+// MaxDecodedSize is the maximum number of bytes a document and the documents
+// unpacked from it may be decoded into, to guard against compression bombs.
+// Documents exceeding it are rejected.
+var MaxDecodedSize int64 = 1 << 30
+
+var errDecodedSizeExceeded = errors.New("decoded documents exceed the size limit")
+
+// decodeBudget is the number of bytes that are left to decode the documents
+// of a tree, shared by the root document and all the documents unpacked from
+// it
+type decodeBudget struct {
+	remaining int64
+}
+
+func newDecodeBudget() *decodeBudget {
+	return &decodeBudget{remaining: MaxDecodedSize}
+}
+
+// spend charges n decoded bytes to the budget, failing once it is exhausted
+func (b *decodeBudget) spend(n int64) error {
+	if n > b.remaining {
+		return fmt.Errorf("%w of %d bytes", errDecodedSizeExceeded, MaxDecodedSize)
+	}
+	b.remaining -= n
+	return nil
+}
+
 func init() {
 	_ = RegisterDocumentProcessor(&ite6.ITE6Processor{}, processor.DocumentITE6Generic)
 	_ = RegisterDocumentProcessor(&ite6.ITE6Processor{}, processor.DocumentITE6SLSA)
 	_ = RegisterDocumentProcessor(&ite6.ITE6Processor{}, processor.DocumentITE6Vul)
+	_ = RegisterDocumentProcessor(&ite6.ITE6Processor{}, processor.DocumentITE6Attestation)
 	_ = RegisterDocumentProcessor(&dsse.DSSEProcessor{}, processor.DocumentDSSE)
+	_ = RegisterDocumentProcessor(&sigstore_bundle.SigstoreBundleProcessor{}, processor.DocumentSigstoreBundle)
+	_ = RegisterDocumentProcessor(&archive.TarProcessor{}, processor.DocumentTar)
+	_ = RegisterDocumentProcessor(&archive.ZipProcessor{}, processor.DocumentZip)
 	_ = RegisterDocumentProcessor(&spdx.SPDXProcessor{}, processor.DocumentSPDX)
+	_ = RegisterDocumentProcessor(&spdx3.SPDX3Processor{}, processor.DocumentSPDX3)
 	_ = RegisterDocumentProcessor(&csaf.CSAFProcessor{}, processor.DocumentCsaf)
 	_ = RegisterDocumentProcessor(&open_vex.OpenVEXProcessor{}, processor.DocumentOpenVEX)
 	_ = RegisterDocumentProcessor(&scorecard.ScorecardProcessor{}, processor.DocumentScorecard)
 	_ = RegisterDocumentProcessor(&cyclonedx.CycloneDXProcessor{}, processor.DocumentCycloneDX)
 	_ = RegisterDocumentProcessor(&deps_dev.DepsDev{}, processor.DocumentDepsDev)
+	_ = RegisterDocumentProcessor(&syft.SyftProcessor{}, processor.DocumentSyft)
+	_ = RegisterDocumentProcessor(&trivy.TrivyProcessor{}, processor.DocumentTrivy)
+	_ = RegisterDocumentProcessor(&grype.GrypeProcessor{}, processor.DocumentGrype)
+	_ = RegisterDocumentProcessor(&sarif.SARIFProcessor{}, processor.DocumentSARIF)
 }
 
 func RegisterDocumentProcessor(p processor.DocumentProcessor, d processor.DocumentType) error {
@@ -129,27 +174,49 @@ func Subscribe(ctx context.Context, em collector.Emitter, blobStore *blob.BlobSt
 // Process processes the documents received from the collector to determine
 // their format and document type.
 func Process(ctx context.Context, i *processor.Document) (processor.DocumentTree, error) {
-	node, err := processHelper(ctx, i)
+	node, err := processHelper(ctx, i, newDecodeBudget())
 	if err != nil {
 		return nil, err
 	}
 	return processor.DocumentTree(node), nil
 }
 
-func processHelper(ctx context.Context, doc *processor.Document) (*processor.DocumentNode, error) {
-	ds, err := processDocument(ctx, doc)
+func processHelper(ctx context.Context, doc *processor.Document, budget *decodeBudget) (*processor.DocumentNode, error) {
+	ds, err := processDocument(ctx, doc, budget)
 	if err != nil {
 		return nil, err
 	}
 
-	children := make([]*processor.DocumentNode, len(ds))
-	for i, d := range ds {
-		d.SourceInformation = doc.SourceInformation
-		n, err := processHelper(ctx, d)
+	// unpacking, such as inflating the entries of a zip archive, may also
+	// expand the document
+	var unpacked int64
+	for _, d := range ds {
+		unpacked += int64(len(d.Blob))
+	}
+	if expanded := unpacked - int64(len(doc.Blob)); expanded > 0 {
+		if err := budget.spend(expanded); err != nil {
+			return nil, err
+		}
+	}
+
+	logger := logging.FromContext(ctx)
+	children := make([]*processor.DocumentNode, 0, len(ds))
+	for _, d := range ds {
+		// unpackers such as archives may set the source of each child
+		if d.SourceInformation == (processor.SourceInformation{}) {
+			d.SourceInformation = doc.SourceInformation
+		}
+		n, err := processHelper(ctx, d, budget)
 		if err != nil {
+			// archives commonly contain files other than documents, such
+			// as READMEs and licenses, which are skipped
+			if isArchive(doc) && d.Type == processor.DocumentUnknown && !errors.Is(err, errDecodedSizeExceeded) {
+				logger.Warnf("skipping archive entry %s: %v", d.SourceInformation.Source, err)
+				continue
+			}
 			return nil, err
 		}
-		children[i] = n
+		children = append(children, n)
 	}
 	return &processor.DocumentNode{
 		Document: doc,
@@ -157,8 +224,12 @@ func processHelper(ctx context.Context, doc *processor.Document) (*processor.Doc
 	}, nil
 }
 
-func processDocument(ctx context.Context, i *processor.Document) ([]*processor.Document, error) {
-	if err := decodeDocument(ctx, i); err != nil {
+func isArchive(doc *processor.Document) bool {
+	return doc.Type == processor.DocumentTar || doc.Type == processor.DocumentZip
+}
+
+func processDocument(ctx context.Context, i *processor.Document, budget *decodeBudget) ([]*processor.Document, error) {
+	if err := decodeDocument(ctx, i, budget); err != nil {
 		return nil, err
 	}
 
@@ -184,13 +255,13 @@ func processDocument(ctx context.Context, i *processor.Document) ([]*processor.D
 }
 
 func preProcessDocument(ctx context.Context, i *processor.Document) error {
-	docType, format, err := guesser.GuessDocument(ctx, i)
+	guess, err := guesser.GuessDocument(ctx, i)
 	if err != nil {
 		return fmt.Errorf("unable to guess document type: %w", err)
 	}
 
-	i.Type = docType
-	i.Format = format
+	i.Type = guess.Type
+	i.Format = guess.Format
 
 	return nil
 }
@@ -230,7 +301,16 @@ func unpackDocument(i *processor.Document) ([]*processor.Document, error) {
 	return p.Unpack(i) // nolint:wrapcheck
 }
 
-func decodeDocument(ctx context.Context, i *processor.Document) error {
+// Guess decodes the document and guesses its format and type without
+// processing it further, to explain how a document would be classified
+func Guess(ctx context.Context, i *processor.Document) (*guesser.Guess, error) {
+	if err := decodeDocument(ctx, i, newDecodeBudget()); err != nil {
+		return nil, err
+	}
+	return guesser.GuessDocument(ctx, i)
+}
+
+func decodeDocument(ctx context.Context, i *processor.Document, budget *decodeBudget) error {
 	logger := logging.FromContext(ctx)
 	var reader io.Reader
 	var err error
@@ -255,20 +335,28 @@ func decodeDocument(ctx context.Context, i *processor.Document) error {
 		if err != nil {
 			return fmt.Errorf("unable to create zstd reader: %w", err)
 		}
+	case processor.EncodingGzip:
+		reader, err = gzip.NewReader(bytes.NewReader(i.Blob))
+		if err != nil {
+			return fmt.Errorf("unable to create gzip reader: %w", err)
+		}
 	}
 	if reader != nil {
-		if err := decompressDocument(i, reader); err != nil {
+		if err := decompressDocument(i, reader, budget); err != nil {
 			return fmt.Errorf("unable to decode document: %w", err)
 		}
 	}
 	return nil
 }
 
-func decompressDocument(i *processor.Document, reader io.Reader) error {
-	uncompressed, err := io.ReadAll(reader)
+func decompressDocument(i *processor.Document, reader io.Reader, budget *decodeBudget) error {
+	uncompressed, err := io.ReadAll(io.LimitReader(reader, budget.remaining+1))
 	if err != nil {
 		return fmt.Errorf("unable to decompress document: %w", err)
 	}
+	if err := budget.spend(int64(len(uncompressed))); err != nil {
+		return err
+	}
 	i.Blob = uncompressed
 	return nil
 }
@@ -16,6 +16,9 @@
 package process
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
 	"strings"
@@ -30,6 +33,7 @@ import (
 	"github.com/guacsec/guac/pkg/emitter"
 	"github.com/guacsec/guac/pkg/events"
 	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/handler/processor/archive"
 	"github.com/guacsec/guac/pkg/handler/processor/guesser"
 	"github.com/guacsec/guac/pkg/logging"
 )
@@ -569,6 +573,160 @@ func Test_SimpleDocProcessTest(t *testing.T) {
 	}
 }
 
+func Test_ProcessArchive(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	var tarBuf bytes.Buffer
+	tw := tar.NewWriter(&tarBuf)
+	for _, entry := range []struct {
+		name string
+		blob []byte
+	}{
+		{"README.md", []byte("# release")},
+		{"sboms/small-spdx.json", testdata.SpdxExampleSmall},
+	} {
+		if err := tw.WriteHeader(&tar.Header{Name: entry.name, Mode: 0o644, Size: int64(len(entry.blob)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(entry.blob); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	docTree, err := Process(ctx, &processor.Document{
+		Blob:   gzipBlob(t, tarBuf.Bytes()),
+		Type:   processor.DocumentUnknown,
+		Format: processor.FormatUnknown,
+		SourceInformation: processor.SourceInformation{
+			Collector: "TestCollector",
+			Source:    "release/sbom.tar.gz",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	root := docTree.Document
+	if root.Type != processor.DocumentTar || root.Encoding != processor.EncodingGzip || !bytes.Equal(root.Blob, tarBuf.Bytes()) {
+		t.Errorf("archive was not decoded as a tar, got type %v and encoding %v", root.Type, root.Encoding)
+	}
+	// the README can't be processed and is skipped
+	expected := dochelper.DocNode(&processor.Document{
+		Blob:   testdata.SpdxExampleSmall,
+		Type:   processor.DocumentSPDX,
+		Format: processor.FormatJSON,
+		SourceInformation: processor.SourceInformation{
+			Collector: "TestCollector",
+			Source:    "release/sbom.tar.gz!sboms/small-spdx.json",
+		},
+	})
+	if len(docTree.Children) != 1 || !dochelper.DocTreeEqual(docTree.Children[0], expected) {
+		t.Errorf("archive entries did not match up, got %d entries", len(docTree.Children))
+		for _, c := range docTree.Children {
+			t.Errorf("got\n%s", dochelper.StringTree(c))
+		}
+	}
+}
+
+func Test_ProcessCompressionBomb(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	maxDecodedSize := MaxDecodedSize
+	MaxDecodedSize = 1024
+	defer func() {
+		MaxDecodedSize = maxDecodedSize
+	}()
+
+	// trailing whitespace compresses well, the document is a fraction of the
+	// size it decodes to
+	sbom := append(append([]byte{}, testdata.SpdxExampleSmall...), bytes.Repeat([]byte(" "), 4096)...)
+	_, err := Process(ctx, &processor.Document{
+		Blob:   gzipBlob(t, sbom),
+		Type:   processor.DocumentUnknown,
+		Format: processor.FormatUnknown,
+		SourceInformation: processor.SourceInformation{
+			Collector: "TestCollector",
+			Source:    "release/sbom.spdx.json.gz",
+		},
+	})
+	if err == nil {
+		t.Fatalf("expected an error decoding a document larger than %d bytes", MaxDecodedSize)
+	}
+}
+
+func Test_ProcessArchiveErrors(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	maxDecodedSize, maxDepth := MaxDecodedSize, archive.MaxDepth
+	MaxDecodedSize, archive.MaxDepth = 2048, 1
+	defer func() {
+		MaxDecodedSize, archive.MaxDepth = maxDecodedSize, maxDepth
+	}()
+
+	readme := bytes.Repeat([]byte("# release\n"), 150)
+	tests := []struct {
+		name string
+		blob []byte
+	}{{
+		// each entry is within the limit, but not all of them together
+		name: "entries decoded beyond the limit",
+		blob: tarBlob(t, map[string][]byte{
+			"README.md.gz":  gzipBlob(t, readme),
+			"NOTICES.md.gz": gzipBlob(t, readme),
+		}),
+	}, {
+		name: "nested archive beyond the maximum depth",
+		blob: tarBlob(t, map[string][]byte{
+			"sboms.tar": tarBlob(t, map[string][]byte{"sboms/small-spdx.json": testdata.SpdxExampleSmall}),
+		}),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Process(ctx, &processor.Document{
+				Blob:   tt.blob,
+				Type:   processor.DocumentUnknown,
+				Format: processor.FormatUnknown,
+				SourceInformation: processor.SourceInformation{
+					Collector: "TestCollector",
+					Source:    "release/sbom.tar",
+				},
+			})
+			if err == nil {
+				t.Fatalf("expected an error processing the archive")
+			}
+		})
+	}
+}
+
+func tarBlob(t *testing.T, entries map[string][]byte) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, blob := range entries {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(blob)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(blob); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func gzipBlob(t *testing.T, blob []byte) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	if _, err := gw.Write(blob); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
 func Test_validateFormat(t *testing.T) {
 	tests := []struct {
 		name    string
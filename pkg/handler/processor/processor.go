@@ -56,8 +56,11 @@ const (
 	DocumentITE6SLSA         DocumentType = "SLSA"
 	DocumentITE6Generic      DocumentType = "ITE6"
 	DocumentITE6Vul          DocumentType = "ITE6VUL"
+	DocumentITE6Attestation  DocumentType = "ITE6_ATTESTATION"
 	DocumentDSSE             DocumentType = "DSSE"
+	DocumentSigstoreBundle   DocumentType = "SIGSTORE_BUNDLE"
 	DocumentSPDX             DocumentType = "SPDX"
+	DocumentSPDX3            DocumentType = "SPDX3"
 	DocumentJsonLines        DocumentType = "JSON_LINES"
 	DocumentScorecard        DocumentType = "SCORECARD"
 	DocumentCycloneDX        DocumentType = "CycloneDX"
@@ -65,6 +68,12 @@ const (
 	DocumentCsaf             DocumentType = "CSAF"
 	DocumentOpenVEX          DocumentType = "OPEN_VEX"
 	DocumentIngestPredicates DocumentType = "INGEST_PREDICATES"
+	DocumentSyft             DocumentType = "SYFT"
+	DocumentTrivy            DocumentType = "TRIVY"
+	DocumentGrype            DocumentType = "GRYPE"
+	DocumentSARIF            DocumentType = "SARIF"
+	DocumentTar              DocumentType = "TAR"
+	DocumentZip              DocumentType = "ZIP"
 	DocumentUnknown          DocumentType = "UNKNOWN"
 )
 
@@ -84,12 +93,15 @@ type EncodingType string
 const (
 	EncodingBzip2   EncodingType = "BZIP2"
 	EncodingZstd    EncodingType = "ZSTD"
+	EncodingGzip    EncodingType = "GZIP"
 	EncodingUnknown EncodingType = "UNKNOWN"
 )
 
 var EncodingExts = map[string]EncodingType{
 	".bz2": EncodingBzip2,
 	".zst": EncodingZstd,
+	".gz":  EncodingGzip,
+	".tgz": EncodingGzip,
 }
 
 // SourceInformation provides additional information about where the document comes from
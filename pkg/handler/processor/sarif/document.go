@@ -0,0 +1,126 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarif
+
+// Report is the subset of a SARIF 2.1.0 log that GUAC ingests, see
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type Report struct {
+	Schema  string `json:"$schema,omitempty"`
+	Version string `json:"version"`
+	Runs    []Run  `json:"runs"`
+}
+
+// Run is a single invocation of an analysis tool
+type Run struct {
+	Tool                     Tool                    `json:"tool"`
+	Invocations              []Invocation            `json:"invocations,omitempty"`
+	VersionControlProvenance []VersionControlDetails `json:"versionControlProvenance,omitempty"`
+	Results                  []Result                `json:"results"`
+	Taxonomies               []ToolComponent         `json:"taxonomies,omitempty"`
+}
+
+// Tool is the analysis tool, made up of its driver and of the extensions,
+// such as the CodeQL query packs, that contributed rules to the run
+type Tool struct {
+	Driver     ToolComponent   `json:"driver"`
+	Extensions []ToolComponent `json:"extensions,omitempty"`
+}
+
+// ToolComponent is the driver or an extension of a tool
+type ToolComponent struct {
+	Name            string                `json:"name"`
+	Version         string                `json:"version,omitempty"`
+	SemanticVersion string                `json:"semanticVersion,omitempty"`
+	Rules           []ReportingDescriptor `json:"rules,omitempty"`
+}
+
+// ReportingDescriptor describes a rule of a tool
+type ReportingDescriptor struct {
+	ID                   string                  `json:"id"`
+	Name                 string                  `json:"name,omitempty"`
+	DefaultConfiguration *ReportingConfiguration `json:"defaultConfiguration,omitempty"`
+	Relationships        []Relationship          `json:"relationships,omitempty"`
+	Properties           RulePropertyBag         `json:"properties,omitempty"`
+}
+
+// ReportingConfiguration is the default configuration of a rule
+type ReportingConfiguration struct {
+	Level string `json:"level,omitempty"`
+}
+
+// Relationship relates a rule to a descriptor of another tool component,
+// such as a weakness of the CWE taxonomy
+type Relationship struct {
+	Target ReportingDescriptorReference `json:"target"`
+	Kinds  []string                     `json:"kinds,omitempty"`
+}
+
+// RulePropertyBag are the properties of a rule used to classify its results
+type RulePropertyBag struct {
+	Tags []string `json:"tags,omitempty"`
+	// SecuritySeverity is the CVSS-like score, between 0.0 and 10.0, that
+	// GitHub code scanning uses to rank security results. Tools write it as
+	// a string or as a number.
+	SecuritySeverity interface{} `json:"security-severity,omitempty"`
+}
+
+// ReportingDescriptorReference refers to a rule or taxon by its id or index
+// in a tool component
+type ReportingDescriptorReference struct {
+	ID            string                  `json:"id,omitempty"`
+	Index         *int                    `json:"index,omitempty"`
+	ToolComponent *ToolComponentReference `json:"toolComponent,omitempty"`
+}
+
+// ToolComponentReference refers to a tool component by its name or index,
+// in the extensions of the tool for rules and in the taxonomies of the run
+// for taxa
+type ToolComponentReference struct {
+	Name  string `json:"name,omitempty"`
+	Index *int   `json:"index,omitempty"`
+}
+
+// Invocation records when the tool ran
+type Invocation struct {
+	StartTimeUTC string `json:"startTimeUtc,omitempty"`
+	EndTimeUTC   string `json:"endTimeUtc,omitempty"`
+}
+
+// VersionControlDetails identifies the revision of the repository analyzed
+type VersionControlDetails struct {
+	RepositoryURI string `json:"repositoryUri"`
+	RevisionID    string `json:"revisionId,omitempty"`
+	RevisionTag   string `json:"revisionTag,omitempty"`
+	Branch        string `json:"branch,omitempty"`
+}
+
+// Result is a finding of the tool
+type Result struct {
+	RuleID       string                         `json:"ruleId,omitempty"`
+	RuleIndex    *int                           `json:"ruleIndex,omitempty"`
+	Rule         *ReportingDescriptorReference  `json:"rule,omitempty"`
+	Kind         string                         `json:"kind,omitempty"`
+	Level        string                         `json:"level,omitempty"`
+	Taxa         []ReportingDescriptorReference `json:"taxa,omitempty"`
+	Suppressions []Suppression                  `json:"suppressions,omitempty"`
+}
+
+// Suppression records that a result was suppressed, in source or by a
+// reviewer
+type Suppression struct {
+	Kind   string `json:"kind"`
+	Status string `json:"status,omitempty"`
+}
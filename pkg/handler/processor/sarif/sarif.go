@@ -0,0 +1,134 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarif
+
+import (
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+type SARIFProcessor struct{}
+
+func (p *SARIFProcessor) ValidateSchema(d *processor.Document) error {
+	if d.Type != processor.DocumentSARIF {
+		return fmt.Errorf("expected document type: %v, actual document type: %v", processor.DocumentSARIF, d.Type)
+	}
+
+	switch d.Format {
+	case processor.FormatJSON:
+		_, err := ParseReport(d.Blob)
+		return err
+	}
+
+	return fmt.Errorf("unable to support parsing of SARIF document format: %v", d.Format)
+}
+
+func (p *SARIFProcessor) Unpack(d *processor.Document) ([]*processor.Document, error) {
+	if d.Type != processor.DocumentSARIF {
+		return nil, fmt.Errorf("expected document type: %v, actual document type: %v", processor.DocumentSARIF, d.Type)
+	}
+
+	return []*processor.Document{}, nil
+}
+
+// ParseReport decodes a SARIF 2.1.0 log
+func ParseReport(blob []byte) (*Report, error) {
+	var report Report
+	if err := json.Unmarshal(blob, &report); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal SARIF report: %w", err)
+	}
+	if report.Version != "2.1.0" {
+		return nil, fmt.Errorf("unsupported SARIF version: %q", report.Version)
+	}
+	if report.Runs == nil {
+		return nil, fmt.Errorf("no runs found in SARIF report")
+	}
+	return &report, nil
+}
+
+// Component returns the tool component a reference points to, which is the
+// driver unless the reference names or indexes one of the extensions
+func (t Tool) Component(ref *ToolComponentReference) *ToolComponent {
+	if ref != nil {
+		if ref.Index != nil && *ref.Index >= 0 && *ref.Index < len(t.Extensions) {
+			return &t.Extensions[*ref.Index]
+		}
+		for i := range t.Extensions {
+			if ref.Name != "" && t.Extensions[i].Name == ref.Name {
+				return &t.Extensions[i]
+			}
+		}
+	}
+	return &t.Driver
+}
+
+// Taxonomy returns the taxonomy of the run a taxon reference points to, by
+// its index and then by its name, or nil if the run doesn't describe it
+func (r Run) Taxonomy(ref *ToolComponentReference) *ToolComponent {
+	if ref == nil {
+		return nil
+	}
+	if ref.Index != nil && *ref.Index >= 0 && *ref.Index < len(r.Taxonomies) {
+		return &r.Taxonomies[*ref.Index]
+	}
+	for i := range r.Taxonomies {
+		if ref.Name != "" && r.Taxonomies[i].Name == ref.Name {
+			return &r.Taxonomies[i]
+		}
+	}
+	return nil
+}
+
+// Rule returns the rule that reported a result, looked up by its index and
+// then by its id, or nil if the tool didn't describe it
+func (t Tool) Rule(result Result) *ReportingDescriptor {
+	var ref *ToolComponentReference
+	index := result.RuleIndex
+	id := result.RuleID
+	if result.Rule != nil {
+		ref = result.Rule.ToolComponent
+		if index == nil {
+			index = result.Rule.Index
+		}
+		if id == "" {
+			id = result.Rule.ID
+		}
+	}
+	component := t.Component(ref)
+	if index != nil && *index >= 0 && *index < len(component.Rules) {
+		return &component.Rules[*index]
+	}
+	if id == "" {
+		return nil
+	}
+	components := append([]*ToolComponent{component}, &t.Driver)
+	for i := range t.Extensions {
+		components = append(components, &t.Extensions[i])
+	}
+	for _, c := range components {
+		for i := range c.Rules {
+			if c.Rules[i].ID == id {
+				return &c.Rules[i]
+			}
+		}
+	}
+	return nil
+}
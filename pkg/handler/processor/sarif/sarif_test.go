@@ -0,0 +1,163 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarif
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/guacsec/guac/internal/testing/testdata"
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+func TestSARIFProcessor_ValidateSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     *processor.Document
+		wantErr bool
+	}{
+		{
+			name: "valid SARIF document",
+			doc: &processor.Document{
+				Blob:   testdata.SARIFExampleCodeQLSemgrep,
+				Type:   processor.DocumentSARIF,
+				Format: processor.FormatJSON,
+			},
+		},
+		{
+			name: "incorrect type",
+			doc: &processor.Document{
+				Blob:   testdata.SARIFExampleCodeQLSemgrep,
+				Type:   processor.DocumentUnknown,
+				Format: processor.FormatJSON,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid SARIF document",
+			doc: &processor.Document{
+				Blob:   []byte(`{"abc": "def"}`),
+				Type:   processor.DocumentSARIF,
+				Format: processor.FormatJSON,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid SARIF document format",
+			doc: &processor.Document{
+				Blob:   testdata.SARIFExampleCodeQLSemgrep,
+				Type:   processor.DocumentSARIF,
+				Format: processor.FormatUnknown,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &SARIFProcessor{}
+			if err := p.ValidateSchema(tt.doc); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSARIFProcessor_Unpack(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     *processor.Document
+		want    []*processor.Document
+		wantErr bool
+	}{
+		{
+			name: "SARIF document",
+			doc: &processor.Document{
+				Type: processor.DocumentSARIF,
+			},
+			want: []*processor.Document{},
+		},
+		{
+			name: "incorrect type",
+			doc: &processor.Document{
+				Type: processor.DocumentUnknown,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &SARIFProcessor{}
+			got, err := p.Unpack(tt.doc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Unpack() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unpack() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTool_Rule(t *testing.T) {
+	report, err := ParseReport(testdata.SARIFExampleCodeQLSemgrep)
+	if err != nil {
+		t.Fatalf("ParseReport() error = %v", err)
+	}
+	codeql, semgrep := report.Runs[0].Tool, report.Runs[1].Tool
+	index := 1
+	tests := []struct {
+		name   string
+		tool   Tool
+		result Result
+		want   string
+	}{
+		{
+			name:   "rule of an extension by index",
+			tool:   codeql,
+			result: Result{Rule: &ReportingDescriptorReference{Index: &index, ToolComponent: &ToolComponentReference{Index: new(int)}}},
+			want:   "js/sql-injection",
+		},
+		{
+			name:   "rule of an extension by id",
+			tool:   codeql,
+			result: Result{RuleID: "js/unused-local-variable"},
+			want:   "js/unused-local-variable",
+		},
+		{
+			name:   "rule of the driver by index",
+			tool:   semgrep,
+			result: Result{RuleIndex: &index},
+			want:   "python.lang.security.audit.eval-detected.eval-detected",
+		},
+		{
+			name:   "unknown rule",
+			tool:   semgrep,
+			result: Result{RuleID: "js/reflected-xss"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if rule := tt.tool.Rule(tt.result); rule != nil {
+				got = rule.ID
+			}
+			if got != tt.want {
+				t.Errorf("Rule() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sigstore_bundle
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// bundle is the part of a Sigstore bundle that is needed to unpack it, the
+// verification material is checked by the verifier
+type bundle struct {
+	MediaType    string         `json:"mediaType"`
+	DSSEEnvelope *dsse.Envelope `json:"dsseEnvelope"`
+}
+
+// SigstoreBundleProcessor processes Sigstore bundles which wrap a DSSE
+// envelope along with the certificate and transparency log entries needed to
+// verify it
+type SigstoreBundleProcessor struct {
+}
+
+func (s *SigstoreBundleProcessor) ValidateSchema(i *processor.Document) error {
+	if i.Type != processor.DocumentSigstoreBundle {
+		return fmt.Errorf("expected document type: %v, actual document type: %v", processor.DocumentSigstoreBundle, i.Type)
+	}
+
+	_, err := parseBundle(i.Blob)
+
+	return err
+}
+
+// Unpack takes in the bundle and returns the payload of its DSSE envelope
+func (s *SigstoreBundleProcessor) Unpack(i *processor.Document) ([]*processor.Document, error) {
+	if i.Type != processor.DocumentSigstoreBundle {
+		return nil, fmt.Errorf("expected document type: %v, actual document type: %v", processor.DocumentSigstoreBundle, i.Type)
+	}
+
+	b, err := parseBundle(i.Blob)
+	if err != nil {
+		return nil, err
+	}
+
+	decodedPayload, err := base64.StdEncoding.DecodeString(b.DSSEEnvelope.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode payload: %w", err)
+	}
+	doc := &processor.Document{
+		Blob:              decodedPayload,
+		Type:              processor.DocumentUnknown,
+		Format:            processor.FormatUnknown,
+		SourceInformation: i.SourceInformation,
+	}
+
+	return []*processor.Document{doc}, nil
+}
+
+func parseBundle(b []byte) (*bundle, error) {
+	var sb bundle
+	if err := json.Unmarshal(b, &sb); err != nil {
+		return nil, err
+	}
+	if sb.DSSEEnvelope == nil {
+		return nil, errors.New("sigstore bundle does not contain a DSSE envelope")
+	}
+	return &sb, nil
+}
@@ -0,0 +1,86 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sigstore_bundle
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+var sourceInformation = processor.SourceInformation{
+	Collector: "TestCollector",
+	Source:    "TestSource",
+}
+
+func TestSigstoreBundleProcessor_Unpack(t *testing.T) {
+	testCases := []struct {
+		name      string
+		doc       processor.Document
+		expected  []*processor.Document
+		expectErr bool
+	}{{
+		name: "bundle with a DSSE envelope",
+		doc: processor.Document{
+			Blob: []byte(`{
+				"mediaType": "application/vnd.dev.sigstore.bundle+json;version=0.2",
+				"verificationMaterial": {"tlogEntries": []},
+				"dsseEnvelope": {"payload": "aGVsbG8gd29ybGQ=", "payloadType": "http://example.com/HelloWorld", "signatures": [{"sig": "c2ln"}]}
+			}`),
+			Type:              processor.DocumentSigstoreBundle,
+			Format:            processor.FormatJSON,
+			SourceInformation: sourceInformation,
+		},
+		expected: []*processor.Document{{
+			Blob:              []byte("hello world"),
+			Type:              processor.DocumentUnknown,
+			Format:            processor.FormatUnknown,
+			SourceInformation: sourceInformation,
+		}},
+	}, {
+		name: "bundle with a message signature",
+		doc: processor.Document{
+			Blob:              []byte(`{"mediaType": "application/vnd.dev.sigstore.bundle+json;version=0.2", "messageSignature": {}}`),
+			Type:              processor.DocumentSigstoreBundle,
+			Format:            processor.FormatJSON,
+			SourceInformation: sourceInformation,
+		},
+		expectErr: true,
+	}, {
+		name: "incorrect type",
+		doc: processor.Document{
+			Blob:              []byte("not a bundle"),
+			Type:              processor.DocumentUnknown,
+			Format:            processor.FormatJSON,
+			SourceInformation: sourceInformation,
+		},
+		expectErr: true,
+	}}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			s := SigstoreBundleProcessor{}
+			actual, err := s.Unpack(&tt.doc)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("SigstoreBundleProcessor.Unpack() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if diff := cmp.Diff(tt.expected, actual); diff != "" {
+				t.Errorf("SigstoreBundleProcessor.Unpack() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
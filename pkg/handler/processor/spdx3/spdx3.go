@@ -0,0 +1,99 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spdx3
+
+import (
+	"fmt"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// contextPrefix is the prefix of the JSON-LD context of all SPDX 3 documents,
+// such as https://spdx.org/rdf/3.0.1/spdx-context.jsonld
+const contextPrefix = "https://spdx.org/rdf/3."
+
+// SPDX3Processor processes SPDX 3 documents.
+// Currently only supports the JSON-LD serialization of SPDX 3
+type SPDX3Processor struct {
+}
+
+type jsonLDDocument struct {
+	Context interface{} `json:"@context"`
+	Graph   []struct {
+		Type   string `json:"type"`
+		AtType string `json:"@type"`
+	} `json:"@graph"`
+}
+
+func (p *SPDX3Processor) ValidateSchema(d *processor.Document) error {
+	if d.Type != processor.DocumentSPDX3 {
+		return fmt.Errorf("expected document type: %v, actual document type: %v", processor.DocumentSPDX3, d.Type)
+	}
+
+	switch d.Format {
+	case processor.FormatJSON:
+		var doc jsonLDDocument
+		if err := json.Unmarshal(d.Blob, &doc); err != nil {
+			return err
+		}
+		if !IsSPDX3Context(doc.Context) {
+			return fmt.Errorf("document @context is not an SPDX 3 context")
+		}
+		for _, element := range doc.Graph {
+			if element.Type == "SpdxDocument" || element.AtType == "SpdxDocument" {
+				return nil
+			}
+		}
+		return fmt.Errorf("document @graph is missing an SpdxDocument element")
+	}
+
+	return fmt.Errorf("unable to support parsing of SPDX 3 document format: %v", d.Format)
+}
+
+// Unpack takes in the document and tries to unpack it
+// if there is a valid decomposition of sub-documents.
+//
+// Returns empty list and nil error if nothing to unpack
+// Returns unpacked list and nil error if successfully unpacked
+func (p *SPDX3Processor) Unpack(d *processor.Document) ([]*processor.Document, error) {
+	if d.Type != processor.DocumentSPDX3 {
+		return nil, fmt.Errorf("expected document type: %v, actual document type: %v", processor.DocumentSPDX3, d.Type)
+	}
+
+	// SPDX 3 doesn't unpack into additional documents at the moment.
+	return []*processor.Document{}, nil
+}
+
+// IsSPDX3Context returns whether a JSON-LD @context, which is either a
+// single context or a list of them, references the SPDX 3 context
+func IsSPDX3Context(context interface{}) bool {
+	switch c := context.(type) {
+	case string:
+		return strings.HasPrefix(c, contextPrefix)
+	case []interface{}:
+		for _, v := range c {
+			if IsSPDX3Context(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
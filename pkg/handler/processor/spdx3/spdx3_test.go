@@ -0,0 +1,112 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spdx3
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/guacsec/guac/internal/testing/testdata"
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+func TestSPDX3Processor_Unpack(t *testing.T) {
+	testCases := []struct {
+		name      string
+		doc       processor.Document
+		expected  []*processor.Document
+		expectErr bool
+	}{{
+		name: "SPDX 3 document",
+		doc: processor.Document{
+			Blob:   testdata.Spdx3Example,
+			Format: processor.FormatJSON,
+			Type:   processor.DocumentSPDX3,
+		},
+		expected:  []*processor.Document{},
+		expectErr: false,
+	}, {
+		name: "Incorrect type",
+		doc: processor.Document{
+			Blob:   testdata.Spdx3Example,
+			Format: processor.FormatJSON,
+			Type:   processor.DocumentSPDX,
+		},
+		expected:  nil,
+		expectErr: true,
+	}}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			d := SPDX3Processor{}
+			actual, err := d.Unpack(&tt.doc)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("SPDX3Processor.Unpack() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("SPDX3Processor.Unpack() = %v, expected %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSPDX3Processor_ValidateSchema(t *testing.T) {
+	testCases := []struct {
+		name      string
+		doc       processor.Document
+		expectErr bool
+	}{{
+		name: "valid SPDX 3 document",
+		doc: processor.Document{
+			Blob:   testdata.Spdx3Example,
+			Format: processor.FormatJSON,
+			Type:   processor.DocumentSPDX3,
+		},
+		expectErr: false,
+	}, {
+		name: "SPDX 2 document",
+		doc: processor.Document{
+			Blob:   testdata.SpdxExampleSmall,
+			Format: processor.FormatJSON,
+			Type:   processor.DocumentSPDX3,
+		},
+		expectErr: true,
+	}, {
+		name: "missing SpdxDocument element",
+		doc: processor.Document{
+			Blob:   []byte(`{"@context": "https://spdx.org/rdf/3.0.1/spdx-context.jsonld", "@graph": [{"type": "software_Package"}]}`),
+			Format: processor.FormatJSON,
+			Type:   processor.DocumentSPDX3,
+		},
+		expectErr: true,
+	}, {
+		name: "invalid format supported",
+		doc: processor.Document{
+			Blob:   testdata.Spdx3Example,
+			Format: processor.FormatUnknown,
+			Type:   processor.DocumentSPDX3,
+		},
+		expectErr: true,
+	}}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			d := SPDX3Processor{}
+			err := d.ValidateSchema(&tt.doc)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("SPDX3Processor.ValidateSchema() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syft
+
+// Relationship types between the artifacts of a Syft document
+const (
+	RelationshipContains     = "contains"
+	RelationshipDependencyOf = "dependency-of"
+	RelationshipEvidentBy    = "evident-by"
+)
+
+// Document is the subset of the native Syft JSON format that GUAC ingests,
+// see https://github.com/anchore/syft/tree/main/schema/json
+type Document struct {
+	Artifacts             []Package      `json:"artifacts"`
+	ArtifactRelationships []Relationship `json:"artifactRelationships"`
+	Files                 []File         `json:"files,omitempty"`
+	Source                Source         `json:"source"`
+	Descriptor            Descriptor     `json:"descriptor"`
+	Schema                Schema         `json:"schema"`
+}
+
+// Package is a package found by Syft
+type Package struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Type    string `json:"type"`
+	PURL    string `json:"purl"`
+}
+
+// Relationship relates two artifacts (packages, files or the source) by
+// their ID
+type Relationship struct {
+	Parent string `json:"parent"`
+	Child  string `json:"child"`
+	Type   string `json:"type"`
+}
+
+// File is a file cataloged by Syft
+type File struct {
+	ID       string      `json:"id"`
+	Location Coordinates `json:"location"`
+	Digests  []Digest    `json:"digests,omitempty"`
+}
+
+// Coordinates locate a file, possibly within a layer of an image
+type Coordinates struct {
+	Path    string `json:"path"`
+	LayerID string `json:"layerID,omitempty"`
+}
+
+// Digest is a checksum of a file
+type Digest struct {
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"value"`
+}
+
+// Source is what was scanned, such as an image, a directory or a file
+type Source struct {
+	ID       string         `json:"id"`
+	Name     string         `json:"name"`
+	Version  string         `json:"version"`
+	Type     string         `json:"type"`
+	Metadata SourceMetadata `json:"metadata"`
+}
+
+// SourceMetadata holds the fields of the image, directory and file source
+// metadata
+type SourceMetadata struct {
+	UserInput      string   `json:"userInput"`
+	ImageID        string   `json:"imageID"`
+	ManifestDigest string   `json:"manifestDigest"`
+	RepoDigests    []string `json:"repoDigests"`
+	Tags           []string `json:"tags"`
+	Path           string   `json:"path"`
+	Digests        []Digest `json:"digests"`
+}
+
+// Descriptor identifies the tool that created the document
+type Descriptor struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Schema is the version of the JSON schema the document follows
+type Schema struct {
+	Version string `json:"version"`
+	URL     string `json:"url"`
+}
@@ -0,0 +1,62 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syft
+
+import (
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+type SyftProcessor struct{}
+
+func (p *SyftProcessor) ValidateSchema(d *processor.Document) error {
+	if d.Type != processor.DocumentSyft {
+		return fmt.Errorf("expected document type: %v, actual document type: %v", processor.DocumentSyft, d.Type)
+	}
+
+	switch d.Format {
+	case processor.FormatJSON:
+		_, err := ParseDocument(d.Blob)
+		return err
+	}
+
+	return fmt.Errorf("unable to support parsing of Syft document format: %v", d.Format)
+}
+
+func (p *SyftProcessor) Unpack(d *processor.Document) ([]*processor.Document, error) {
+	if d.Type != processor.DocumentSyft {
+		return nil, fmt.Errorf("expected document type: %v, actual document type: %v", processor.DocumentSyft, d.Type)
+	}
+
+	return []*processor.Document{}, nil
+}
+
+// ParseDocument decodes a native Syft JSON document
+func ParseDocument(blob []byte) (*Document, error) {
+	var doc Document
+	if err := json.Unmarshal(blob, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Syft document: %w", err)
+	}
+	if doc.Artifacts == nil {
+		return nil, fmt.Errorf("no artifacts found in Syft document")
+	}
+	return &doc, nil
+}
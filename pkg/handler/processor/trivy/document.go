@@ -0,0 +1,103 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trivy
+
+// Report is the subset of the native Trivy JSON report that GUAC ingests,
+// see https://aquasecurity.github.io/trivy/latest/docs/configuration/reporting/#json
+type Report struct {
+	SchemaVersion int      `json:"SchemaVersion"`
+	CreatedAt     string   `json:"CreatedAt,omitempty"`
+	ArtifactName  string   `json:"ArtifactName"`
+	ArtifactType  string   `json:"ArtifactType"`
+	Metadata      Metadata `json:"Metadata"`
+	Results       []Result `json:"Results,omitempty"`
+	// Trivy is only set by recent versions of Trivy
+	Trivy *Tool `json:"Trivy,omitempty"`
+}
+
+// Metadata describes the scanned artifact
+type Metadata struct {
+	ImageID     string   `json:"ImageID,omitempty"`
+	RepoTags    []string `json:"RepoTags,omitempty"`
+	RepoDigests []string `json:"RepoDigests,omitempty"`
+}
+
+// Tool identifies the version of Trivy that created the report
+type Tool struct {
+	Version string `json:"Version"`
+}
+
+// Result holds the packages and vulnerabilities found in one target, such as
+// the OS packages or a lock file of the artifact
+type Result struct {
+	Target          string          `json:"Target"`
+	Class           string          `json:"Class,omitempty"`
+	Type            string          `json:"Type,omitempty"`
+	Packages        []Package       `json:"Packages,omitempty"`
+	Vulnerabilities []Vulnerability `json:"Vulnerabilities,omitempty"`
+}
+
+// Package is a package found in a target, only listed with --list-all-pkgs
+type Package struct {
+	ID         string        `json:"ID,omitempty"`
+	Name       string        `json:"Name"`
+	Version    string        `json:"Version"`
+	Identifier PkgIdentifier `json:"Identifier"`
+}
+
+// PkgIdentifier identifies a package
+type PkgIdentifier struct {
+	PURL string `json:"PURL,omitempty"`
+	UID  string `json:"UID,omitempty"`
+}
+
+// Vulnerability is a vulnerability detected in an installed package
+type Vulnerability struct {
+	VulnerabilityID  string        `json:"VulnerabilityID"`
+	PkgID            string        `json:"PkgID,omitempty"`
+	PkgName          string        `json:"PkgName"`
+	PkgIdentifier    PkgIdentifier `json:"PkgIdentifier"`
+	InstalledVersion string        `json:"InstalledVersion"`
+	FixedVersion     string        `json:"FixedVersion,omitempty"`
+	Status           string        `json:"Status,omitempty"`
+	SeveritySource   string        `json:"SeveritySource,omitempty"`
+	DataSource       *DataSource   `json:"DataSource,omitempty"`
+	Severity         string        `json:"Severity,omitempty"`
+	// VendorSeverity is the severity each vendor rated the vulnerability,
+	// from 0 (UNKNOWN) to 4 (CRITICAL), see SeverityNames
+	VendorSeverity map[string]int  `json:"VendorSeverity,omitempty"`
+	CVSS           map[string]CVSS `json:"CVSS,omitempty"`
+}
+
+// SeverityNames are the names of the severities of VendorSeverity
+var SeverityNames = []string{"UNKNOWN", "LOW", "MEDIUM", "HIGH", "CRITICAL"}
+
+// DataSource is the advisory database the vulnerability was found in
+type DataSource struct {
+	ID   string `json:"ID"`
+	Name string `json:"Name"`
+	URL  string `json:"URL"`
+}
+
+// CVSS holds the scores a vendor assigned to the vulnerability
+type CVSS struct {
+	V2Vector  string  `json:"V2Vector,omitempty"`
+	V3Vector  string  `json:"V3Vector,omitempty"`
+	V40Vector string  `json:"V40Vector,omitempty"`
+	V2Score   float64 `json:"V2Score,omitempty"`
+	V3Score   float64 `json:"V3Score,omitempty"`
+	V40Score  float64 `json:"V40Score,omitempty"`
+}
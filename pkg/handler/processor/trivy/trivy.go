@@ -0,0 +1,62 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trivy
+
+import (
+	"fmt"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/guacsec/guac/pkg/handler/processor"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+type TrivyProcessor struct{}
+
+func (p *TrivyProcessor) ValidateSchema(d *processor.Document) error {
+	if d.Type != processor.DocumentTrivy {
+		return fmt.Errorf("expected document type: %v, actual document type: %v", processor.DocumentTrivy, d.Type)
+	}
+
+	switch d.Format {
+	case processor.FormatJSON:
+		_, err := ParseReport(d.Blob)
+		return err
+	}
+
+	return fmt.Errorf("unable to support parsing of Trivy document format: %v", d.Format)
+}
+
+func (p *TrivyProcessor) Unpack(d *processor.Document) ([]*processor.Document, error) {
+	if d.Type != processor.DocumentTrivy {
+		return nil, fmt.Errorf("expected document type: %v, actual document type: %v", processor.DocumentTrivy, d.Type)
+	}
+
+	return []*processor.Document{}, nil
+}
+
+// ParseReport decodes a native Trivy JSON report
+func ParseReport(blob []byte) (*Report, error) {
+	var report Report
+	if err := json.Unmarshal(blob, &report); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Trivy report: %w", err)
+	}
+	if report.SchemaVersion == 0 || report.ArtifactName == "" {
+		return nil, fmt.Errorf("missing schema version or artifact name in Trivy report")
+	}
+	return &report, nil
+}
@@ -36,6 +36,7 @@ import (
 // Synchronously ingest document using GraphQL endpoint
 func Ingest(ctx context.Context, d *processor.Document, graphqlEndpoint string, csubClient csub_client.Client) error {
 	logger := logging.FromContext(ctx)
+	ctx = withSBOMPackageResolver(ctx, graphqlEndpoint)
 	// Get pipeline of components
 	processorFunc := GetProcessor(ctx)
 	ingestorFunc := GetIngestor(ctx)
@@ -73,7 +74,6 @@ func MergedIngest(ctx context.Context, docs []*processor.Document, graphqlEndpoi
 	logger := logging.FromContext(ctx)
 	// Get pipeline of components
 	processorFunc := GetProcessor(ctx)
-	ingestorFunc := GetIngestor(ctx)
 	collectSubEmitFunc := GetCollectSubEmit(ctx, csubClient)
 	assemblerFunc := GetAssembler(ctx, graphqlEndpoint)
 
@@ -88,6 +88,9 @@ func MergedIngest(ctx context.Context, docs []*processor.Document, graphqlEndpoi
 			return fmt.Errorf("unable to process doc: %v, format: %v, document: %v", err, d.Format, d.Type)
 		}
 
+		// the resolver caches the SBOMs it looks up for the parse of a
+		// single document
+		ingestorFunc := GetIngestor(withSBOMPackageResolver(ctx, graphqlEndpoint))
 		preds, idstrs, err := ingestorFunc(docTree)
 		if err != nil {
 			return fmt.Errorf("unable to ingest doc tree: %v", err)
@@ -140,6 +143,14 @@ func MergedIngest(ctx context.Context, docs []*processor.Document, graphqlEndpoi
 	return nil
 }
 
+// withSBOMPackageResolver lets parsers resolve references into SBOMs that
+// were previously ingested through the GraphQL endpoint
+func withSBOMPackageResolver(ctx context.Context, graphqlEndpoint string) context.Context {
+	httpClient := http.Client{}
+	gqlclient := graphql.NewClient(graphqlEndpoint, &httpClient)
+	return parser_common.WithSBOMPackageResolver(ctx, parser_common.NewGraphQLSBOMPackageResolver(gqlclient))
+}
+
 func GetProcessor(ctx context.Context) func(*processor.Document) (processor.DocumentTree, error) {
 	return func(d *processor.Document) (processor.DocumentTree, error) {
 		return process.Process(ctx, d)
@@ -126,20 +126,10 @@ func Store(ctx context.Context, id string, pemBytes []byte, providerType KeyProv
 	if err != nil {
 		return err
 	}
-	keyHash, err := dsse.SHA256KeyID(key)
+	foundKey, err := NewKey(key)
 	if err != nil {
 		return err
 	}
-	keyType, KeyScheme, err := getKeyInfo(key)
-	if err != nil {
-		return err
-	}
-	foundKey := &Key{
-		Hash:   keyHash,
-		Type:   keyType,
-		Val:    key,
-		Scheme: KeyScheme,
-	}
 	if provider, ok := keyProviders[providerType]; ok {
 		err := provider.StoreKey(ctx, id, foundKey)
 		if err != nil {
@@ -151,6 +141,25 @@ func Store(ctx context.Context, id string, pemBytes []byte, providerType KeyProv
 	return nil
 }
 
+// NewKey wraps the public key, computing its hash and determining its type
+// and scheme
+func NewKey(pub crypto.PublicKey) (*Key, error) {
+	keyHash, err := dsse.SHA256KeyID(pub)
+	if err != nil {
+		return nil, err
+	}
+	keyType, keyScheme, err := getKeyInfo(pub)
+	if err != nil {
+		return nil, err
+	}
+	return &Key{
+		Hash:   keyHash,
+		Type:   keyType,
+		Val:    pub,
+		Scheme: keyScheme,
+	}, nil
+}
+
 // Delete goes to the specified key provider and deletes the Key
 // returns a nil error when successful
 func Delete(ctx context.Context, id string, providerType KeyProviderType) error {
@@ -0,0 +1,55 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/guacsec/guac/pkg/assembler"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/ingestor/parser/common"
+)
+
+// archiveParser parses tar and zip archives. The archive itself has nothing
+// to ingest, its entries are unpacked by the processor and parsed on their own.
+type archiveParser struct{}
+
+// NewArchiveParser initializes the archiveParser
+func NewArchiveParser() common.DocumentParser {
+	return &archiveParser{}
+}
+
+// Parse checks that the document is an archive
+func (a *archiveParser) Parse(ctx context.Context, doc *processor.Document) error {
+	if doc.Type != processor.DocumentTar && doc.Type != processor.DocumentZip {
+		return fmt.Errorf("expected an archive, actual document type: %v", doc.Type)
+	}
+	return nil
+}
+
+// GetIdentities gets the identity node from the document if they exist
+func (a *archiveParser) GetIdentities(ctx context.Context) []common.TrustInformation {
+	return nil
+}
+
+func (a *archiveParser) GetIdentifiers(ctx context.Context) (*common.IdentifierStrings, error) {
+	return nil, fmt.Errorf("archives have no identifiers")
+}
+
+func (a *archiveParser) GetPredicates(ctx context.Context) *assembler.IngestPredicates {
+	return &assembler.IngestPredicates{}
+}
@@ -0,0 +1,395 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package attestation parses the in-toto attestation framework predicates
+// other than SLSA provenance into evidence about the subjects of the
+// statement:
+//
+// - test-result/v0.1 results become CertifyGood (passed) or CertifyBad
+// (failed), along with a HasMetadata of the result.
+//
+// - link/v0.3 steps become HasMetadata of the step and its command.
+//
+// - scai/attribute-report attributes become HasMetadata of the attribute on
+// its target, or on the subjects when the attribute has no target.
+//
+// - release/v0.1 releases become IsOccurrence of the released package for
+// each subject, and HasMetadata of the release id.
+//
+// - runtime-trace/v0.1 traces become HasMetadata of the monitor that
+// recorded the trace of the build.
+package attestation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/guacsec/guac/pkg/assembler"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	"github.com/guacsec/guac/pkg/assembler/helpers"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/ingestor/parser/common"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// zeroTime is the time of the evidence of predicates that don't record when
+// they were made, such as test results, links, SCAI reports and releases
+var zeroTime = time.Unix(0, 0)
+
+const (
+	predicateTestResult = "https://in-toto.io/attestation/test-result/v0.1"
+	predicateLink       = "https://in-toto.io/attestation/link/v0.3"
+	predicateSCAI       = "https://in-toto.io/attestation/scai/attribute-report"
+	predicateRelease    = "https://in-toto.io/attestation/release/v0.1"
+	predicateRuntime    = "https://in-toto.io/attestation/runtime-trace/v0.1"
+)
+
+// resourceDescriptor is an in-toto v1 resource descriptor, which is also
+// compatible with the subjects of v0.1 statements
+type resourceDescriptor struct {
+	Name   string            `json:"name,omitempty"`
+	URI    string            `json:"uri,omitempty"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+type statement struct {
+	Type          string               `json:"_type"`
+	Subject       []resourceDescriptor `json:"subject"`
+	PredicateType string               `json:"predicateType"`
+	Predicate     jsoniter.RawMessage  `json:"predicate"`
+}
+
+type testResultPredicate struct {
+	Result        string               `json:"result"`
+	Configuration []resourceDescriptor `json:"configuration"`
+	URL           string               `json:"url"`
+	PassedTests   []string             `json:"passedTests"`
+	WarnedTests   []string             `json:"warnedTests"`
+	FailedTests   []string             `json:"failedTests"`
+}
+
+type linkPredicate struct {
+	Name      string               `json:"name"`
+	Command   []string             `json:"command"`
+	Materials []resourceDescriptor `json:"materials"`
+}
+
+type scaiPredicate struct {
+	Attributes []struct {
+		Attribute string              `json:"attribute"`
+		Target    *resourceDescriptor `json:"target"`
+		Evidence  *resourceDescriptor `json:"evidence"`
+	} `json:"attributes"`
+	Producer *resourceDescriptor `json:"producer"`
+}
+
+type releasePredicate struct {
+	Purl      string `json:"purl"`
+	ReleaseID string `json:"releaseId"`
+}
+
+type runtimeTracePredicate struct {
+	Monitor struct {
+		Type string `json:"type"`
+	} `json:"monitor"`
+	MonitorLog struct {
+		Process    []interface{} `json:"process"`
+		Network    []interface{} `json:"network"`
+		FileAccess []interface{} `json:"fileAccess"`
+	} `json:"monitorLog"`
+	Metadata struct {
+		BuildStartedOn  *time.Time `json:"buildStartedOn"`
+		BuildFinishedOn *time.Time `json:"buildFinishedOn"`
+	} `json:"metadata"`
+}
+
+// subject is what evidence is recorded on: the artifacts of its digests, or
+// its package when it has no digests
+type subject struct {
+	pkg       *model.PkgInputSpec
+	artifacts []*model.ArtifactInputSpec
+}
+
+type attestationParser struct {
+	doc      *processor.Document
+	stmt     *statement
+	subjects []subject
+	// timestamp is when the predicate was made, if it records it
+	timestamp         time.Time
+	preds             *assembler.IngestPredicates
+	identifierStrings *common.IdentifierStrings
+}
+
+// NewAttestationParser initializes the attestationParser
+func NewAttestationParser() common.DocumentParser {
+	return &attestationParser{
+		identifierStrings: &common.IdentifierStrings{},
+	}
+}
+
+// Parse breaks out the document into the graph components
+func (a *attestationParser) Parse(ctx context.Context, doc *processor.Document) error {
+	a.doc = doc
+	a.preds = &assembler.IngestPredicates{}
+	a.stmt = &statement{}
+	a.timestamp = zeroTime
+	if err := json.Unmarshal(doc.Blob, a.stmt); err != nil {
+		return fmt.Errorf("failed to parse in-toto statement: %w", err)
+	}
+	for _, sub := range a.stmt.Subject {
+		a.subjects = append(a.subjects, a.getSubject(sub))
+	}
+
+	var err error
+	switch {
+	case a.stmt.PredicateType == predicateTestResult:
+		err = a.parseTestResult()
+	case a.stmt.PredicateType == predicateLink:
+		err = a.parseLink()
+	case strings.HasPrefix(a.stmt.PredicateType, predicateSCAI):
+		err = a.parseSCAI()
+	case a.stmt.PredicateType == predicateRelease:
+		err = a.parseRelease()
+	case a.stmt.PredicateType == predicateRuntime:
+		err = a.parseRuntimeTrace()
+	default:
+		err = fmt.Errorf("unsupported predicate type %q", a.stmt.PredicateType)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse %s predicate: %w", a.stmt.PredicateType, err)
+	}
+	return nil
+}
+
+func (a *attestationParser) getSubject(rd resourceDescriptor) subject {
+	var sub subject
+	for alg, digest := range rd.Digest {
+		sub.artifacts = append(sub.artifacts, &model.ArtifactInputSpec{
+			Algorithm: strings.ToLower(alg),
+			Digest:    strings.ToLower(digest),
+		})
+	}
+	for _, name := range []string{rd.Name, rd.URI} {
+		if !strings.HasPrefix(name, "pkg:") {
+			continue
+		}
+		if pkg, err := helpers.PurlToPkg(name); err == nil {
+			sub.pkg = pkg
+			a.identifierStrings.PurlStrings = append(a.identifierStrings.PurlStrings, name)
+			break
+		}
+	}
+	return sub
+}
+
+func (a *attestationParser) parseTestResult() error {
+	var predicate testResultPredicate
+	if err := json.Unmarshal(a.stmt.Predicate, &predicate); err != nil {
+		return err
+	}
+	justification := fmt.Sprintf("in-toto test result %s: %d passed, %d warned, %d failed",
+		predicate.Result, len(predicate.PassedTests), len(predicate.WarnedTests), len(predicate.FailedTests))
+	if predicate.URL != "" {
+		justification += " (" + predicate.URL + ")"
+	}
+
+	for _, sub := range a.subjects {
+		a.addMetadata(sub, "in-toto.test-result", predicate.Result, justification)
+		switch predicate.Result {
+		case "PASSED":
+			a.addCertifyGood(sub, justification)
+		case "FAILED":
+			a.addCertifyBad(sub, justification)
+		}
+	}
+	return nil
+}
+
+func (a *attestationParser) parseLink() error {
+	var predicate linkPredicate
+	if err := json.Unmarshal(a.stmt.Predicate, &predicate); err != nil {
+		return err
+	}
+	justification := "in-toto link"
+	if len(predicate.Command) > 0 {
+		justification += ": " + strings.Join(predicate.Command, " ")
+	}
+	for _, sub := range a.subjects {
+		a.addMetadata(sub, "in-toto.link", predicate.Name, justification)
+	}
+	return nil
+}
+
+func (a *attestationParser) parseSCAI() error {
+	var predicate scaiPredicate
+	if err := json.Unmarshal(a.stmt.Predicate, &predicate); err != nil {
+		return err
+	}
+	for _, attribute := range predicate.Attributes {
+		justification := "SCAI attribute report"
+		if attribute.Evidence != nil {
+			justification += " with evidence " + resourceName(*attribute.Evidence)
+		}
+		if predicate.Producer != nil {
+			justification += " produced by " + resourceName(*predicate.Producer)
+		}
+
+		subjects := a.subjects
+		if attribute.Target != nil {
+			if target := a.getSubject(*attribute.Target); target.pkg != nil || len(target.artifacts) > 0 {
+				subjects = []subject{target}
+			}
+		}
+		for _, sub := range subjects {
+			a.addMetadata(sub, "scai.attribute", attribute.Attribute, justification)
+		}
+	}
+	return nil
+}
+
+func (a *attestationParser) parseRelease() error {
+	var predicate releasePredicate
+	if err := json.Unmarshal(a.stmt.Predicate, &predicate); err != nil {
+		return err
+	}
+	pkg, err := helpers.PurlToPkg(predicate.Purl)
+	if err != nil {
+		return fmt.Errorf("bad purl of release: %w", err)
+	}
+	a.identifierStrings.PurlStrings = append(a.identifierStrings.PurlStrings, predicate.Purl)
+
+	release := subject{pkg: pkg}
+	a.addMetadata(release, "in-toto.release", predicate.ReleaseID, "in-toto release attestation")
+	for _, sub := range a.subjects {
+		for _, art := range sub.artifacts {
+			a.preds.IsOccurrence = append(a.preds.IsOccurrence, assembler.IsOccurrenceIngest{
+				Pkg:      pkg,
+				Artifact: art,
+				IsOccurrence: &model.IsOccurrenceInputSpec{
+					Justification: "in-toto release attestation",
+					Origin:        a.doc.SourceInformation.Source,
+					Collector:     a.doc.SourceInformation.Collector,
+				},
+			})
+		}
+	}
+	return nil
+}
+
+func (a *attestationParser) parseRuntimeTrace() error {
+	var predicate runtimeTracePredicate
+	if err := json.Unmarshal(a.stmt.Predicate, &predicate); err != nil {
+		return err
+	}
+	// the trace was recorded during the build
+	if predicate.Metadata.BuildFinishedOn != nil {
+		a.timestamp = predicate.Metadata.BuildFinishedOn.UTC()
+	} else if predicate.Metadata.BuildStartedOn != nil {
+		a.timestamp = predicate.Metadata.BuildStartedOn.UTC()
+	}
+	justification := fmt.Sprintf("in-toto runtime trace: %d process, %d network and %d file access events",
+		len(predicate.MonitorLog.Process), len(predicate.MonitorLog.Network), len(predicate.MonitorLog.FileAccess))
+	for _, sub := range a.subjects {
+		a.addMetadata(sub, "in-toto.runtime-trace", predicate.Monitor.Type, justification)
+	}
+	return nil
+}
+
+func resourceName(rd resourceDescriptor) string {
+	if rd.URI != "" {
+		return rd.URI
+	}
+	return rd.Name
+}
+
+func (a *attestationParser) addMetadata(sub subject, key string, value string, justification string) {
+	if value == "" {
+		return
+	}
+	metadata := &model.HasMetadataInputSpec{
+		Key:           key,
+		Value:         value,
+		Timestamp:     a.timestamp,
+		Justification: justification,
+		Origin:        a.doc.SourceInformation.Source,
+		Collector:     a.doc.SourceInformation.Collector,
+	}
+	for _, art := range sub.artifacts {
+		a.preds.HasMetadata = append(a.preds.HasMetadata, assembler.HasMetadataIngest{Artifact: art, HasMetadata: metadata})
+	}
+	if len(sub.artifacts) == 0 && sub.pkg != nil {
+		a.preds.HasMetadata = append(a.preds.HasMetadata, assembler.HasMetadataIngest{
+			Pkg:          sub.pkg,
+			PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+			HasMetadata:  metadata,
+		})
+	}
+}
+
+func (a *attestationParser) addCertifyGood(sub subject, justification string) {
+	good := &model.CertifyGoodInputSpec{
+		Justification: justification,
+		Origin:        a.doc.SourceInformation.Source,
+		Collector:     a.doc.SourceInformation.Collector,
+		KnownSince:    a.timestamp,
+	}
+	for _, art := range sub.artifacts {
+		a.preds.CertifyGood = append(a.preds.CertifyGood, assembler.CertifyGoodIngest{Artifact: art, CertifyGood: good})
+	}
+	if len(sub.artifacts) == 0 && sub.pkg != nil {
+		a.preds.CertifyGood = append(a.preds.CertifyGood, assembler.CertifyGoodIngest{
+			Pkg:          sub.pkg,
+			PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+			CertifyGood:  good,
+		})
+	}
+}
+
+func (a *attestationParser) addCertifyBad(sub subject, justification string) {
+	bad := &model.CertifyBadInputSpec{
+		Justification: justification,
+		Origin:        a.doc.SourceInformation.Source,
+		Collector:     a.doc.SourceInformation.Collector,
+		KnownSince:    a.timestamp,
+	}
+	for _, art := range sub.artifacts {
+		a.preds.CertifyBad = append(a.preds.CertifyBad, assembler.CertifyBadIngest{Artifact: art, CertifyBad: bad})
+	}
+	if len(sub.artifacts) == 0 && sub.pkg != nil {
+		a.preds.CertifyBad = append(a.preds.CertifyBad, assembler.CertifyBadIngest{
+			Pkg:          sub.pkg,
+			PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+			CertifyBad:   bad,
+		})
+	}
+}
+
+func (a *attestationParser) GetPredicates(ctx context.Context) *assembler.IngestPredicates {
+	return a.preds
+}
+
+// GetIdentities gets the identity node from the document if they exist
+func (a *attestationParser) GetIdentities(ctx context.Context) []common.TrustInformation {
+	return nil
+}
+
+func (a *attestationParser) GetIdentifiers(ctx context.Context) (*common.IdentifierStrings, error) {
+	return a.identifierStrings, nil
+}
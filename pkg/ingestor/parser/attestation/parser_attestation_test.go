@@ -0,0 +1,192 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/guacsec/guac/internal/testing/testdata"
+	"github.com/guacsec/guac/pkg/assembler"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	"github.com/guacsec/guac/pkg/assembler/helpers"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+func Test_attestationParser(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	art := &model.ArtifactInputSpec{Algorithm: "sha256", Digest: "abcd"}
+	release, _ := helpers.PurlToPkg("pkg:npm/app@1.2.3")
+	source := processor.SourceInformation{Collector: "TestCollector", Source: "TestSource"}
+	metadata := func(key, value, justification string) []assembler.HasMetadataIngest {
+		return []assembler.HasMetadataIngest{{
+			Artifact: art,
+			HasMetadata: &model.HasMetadataInputSpec{
+				Key:           key,
+				Value:         value,
+				Timestamp:     zeroTime,
+				Justification: justification,
+				Origin:        "TestSource",
+				Collector:     "TestCollector",
+			},
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		blob    string
+		want    *assembler.IngestPredicates
+		wantErr bool
+	}{{
+		name: "passed test result",
+		blob: `{"_type": "https://in-toto.io/Statement/v1", "subject": [{"name": "app.tgz", "digest": {"sha256": "abcd"}}],
+			"predicateType": "https://in-toto.io/attestation/test-result/v0.1",
+			"predicate": {"result": "PASSED", "url": "https://ci.example.com/run/1", "passedTests": ["a", "b"]}}`,
+		want: &assembler.IngestPredicates{
+			HasMetadata: metadata("in-toto.test-result", "PASSED", "in-toto test result PASSED: 2 passed, 0 warned, 0 failed (https://ci.example.com/run/1)"),
+			CertifyGood: []assembler.CertifyGoodIngest{{
+				Artifact: art,
+				CertifyGood: &model.CertifyGoodInputSpec{
+					KnownSince:    zeroTime,
+					Justification: "in-toto test result PASSED: 2 passed, 0 warned, 0 failed (https://ci.example.com/run/1)",
+					Origin:        "TestSource",
+					Collector:     "TestCollector",
+				},
+			}},
+		},
+	}, {
+		name: "failed test result on a package",
+		blob: `{"_type": "https://in-toto.io/Statement/v1", "subject": [{"name": "pkg:npm/app@1.2.3"}],
+			"predicateType": "https://in-toto.io/attestation/test-result/v0.1",
+			"predicate": {"result": "FAILED", "failedTests": ["a"]}}`,
+		want: &assembler.IngestPredicates{
+			HasMetadata: []assembler.HasMetadataIngest{{
+				Pkg:          release,
+				PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+				HasMetadata: &model.HasMetadataInputSpec{
+					Key:           "in-toto.test-result",
+					Value:         "FAILED",
+					Timestamp:     zeroTime,
+					Justification: "in-toto test result FAILED: 0 passed, 0 warned, 1 failed",
+					Origin:        "TestSource",
+					Collector:     "TestCollector",
+				},
+			}},
+			CertifyBad: []assembler.CertifyBadIngest{{
+				Pkg:          release,
+				PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+				CertifyBad: &model.CertifyBadInputSpec{
+					KnownSince:    zeroTime,
+					Justification: "in-toto test result FAILED: 0 passed, 0 warned, 1 failed",
+					Origin:        "TestSource",
+					Collector:     "TestCollector",
+				},
+			}},
+		},
+	}, {
+		name: "link",
+		blob: `{"_type": "https://in-toto.io/Statement/v1", "subject": [{"name": "app.tgz", "digest": {"sha256": "abcd"}}],
+			"predicateType": "https://in-toto.io/attestation/link/v0.3",
+			"predicate": {"name": "package", "command": ["npm", "pack"]}}`,
+		want: &assembler.IngestPredicates{
+			HasMetadata: metadata("in-toto.link", "package", "in-toto link: npm pack"),
+		},
+	}, {
+		name: "SCAI attribute report with a target",
+		blob: `{"_type": "https://in-toto.io/Statement/v1", "subject": [{"name": "report", "digest": {"sha256": "ffff"}}],
+			"predicateType": "https://in-toto.io/attestation/scai/attribute-report/v0.2",
+			"predicate": {"attributes": [{"attribute": "CODE_REVIEWED", "target": {"name": "app.tgz", "digest": {"sha256": "ABCD"}},
+				"evidence": {"uri": "https://github.com/example/app/pull/1"}}]}}`,
+		want: &assembler.IngestPredicates{
+			HasMetadata: metadata("scai.attribute", "CODE_REVIEWED", "SCAI attribute report with evidence https://github.com/example/app/pull/1"),
+		},
+	}, {
+		name: "release",
+		blob: `{"_type": "https://in-toto.io/Statement/v1", "subject": [{"name": "app.tgz", "digest": {"sha256": "abcd"}}],
+			"predicateType": "https://in-toto.io/attestation/release/v0.1",
+			"predicate": {"purl": "pkg:npm/app@1.2.3", "releaseId": "v1.2.3"}}`,
+		want: &assembler.IngestPredicates{
+			IsOccurrence: []assembler.IsOccurrenceIngest{{
+				Pkg:      release,
+				Artifact: art,
+				IsOccurrence: &model.IsOccurrenceInputSpec{
+					Justification: "in-toto release attestation",
+					Origin:        "TestSource",
+					Collector:     "TestCollector",
+				},
+			}},
+			HasMetadata: []assembler.HasMetadataIngest{{
+				Pkg:          release,
+				PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+				HasMetadata: &model.HasMetadataInputSpec{
+					Key:           "in-toto.release",
+					Value:         "v1.2.3",
+					Timestamp:     zeroTime,
+					Justification: "in-toto release attestation",
+					Origin:        "TestSource",
+					Collector:     "TestCollector",
+				},
+			}},
+		},
+	}, {
+		name: "runtime trace",
+		blob: `{"_type": "https://in-toto.io/Statement/v1", "subject": [{"name": "app.tgz", "digest": {"sha256": "abcd"}}],
+			"predicateType": "https://in-toto.io/attestation/runtime-trace/v0.1",
+			"predicate": {"monitor": {"type": "https://github.com/cilium/tetragon"}, "monitorLog": {"process": [{}, {}], "network": [{}]},
+				"metadata": {"buildStartedOn": "2024-03-01T10:00:00Z", "buildFinishedOn": "2024-03-01T10:05:00+01:00"}}}`,
+		want: &assembler.IngestPredicates{
+			HasMetadata: []assembler.HasMetadataIngest{{
+				Artifact: art,
+				HasMetadata: &model.HasMetadataInputSpec{
+					Key:           "in-toto.runtime-trace",
+					Value:         "https://github.com/cilium/tetragon",
+					Timestamp:     time.Date(2024, time.March, 1, 9, 5, 0, 0, time.UTC),
+					Justification: "in-toto runtime trace: 2 process, 1 network and 0 file access events",
+					Origin:        "TestSource",
+					Collector:     "TestCollector",
+				},
+			}},
+		},
+	}, {
+		name:    "release with a bad purl",
+		blob:    `{"_type": "https://in-toto.io/Statement/v1", "predicateType": "https://in-toto.io/attestation/release/v0.1", "predicate": {"purl": "app"}}`,
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewAttestationParser()
+			err := p.Parse(ctx, &processor.Document{
+				Blob:              []byte(tt.blob),
+				Format:            processor.FormatJSON,
+				Type:              processor.DocumentITE6Attestation,
+				SourceInformation: source,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("attestationParser.Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if d := cmp.Diff(tt.want, p.GetPredicates(ctx), testdata.IngestPredicatesCmpOpts...); len(d) != 0 {
+				t.Errorf("attestation.GetPredicate mismatch values (+got, -expected): %s", d)
+			}
+		})
+	}
+}
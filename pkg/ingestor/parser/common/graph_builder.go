@@ -105,11 +105,19 @@ func addMetadata(predicates *assembler.IngestPredicates, foundIdentities []Trust
 
 	for _, v := range predicates.VulnMetadata {
 		v.VulnMetadata.Collector = srcInfo.Collector
-		v.VulnMetadata.Origin = srcInfo.Source
+		// ratings may carry their own source
+		if v.VulnMetadata.Origin == "" {
+			v.VulnMetadata.Origin = srcInfo.Source
+		}
 	}
 
 	for _, v := range predicates.Vex {
 		v.VexData.Collector = srcInfo.Collector
 		v.VexData.Origin = srcInfo.Source
 	}
+
+	for _, v := range predicates.PointOfContact {
+		v.PointOfContact.Collector = srcInfo.Collector
+		v.PointOfContact.Origin = srcInfo.Source
+	}
 }
@@ -0,0 +1,128 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Khan/genqlient/graphql"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	asmhelpers "github.com/guacsec/guac/pkg/assembler/helpers"
+)
+
+type sbomResolverKey struct{}
+
+// SBOMPackageResolver resolves components of previously ingested SBOMs, so
+// that documents referencing them (such as standalone VEX documents) can be
+// attached to the same packages
+type SBOMPackageResolver interface {
+	// ResolvePackages returns the packages included in the SBOM with the
+	// given uri which are identified by ref, either by purl or by name
+	ResolvePackages(ctx context.Context, sbomURI string, ref string) ([]*model.PkgInputSpec, error)
+}
+
+// WithSBOMPackageResolver returns a context that parsers can use to resolve
+// references into previously ingested SBOMs
+func WithSBOMPackageResolver(ctx context.Context, resolver SBOMPackageResolver) context.Context {
+	return context.WithValue(ctx, sbomResolverKey{}, resolver)
+}
+
+// SBOMPackageResolverFromContext returns the resolver stored in the context,
+// or nil if there is none
+func SBOMPackageResolverFromContext(ctx context.Context) SBOMPackageResolver {
+	if resolver, ok := ctx.Value(sbomResolverKey{}).(SBOMPackageResolver); ok {
+		return resolver
+	}
+	return nil
+}
+
+type graphQLSBOMPackageResolver struct {
+	client graphql.Client
+
+	mu sync.Mutex
+	// sboms caches the packages included in the SBOMs looked up by uri, as
+	// a document usually references many components of the same SBOM
+	sboms map[string][]sbomPackage
+}
+
+type sbomPackage struct {
+	purl string
+	name string
+}
+
+// NewGraphQLSBOMPackageResolver returns a resolver that looks up HasSBOM
+// nodes through the GraphQL API. The SBOMs looked up are cached, so a new
+// resolver is meant to be used for each parse, which then sees the SBOMs
+// ingested in between.
+func NewGraphQLSBOMPackageResolver(client graphql.Client) SBOMPackageResolver {
+	return &graphQLSBOMPackageResolver{client: client, sboms: map[string][]sbomPackage{}}
+}
+
+func (r *graphQLSBOMPackageResolver) ResolvePackages(ctx context.Context, sbomURI string, ref string) ([]*model.PkgInputSpec, error) {
+	included, err := r.sbomPackages(ctx, sbomURI)
+	if err != nil {
+		return nil, err
+	}
+
+	var pkgs []*model.PkgInputSpec
+	for _, pkg := range included {
+		if pkg.purl != ref && pkg.name != ref && pkg.name != asmhelpers.SanitizeString(ref) {
+			continue
+		}
+		pkgInput, err := asmhelpers.PurlToPkg(pkg.purl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create package input spec from %q: %w", pkg.purl, err)
+		}
+		pkgs = append(pkgs, pkgInput)
+	}
+	return pkgs, nil
+}
+
+// sbomPackages returns the distinct packages included in the SBOMs with the
+// given uri, querying them only the first time
+func (r *graphQLSBOMPackageResolver) sbomPackages(ctx context.Context, sbomURI string) ([]sbomPackage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if included, ok := r.sboms[sbomURI]; ok {
+		return included, nil
+	}
+
+	response, err := model.HasSBOMs(ctx, r.client, model.HasSBOMSpec{Uri: &sbomURI})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query HasSBOM with uri %q: %w", sbomURI, err)
+	}
+
+	var included []sbomPackage
+	seen := map[string]bool{}
+	for _, hasSBOM := range response.HasSBOM {
+		for _, software := range hasSBOM.IncludedSoftware {
+			pkg, ok := software.(*model.AllHasSBOMTreeIncludedSoftwarePackage)
+			if !ok || len(pkg.Namespaces) == 0 || len(pkg.Namespaces[0].Names) == 0 {
+				continue
+			}
+			purl := asmhelpers.AllPkgTreeToPurl(&pkg.AllPkgTree)
+			if seen[purl] {
+				continue
+			}
+			seen[purl] = true
+			included = append(included, sbomPackage{purl: purl, name: pkg.Namespaces[0].Names[0].Name})
+		}
+	}
+	r.sboms[sbomURI] = included
+	return included, nil
+}
@@ -0,0 +1,78 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/google/go-cmp/cmp"
+	asmhelpers "github.com/guacsec/guac/pkg/assembler/helpers"
+)
+
+const hasSBOMsResponse = `{"data": {"HasSBOM": [{
+  "id": "1",
+  "uri": "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79",
+  "includedSoftware": [
+    {"__typename": "Package", "id": "2", "type": "npm", "namespaces": [{"id": "3", "namespace": "", "names": [
+      {"id": "4", "name": "lib", "versions": [{"id": "5", "version": "1.0.0", "qualifiers": [], "subpath": ""}]}
+    ]}]},
+    {"__typename": "Package", "id": "6", "type": "npm", "namespaces": [{"id": "7", "namespace": "", "names": [
+      {"id": "8", "name": "app", "versions": [{"id": "9", "version": "3.0.0", "qualifiers": [], "subpath": ""}]}
+    ]}]}
+  ]
+}]}}`
+
+func TestGraphQLSBOMPackageResolver(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(hasSBOMsResponse))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	resolver := NewGraphQLSBOMPackageResolver(graphql.NewClient(server.URL, server.Client()))
+	const sbomURI = "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79"
+	for ref, purl := range map[string]string{
+		"lib":                 "pkg:npm/lib@1.0.0",
+		"pkg:npm/app@3.0.0":   "pkg:npm/app@3.0.0",
+		"lib-not-in-the-sbom": "",
+	} {
+		got, err := resolver.ResolvePackages(ctx, sbomURI, ref)
+		if err != nil {
+			t.Fatalf("ResolvePackages(%q) error: %v", ref, err)
+		}
+		var gotPurls []string
+		for _, pkg := range got {
+			gotPurls = append(gotPurls, asmhelpers.PkgInputSpecToPurl(pkg))
+		}
+		var want []string
+		if purl != "" {
+			want = []string{purl}
+		}
+		if diff := cmp.Diff(want, gotPurls); diff != "" {
+			t.Errorf("ResolvePackages(%q) mismatch (-want +got):\n%s", ref, diff)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the SBOM to be queried once, got %d queries", requests)
+	}
+}
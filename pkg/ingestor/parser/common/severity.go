@@ -0,0 +1,51 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"strings"
+
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+)
+
+// severityScores rank the qualitative severity ratings. Negligible is a rating
+// of some distro feeds below low.
+var severityScores = map[string]float64{
+	"critical":   4,
+	"high":       3,
+	"medium":     2,
+	"moderate":   2,
+	"low":        1,
+	"negligible": 0,
+	"none":       0,
+}
+
+// SeverityMetadata returns the metadata recording the severity a source,
+// such as a distro feed, rated a vulnerability without a CVSS score. As the
+// rating is not a CVSS score, it is recorded with the SEVERITY score type,
+// ranked from 0 for none or negligible to 4 for critical. Unknown severities
+// return nil.
+func SeverityMetadata(source string, severity string) *model.VulnerabilityMetadataInputSpec {
+	score, ok := severityScores[strings.ToLower(severity)]
+	if !ok {
+		return nil
+	}
+	return &model.VulnerabilityMetadataInputSpec{
+		ScoreType:  model.VulnerabilityScoreTypeSeverity,
+		ScoreValue: score,
+		Origin:     source,
+	}
+}
@@ -0,0 +1,76 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	asmhelpers "github.com/guacsec/guac/pkg/assembler/helpers"
+)
+
+// repositoryPathSegments are the number of path segments of a repository URL
+// on the forges that helpers.VcsToSrc recognizes from https URLs
+var repositoryPathSegments = map[string]int{
+	"github.com":          2,
+	"gitlab.com":          2,
+	"bitbucket.org":       2,
+	"go.googlesource.com": 1,
+}
+
+// SourceFromLocation returns the source repository of a VCS location from an
+// SBOM, such as git+https://github.com/org/repo@v1.0.0 or
+// https://github.com/org/repo.git. Other locations, such as release archives
+// or NOASSERTION, return nil.
+func SourceFromLocation(location string) *model.SourceInputSpec {
+	u, err := url.Parse(location)
+	if err != nil || u.Host == "" {
+		return nil
+	}
+	if !strings.Contains(u.Scheme, "+") {
+		segments, ok := repositoryPathSegments[u.Host]
+		if !ok || u.Scheme != "https" {
+			return nil
+		}
+		path := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+		if path == "" || strings.Count(path, "/") != segments-1 {
+			return nil
+		}
+		location = "https://" + u.Host + "/" + path
+	}
+
+	src, err := asmhelpers.VcsToSrc(location)
+	if err != nil || src.Name == "" {
+		return nil
+	}
+	src.Name = strings.TrimSuffix(src.Name, ".git")
+	return src
+}
+
+var contactEmail = regexp.MustCompile(`^(.*?)\s*[(<]([^()<>\s]+@[^()<>\s]+)[)>]\s*$`)
+
+// ParseContact splits a contact of the form "name (email)" or
+// "name <email>", as found in SPDX suppliers and originators and CycloneDX
+// authors, into the name and the email, which is empty when absent
+func ParseContact(contact string) (string, string) {
+	contact = strings.TrimSpace(contact)
+	if m := contactEmail.FindStringSubmatch(contact); m != nil {
+		return m[1], m[2]
+	}
+	return contact, ""
+}
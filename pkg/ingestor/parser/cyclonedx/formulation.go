@@ -0,0 +1,280 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cyclonedx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/jeremywohl/flatten"
+
+	"github.com/guacsec/guac/pkg/assembler"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+// formulationBuildType is the build type of the provenance derived from
+// CycloneDX formulation workflows
+const formulationBuildType = "https://cyclonedx.org/schema/bom/formulation/workflow"
+
+// addFormulaComponents records the checksums of the components used to build
+// the software, such as compilers and build tools, so that workflows can
+// reference them as materials. They aren't dependencies of the software.
+func (c *cyclonedxParser) addFormulaComponents(components []cdx.Component) {
+	for _, comp := range components {
+		if comp.Hashes != nil {
+			for _, checksum := range *comp.Hashes {
+				c.packageArtifacts[comp.BOMRef] = append(c.packageArtifacts[comp.BOMRef], &model.ArtifactInputSpec{
+					Algorithm: strings.ToLower(string(checksum.Algorithm)),
+					Digest:    checksum.Value,
+				})
+			}
+		}
+		if comp.Components != nil {
+			c.addFormulaComponents(*comp.Components)
+		}
+	}
+}
+
+// addFormulation maps the workflows of the formulation, which describe how the
+// software was built, to build provenance. Workflows producing artifacts with
+// checksums become HasSLSA, the others are recorded as HasMetadata on the
+// packages they produced or on the top level package.
+func (c *cyclonedxParser) addFormulation(ctx context.Context, preds *assembler.IngestPredicates, toplevel []*model.PkgInputSpec, timestamp time.Time) {
+	logger := logging.FromContext(ctx)
+	if c.cdxBom.Formulation == nil {
+		return
+	}
+	for _, formula := range *c.cdxBom.Formulation {
+		if formula.Workflows == nil {
+			continue
+		}
+		for _, workflow := range *formula.Workflows {
+			inputs, outputs := workflowResources(workflow)
+
+			var materials []model.ArtifactInputSpec
+			for _, input := range inputs {
+				for _, art := range c.resourceArtifacts(input) {
+					materials = append(materials, *art)
+				}
+			}
+			var subjects []*model.ArtifactInputSpec
+			for _, output := range outputs {
+				subjects = append(subjects, c.resourceArtifacts(output)...)
+			}
+
+			if len(subjects) == 0 {
+				var pkgs []*model.PkgInputSpec
+				for _, output := range outputs {
+					if output.Ref != "" {
+						pkgs = append(pkgs, c.packagePackages[output.Ref]...)
+					}
+				}
+				if len(pkgs) == 0 {
+					pkgs = toplevel
+				}
+				for _, pkg := range pkgs {
+					preds.HasMetadata = append(preds.HasMetadata, workflowMetadata(pkg, workflow, timestamp, c.doc.SourceInformation)...)
+				}
+				continue
+			}
+
+			slsa, err := c.workflowSLSA(workflow)
+			if err != nil {
+				logger.Errorf("could not convert CycloneDX workflow %q to provenance: %v", workflowID(workflow), err)
+				continue
+			}
+			builder := &model.BuilderInputSpec{Uri: workflowBuilder(workflow)}
+			for _, subject := range subjects {
+				preds.HasSlsa = append(preds.HasSlsa, assembler.HasSlsaIngest{
+					Artifact:  subject,
+					HasSlsa:   slsa,
+					Materials: materials,
+					Builder:   builder,
+				})
+			}
+		}
+	}
+}
+
+// resourceArtifacts returns the artifacts of a resource, which is either a
+// reference to a component of the BOM or an external reference with hashes
+func (c *cyclonedxParser) resourceArtifacts(resource cdx.ResourceReferenceChoice) []*model.ArtifactInputSpec {
+	if resource.Ref != "" {
+		return c.packageArtifacts[resource.Ref]
+	}
+	var artifacts []*model.ArtifactInputSpec
+	if resource.ExternalReference != nil && resource.ExternalReference.Hashes != nil {
+		for _, checksum := range *resource.ExternalReference.Hashes {
+			artifacts = append(artifacts, &model.ArtifactInputSpec{
+				Algorithm: strings.ToLower(string(checksum.Algorithm)),
+				Digest:    checksum.Value,
+			})
+		}
+	}
+	return artifacts
+}
+
+// workflowResources collects the inputs and outputs of a workflow and of its
+// tasks.
+func workflowResources(workflow cdx.Workflow) ([]cdx.ResourceReferenceChoice, []cdx.ResourceReferenceChoice) {
+	inputLists := []*[]cdx.TaskInput{workflow.Inputs}
+	outputLists := []*[]cdx.TaskOutput{workflow.Outputs}
+	if workflow.Tasks != nil {
+		for _, task := range *workflow.Tasks {
+			inputLists = append(inputLists, task.Inputs)
+			outputLists = append(outputLists, task.Outputs)
+		}
+	}
+
+	var inputs, outputs []cdx.ResourceReferenceChoice
+	for _, list := range inputLists {
+		if list == nil {
+			continue
+		}
+		for _, input := range *list {
+			for _, resource := range []*cdx.ResourceReferenceChoice{input.Resource, input.Source} {
+				if resource != nil {
+					inputs = append(inputs, *resource)
+				}
+			}
+		}
+	}
+	for _, list := range outputLists {
+		if list == nil {
+			continue
+		}
+		for _, output := range *list {
+			for _, resource := range []*cdx.ResourceReferenceChoice{output.Resource, output.Target} {
+				if resource != nil {
+					outputs = append(outputs, *resource)
+				}
+			}
+		}
+	}
+	return inputs, outputs
+}
+
+func (c *cyclonedxParser) workflowSLSA(workflow cdx.Workflow) (*model.SLSAInputSpec, error) {
+	slsa := &model.SLSAInputSpec{
+		BuildType:   formulationBuildType,
+		SlsaVersion: "https://cyclonedx.org/schema/bom/" + c.cdxBom.SpecVersion.String(),
+	}
+	if started, err := time.Parse(time.RFC3339, workflow.TimeStart); err == nil {
+		slsa.StartedOn = &started
+	}
+	if finished, err := time.Parse(time.RFC3339, workflow.TimeEnd); err == nil {
+		slsa.FinishedOn = &finished
+	}
+
+	// the workflow with its tasks and steps is kept as the predicate
+	workflowJSON, err := json.Marshal(workflow)
+	if err != nil {
+		return nil, err
+	}
+	var workflowMap map[string]interface{}
+	if err := json.Unmarshal(workflowJSON, &workflowMap); err != nil {
+		return nil, err
+	}
+	flatMap, err := flatten.Flatten(workflowMap, "cdx.", flatten.SeparatorStyle{Middle: "."})
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range flatMap {
+		slsa.SlsaPredicate = append(slsa.SlsaPredicate, model.SLSAPredicateInputSpec{
+			Key:   k,
+			Value: fmt.Sprintf("%v", v),
+		})
+	}
+	return slsa, nil
+}
+
+// workflowBuilder returns the build system the workflow references, falling
+// back to the identifier of the workflow itself
+func workflowBuilder(workflow cdx.Workflow) string {
+	references := workflow.ResourceReferences
+	if references == nil && workflow.Trigger != nil {
+		references = workflow.Trigger.ResourceReferences
+	}
+	if references != nil {
+		for _, reference := range *references {
+			if reference.ExternalReference != nil && reference.ExternalReference.Type == cdx.ERTypeBuildSystem {
+				return reference.ExternalReference.URL
+			}
+		}
+	}
+	return workflowID(workflow)
+}
+
+func workflowID(workflow cdx.Workflow) string {
+	for _, id := range []string{workflow.UID, workflow.BOMRef, workflow.Name} {
+		if id != "" {
+			return id
+		}
+	}
+	return "cyclonedx-workflow"
+}
+
+// workflowMetadata records the workflow, its tasks and their steps and
+// commands as metadata of a package it produced, with the origin and
+// collector of the document
+func workflowMetadata(pkg *model.PkgInputSpec, workflow cdx.Workflow, timestamp time.Time, srcInfo processor.SourceInformation) []assembler.HasMetadataIngest {
+	var metadata []assembler.HasMetadataIngest
+	add := func(key string, value string) {
+		if value == "" {
+			return
+		}
+		metadata = append(metadata, assembler.HasMetadataIngest{
+			Pkg:          pkg,
+			PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+			HasMetadata: &model.HasMetadataInputSpec{
+				Key:           key,
+				Value:         value,
+				Timestamp:     timestamp,
+				Justification: "Found in CycloneDX formulation.",
+				Origin:        srcInfo.Source,
+				Collector:     srcInfo.Collector,
+			},
+		})
+	}
+	addSteps := func(steps *[]cdx.TaskStep) {
+		if steps == nil {
+			return
+		}
+		for _, step := range *steps {
+			add("cdx.formulation.step", step.Name)
+			if step.Commands != nil {
+				for _, command := range *step.Commands {
+					add("cdx.formulation.command", command.Executed)
+				}
+			}
+		}
+	}
+
+	add("cdx.formulation.workflow", workflowID(workflow))
+	addSteps(workflow.Steps)
+	if workflow.Tasks != nil {
+		for _, task := range *workflow.Tasks {
+			add("cdx.formulation.task", task.Name)
+			addSteps(task.Steps)
+		}
+	}
+	return metadata
+}
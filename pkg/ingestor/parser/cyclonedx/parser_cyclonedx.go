@@ -39,21 +39,50 @@ var json = jsoniter.ConfigCompatibleWithStandardLibrary
 var zeroTime = time.Unix(0, 0)
 
 var vexStatusMap = map[cdx.ImpactAnalysisState]model.VexStatus{
-	cdx.IASResolved:    model.VexStatusFixed,
-	cdx.IASExploitable: model.VexStatusAffected,
-	cdx.IASInTriage:    model.VexStatusUnderInvestigation,
-	cdx.IASNotAffected: model.VexStatusNotAffected,
+	cdx.IASResolved:             model.VexStatusFixed,
+	cdx.IASResolvedWithPedigree: model.VexStatusFixed,
+	cdx.IASExploitable:          model.VexStatusAffected,
+	cdx.IASInTriage:             model.VexStatusUnderInvestigation,
+	cdx.IASFalsePositive:        model.VexStatusNotAffected,
+	cdx.IASNotAffected:          model.VexStatusNotAffected,
 }
 
 var justificationsMap = map[cdx.ImpactAnalysisJustification]model.VexJustification{
-	cdx.IAJCodeNotPresent:   model.VexJustificationVulnerableCodeNotPresent,
-	cdx.IAJCodeNotReachable: model.VexJustificationVulnerableCodeNotInExecutePath,
+	cdx.IAJCodeNotPresent:               model.VexJustificationVulnerableCodeNotPresent,
+	cdx.IAJCodeNotReachable:             model.VexJustificationVulnerableCodeNotInExecutePath,
+	cdx.IAJRequiresConfiguration:        model.VexJustificationVulnerableCodeCannotBeControlledByAdversary,
+	cdx.IAJRequiresDependency:           model.VexJustificationComponentNotPresent,
+	cdx.IAJRequiresEnvironment:          model.VexJustificationVulnerableCodeCannotBeControlledByAdversary,
+	cdx.IAJProtectedByCompiler:          model.VexJustificationInlineMitigationsAlreadyExist,
+	cdx.IAJProtectedAtRuntime:           model.VexJustificationInlineMitigationsAlreadyExist,
+	cdx.IAJProtectedAtPerimeter:         model.VexJustificationInlineMitigationsAlreadyExist,
+	cdx.IAJProtectedByMitigatingControl: model.VexJustificationInlineMitigationsAlreadyExist,
+}
+
+// affectedStatusMap maps the status of a single affected version, which
+// overrides the state of the analysis for that version
+var affectedStatusMap = map[cdx.VulnerabilityStatus]model.VexStatus{
+	cdx.VulnerabilityStatusAffected:    model.VexStatusAffected,
+	cdx.VulnerabilityStatusNotAffected: model.VexStatusNotAffected,
+	cdx.VulnerabilityStatusUnknown:     model.VexStatusUnderInvestigation,
+}
+
+var scoreTypeMap = map[cdx.ScoringMethod]model.VulnerabilityScoreType{
+	cdx.ScoringMethodCVSSv2:  model.VulnerabilityScoreTypeCvssv2,
+	cdx.ScoringMethodCVSSv3:  model.VulnerabilityScoreTypeCvssv3,
+	cdx.ScoringMethodCVSSv31: model.VulnerabilityScoreTypeCvssv31,
+	cdx.ScoringMethodCVSSv4:  model.VulnerabilityScoreTypeCvssv4,
+	cdx.ScoringMethodOWASP:   model.VulnerabilityScoreTypeOwasp,
+	cdx.ScoringMethodSSVC:    model.VulnerabilityScoreTypeSsvc,
 }
 
 type cyclonedxParser struct {
 	doc               *processor.Document
 	packagePackages   map[string][]*model.PkgInputSpec
 	packageArtifacts  map[string][]*model.ArtifactInputSpec
+	packageSources    map[string][]*model.SourceInputSpec
+	packageContacts   map[string][]*model.PointOfContactInputSpec
+	nestedRefs        map[string][]string
 	identifierStrings *common.IdentifierStrings
 	cdxBom            *cdx.BOM
 	vulnData          vulnData
@@ -69,6 +98,9 @@ func NewCycloneDXParser() common.DocumentParser {
 	return &cyclonedxParser{
 		packagePackages:   map[string][]*model.PkgInputSpec{},
 		packageArtifacts:  map[string][]*model.ArtifactInputSpec{},
+		packageSources:    map[string][]*model.SourceInputSpec{},
+		packageContacts:   map[string][]*model.PointOfContactInputSpec{},
+		nestedRefs:        map[string][]string{},
 		identifierStrings: &common.IdentifierStrings{},
 	}
 }
@@ -135,6 +167,27 @@ func (c *cyclonedxParser) getTopLevelPackage() error {
 				c.packageArtifacts[c.cdxBom.Metadata.Component.BOMRef] = append(c.packageArtifacts[c.cdxBom.Metadata.Component.BOMRef], artifact)
 			}
 		}
+
+		c.addSourcesAndContacts(c.cdxBom.Metadata.Component)
+		ref := c.cdxBom.Metadata.Component.BOMRef
+		if c.cdxBom.Metadata.Authors != nil {
+			for _, author := range *c.cdxBom.Metadata.Authors {
+				if author.Name == "" && author.Email == "" {
+					continue
+				}
+				c.packageContacts[ref] = append(c.packageContacts[ref], &model.PointOfContactInputSpec{
+					Email:         author.Email,
+					Info:          "author: " + author.Name,
+					Justification: "Found in CycloneDX metadata authors.",
+				})
+			}
+		}
+		c.packageContacts[ref] = append(c.packageContacts[ref], organizationalContacts("manufacturer", c.cdxBom.Metadata.Manufacture, "Found in CycloneDX metadata manufacture.")...)
+		c.packageContacts[ref] = append(c.packageContacts[ref], organizationalContacts("supplier", c.cdxBom.Metadata.Supplier, "Found in CycloneDX metadata supplier.")...)
+		return nil
+	} else if c.cdxBom.Vulnerabilities != nil && len(*c.cdxBom.Vulnerabilities) > 0 {
+		// standalone VEX BOMs only contain vulnerabilities, which reference
+		// components of other BOMs
 		return nil
 	} else {
 		// currently GUAC does not support CycloneDX component field in metadata or the BOM ref being nil.
@@ -171,45 +224,181 @@ func parseContainerType(name string, version string, topLevel bool) string {
 }
 
 func (c *cyclonedxParser) getPackages() error {
+	// components nested in the top level component are already connected
+	// to it by the top level package heuristic
+	if c.cdxBom.Metadata != nil && c.cdxBom.Metadata.Component != nil && c.cdxBom.Metadata.Component.Components != nil {
+		if err := c.addComponents("", *c.cdxBom.Metadata.Component.Components); err != nil {
+			return err
+		}
+	}
 	if c.cdxBom.Components != nil {
-		for _, comp := range *c.cdxBom.Components {
-			// skipping over the "operating-system" type as it does not contain
-			// the required purl for package node. Currently there is no use-case
-			// to capture OS for GUAC.
-			if comp.Type != cdx.ComponentTypeOS {
-				purl := comp.PackageURL
-				if purl == "" {
-					if comp.Type == cdx.ComponentTypeContainer {
-						purl = parseContainerType(comp.Name, comp.Version, false)
-					} else if comp.Type == cdx.ComponentTypeFile {
-						purl = guacCDXFilePurl(comp.Name, comp.Version, false)
-					} else {
-						purl = asmhelpers.GuacPkgPurl(comp.Name, &comp.Version)
-					}
-				}
-				pkg, err := asmhelpers.PurlToPkg(purl)
-				if err != nil {
-					return err
+		if err := c.addComponents("", *c.cdxBom.Components); err != nil {
+			return err
+		}
+	}
+	if c.cdxBom.Services != nil {
+		if err := c.addServices("", *c.cdxBom.Services); err != nil {
+			return err
+		}
+	}
+	if c.cdxBom.Formulation != nil {
+		for _, formula := range *c.cdxBom.Formulation {
+			if formula.Components != nil {
+				c.addFormulaComponents(*formula.Components)
+			}
+		}
+	}
+	return nil
+}
+
+// addComponents adds the packages of the components, and of the components
+// nested within them, which are recorded as dependencies of their parent.
+func (c *cyclonedxParser) addComponents(parentRef string, components []cdx.Component) error {
+	for i := range components {
+		comp := &components[i]
+		nestedParentRef := parentRef
+		// skipping over the "operating-system" type as it does not contain
+		// the required purl for package node. Currently there is no use-case
+		// to capture OS for GUAC.
+		if comp.Type != cdx.ComponentTypeOS {
+			purl := comp.PackageURL
+			if purl == "" {
+				if comp.Type == cdx.ComponentTypeContainer {
+					purl = parseContainerType(comp.Name, comp.Version, false)
+				} else if comp.Type == cdx.ComponentTypeFile {
+					purl = guacCDXFilePurl(comp.Name, comp.Version, false)
+				} else {
+					purl = asmhelpers.GuacPkgPurl(comp.Name, &comp.Version)
 				}
-				c.packagePackages[comp.BOMRef] = append(c.packagePackages[comp.BOMRef], pkg)
-				c.identifierStrings.PurlStrings = append(c.identifierStrings.PurlStrings, comp.PackageURL)
-
-				// if checksums exists create an artifact for each of them
-				if comp.Hashes != nil {
-					for _, checksum := range *comp.Hashes {
-						artifact := &model.ArtifactInputSpec{
-							Algorithm: strings.ToLower(string(checksum.Algorithm)),
-							Digest:    checksum.Value,
-						}
-						c.packageArtifacts[comp.BOMRef] = append(c.packageArtifacts[comp.BOMRef], artifact)
+			}
+			pkg, err := asmhelpers.PurlToPkg(purl)
+			if err != nil {
+				return err
+			}
+			c.packagePackages[comp.BOMRef] = append(c.packagePackages[comp.BOMRef], pkg)
+			c.identifierStrings.PurlStrings = append(c.identifierStrings.PurlStrings, comp.PackageURL)
+
+			// if checksums exists create an artifact for each of them
+			if comp.Hashes != nil {
+				for _, checksum := range *comp.Hashes {
+					artifact := &model.ArtifactInputSpec{
+						Algorithm: strings.ToLower(string(checksum.Algorithm)),
+						Digest:    checksum.Value,
 					}
+					c.packageArtifacts[comp.BOMRef] = append(c.packageArtifacts[comp.BOMRef], artifact)
 				}
 			}
+			c.addSourcesAndContacts(comp)
+
+			if parentRef != "" {
+				c.nestedRefs[parentRef] = append(c.nestedRefs[parentRef], comp.BOMRef)
+			}
+			nestedParentRef = comp.BOMRef
+		}
+		if comp.Components != nil {
+			if err := c.addComponents(nestedParentRef, *comp.Components); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addServices adds a package for each of the services, and of the services
+// nested within them, as GUAC doesn't distinguish services from packages.
+func (c *cyclonedxParser) addServices(parentRef string, services []cdx.Service) error {
+	for i := range services {
+		svc := &services[i]
+		name := svc.Name
+		if svc.Group != "" {
+			name = svc.Group + "/" + svc.Name
+		}
+		pkg, err := asmhelpers.PurlToPkg(asmhelpers.GuacPkgPurl(name, &svc.Version))
+		if err != nil {
+			return err
+		}
+		c.packagePackages[svc.BOMRef] = append(c.packagePackages[svc.BOMRef], pkg)
+
+		c.addVCSSources(svc.BOMRef, svc.ExternalReferences)
+		c.packageContacts[svc.BOMRef] = append(c.packageContacts[svc.BOMRef], organizationalContacts("provider", svc.Provider, "Found in CycloneDX service provider.")...)
+
+		if parentRef != "" {
+			c.nestedRefs[parentRef] = append(c.nestedRefs[parentRef], svc.BOMRef)
+		}
+		if svc.Services != nil {
+			if err := c.addServices(svc.BOMRef, *svc.Services); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
+// addSourcesAndContacts collects the VCS external references of a component
+// as its source repositories, and its supplier, author and publisher as its
+// points of contact
+func (c *cyclonedxParser) addSourcesAndContacts(comp *cdx.Component) {
+	c.addVCSSources(comp.BOMRef, comp.ExternalReferences)
+
+	c.packageContacts[comp.BOMRef] = append(c.packageContacts[comp.BOMRef], organizationalContacts("supplier", comp.Supplier, "Found in CycloneDX component supplier.")...)
+	for role, contact := range map[string]string{"author": comp.Author, "publisher": comp.Publisher} {
+		if contact == "" {
+			continue
+		}
+		name, email := common.ParseContact(contact)
+		c.packageContacts[comp.BOMRef] = append(c.packageContacts[comp.BOMRef], &model.PointOfContactInputSpec{
+			Email:         email,
+			Info:          role + ": " + name,
+			Justification: fmt.Sprintf("Found in CycloneDX component %s.", role),
+		})
+	}
+}
+
+func (c *cyclonedxParser) addVCSSources(ref string, extRefs *[]cdx.ExternalReference) {
+	if extRefs == nil {
+		return
+	}
+	for _, extRef := range *extRefs {
+		if extRef.Type != cdx.ERTypeVCS {
+			continue
+		}
+		if src := common.SourceFromLocation(extRef.URL); src != nil {
+			c.packageSources[ref] = append(c.packageSources[ref], src)
+		}
+	}
+}
+
+// organizationalContacts returns a point of contact for each contact of an
+// organization, or one for the organization itself when it lists no contacts
+func organizationalContacts(role string, org *cdx.OrganizationalEntity, justification string) []*model.PointOfContactInputSpec {
+	if org == nil {
+		return nil
+	}
+	info := role + ": " + org.Name
+	if org.URL != nil && len(*org.URL) > 0 {
+		info = fmt.Sprintf("%s (%s)", info, strings.Join(*org.URL, ", "))
+	}
+	if org.Contact == nil || len(*org.Contact) == 0 {
+		if org.Name == "" {
+			return nil
+		}
+		return []*model.PointOfContactInputSpec{{Info: info, Justification: justification}}
+	}
+	var pocs []*model.PointOfContactInputSpec
+	for _, contact := range *org.Contact {
+		contactInfo := info
+		if contact.Name != "" {
+			contactInfo = fmt.Sprintf("%s, %s", info, contact.Name)
+		}
+		pocs = append(pocs, &model.PointOfContactInputSpec{
+			Email:         contact.Email,
+			Info:          contactInfo,
+			Justification: justification,
+		})
+	}
+	return pocs
+}
+
 func ParseCycloneDXBOM(doc *processor.Document) (*cdx.BOM, error) {
 	bom := cdx.BOM{}
 	switch doc.Format {
@@ -240,23 +429,24 @@ func (c *cyclonedxParser) GetPredicates(ctx context.Context) *assembler.IngestPr
 		toplevel = c.getPackageElement(c.cdxBom.Metadata.Component.BOMRef)
 	}
 
-	// adding top level package edge manually for all depends on package
-	// TODO: This is not based on the relationship so that can be inaccurate (can capture both direct and in-direct)...Remove this and be done below by the *c.cdxBom.Dependencies?
-	// see https://github.com/CycloneDX/specification/issues/33
-	if toplevel != nil {
-		var timestamp time.Time
+	// set the time to zero time if timestamp is not provided
+	timestamp := zeroTime
+	if c.cdxBom.Metadata != nil && c.cdxBom.Metadata.Timestamp != "" {
 		var err error
-		if c.cdxBom.Metadata.Timestamp == "" {
-			// set the time to zero time if timestamp is not provided
-			timestamp = zeroTime
-		} else {
-			timestamp, err = time.Parse(time.RFC3339, c.cdxBom.Metadata.Timestamp)
-			if err != nil {
-				logger.Errorf("SPDX document had invalid created time %q : %v", c.cdxBom.Metadata.Timestamp, err)
+		timestamp, err = time.Parse(time.RFC3339, c.cdxBom.Metadata.Timestamp)
+		if err != nil {
+			logger.Errorf("SPDX document had invalid created time %q : %v", c.cdxBom.Metadata.Timestamp, err)
+			if toplevel != nil {
 				return nil
 			}
+			timestamp = zeroTime
 		}
+	}
 
+	// adding top level package edge manually for all depends on package
+	// TODO: This is not based on the relationship so that can be inaccurate (can capture both direct and in-direct)...Remove this and be done below by the *c.cdxBom.Dependencies?
+	// see https://github.com/CycloneDX/specification/issues/33
+	if toplevel != nil {
 		preds.IsDependency = append(preds.IsDependency, common.CreateTopLevelIsDeps(toplevel[0], c.packagePackages, nil, "top-level package GUAC heuristic connecting to each file/package")...)
 		preds.HasSBOM = append(preds.HasSBOM, common.CreateTopLevelHasSBOM(toplevel[0], c.doc, c.cdxBom.SerialNumber, timestamp))
 	}
@@ -275,6 +465,52 @@ func (c *cyclonedxParser) GetPredicates(ctx context.Context) *assembler.IngestPr
 		}
 	}
 
+	for id, srcs := range c.packageSources {
+		for _, src := range srcs {
+			for _, pkg := range c.packagePackages[id] {
+				preds.HasSourceAt = append(preds.HasSourceAt, assembler.HasSourceAtIngest{
+					Pkg:          pkg,
+					PkgMatchFlag: common.GetMatchFlagsFromPkgInput(pkg),
+					Src:          src,
+					HasSourceAt: &model.HasSourceAtInputSpec{
+						KnownSince:    timestamp,
+						Justification: "Found in CycloneDX vcs external reference.",
+					},
+				})
+			}
+		}
+	}
+
+	for id, pocs := range c.packageContacts {
+		for _, poc := range pocs {
+			poc.Since = timestamp
+			for _, pkg := range c.packagePackages[id] {
+				preds.PointOfContact = append(preds.PointOfContact, assembler.PointOfContactIngest{
+					Pkg:            pkg,
+					PkgMatchFlag:   common.GetMatchFlagsFromPkgInput(pkg),
+					PointOfContact: poc,
+				})
+			}
+		}
+	}
+
+	for parentRef, childRefs := range c.nestedRefs {
+		for _, parent := range c.packagePackages[parentRef] {
+			for _, childRef := range childRefs {
+				p, err := common.GetIsDep(parent, c.packagePackages[childRef], []*model.PkgInputSpec{}, "CDX BOM nested component")
+				if err != nil {
+					logger.Errorf("error generating CycloneDX edge %v", err)
+					continue
+				}
+				if p != nil {
+					preds.IsDependency = append(preds.IsDependency, *p)
+				}
+			}
+		}
+	}
+
+	c.addFormulation(ctx, preds, toplevel, timestamp)
+
 	preds.Vex = c.vulnData.vex
 	preds.VulnMetadata = c.vulnData.vulnMetadata
 	preds.CertifyVuln = c.vulnData.certifyVuln
@@ -318,8 +554,6 @@ func (c *cyclonedxParser) getVulnerabilities(ctx context.Context) error {
 		return nil
 	}
 
-	var status model.VexStatus
-	var justification model.VexJustification
 	var publishedTime time.Time
 	for _, vulnerability := range *c.cdxBom.Vulnerabilities {
 		vuln, err := asmhelpers.CreateVulnInput(vulnerability.ID)
@@ -327,16 +561,33 @@ func (c *cyclonedxParser) getVulnerabilities(ctx context.Context) error {
 			return fmt.Errorf("failed to create vuln input spec %v", err)
 		}
 
-		if vexStatus, ok := vexStatusMap[vulnerability.Analysis.State]; ok {
-			status = vexStatus
-		} else {
-			return fmt.Errorf("unknown vulnerability status %s", vulnerability.Analysis.State)
-		}
+		// a vulnerability without an analysis has not been triaged yet
+		status := model.VexStatusUnderInvestigation
+		justification := model.VexJustificationNotProvided
+		var statement string
+		var responses []string
+		if vulnerability.Analysis != nil {
+			if vulnerability.Analysis.State != "" {
+				if vexStatus, ok := vexStatusMap[vulnerability.Analysis.State]; ok {
+					status = vexStatus
+				} else {
+					return fmt.Errorf("unknown vulnerability status %s", vulnerability.Analysis.State)
+				}
+			}
 
-		if vexJustification, ok := justificationsMap[vulnerability.Analysis.Justification]; ok {
-			justification = vexJustification
-		} else {
-			justification = model.VexJustificationNotProvided
+			if vexJustification, ok := justificationsMap[vulnerability.Analysis.Justification]; ok {
+				justification = vexJustification
+			}
+
+			if vulnerability.Analysis.Response != nil {
+				for _, res := range *vulnerability.Analysis.Response {
+					responses = append(responses, string(res))
+				}
+			}
+			statement = vulnerability.Analysis.Detail
+		}
+		if statement == "" {
+			statement = strings.Join(responses, ",")
 		}
 
 		if vulnerability.Published != "" {
@@ -345,71 +596,113 @@ func (c *cyclonedxParser) getVulnerabilities(ctx context.Context) error {
 			publishedTime = time.Unix(0, 0)
 		}
 
-		vd := model.VexStatementInputSpec{
-			Status:           status,
-			VexJustification: justification,
-			KnownSince:       publishedTime,
-			StatusNotes:      fmt.Sprintf("%s:%s", string(status), string(justification)),
-		}
-
-		if vulnerability.Analysis.Detail != "" {
-			vd.Statement = vulnerability.Analysis.Detail
-		} else if vulnerability.Analysis.Response != nil {
-			var response []string
-			for _, res := range *vulnerability.Analysis.Response {
-				response = append(response, string(res))
-			}
-			vd.Statement = strings.Join(response, ",")
-		}
-
-		for _, affect := range *vulnerability.Affects {
-			vi, err := c.getAffectedPackages(ctx, vuln, vd, affect)
-			if vi == nil || err != nil {
-				return fmt.Errorf("failed to get affected packages for vulnerability %s - %v", vulnerability.ID, err)
-			}
-			c.vulnData.vex = append(c.vulnData.vex, *vi...)
+		if vulnerability.Affects != nil {
+			for _, affect := range *vulnerability.Affects {
+				affected, err := c.getAffectedPackages(ctx, affect)
+				if err != nil {
+					return fmt.Errorf("failed to get affected packages for vulnerability %s - %v", vulnerability.ID, err)
+				}
 
-			for _, v := range *vi {
-				if status == model.VexStatusAffected || status == model.VexStatusUnderInvestigation {
-					cv := assembler.CertifyVulnIngest{
+				for _, a := range affected {
+					// the status of a specific version overrides the analysis
+					pkgStatus := status
+					if versionStatus, ok := affectedStatusMap[a.status]; ok {
+						pkgStatus = versionStatus
+					}
+					c.vulnData.vex = append(c.vulnData.vex, assembler.VexIngest{
+						Pkg:           a.pkg,
 						Vulnerability: vuln,
-						VulnData: &model.ScanMetadataInput{
-							TimeScanned: publishedTime,
+						VexData: &model.VexStatementInputSpec{
+							Status:           pkgStatus,
+							VexJustification: justification,
+							Statement:        statement,
+							StatusNotes:      vexStatusNotes(pkgStatus, justification, responses),
+							KnownSince:       publishedTime,
 						},
-						Pkg: v.Pkg,
+					})
+
+					if pkgStatus == model.VexStatusAffected || pkgStatus == model.VexStatusUnderInvestigation {
+						cv := assembler.CertifyVulnIngest{
+							Vulnerability: vuln,
+							VulnData: &model.ScanMetadataInput{
+								TimeScanned: publishedTime,
+							},
+							Pkg: a.pkg,
+						}
+						c.vulnData.certifyVuln = append(c.vulnData.certifyVuln, cv)
 					}
-					c.vulnData.certifyVuln = append(c.vulnData.certifyVuln, cv)
 				}
 			}
 		}
 
-		for _, vulnRating := range *vulnerability.Ratings {
-			vm := assembler.VulnMetadataIngest{
-				Vulnerability: vuln,
-				VulnMetadata: &model.VulnerabilityMetadataInputSpec{
-					ScoreType:  model.VulnerabilityScoreType(vulnRating.Method),
-					ScoreValue: *vulnRating.Score,
-					Timestamp:  publishedTime,
-				},
+		if vulnerability.Ratings != nil {
+			for _, vulnRating := range *vulnerability.Ratings {
+				scoreType, ok := scoreTypeMap[vulnRating.Method]
+				if !ok || vulnRating.Score == nil {
+					logger.Debugf("[cdx vex] skipping rating of %s without a score of a known method: %q", vulnerability.ID, vulnRating.Method)
+					continue
+				}
+				vm := assembler.VulnMetadataIngest{
+					Vulnerability: vuln,
+					VulnMetadata: &model.VulnerabilityMetadataInputSpec{
+						ScoreType:  scoreType,
+						ScoreValue: *vulnRating.Score,
+						Timestamp:  publishedTime,
+						Origin:     ratingSource(vulnRating.Source),
+					},
+				}
+				c.vulnData.vulnMetadata = append(c.vulnData.vulnMetadata, vm)
 			}
-			c.vulnData.vulnMetadata = append(c.vulnData.vulnMetadata, vm)
 		}
 	}
 
 	return nil
 }
 
-// Get package name and range versions to create package input spec for the affected packages.
-func (c *cyclonedxParser) getAffectedPackages(ctx context.Context, vulnInput *model.VulnerabilityInputSpec, vexData model.VexStatementInputSpec, affectsObj cdx.Affects) (*[]assembler.VexIngest, error) {
+// vexStatusNotes records the status and justification of the statement,
+// followed by the responses of the analysis if there are any
+func vexStatusNotes(status model.VexStatus, justification model.VexJustification, responses []string) string {
+	notes := fmt.Sprintf("%s:%s", string(status), string(justification))
+	if len(responses) > 0 {
+		notes += "; response: " + strings.Join(responses, ",")
+	}
+	return notes
+}
+
+// ratingSource returns the name of the source of the rating, falling back to
+// its URL. Ratings without a source get the origin of the document.
+func ratingSource(source *cdx.Source) string {
+	if source == nil {
+		return ""
+	}
+	if source.Name != "" {
+		return source.Name
+	}
+	return source.URL
+}
+
+// affectedPackage is a package referenced by a vulnerability, along with the
+// status of its version when one is given
+type affectedPackage struct {
+	pkg    *model.PkgInputSpec
+	status cdx.VulnerabilityStatus
+}
+
+// Get the packages referenced by affects and the versions and version ranges
+// of them that the vulnerability applies to.
+func (c *cyclonedxParser) getAffectedPackages(ctx context.Context, affectsObj cdx.Affects) ([]affectedPackage, error) {
 	logger := logging.FromContext(ctx)
 	pkgRef := affectsObj.Ref
 
-	// split ref using # as delimiter.
-	pkgRefInfo := strings.Split(pkgRef, "#")
-	if len(pkgRefInfo) != 2 {
+	// the ref is either a bom-ref within this BOM, or a bom-link to a
+	// component of another BOM: urn:cdx:serialNumber/version#bom-ref
+	bomLink, pkdIdentifier, isBOMLink := strings.Cut(pkgRef, "#")
+	if !isBOMLink {
+		bomLink, pkdIdentifier = "", pkgRef
+	}
+	if pkdIdentifier == "" || strings.Contains(pkdIdentifier, "#") {
 		return nil, fmt.Errorf("malformed affected-package reference: %q", affectsObj.Ref)
 	}
-	pkdIdentifier := pkgRefInfo[1]
 
 	// check whether the ref contains a purl
 	if strings.Contains(pkdIdentifier, "pkg:") {
@@ -418,40 +711,123 @@ func (c *cyclonedxParser) getAffectedPackages(ctx context.Context, vulnInput *mo
 			return nil, fmt.Errorf("unable to create package input spec: %v", err)
 		}
 		c.identifierStrings.PurlStrings = append(c.identifierStrings.PurlStrings, pkdIdentifier)
-		return &[]assembler.VexIngest{{VexData: &vexData, Vulnerability: vulnInput, Pkg: pkg}}, nil
+		return []affectedPackage{{pkg: pkg}}, nil
 	}
 
+	external := bomLink != "" && bomLinkSBOMURI(bomLink) != c.cdxBom.SerialNumber
+	var candidates []*model.PkgInputSpec
+	if external {
+		candidates = c.resolveBOMLink(ctx, bomLink, pkdIdentifier)
+	} else {
+		candidates = c.getPackageElement(pkdIdentifier)
+	}
+
+	var affected []affectedPackage
 	if affectsObj.Range == nil {
-		return nil, fmt.Errorf("no vulnerable components found for ref %q", affectsObj.Ref)
+		if len(candidates) == 0 {
+			if external {
+				logger.Warnf("[cdx vex] no previously ingested SBOM contains %q", pkgRef)
+				return nil, nil
+			}
+			return nil, fmt.Errorf("no vulnerable components found for ref %q", affectsObj.Ref)
+		}
+		for _, pkg := range candidates {
+			affected = append(affected, affectedPackage{pkg: pkg})
+		}
+		return affected, nil
 	}
 
-	var viList []assembler.VexIngest
 	for _, affect := range *affectsObj.Range {
-		// TODO: Handle package range versions (see - https://github.com/CycloneDX/bom-examples/blob/master/VEX/CISA-Use-Cases/Case-8/vex.json#L42)
-		if affect.Range != "" {
-			logger.Debugf("[cdx vex] package range versions not supported yet: %q", affect.Range)
-			continue
-		}
-		if affect.Version == "" {
+		switch {
+		case affect.Range != "":
+			matched := false
+			for _, pkg := range candidates {
+				if pkg.Version == nil {
+					continue
+				}
+				inRange, err := matchVersRange(affect.Range, *pkg.Version)
+				if err != nil {
+					logger.Warnf("[cdx vex] skipping invalid version range for package ref %q: %v", pkgRef, err)
+					break
+				}
+				if inRange {
+					affected = append(affected, affectedPackage{pkg: pkg, status: affect.Status})
+					matched = true
+				}
+			}
+			if !matched {
+				logger.Debugf("[cdx vex] no known version of %q is within range %q", pkgRef, affect.Range)
+			}
+		case affect.Version != "":
+			var pkgs []*model.PkgInputSpec
+			for _, pkg := range candidates {
+				if pkg.Version != nil && *pkg.Version == affect.Version {
+					pkgs = append(pkgs, pkg)
+				}
+			}
+			if len(pkgs) == 0 {
+				pkg, err := c.affectedVersionPackage(candidates, pkdIdentifier, affect.Version)
+				if err != nil {
+					return nil, err
+				}
+				pkgs = append(pkgs, pkg)
+			}
+			for _, pkg := range pkgs {
+				affected = append(affected, affectedPackage{pkg: pkg, status: affect.Status})
+			}
+		default:
 			return nil, fmt.Errorf("no version found for package ref %q", pkgRef)
 		}
-		vi := &assembler.VexIngest{
-			VexData:       &vexData,
-			Vulnerability: vulnInput,
-		}
+	}
 
-		// create guac specific identifier string using affected package name and version.
-		pkgID := guacCDXPkgPurl(pkdIdentifier, affect.Version, "", false)
-		pkg, err := asmhelpers.PurlToPkg(pkgID)
-		if err != nil {
-			return nil, fmt.Errorf("unable to create package input spec from guac pkg purl: %v", err)
-		}
-		vi.Pkg = pkg
-		viList = append(viList, *vi)
-		c.identifierStrings.PurlStrings = append(c.identifierStrings.PurlStrings, pkgID)
+	return affected, nil
+}
+
+// affectedVersionPackage creates the package of an affected version that
+// isn't otherwise known, based on the referenced component if it was found.
+func (c *cyclonedxParser) affectedVersionPackage(candidates []*model.PkgInputSpec, pkdIdentifier string, version string) (*model.PkgInputSpec, error) {
+	if len(candidates) > 0 {
+		pkg := *candidates[0]
+		pkg.Version = &version
+		c.identifierStrings.PurlStrings = append(c.identifierStrings.PurlStrings, asmhelpers.PkgInputSpecToPurl(&pkg))
+		return &pkg, nil
+	}
+
+	// create guac specific identifier string using affected package name and version.
+	pkgID := guacCDXPkgPurl(pkdIdentifier, version, "", false)
+	pkg, err := asmhelpers.PurlToPkg(pkgID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create package input spec from guac pkg purl: %v", err)
+	}
+	c.identifierStrings.PurlStrings = append(c.identifierStrings.PurlStrings, pkgID)
+	return pkg, nil
+}
+
+// resolveBOMLink looks up the component of a bom-link in previously ingested
+// SBOMs, whose uri is the serial number of the linked BOM.
+func (c *cyclonedxParser) resolveBOMLink(ctx context.Context, bomLink string, bomRef string) []*model.PkgInputSpec {
+	logger := logging.FromContext(ctx)
+	resolver := common.SBOMPackageResolverFromContext(ctx)
+	if resolver == nil {
+		logger.Debugf("[cdx vex] no SBOM resolver to resolve bom-link %s#%s", bomLink, bomRef)
+		return nil
 	}
+	pkgs, err := resolver.ResolvePackages(ctx, bomLinkSBOMURI(bomLink), bomRef)
+	if err != nil {
+		logger.Warnf("[cdx vex] unable to resolve bom-link %s#%s: %v", bomLink, bomRef, err)
+		return nil
+	}
+	for _, pkg := range pkgs {
+		c.identifierStrings.PurlStrings = append(c.identifierStrings.PurlStrings, asmhelpers.PkgInputSpecToPurl(pkg))
+	}
+	return pkgs
+}
 
-	return &viList, nil
+// bomLinkSBOMURI returns the serial number of the BOM a bom-link refers to,
+// which is the uri of its HasSBOM.
+func bomLinkSBOMURI(bomLink string) string {
+	serial, _, _ := strings.Cut(strings.TrimPrefix(bomLink, "urn:cdx:"), "/")
+	return "urn:uuid:" + serial
 }
 
 func (c *cyclonedxParser) getPackageElement(elementID string) []*model.PkgInputSpec {
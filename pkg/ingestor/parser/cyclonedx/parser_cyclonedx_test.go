@@ -298,6 +298,9 @@ func Test_cyclonedxParser_addRootPackage(t *testing.T) {
 					},
 				},
 				packagePackages:   map[string][]*model.PkgInputSpec{},
+				packageSources:    map[string][]*model.SourceInputSpec{},
+				packageContacts:   map[string][]*model.PointOfContactInputSpec{},
+				nestedRefs:        map[string][]string{},
 				identifierStrings: &common.IdentifierStrings{},
 			}
 			c.cdxBom = tt.cdxBom
@@ -455,6 +458,9 @@ func Test_cyclonedxParser_getComponentPackages(t *testing.T) {
 					},
 				},
 				packagePackages:   map[string][]*model.PkgInputSpec{},
+				packageSources:    map[string][]*model.SourceInputSpec{},
+				packageContacts:   map[string][]*model.PointOfContactInputSpec{},
+				nestedRefs:        map[string][]string{},
 				identifierStrings: &common.IdentifierStrings{},
 			}
 			c.cdxBom = tt.cdxBom
@@ -516,3 +522,357 @@ func affectedVexPredicates() *assembler.IngestPredicates {
 		},
 	}
 }
+
+func Test_cyclonedxParser_sourcesAndContacts(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	doc := &processor.Document{
+		Blob: []byte(`{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "metadata": {
+    "timestamp": "2023-01-02T03:04:05Z",
+    "authors": [{"name": "Jane Doe", "email": "jane@example.com"}],
+    "manufacture": {"name": "ExampleCo", "url": ["https://example.com"]},
+    "component": {
+      "bom-ref": "app",
+      "type": "application",
+      "name": "app",
+      "version": "1.0.0",
+      "purl": "pkg:golang/example.com/app@1.0.0",
+      "externalReferences": [{"type": "vcs", "url": "git+https://github.com/example/app@v1.0.0"}]
+    }
+  },
+  "components": [
+    {
+      "bom-ref": "lib",
+      "type": "library",
+      "name": "lib",
+      "version": "2.0.0",
+      "purl": "pkg:golang/example.com/lib@2.0.0",
+      "author": "John Roe <john@example.com>",
+      "supplier": {"name": "LibCo", "contact": [{"name": "Security Team", "email": "security@libco.example"}]},
+      "externalReferences": [
+        {"type": "vcs", "url": "https://github.com/example/lib.git"},
+        {"type": "website", "url": "https://github.com/example/lib"}
+      ]
+    }
+  ]
+}`),
+		Format: processor.FormatJSON,
+		Type:   processor.DocumentCycloneDX,
+	}
+	app, _ := asmhelpers.PurlToPkg("pkg:golang/example.com/app@1.0.0")
+	lib, _ := asmhelpers.PurlToPkg("pkg:golang/example.com/lib@2.0.0")
+	timestamp, _ := time.Parse(time.RFC3339, "2023-01-02T03:04:05Z")
+	matchFlag := model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion}
+	tag := "v1.0.0"
+	want := &assembler.IngestPredicates{
+		HasSourceAt: []assembler.HasSourceAtIngest{
+			{
+				Pkg:          app,
+				PkgMatchFlag: matchFlag,
+				Src:          &model.SourceInputSpec{Type: "git", Namespace: "github.com/example", Name: "app", Tag: &tag},
+				HasSourceAt:  &model.HasSourceAtInputSpec{KnownSince: timestamp, Justification: "Found in CycloneDX vcs external reference."},
+			},
+			{
+				Pkg:          lib,
+				PkgMatchFlag: matchFlag,
+				Src:          &model.SourceInputSpec{Type: "git", Namespace: "github.com/example", Name: "lib"},
+				HasSourceAt:  &model.HasSourceAtInputSpec{KnownSince: timestamp, Justification: "Found in CycloneDX vcs external reference."},
+			},
+		},
+		PointOfContact: []assembler.PointOfContactIngest{
+			{
+				Pkg:            app,
+				PkgMatchFlag:   matchFlag,
+				PointOfContact: &model.PointOfContactInputSpec{Email: "jane@example.com", Info: "author: Jane Doe", Since: timestamp, Justification: "Found in CycloneDX metadata authors."},
+			},
+			{
+				Pkg:            app,
+				PkgMatchFlag:   matchFlag,
+				PointOfContact: &model.PointOfContactInputSpec{Info: "manufacturer: ExampleCo (https://example.com)", Since: timestamp, Justification: "Found in CycloneDX metadata manufacture."},
+			},
+			{
+				Pkg:            lib,
+				PkgMatchFlag:   matchFlag,
+				PointOfContact: &model.PointOfContactInputSpec{Email: "john@example.com", Info: "author: John Roe", Since: timestamp, Justification: "Found in CycloneDX component author."},
+			},
+			{
+				Pkg:            lib,
+				PkgMatchFlag:   matchFlag,
+				PointOfContact: &model.PointOfContactInputSpec{Email: "security@libco.example", Info: "supplier: LibCo, Security Team", Since: timestamp, Justification: "Found in CycloneDX component supplier."},
+			},
+		},
+	}
+
+	c := NewCycloneDXParser()
+	if err := c.Parse(ctx, doc); err != nil {
+		t.Fatalf("cyclonedxParser.Parse() error = %v", err)
+	}
+	got := c.GetPredicates(ctx)
+	got.HasSBOM, got.IsDependency = nil, nil
+	if d := cmp.Diff(want, got, testdata.IngestPredicatesCmpOpts...); len(d) != 0 {
+		t.Errorf("cyclonedx.GetPredicates mismatch values (+got, -expected): %s", d)
+	}
+}
+
+type fakeSBOMResolver struct {
+	packages map[string][]*model.PkgInputSpec
+}
+
+func (r *fakeSBOMResolver) ResolvePackages(ctx context.Context, sbomURI string, ref string) ([]*model.PkgInputSpec, error) {
+	return r.packages[sbomURI+"#"+ref], nil
+}
+
+func Test_cyclonedxParser_standaloneVEX(t *testing.T) {
+	doc := &processor.Document{
+		Blob: []byte(`{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "metadata": {"timestamp": "2023-01-02T03:04:05Z"},
+  "vulnerabilities": [
+    {
+      "id": "CVE-2023-1234",
+      "published": "2023-01-01T00:00:00Z",
+      "ratings": [
+        {"source": {"name": "NVD"}, "score": 9.8, "method": "CVSSv31"},
+        {"source": {"url": "https://example.com/advisory"}, "score": 6.1, "method": "CVSSv4"},
+        {"source": {"name": "Vendor"}, "severity": "high", "method": "other"}
+      ],
+      "analysis": {
+        "state": "exploitable",
+        "response": ["update"]
+      },
+      "affects": [
+        {
+          "ref": "urn:cdx:3e671687-395b-41f5-a30f-a58921a69b79/1#lib",
+          "versions": [
+            {"range": "vers:generic/>=1.0.0|<2.0.0", "status": "affected"},
+            {"range": ">=3.0.0", "status": "affected"},
+            {"version": "2.0.0", "status": "unaffected"}
+          ]
+        },
+        {"ref": "urn:cdx:3e671687-395b-41f5-a30f-a58921a69b79/1#app"}
+      ]
+    }
+  ]
+}`),
+		Format: processor.FormatJSON,
+		Type:   processor.DocumentCycloneDX,
+	}
+	lib10, _ := asmhelpers.PurlToPkg("pkg:npm/lib@1.0.0")
+	lib15, _ := asmhelpers.PurlToPkg("pkg:npm/lib@1.5.0")
+	lib20, _ := asmhelpers.PurlToPkg("pkg:npm/lib@2.0.0")
+	app, _ := asmhelpers.PurlToPkg("pkg:npm/app@3.0.0")
+	resolver := &fakeSBOMResolver{packages: map[string][]*model.PkgInputSpec{
+		"urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79#lib": {lib10, lib15, lib20},
+		"urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79#app": {app},
+	}}
+	ctx := common.WithSBOMPackageResolver(logging.WithLogger(context.Background()), resolver)
+
+	vuln, _ := asmhelpers.CreateVulnInput("CVE-2023-1234")
+	published, _ := time.Parse(time.RFC3339, "2023-01-01T00:00:00Z")
+	vexData := func(status model.VexStatus) *model.VexStatementInputSpec {
+		return &model.VexStatementInputSpec{
+			Status:           status,
+			VexJustification: model.VexJustificationNotProvided,
+			Statement:        "update",
+			StatusNotes:      string(status) + ":" + string(model.VexJustificationNotProvided) + "; response: update",
+			KnownSince:       published,
+		}
+	}
+	var vex []assembler.VexIngest
+	var certifyVuln []assembler.CertifyVulnIngest
+	for _, pkg := range []*model.PkgInputSpec{lib10, lib15, app} {
+		vex = append(vex, assembler.VexIngest{Pkg: pkg, Vulnerability: vuln, VexData: vexData(model.VexStatusAffected)})
+		certifyVuln = append(certifyVuln, assembler.CertifyVulnIngest{Pkg: pkg, Vulnerability: vuln, VulnData: &model.ScanMetadataInput{TimeScanned: published}})
+	}
+	vex = append(vex, assembler.VexIngest{Pkg: lib20, Vulnerability: vuln, VexData: vexData(model.VexStatusNotAffected)})
+	want := &assembler.IngestPredicates{
+		Vex:         vex,
+		CertifyVuln: certifyVuln,
+		VulnMetadata: []assembler.VulnMetadataIngest{
+			{
+				Vulnerability: vuln,
+				VulnMetadata: &model.VulnerabilityMetadataInputSpec{
+					ScoreType:  model.VulnerabilityScoreTypeCvssv31,
+					ScoreValue: 9.8,
+					Timestamp:  published,
+					Origin:     "NVD",
+				},
+			},
+			{
+				Vulnerability: vuln,
+				VulnMetadata: &model.VulnerabilityMetadataInputSpec{
+					ScoreType:  model.VulnerabilityScoreTypeCvssv4,
+					ScoreValue: 6.1,
+					Timestamp:  published,
+					Origin:     "https://example.com/advisory",
+				},
+			},
+		},
+	}
+
+	s := NewCycloneDXParser()
+	if err := s.Parse(ctx, doc); err != nil {
+		t.Fatalf("cyclonedxParser.Parse() error = %v", err)
+	}
+	preds := s.GetPredicates(ctx)
+	if d := cmp.Diff(want, preds, testdata.IngestPredicatesCmpOpts...); len(d) != 0 {
+		t.Errorf("cyclondx.GetPredicate mismatch values (+got, -expected): %s", d)
+	}
+
+	// without previously ingested SBOMs only explicit versions are recorded
+	s = NewCycloneDXParser()
+	if err := s.Parse(logging.WithLogger(context.Background()), doc); err != nil {
+		t.Fatalf("cyclonedxParser.Parse() error = %v", err)
+	}
+	preds = s.GetPredicates(ctx)
+	if len(preds.Vex) != 1 || !cmp.Equal(preds.Vex[0].Pkg, guacPkgHelper("lib", "2.0.0")) {
+		t.Errorf("got VEX %+v, want only the explicit unaffected version", preds.Vex)
+	}
+}
+
+func Test_cyclonedxParser_nestedComponentsServicesAndFormulation(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	doc := &processor.Document{
+		Blob: []byte(`{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "metadata": {
+    "timestamp": "2023-01-02T03:04:05Z",
+    "component": {"bom-ref": "app", "type": "application", "name": "app", "version": "1.0.0", "purl": "pkg:golang/example.com/app@1.0.0"}
+  },
+  "components": [
+    {
+      "bom-ref": "image",
+      "type": "container",
+      "name": "image",
+      "purl": "pkg:oci/image@sha256:abc",
+      "hashes": [{"alg": "SHA-256", "content": "abc"}],
+      "components": [
+        {"bom-ref": "openssl", "type": "library", "name": "openssl", "version": "3.0.0", "purl": "pkg:deb/debian/openssl@3.0.0"}
+      ]
+    }
+  ],
+  "services": [
+    {
+      "bom-ref": "api",
+      "group": "example",
+      "name": "api",
+      "version": "2.0",
+      "provider": {"name": "ExampleCo"},
+      "services": [{"bom-ref": "db", "name": "db", "version": "15"}]
+    }
+  ],
+  "formulation": [
+    {
+      "components": [
+        {"bom-ref": "go", "type": "application", "name": "go", "hashes": [{"alg": "SHA-256", "content": "def"}]}
+      ],
+      "workflows": [
+        {
+          "bom-ref": "build",
+          "uid": "build-1",
+          "resourceReferences": [{"externalReference": {"type": "build-system", "url": "https://ci.example.com"}}],
+          "timeStart": "2023-01-02T01:00:00Z",
+          "timeEnd": "2023-01-02T02:00:00Z",
+          "inputs": [{"resource": {"ref": "go"}}],
+          "outputs": [{"resource": {"ref": "image"}}]
+        },
+        {
+          "uid": "release",
+          "tasks": [
+            {"name": "publish", "steps": [{"name": "push", "commands": [{"executed": "make publish"}]}]}
+          ]
+        }
+      ]
+    }
+  ]
+}`),
+		Format: processor.FormatJSON,
+		Type:   processor.DocumentCycloneDX,
+		SourceInformation: processor.SourceInformation{
+			Collector: "TestCollector",
+			Source:    "TestSource",
+		},
+	}
+	app, _ := asmhelpers.PurlToPkg("pkg:golang/example.com/app@1.0.0")
+	image, _ := asmhelpers.PurlToPkg("pkg:oci/image@sha256:abc")
+	openssl, _ := asmhelpers.PurlToPkg("pkg:deb/debian/openssl@3.0.0")
+	api := guacPkgHelper("example/api", "2.0")
+	db := guacPkgHelper("db", "15")
+	timestamp, _ := time.Parse(time.RFC3339, "2023-01-02T03:04:05Z")
+
+	s := NewCycloneDXParser()
+	if err := s.Parse(ctx, doc); err != nil {
+		t.Fatalf("cyclonedxParser.Parse() error = %v", err)
+	}
+	preds := s.GetPredicates(ctx)
+
+	deps := map[string]bool{}
+	for _, dep := range preds.IsDependency {
+		deps[dep.Pkg.Name+"->"+dep.DepPkg.Name+":"+dep.IsDependency.Justification] = true
+	}
+	for _, want := range []string{
+		app.Name + "->" + image.Name + ":top-level package GUAC heuristic connecting to each file/package",
+		app.Name + "->" + openssl.Name + ":top-level package GUAC heuristic connecting to each file/package",
+		app.Name + "->" + api.Name + ":top-level package GUAC heuristic connecting to each file/package",
+		image.Name + "->" + openssl.Name + ":CDX BOM nested component",
+		api.Name + "->" + db.Name + ":CDX BOM nested component",
+	} {
+		if !deps[want] {
+			t.Errorf("missing IsDependency %s, got %v", want, deps)
+		}
+	}
+	if deps[app.Name+"->go:top-level package GUAC heuristic connecting to each file/package"] {
+		t.Errorf("formula components should not be dependencies of the top level package")
+	}
+
+	wantPOC := []assembler.PointOfContactIngest{{
+		Pkg:          api,
+		PkgMatchFlag: common.GetMatchFlagsFromPkgInput(api),
+		PointOfContact: &model.PointOfContactInputSpec{
+			Info:          "provider: ExampleCo",
+			Justification: "Found in CycloneDX service provider.",
+			Since:         timestamp,
+		},
+	}}
+	if d := cmp.Diff(wantPOC, preds.PointOfContact, testdata.IngestPredicatesCmpOpts...); len(d) != 0 {
+		t.Errorf("PointOfContact mismatch values (+got, -expected): %s", d)
+	}
+
+	if len(preds.HasSlsa) != 1 {
+		t.Fatalf("got HasSlsa %+v, want the build workflow", preds.HasSlsa)
+	}
+	slsa := preds.HasSlsa[0]
+	started, _ := time.Parse(time.RFC3339, "2023-01-02T01:00:00Z")
+	if !cmp.Equal(slsa.Artifact, &model.ArtifactInputSpec{Algorithm: "sha-256", Digest: "abc"}) ||
+		!cmp.Equal(slsa.Materials, []model.ArtifactInputSpec{{Algorithm: "sha-256", Digest: "def"}}) ||
+		slsa.Builder.Uri != "https://ci.example.com" ||
+		slsa.HasSlsa.BuildType != formulationBuildType ||
+		slsa.HasSlsa.SlsaVersion != "https://cyclonedx.org/schema/bom/1.5" ||
+		slsa.HasSlsa.StartedOn == nil || !slsa.HasSlsa.StartedOn.Equal(started) {
+		t.Errorf("got HasSlsa %+v, want the provenance of the build workflow", slsa)
+	}
+
+	var metadata []string
+	for _, m := range preds.HasMetadata {
+		if !cmp.Equal(m.Pkg, app) {
+			t.Errorf("got metadata on %v, want it on the top level package", m.Pkg)
+		}
+		if m.HasMetadata.Origin != "TestSource" || m.HasMetadata.Collector != "TestCollector" {
+			t.Errorf("got metadata from %q collected by %q, want the source information of the document", m.HasMetadata.Origin, m.HasMetadata.Collector)
+		}
+		metadata = append(metadata, m.HasMetadata.Key+"="+m.HasMetadata.Value)
+	}
+	wantMetadata := []string{
+		"cdx.formulation.workflow=release",
+		"cdx.formulation.task=publish",
+		"cdx.formulation.step=push",
+		"cdx.formulation.command=make publish",
+	}
+	if d := cmp.Diff(wantMetadata, metadata); len(d) != 0 {
+		t.Errorf("HasMetadata mismatch values (+got, -expected): %s", d)
+	}
+}
@@ -0,0 +1,144 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cyclonedx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/guacsec/guac/pkg/misc/versioncmp"
+)
+
+// comparators of vers constraints, longest first so that ">=" isn't parsed
+// as ">"
+var versComparators = []string{">=", "<=", "!=", ">", "<", "="}
+
+// versSchemes are the version semantics of the vers schemes. Other schemes,
+// such as generic, use a generic ordering of the numeric and alphabetic parts
+// of versions.
+var versSchemes = map[string]versioncmp.Func{
+	"npm":    versioncmp.Semver,
+	"golang": versioncmp.Semver,
+	"cargo":  versioncmp.Semver,
+	"hex":    versioncmp.Semver,
+	"pub":    versioncmp.Semver,
+	"swift":  versioncmp.Semver,
+	"github": versioncmp.Semver,
+	"pypi":   versioncmp.PEP440,
+	"maven":  versioncmp.Maven,
+	"deb":    versioncmp.Debian,
+}
+
+// versBound is a version bound of an interval of a vers range
+type versBound struct {
+	comparator string
+	version    string
+}
+
+// check returns whether v satisfies the bound
+func (b versBound) check(compare versioncmp.Func, v string) bool {
+	switch c := compare(v, b.version); b.comparator {
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	default:
+		return c == 0
+	}
+}
+
+// matchVersRange returns whether the version is within a CycloneDX version
+// range, which uses the vers syntax (https://github.com/package-url/purl-spec/blob/master/VERSION-RANGE-SPEC.rst),
+// such as vers:generic/>=2.9|<=4.1. Versions are compared following the
+// semantics of the scheme of the range, so that for instance 1.0.post1 is
+// above 1.0 in a vers:pypi range.
+func matchVersRange(versRange string, version string) (bool, error) {
+	if !strings.HasPrefix(versRange, "vers:") {
+		return false, fmt.Errorf("unsupported version range %q", versRange)
+	}
+	scheme, constraints, found := strings.Cut(strings.TrimPrefix(versRange, "vers:"), "/")
+	if !found || strings.TrimSpace(constraints) == "" {
+		return false, fmt.Errorf("malformed version range %q", versRange)
+	}
+	if strings.TrimSpace(constraints) == "*" {
+		return true, nil
+	}
+	compare, ok := versSchemes[strings.ToLower(scheme)]
+	if !ok {
+		compare = versioncmp.Generic
+	}
+
+	// the constraints are sorted by version, so each lower bound followed
+	// by an upper bound forms an interval
+	var intervals [][]versBound
+	var lower *versBound
+	excluded := false
+	for _, constraint := range strings.Split(constraints, "|") {
+		comparator, bound := splitVersConstraint(strings.TrimSpace(constraint))
+		if bound == "" {
+			return false, fmt.Errorf("malformed constraint %q in version range %q", constraint, versRange)
+		}
+		b := versBound{comparator: comparator, version: bound}
+		switch comparator {
+		case "!=":
+			excluded = excluded || compare(version, bound) == 0
+		case "=":
+			intervals = append(intervals, []versBound{b})
+		case ">", ">=":
+			if lower != nil {
+				intervals = append(intervals, []versBound{*lower})
+			}
+			lower = &b
+		case "<", "<=":
+			if lower != nil {
+				intervals = append(intervals, []versBound{*lower, b})
+				lower = nil
+			} else {
+				intervals = append(intervals, []versBound{b})
+			}
+		}
+	}
+	if lower != nil {
+		intervals = append(intervals, []versBound{*lower})
+	}
+	if excluded {
+		return false, nil
+	}
+
+	for _, interval := range intervals {
+		match := true
+		for _, b := range interval {
+			match = match && b.check(compare, version)
+		}
+		if match {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func splitVersConstraint(constraint string) (string, string) {
+	for _, comparator := range versComparators {
+		if strings.HasPrefix(constraint, comparator) {
+			return comparator, strings.TrimSpace(strings.TrimPrefix(constraint, comparator))
+		}
+	}
+	return "=", constraint
+}
@@ -0,0 +1,68 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cyclonedx
+
+import "testing"
+
+func Test_matchVersRange(t *testing.T) {
+	tests := []struct {
+		versRange string
+		version   string
+		want      bool
+		wantErr   bool
+	}{
+		{versRange: "vers:generic/>=2.9|<=4.1", version: "3.0", want: true},
+		{versRange: "vers:generic/>=2.9|<=4.1", version: "4.1", want: true},
+		{versRange: "vers:generic/>=2.9|<=4.1", version: "2.10", want: true},
+		{versRange: "vers:generic/>=2.9|<=4.1", version: "4.2", want: false},
+		{versRange: "vers:npm/<1.0.0|>=2.0.0|<3.0.0", version: "0.9.0", want: true},
+		{versRange: "vers:npm/<1.0.0|>=2.0.0|<3.0.0", version: "1.5.0", want: false},
+		{versRange: "vers:npm/<1.0.0|>=2.0.0|<3.0.0", version: "2.1.0", want: true},
+		{versRange: "vers:npm/1.2.3|>=5.0.0", version: "1.2.3", want: true},
+		{versRange: "vers:npm/1.2.3|>=5.0.0", version: "7.0.0", want: true},
+		{versRange: "vers:npm/>=1.0.0|!=1.1.0|<2.0.0", version: "1.1.0", want: false},
+		{versRange: "vers:npm/>=1.0.0|<2.0.0", version: "2.0.0-rc.1", want: true},
+		{versRange: "vers:npm/>=1.0.0|<2.0.0", version: "1.0.0-rc.1", want: false},
+		{versRange: "vers:npm/>=1.0.0-beta|<=1.0.0-rc.2", version: "1.0.0-rc.1", want: true},
+		{versRange: "vers:npm/>=1.0.0-beta|<=1.0.0-rc.2", version: "1.0.0", want: false},
+		{versRange: "vers:npm/2.0.0-rc.1", version: "2.0.0-rc.1", want: true},
+		{versRange: "vers:npm/*", version: "anything", want: true},
+		{versRange: "vers:pypi/>=1.0|<1.0.post1", version: "1.0", want: true},
+		{versRange: "vers:pypi/>=1.0|<1.0.post1", version: "1.0.post1", want: false},
+		{versRange: "vers:pypi/>=1.0|<1.0.post1", version: "1.0rc1", want: false},
+		{versRange: "vers:maven/>=2.0.0|<2.1.0", version: "2.0.0.RELEASE", want: true},
+		{versRange: "vers:maven/>=2.0.0|<2.1.0", version: "2.0.0-RC1", want: false},
+		{versRange: "vers:maven/>=2.0.0|<2.1.0", version: "2.1.0.RELEASE", want: false},
+		{versRange: "vers:deb/>=1:2.4-1|<1:2.5", version: "1:2.4-1", want: true},
+		{versRange: "vers:deb/>=1:2.4-1|<1:2.5", version: "1:2.4-1+deb11u1", want: true},
+		{versRange: "vers:deb/>=1:2.4-1|<1:2.5", version: "2.4-1", want: false},
+		{versRange: "vers:deb/>=1:2.4-1|<1:2.5", version: "1:2.5~rc1", want: true},
+		{versRange: "vers:generic/>=1.0", version: "not-a-version", want: false},
+		{versRange: ">=1.0", version: "1.0", wantErr: true},
+		{versRange: "vers:generic/", version: "1.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.versRange+" "+tt.version, func(t *testing.T) {
+			got, err := matchVersRange(tt.versRange, tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("matchVersRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("matchVersRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package grype parses the native JSON format of Grype.
+//
+// - Each match of a vulnerability against a package becomes a CertifyVuln,
+// with the versions of Grype and of its database as scan metadata.
+//
+// - The related vulnerabilities of a match, such as the CVE of a GHSA, become
+// VulnEqual.
+//
+// - The CVSS scores of the matched and related vulnerabilities become
+// VulnerabilityMetadata, as do the severities of the records without a CVSS
+// score (see common.SeverityMetadata).
+package grype
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/guacsec/guac/pkg/assembler"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	asmhelpers "github.com/guacsec/guac/pkg/assembler/helpers"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/handler/processor/grype"
+	"github.com/guacsec/guac/pkg/ingestor/parser/common"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+const (
+	scannerURI = "https://github.com/anchore/grype"
+	// dbURI is the Grype database, used when the document doesn't record
+	// where it was downloaded from
+	dbURI = "https://github.com/anchore/grype-db"
+)
+
+var zeroTime = time.Unix(0, 0)
+
+var cvssScoreTypes = map[string]model.VulnerabilityScoreType{
+	"2.0": model.VulnerabilityScoreTypeCvssv2,
+	"3.0": model.VulnerabilityScoreTypeCvssv3,
+	"3.1": model.VulnerabilityScoreTypeCvssv31,
+	"4.0": model.VulnerabilityScoreTypeCvssv4,
+}
+
+type grypeParser struct {
+	identifierStrings *common.IdentifierStrings
+	certifyVulns      []assembler.CertifyVulnIngest
+	vulnEquals        []assembler.VulnEqualIngest
+	vulnMetadata      []assembler.VulnMetadataIngest
+}
+
+func NewGrypeParser() common.DocumentParser {
+	return &grypeParser{
+		identifierStrings: &common.IdentifierStrings{},
+	}
+}
+
+// Parse breaks out the document into the graph components
+func (g *grypeParser) Parse(ctx context.Context, doc *processor.Document) error {
+	logger := logging.FromContext(ctx)
+	grypeDoc, err := grype.ParseDocument(doc.Blob)
+	if err != nil {
+		return fmt.Errorf("failed to parse Grype document: %w", err)
+	}
+
+	descriptor := grypeDoc.Descriptor
+	timeScanned := zeroTime
+	if descriptor.Timestamp != "" {
+		if timeScanned, err = time.Parse(time.RFC3339, descriptor.Timestamp); err != nil {
+			logger.Warnf("Grype document had invalid timestamp %q: %v", descriptor.Timestamp, err)
+			timeScanned = zeroTime
+		}
+	}
+	scanMetadata := &model.ScanMetadataInput{
+		TimeScanned:    timeScanned,
+		DbUri:          dbURI,
+		DbVersion:      descriptor.DB.DBVersion(),
+		ScannerUri:     scannerURI,
+		ScannerVersion: descriptor.Version,
+	}
+	if descriptor.DB.Status != nil && descriptor.DB.Status.From != "" {
+		scanMetadata.DbUri = descriptor.DB.Status.From
+	}
+
+	seenMetadata := map[string]bool{}
+	seenEquals := map[string]bool{}
+	for _, match := range grypeDoc.Matches {
+		pkg, err := g.createPackage(match.Artifact)
+		if err != nil {
+			return err
+		}
+		vuln, err := asmhelpers.CreateVulnInput(match.Vulnerability.ID)
+		if err != nil {
+			return fmt.Errorf("failed to create vulnerability input: %w", err)
+		}
+		g.certifyVulns = append(g.certifyVulns, assembler.CertifyVulnIngest{
+			Pkg:           pkg,
+			Vulnerability: vuln,
+			VulnData:      scanMetadata,
+		})
+		// the same vulnerability is recorded in several namespaces, such as
+		// the one of the distro and the one of NVD
+		if key := vuln.VulnerabilityID + "|" + match.Vulnerability.Namespace; !seenMetadata[key] {
+			seenMetadata[key] = true
+			g.vulnMetadata = append(g.vulnMetadata, cvssMetadata(ctx, vuln, match.Vulnerability.VulnerabilityRecord, timeScanned)...)
+		}
+
+		for _, related := range match.RelatedVulnerabilities {
+			relatedVuln, err := asmhelpers.CreateVulnInput(related.ID)
+			if err != nil {
+				return fmt.Errorf("failed to create vulnerability input: %w", err)
+			}
+			if key := relatedVuln.VulnerabilityID + "|" + related.Namespace; !seenMetadata[key] {
+				seenMetadata[key] = true
+				g.vulnMetadata = append(g.vulnMetadata, cvssMetadata(ctx, relatedVuln, related, timeScanned)...)
+			}
+			key := vuln.VulnerabilityID + "|" + relatedVuln.VulnerabilityID
+			if relatedVuln.VulnerabilityID == vuln.VulnerabilityID || seenEquals[key] {
+				continue
+			}
+			seenEquals[key] = true
+			g.vulnEquals = append(g.vulnEquals, assembler.VulnEqualIngest{
+				Vulnerability:      vuln,
+				EqualVulnerability: relatedVuln,
+				VulnEqual: &model.VulnEqualInputSpec{
+					Justification: "related vulnerability of Grype match",
+				},
+			})
+		}
+	}
+	return nil
+}
+
+// createPackage creates the package of the artifact from its purl, or a GUAC
+// purl from its name and version if Grype didn't identify it
+func (g *grypeParser) createPackage(artifact grype.Package) (*model.PkgInputSpec, error) {
+	purl := artifact.PURL
+	if purl == "" {
+		var version *string
+		if artifact.Version != "" {
+			version = &artifact.Version
+		}
+		purl = asmhelpers.GuacPkgPurl(artifact.Name, version)
+	}
+	pkg, err := asmhelpers.PurlToPkg(purl)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create package input spec from %q: %w", purl, err)
+	}
+	g.identifierStrings.PurlStrings = append(g.identifierStrings.PurlStrings, purl)
+	return pkg, nil
+}
+
+// cvssMetadata creates the metadata of the CVSS scores of a vulnerability,
+// whose origin is the source of the score or else the namespace of the record.
+// Records without a CVSS score, as in many distro feeds, have their severity
+// recorded instead.
+func cvssMetadata(ctx context.Context, vuln *model.VulnerabilityInputSpec, record grype.VulnerabilityRecord, timestamp time.Time) []assembler.VulnMetadataIngest {
+	logger := logging.FromContext(ctx)
+	var metadata []assembler.VulnMetadataIngest
+	for _, cvss := range record.CVSS {
+		scoreType, ok := cvssScoreTypes[cvss.Version]
+		if !ok || cvss.Metrics.BaseScore == 0 {
+			logger.Debugf("[grype] skipping CVSS %q score of %s of an unknown version or without a base score", cvss.Version, record.ID)
+			continue
+		}
+		origin := cvss.Source
+		if origin == "" {
+			origin = record.Namespace
+		}
+		metadata = append(metadata, assembler.VulnMetadataIngest{
+			Vulnerability: vuln,
+			VulnMetadata: &model.VulnerabilityMetadataInputSpec{
+				ScoreType:  scoreType,
+				ScoreValue: cvss.Metrics.BaseScore,
+				Timestamp:  timestamp,
+				Origin:     origin,
+			},
+		})
+	}
+	if len(metadata) == 0 && record.Severity != "" {
+		if severity := common.SeverityMetadata(record.Namespace, record.Severity); severity != nil {
+			severity.Timestamp = timestamp
+			metadata = append(metadata, assembler.VulnMetadataIngest{Vulnerability: vuln, VulnMetadata: severity})
+		} else {
+			logger.Debugf("[grype] skipping %s severity %q of %s", record.Namespace, record.Severity, record.ID)
+		}
+	}
+	return metadata
+}
+
+func (g *grypeParser) GetPredicates(ctx context.Context) *assembler.IngestPredicates {
+	return &assembler.IngestPredicates{
+		CertifyVuln:  g.certifyVulns,
+		VulnEqual:    g.vulnEquals,
+		VulnMetadata: g.vulnMetadata,
+	}
+}
+
+// GetIdentities gets the identity node from the document if they exist
+func (g *grypeParser) GetIdentities(ctx context.Context) []common.TrustInformation {
+	return nil
+}
+
+func (g *grypeParser) GetIdentifiers(ctx context.Context) (*common.IdentifierStrings, error) {
+	return g.identifierStrings, nil
+}
@@ -0,0 +1,117 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grype
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/guacsec/guac/internal/testing/testdata"
+	"github.com/guacsec/guac/pkg/assembler"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	asmhelpers "github.com/guacsec/guac/pkg/assembler/helpers"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+func mustPkg(t *testing.T, purl string) *model.PkgInputSpec {
+	t.Helper()
+	pkg, err := asmhelpers.PurlToPkg(purl)
+	if err != nil {
+		t.Fatalf("PurlToPkg(%q) failed: %v", purl, err)
+	}
+	return pkg
+}
+
+func Test_grypeParser(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	scanned, _ := time.Parse(time.RFC3339, "2024-03-11T09:30:00.123456789Z")
+
+	scanMetadata := &model.ScanMetadataInput{
+		TimeScanned:    scanned,
+		DbUri:          "https://github.com/anchore/grype-db",
+		DbVersion:      "2024-03-11T01:29:13Z",
+		ScannerUri:     "https://github.com/anchore/grype",
+		ScannerVersion: "0.74.7",
+	}
+	cve := &model.VulnerabilityInputSpec{Type: "cve", VulnerabilityID: "cve-2024-0727"}
+	ghsa := &model.VulnerabilityInputSpec{Type: "ghsa", VulnerabilityID: "ghsa-m425-mq94-257g"}
+	relatedCVE := &model.VulnerabilityInputSpec{Type: "cve", VulnerabilityID: "cve-2023-44487"}
+	metadata := func(vuln *model.VulnerabilityInputSpec, score float64, origin string) assembler.VulnMetadataIngest {
+		return assembler.VulnMetadataIngest{
+			Vulnerability: vuln,
+			VulnMetadata: &model.VulnerabilityMetadataInputSpec{
+				ScoreType:  model.VulnerabilityScoreTypeCvssv31,
+				ScoreValue: score,
+				Timestamp:  scanned,
+				Origin:     origin,
+			},
+		}
+	}
+
+	want := &assembler.IngestPredicates{
+		CertifyVuln: []assembler.CertifyVulnIngest{
+			{
+				Pkg:           mustPkg(t, "pkg:apk/alpine/libcrypto3@3.1.4-r5?arch=x86_64&distro=alpine-3.19.1"),
+				Vulnerability: cve,
+				VulnData:      scanMetadata,
+			},
+			{
+				Pkg:           mustPkg(t, "pkg:guac/pkg/google.golang.org/grpc@v1.56.2"),
+				Vulnerability: ghsa,
+				VulnData:      scanMetadata,
+			},
+		},
+		VulnEqual: []assembler.VulnEqualIngest{
+			{
+				Vulnerability:      ghsa,
+				EqualVulnerability: relatedCVE,
+				VulnEqual: &model.VulnEqualInputSpec{
+					Justification: "related vulnerability of Grype match",
+				},
+			},
+		},
+		VulnMetadata: []assembler.VulnMetadataIngest{
+			{
+				Vulnerability: cve,
+				VulnMetadata: &model.VulnerabilityMetadataInputSpec{
+					ScoreType:  model.VulnerabilityScoreTypeSeverity,
+					ScoreValue: 2,
+					Timestamp:  scanned,
+					Origin:     "alpine:distro:alpine:3.19",
+				},
+			},
+			metadata(cve, 5.5, "nvd@nist.gov"),
+			metadata(ghsa, 7.5, "github:language:go"),
+			metadata(relatedCVE, 7.5, "nvd@nist.gov"),
+		},
+	}
+
+	g := NewGrypeParser()
+	err := g.Parse(ctx, &processor.Document{
+		Blob:   testdata.GrypeExampleAlpine,
+		Type:   processor.DocumentGrype,
+		Format: processor.FormatJSON,
+	})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if d := cmp.Diff(want, g.GetPredicates(ctx), testdata.IngestPredicatesCmpOpts...); d != "" {
+		t.Errorf("GetPredicates() mismatch (-want +got):\n%s", d)
+	}
+}
@@ -21,29 +21,45 @@ import (
 
 	"github.com/guacsec/guac/pkg/assembler"
 	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/ingestor/parser/archive"
+	"github.com/guacsec/guac/pkg/ingestor/parser/attestation"
 	"github.com/guacsec/guac/pkg/ingestor/parser/common"
 	"github.com/guacsec/guac/pkg/ingestor/parser/csaf"
 	"github.com/guacsec/guac/pkg/ingestor/parser/cyclonedx"
 	"github.com/guacsec/guac/pkg/ingestor/parser/deps_dev"
 	"github.com/guacsec/guac/pkg/ingestor/parser/dsse"
+	"github.com/guacsec/guac/pkg/ingestor/parser/grype"
 	"github.com/guacsec/guac/pkg/ingestor/parser/open_vex"
+	"github.com/guacsec/guac/pkg/ingestor/parser/sarif"
 	"github.com/guacsec/guac/pkg/ingestor/parser/scorecard"
 	"github.com/guacsec/guac/pkg/ingestor/parser/slsa"
 	"github.com/guacsec/guac/pkg/ingestor/parser/spdx"
+	"github.com/guacsec/guac/pkg/ingestor/parser/spdx3"
+	"github.com/guacsec/guac/pkg/ingestor/parser/syft"
+	"github.com/guacsec/guac/pkg/ingestor/parser/trivy"
 	"github.com/guacsec/guac/pkg/ingestor/parser/vuln"
 	"github.com/guacsec/guac/pkg/logging"
 )
 
 func init() {
 	_ = RegisterDocumentParser(dsse.NewDSSEParser, processor.DocumentDSSE)
+	_ = RegisterDocumentParser(dsse.NewDSSEParser, processor.DocumentSigstoreBundle)
 	_ = RegisterDocumentParser(slsa.NewSLSAParser, processor.DocumentITE6SLSA)
 	_ = RegisterDocumentParser(vuln.NewVulnCertificationParser, processor.DocumentITE6Vul)
+	_ = RegisterDocumentParser(attestation.NewAttestationParser, processor.DocumentITE6Attestation)
 	_ = RegisterDocumentParser(spdx.NewSpdxParser, processor.DocumentSPDX)
+	_ = RegisterDocumentParser(spdx3.NewSpdx3Parser, processor.DocumentSPDX3)
 	_ = RegisterDocumentParser(cyclonedx.NewCycloneDXParser, processor.DocumentCycloneDX)
 	_ = RegisterDocumentParser(scorecard.NewScorecardParser, processor.DocumentScorecard)
 	_ = RegisterDocumentParser(deps_dev.NewDepsDevParser, processor.DocumentDepsDev)
 	_ = RegisterDocumentParser(csaf.NewCsafParser, processor.DocumentCsaf)
 	_ = RegisterDocumentParser(open_vex.NewOpenVEXParser, processor.DocumentOpenVEX)
+	_ = RegisterDocumentParser(archive.NewArchiveParser, processor.DocumentTar)
+	_ = RegisterDocumentParser(archive.NewArchiveParser, processor.DocumentZip)
+	_ = RegisterDocumentParser(syft.NewSyftParser, processor.DocumentSyft)
+	_ = RegisterDocumentParser(trivy.NewTrivyParser, processor.DocumentTrivy)
+	_ = RegisterDocumentParser(grype.NewGrypeParser, processor.DocumentGrype)
+	_ = RegisterDocumentParser(sarif.NewSARIFParser, processor.DocumentSARIF)
 }
 
 var (
@@ -0,0 +1,423 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sarif parses SARIF 2.1.0 reports of static analysis tools, such
+// as CodeQL and Semgrep.
+//
+// - Each run is attributed to the revision of the source repository recorded
+// in its version control provenance, runs without it are skipped.
+//
+// - The results of a run are aggregated into HasMetadata of the source, with
+// keys namespaced by the name of the tool:
+//   - sarif:<tool>:results is the number of results
+//   - sarif:<tool>:severity is <severity>=<count> for each severity
+//   - sarif:<tool>:cwe is CWE-<id>=<count> for each CWE the rules map to
+//   - sarif:<tool>:rule:<rule id> is <severity>=<count> for each rule
+//
+// The severity of a security result is derived from the security-severity
+// score of its rule the way GitHub code scanning does (critical, high,
+// medium or low), other results keep their SARIF level (error, warning, note
+// or none). Suppressed results and results that aren't failures aren't
+// counted.
+package sarif
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/guacsec/guac/pkg/assembler"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	asmhelpers "github.com/guacsec/guac/pkg/assembler/helpers"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/handler/processor/sarif"
+	"github.com/guacsec/guac/pkg/ingestor/parser/common"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+const (
+	// defaultLevel is the level of results whose level isn't set, by the
+	// result or by the default configuration of its rule
+	defaultLevel = "warning"
+	cweTaxonomy  = "CWE"
+)
+
+var zeroTime = time.Unix(0, 0)
+
+var (
+	// cweTag matches the CWE tags of CodeQL (external/cwe/cwe-079) and of
+	// Semgrep (CWE-79: Improper Neutralization ...)
+	cweTag = regexp.MustCompile(`(?i)\bcwe[-_/: ]*0*(\d+)`)
+	// cweID matches the id of a taxon of the CWE taxonomy, 79 or CWE-79
+	cweID = regexp.MustCompile(`(?i)^(?:cwe-)?0*(\d+)$`)
+)
+
+// ruleSeverity is a rule along with the severity of some of its results
+type ruleSeverity struct {
+	rule     string
+	severity string
+}
+
+// findings are the aggregated results of a tool on a source
+type findings struct {
+	src        *model.SourceInputSpec
+	tool       string
+	version    string
+	timestamp  time.Time
+	total      int
+	severities map[string]int
+	cwes       map[string]int
+	rules      map[ruleSeverity]int
+	ruleCWEs   map[string]map[string]bool
+}
+
+type sarifParser struct {
+	identifierStrings *common.IdentifierStrings
+	hasMetadata       []assembler.HasMetadataIngest
+}
+
+func NewSARIFParser() common.DocumentParser {
+	return &sarifParser{
+		identifierStrings: &common.IdentifierStrings{},
+	}
+}
+
+// Parse breaks out the document into the graph components
+func (s *sarifParser) Parse(ctx context.Context, doc *processor.Document) error {
+	logger := logging.FromContext(ctx)
+	report, err := sarif.ParseReport(doc.Blob)
+	if err != nil {
+		return fmt.Errorf("failed to parse SARIF report: %w", err)
+	}
+
+	// runs of the same tool on the same revision, such as the CodeQL runs of
+	// each language of a repository, are aggregated together
+	var keys []string
+	aggregated := map[string]*findings{}
+	for i, run := range report.Runs {
+		tool := run.Tool.Driver.Name
+		if tool == "" {
+			return fmt.Errorf("run %d of SARIF report has no tool name", i)
+		}
+		src := s.runSource(ctx, run)
+		if src == nil {
+			logger.Warnf("[sarif] skipping run %d of %s without the version control provenance of a known source", i, tool)
+			continue
+		}
+
+		key := srcKey(src) + "|" + tool
+		f, ok := aggregated[key]
+		if !ok {
+			f = &findings{
+				src:        src,
+				tool:       tool,
+				timestamp:  zeroTime,
+				severities: map[string]int{},
+				cwes:       map[string]int{},
+				rules:      map[ruleSeverity]int{},
+				ruleCWEs:   map[string]map[string]bool{},
+			}
+			aggregated[key] = f
+			keys = append(keys, key)
+		}
+		if f.version == "" {
+			f.version = run.Tool.Driver.SemanticVersion
+			if f.version == "" {
+				f.version = run.Tool.Driver.Version
+			}
+		}
+		if t := runTime(ctx, run); t.After(f.timestamp) {
+			f.timestamp = t
+		}
+
+		for _, result := range run.Results {
+			if !isFinding(result) {
+				continue
+			}
+			rule := run.Tool.Rule(result)
+			ruleID := resultRuleID(result, rule)
+			if ruleID == "" {
+				logger.Debugf("[sarif] skipping result of %s without a rule", tool)
+				continue
+			}
+			severity := resultSeverity(result, rule)
+			f.total++
+			f.severities[severity]++
+			f.rules[ruleSeverity{rule: ruleID, severity: severity}]++
+			for _, cwe := range resultCWEs(run, result, rule) {
+				f.cwes[cwe]++
+				if f.ruleCWEs[ruleID] == nil {
+					f.ruleCWEs[ruleID] = map[string]bool{}
+				}
+				f.ruleCWEs[ruleID][cwe] = true
+			}
+		}
+	}
+
+	for _, key := range keys {
+		s.hasMetadata = append(s.hasMetadata, aggregated[key].metadata(doc.SourceInformation)...)
+	}
+	return nil
+}
+
+// runSource returns the source repository at the revision the run analyzed,
+// or nil if the run doesn't record one
+func (s *sarifParser) runSource(ctx context.Context, run sarif.Run) *model.SourceInputSpec {
+	logger := logging.FromContext(ctx)
+	for _, vcs := range run.VersionControlProvenance {
+		src := common.SourceFromLocation(vcs.RepositoryURI)
+		if src == nil && strings.Contains(vcs.RepositoryURI, "://") {
+			// repositories outside of the well known forges
+			var err error
+			if src, err = asmhelpers.VcsToSrc("git+" + vcs.RepositoryURI); err != nil {
+				logger.Debugf("[sarif] unable to create source from repository %q: %v", vcs.RepositoryURI, err)
+				src = nil
+			}
+		}
+		if src == nil || src.Name == "" {
+			continue
+		}
+		src.Commit, src.Tag = nil, nil
+		if vcs.RevisionID != "" {
+			commit := vcs.RevisionID
+			src.Commit = &commit
+		} else if vcs.RevisionTag != "" {
+			tag := vcs.RevisionTag
+			src.Tag = &tag
+		}
+		s.identifierStrings.VcsStrings = append(s.identifierStrings.VcsStrings, vcs.RepositoryURI)
+		return src
+	}
+	return nil
+}
+
+func srcKey(src *model.SourceInputSpec) string {
+	key := src.Type + "+" + src.Namespace + "/" + src.Name
+	if src.Commit != nil {
+		key += "@" + *src.Commit
+	} else if src.Tag != nil {
+		key += "@" + *src.Tag
+	}
+	return key
+}
+
+// runTime returns when the run ended, or else started
+func runTime(ctx context.Context, run sarif.Run) time.Time {
+	logger := logging.FromContext(ctx)
+	for _, invocation := range run.Invocations {
+		for _, value := range []string{invocation.EndTimeUTC, invocation.StartTimeUTC} {
+			if value == "" {
+				continue
+			}
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				logger.Warnf("SARIF invocation had invalid time %q: %v", value, err)
+				continue
+			}
+			return t
+		}
+	}
+	return zeroTime
+}
+
+// isFinding returns whether the result is a failure that wasn't suppressed
+func isFinding(result sarif.Result) bool {
+	if result.Kind != "" && result.Kind != "fail" {
+		return false
+	}
+	for _, suppression := range result.Suppressions {
+		if suppression.Status == "" || suppression.Status == "accepted" {
+			return false
+		}
+	}
+	return true
+}
+
+func resultRuleID(result sarif.Result, rule *sarif.ReportingDescriptor) string {
+	switch {
+	case result.RuleID != "":
+		return result.RuleID
+	case result.Rule != nil && result.Rule.ID != "":
+		return result.Rule.ID
+	case rule != nil:
+		return rule.ID
+	}
+	return ""
+}
+
+// resultSeverity returns the severity of the security-severity score of the
+// rule, or else the level of the result
+func resultSeverity(result sarif.Result, rule *sarif.ReportingDescriptor) string {
+	if rule != nil {
+		if score, ok := securitySeverity(rule.Properties.SecuritySeverity); ok {
+			switch {
+			case score >= 9.0:
+				return "critical"
+			case score >= 7.0:
+				return "high"
+			case score >= 4.0:
+				return "medium"
+			case score > 0:
+				return "low"
+			}
+		}
+	}
+	if result.Level != "" {
+		return result.Level
+	}
+	if rule != nil && rule.DefaultConfiguration != nil && rule.DefaultConfiguration.Level != "" {
+		return rule.DefaultConfiguration.Level
+	}
+	return defaultLevel
+}
+
+func securitySeverity(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		score, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return score, err == nil
+	}
+	return 0, false
+}
+
+// resultCWEs returns the sorted CWEs of the tags and relationships of the
+// rule, and of the taxa of the result
+func resultCWEs(run sarif.Run, result sarif.Result, rule *sarif.ReportingDescriptor) []string {
+	set := map[string]bool{}
+	addTaxon := func(ref sarif.ReportingDescriptorReference) {
+		if ref.ToolComponent == nil || ref.ID == "" {
+			return
+		}
+		name := ref.ToolComponent.Name
+		if name == "" {
+			if taxonomy := run.Taxonomy(ref.ToolComponent); taxonomy != nil {
+				name = taxonomy.Name
+			}
+		}
+		if !strings.EqualFold(name, cweTaxonomy) {
+			return
+		}
+		if m := cweID.FindStringSubmatch(ref.ID); m != nil {
+			set["CWE-"+m[1]] = true
+		}
+	}
+	if rule != nil {
+		for _, tag := range rule.Properties.Tags {
+			for _, m := range cweTag.FindAllStringSubmatch(tag, -1) {
+				set["CWE-"+m[1]] = true
+			}
+		}
+		for _, relationship := range rule.Relationships {
+			addTaxon(relationship.Target)
+		}
+	}
+	for _, taxon := range result.Taxa {
+		addTaxon(taxon)
+	}
+	return sortedKeys(set)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	var keys []string
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// metadata creates the HasMetadata of the source from the aggregated
+// findings
+func (f *findings) metadata(info processor.SourceInformation) []assembler.HasMetadataIngest {
+	prefix := "sarif:" + f.tool
+	justification := "aggregated SARIF results of " + f.tool
+	if f.version != "" {
+		justification += " " + f.version
+	}
+	var metadata []assembler.HasMetadataIngest
+	add := func(key, value, justification string) {
+		metadata = append(metadata, assembler.HasMetadataIngest{
+			Src: f.src,
+			HasMetadata: &model.HasMetadataInputSpec{
+				Key:           key,
+				Value:         value,
+				Timestamp:     f.timestamp,
+				Justification: justification,
+				Origin:        info.Source,
+				Collector:     info.Collector,
+			},
+		})
+	}
+
+	add(prefix+":results", strconv.Itoa(f.total), justification)
+
+	var severities []string
+	for severity := range f.severities {
+		severities = append(severities, severity)
+	}
+	sort.Strings(severities)
+	for _, severity := range severities {
+		add(prefix+":severity", fmt.Sprintf("%s=%d", severity, f.severities[severity]), justification)
+	}
+
+	var cwes []string
+	for cwe := range f.cwes {
+		cwes = append(cwes, cwe)
+	}
+	sort.Strings(cwes)
+	for _, cwe := range cwes {
+		add(prefix+":cwe", fmt.Sprintf("%s=%d", cwe, f.cwes[cwe]), justification)
+	}
+
+	var rules []ruleSeverity
+	for rule := range f.rules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].rule != rules[j].rule {
+			return rules[i].rule < rules[j].rule
+		}
+		return rules[i].severity < rules[j].severity
+	})
+	for _, rule := range rules {
+		ruleJustification := justification
+		if cwes := sortedKeys(f.ruleCWEs[rule.rule]); len(cwes) > 0 {
+			ruleJustification += " for " + strings.Join(cwes, ", ")
+		}
+		add(prefix+":rule:"+rule.rule, fmt.Sprintf("%s=%d", rule.severity, f.rules[rule]), ruleJustification)
+	}
+	return metadata
+}
+
+func (s *sarifParser) GetPredicates(ctx context.Context) *assembler.IngestPredicates {
+	return &assembler.IngestPredicates{
+		HasMetadata: s.hasMetadata,
+	}
+}
+
+// GetIdentities gets the identity node from the document if they exist
+func (s *sarifParser) GetIdentities(ctx context.Context) []common.TrustInformation {
+	return nil
+}
+
+func (s *sarifParser) GetIdentifiers(ctx context.Context) (*common.IdentifierStrings, error) {
+	return s.identifierStrings, nil
+}
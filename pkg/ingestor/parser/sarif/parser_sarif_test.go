@@ -0,0 +1,239 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sarif
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/guacsec/guac/internal/testing/testdata"
+	"github.com/guacsec/guac/pkg/assembler"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/ingestor/parser/common"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+func Test_sarifParser(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	analyzed, _ := time.Parse(time.RFC3339, "2024-03-11T09:12:30Z")
+
+	commit := "5f0c2a1e9b3d4c7a8e6f1b2d3c4a5e6f7a8b9c0d"
+	src := &model.SourceInputSpec{
+		Type:      "git",
+		Namespace: "github.com/guacsec",
+		Name:      "guac-demo",
+		Commit:    &commit,
+	}
+	metadata := func(key, value string, timestamp time.Time, justification string) assembler.HasMetadataIngest {
+		return assembler.HasMetadataIngest{
+			Src: src,
+			HasMetadata: &model.HasMetadataInputSpec{
+				Key:           key,
+				Value:         value,
+				Timestamp:     timestamp,
+				Justification: justification,
+				Origin:        "file:///sarif/results.sarif",
+				Collector:     "FileCollector",
+			},
+		}
+	}
+	codeql := "aggregated SARIF results of CodeQL 2.16.4"
+	semgrep := "aggregated SARIF results of Semgrep OSS 1.66.0"
+	sqlRule := "python.django.security.injection.sql.sql-injection-using-raw.sql-injection-using-raw"
+	evalRule := "python.lang.security.audit.eval-detected.eval-detected"
+
+	want := &assembler.IngestPredicates{
+		HasMetadata: []assembler.HasMetadataIngest{
+			metadata("sarif:CodeQL:results", "4", analyzed, codeql),
+			metadata("sarif:CodeQL:severity", "high=1", analyzed, codeql),
+			metadata("sarif:CodeQL:severity", "medium=2", analyzed, codeql),
+			metadata("sarif:CodeQL:severity", "note=1", analyzed, codeql),
+			metadata("sarif:CodeQL:cwe", "CWE-116=2", analyzed, codeql),
+			metadata("sarif:CodeQL:cwe", "CWE-79=2", analyzed, codeql),
+			metadata("sarif:CodeQL:cwe", "CWE-89=1", analyzed, codeql),
+			metadata("sarif:CodeQL:cwe", "CWE-90=1", analyzed, codeql),
+			metadata("sarif:CodeQL:cwe", "CWE-943=1", analyzed, codeql),
+			metadata("sarif:CodeQL:rule:js/reflected-xss", "medium=2", analyzed, codeql+" for CWE-116, CWE-79"),
+			metadata("sarif:CodeQL:rule:js/sql-injection", "high=1", analyzed, codeql+" for CWE-89, CWE-90, CWE-943"),
+			metadata("sarif:CodeQL:rule:js/unused-local-variable", "note=1", analyzed, codeql),
+			metadata("sarif:Semgrep OSS:results", "3", zeroTime, semgrep),
+			metadata("sarif:Semgrep OSS:severity", "error=1", zeroTime, semgrep),
+			metadata("sarif:Semgrep OSS:severity", "warning=2", zeroTime, semgrep),
+			metadata("sarif:Semgrep OSS:cwe", "CWE-89=1", zeroTime, semgrep),
+			metadata("sarif:Semgrep OSS:cwe", "CWE-95=2", zeroTime, semgrep),
+			metadata("sarif:Semgrep OSS:rule:"+sqlRule, "error=1", zeroTime, semgrep+" for CWE-89"),
+			metadata("sarif:Semgrep OSS:rule:"+evalRule, "warning=2", zeroTime, semgrep+" for CWE-95"),
+		},
+	}
+
+	s := NewSARIFParser()
+	err := s.Parse(ctx, &processor.Document{
+		Blob:   testdata.SARIFExampleCodeQLSemgrep,
+		Type:   processor.DocumentSARIF,
+		Format: processor.FormatJSON,
+		SourceInformation: processor.SourceInformation{
+			Collector: "FileCollector",
+			Source:    "file:///sarif/results.sarif",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if d := cmp.Diff(want, s.GetPredicates(ctx), testdata.IngestPredicatesCmpOpts...); d != "" {
+		t.Errorf("GetPredicates() mismatch (-want +got):\n%s", d)
+	}
+
+	ids, err := s.GetIdentifiers(ctx)
+	if err != nil {
+		t.Fatalf("GetIdentifiers() error = %v", err)
+	}
+	wantIDs := &common.IdentifierStrings{VcsStrings: []string{
+		"https://github.com/guacsec/guac-demo",
+		"https://github.com/guacsec/guac-demo.git",
+	}}
+	if d := cmp.Diff(wantIDs, ids); d != "" {
+		t.Errorf("GetIdentifiers() mismatch (-want +got):\n%s", d)
+	}
+}
+
+func Test_sarifParser_runs(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	tag := "v1.2.0"
+	tests := []struct {
+		name string
+		blob string
+		want []assembler.HasMetadataIngest
+	}{
+		{
+			name: "run without version control provenance",
+			blob: `{"version": "2.1.0", "runs": [{"tool": {"driver": {"name": "Semgrep OSS"}}, "results": [{"ruleId": "eval-detected"}]}]}`,
+		},
+		{
+			name: "numeric security severity and CWE taxa on a self-hosted repository tag",
+			blob: `{"version": "2.1.0", "runs": [{
+				"tool": {"driver": {"name": "scanner", "rules": [
+					{"id": "path-traversal", "properties": {"security-severity": 9.1},
+					 "relationships": [{"target": {"id": "22", "toolComponent": {"name": "CWE"}}, "kinds": ["superset"]}]}]}},
+				"versionControlProvenance": [{"repositoryUri": "https://git.example.com/platform/api", "revisionTag": "v1.2.0"}],
+				"results": [
+					{"ruleIndex": 0, "level": "error", "taxa": [{"id": "CWE-023", "toolComponent": {"name": "CWE"}}]},
+					{"ruleId": "path-traversal", "kind": "pass"}
+				]}]}`,
+			want: []assembler.HasMetadataIngest{
+				{
+					Src: &model.SourceInputSpec{Type: "git", Namespace: "git.example.com/platform", Name: "api", Tag: &tag},
+					HasMetadata: &model.HasMetadataInputSpec{
+						Key: "sarif:scanner:results", Value: "1", Timestamp: zeroTime, Justification: "aggregated SARIF results of scanner",
+					},
+				},
+				{
+					Src: &model.SourceInputSpec{Type: "git", Namespace: "git.example.com/platform", Name: "api", Tag: &tag},
+					HasMetadata: &model.HasMetadataInputSpec{
+						Key: "sarif:scanner:severity", Value: "critical=1", Timestamp: zeroTime, Justification: "aggregated SARIF results of scanner",
+					},
+				},
+				{
+					Src: &model.SourceInputSpec{Type: "git", Namespace: "git.example.com/platform", Name: "api", Tag: &tag},
+					HasMetadata: &model.HasMetadataInputSpec{
+						Key: "sarif:scanner:cwe", Value: "CWE-22=1", Timestamp: zeroTime, Justification: "aggregated SARIF results of scanner",
+					},
+				},
+				{
+					Src: &model.SourceInputSpec{Type: "git", Namespace: "git.example.com/platform", Name: "api", Tag: &tag},
+					HasMetadata: &model.HasMetadataInputSpec{
+						Key: "sarif:scanner:cwe", Value: "CWE-23=1", Timestamp: zeroTime, Justification: "aggregated SARIF results of scanner",
+					},
+				},
+				{
+					Src: &model.SourceInputSpec{Type: "git", Namespace: "git.example.com/platform", Name: "api", Tag: &tag},
+					HasMetadata: &model.HasMetadataInputSpec{
+						Key: "sarif:scanner:rule:path-traversal", Value: "critical=1", Timestamp: zeroTime,
+						Justification: "aggregated SARIF results of scanner for CWE-22, CWE-23",
+					},
+				},
+			},
+		},
+		{
+			name: "CWE taxa referencing the taxonomies of the run by index",
+			blob: `{"version": "2.1.0", "runs": [{
+				"tool": {"driver": {"name": "scanner", "rules": [{"id": "xss", "properties": {"security-severity": "6.1"}}]},
+					"extensions": [{"name": "scanner-rules"}]},
+				"taxonomies": [{"name": "CWE"}],
+				"versionControlProvenance": [{"repositoryUri": "https://git.example.com/platform/api", "revisionTag": "v1.2.0"}],
+				"results": [{"ruleIndex": 0, "taxa": [{"id": "79", "toolComponent": {"index": 0}}]}]}]}`,
+			want: []assembler.HasMetadataIngest{
+				{
+					Src: &model.SourceInputSpec{Type: "git", Namespace: "git.example.com/platform", Name: "api", Tag: &tag},
+					HasMetadata: &model.HasMetadataInputSpec{
+						Key: "sarif:scanner:results", Value: "1", Timestamp: zeroTime, Justification: "aggregated SARIF results of scanner",
+					},
+				},
+				{
+					Src: &model.SourceInputSpec{Type: "git", Namespace: "git.example.com/platform", Name: "api", Tag: &tag},
+					HasMetadata: &model.HasMetadataInputSpec{
+						Key: "sarif:scanner:severity", Value: "medium=1", Timestamp: zeroTime, Justification: "aggregated SARIF results of scanner",
+					},
+				},
+				{
+					Src: &model.SourceInputSpec{Type: "git", Namespace: "git.example.com/platform", Name: "api", Tag: &tag},
+					HasMetadata: &model.HasMetadataInputSpec{
+						Key: "sarif:scanner:cwe", Value: "CWE-79=1", Timestamp: zeroTime, Justification: "aggregated SARIF results of scanner",
+					},
+				},
+				{
+					Src: &model.SourceInputSpec{Type: "git", Namespace: "git.example.com/platform", Name: "api", Tag: &tag},
+					HasMetadata: &model.HasMetadataInputSpec{
+						Key: "sarif:scanner:rule:xss", Value: "medium=1", Timestamp: zeroTime,
+						Justification: "aggregated SARIF results of scanner for CWE-79",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSARIFParser()
+			err := s.Parse(ctx, &processor.Document{
+				Blob:   []byte(tt.blob),
+				Type:   processor.DocumentSARIF,
+				Format: processor.FormatJSON,
+			})
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			want := &assembler.IngestPredicates{HasMetadata: tt.want}
+			if d := cmp.Diff(want, s.GetPredicates(ctx), testdata.IngestPredicatesCmpOpts...); d != "" {
+				t.Errorf("GetPredicates() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func Test_sarifParser_invalid(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	s := NewSARIFParser()
+	err := s.Parse(ctx, &processor.Document{
+		Blob:   []byte(`{"version": "2.0.0", "runs": []}`),
+		Type:   processor.DocumentSARIF,
+		Format: processor.FormatJSON,
+	})
+	if err == nil {
+		t.Errorf("expected an error parsing a SARIF report of an unsupported version")
+	}
+}
@@ -41,6 +41,8 @@ type spdxParser struct {
 	packagePackages     map[string][]*model.PkgInputSpec
 	packageArtifacts    map[string][]*model.ArtifactInputSpec
 	packageLegals       map[string][]*model.CertifyLegalInputSpec
+	packageSources      map[string][]*model.SourceInputSpec
+	packageContacts     map[string][]*model.PointOfContactInputSpec
 	filePackages        map[string][]*model.PkgInputSpec
 	fileArtifacts       map[string][]*model.ArtifactInputSpec
 	topLevelPackages    map[string][]*model.PkgInputSpec
@@ -55,6 +57,8 @@ func NewSpdxParser() common.DocumentParser {
 		packagePackages:     map[string][]*model.PkgInputSpec{},
 		packageArtifacts:    map[string][]*model.ArtifactInputSpec{},
 		packageLegals:       map[string][]*model.CertifyLegalInputSpec{},
+		packageSources:      map[string][]*model.SourceInputSpec{},
+		packageContacts:     map[string][]*model.PointOfContactInputSpec{},
 		filePackages:        map[string][]*model.PkgInputSpec{},
 		fileArtifacts:       map[string][]*model.ArtifactInputSpec{},
 		topLevelPackages:    map[string][]*model.PkgInputSpec{},
@@ -165,6 +169,20 @@ func (s *spdxParser) getPackages() error {
 				s.packageLegals[string(pac.PackageSPDXIdentifier)], cl)
 		}
 
+		if src := common.SourceFromLocation(pac.PackageDownloadLocation); src != nil {
+			s.packageSources[string(pac.PackageSPDXIdentifier)] = append(s.packageSources[string(pac.PackageSPDXIdentifier)], src)
+		}
+		if pac.PackageSupplier != nil {
+			if poc := s.contact("supplier", pac.PackageSupplier.SupplierType, pac.PackageSupplier.Supplier); poc != nil {
+				s.packageContacts[string(pac.PackageSPDXIdentifier)] = append(s.packageContacts[string(pac.PackageSPDXIdentifier)], poc)
+			}
+		}
+		if pac.PackageOriginator != nil {
+			if poc := s.contact("originator", pac.PackageOriginator.OriginatorType, pac.PackageOriginator.Originator); poc != nil {
+				s.packageContacts[string(pac.PackageSPDXIdentifier)] = append(s.packageContacts[string(pac.PackageSPDXIdentifier)], poc)
+			}
+		}
+
 	}
 
 	// If there is no top level Spdx Id that can be derived from the relationships, we take a best guess for the SpdxId.
@@ -182,6 +200,25 @@ func (s *spdxParser) getPackages() error {
 	return nil
 }
 
+// contact returns the point of contact of a package supplier or originator,
+// such as "Organization: ExampleCo (contact@example.com)"
+func (s *spdxParser) contact(role string, contactType string, contact string) *model.PointOfContactInputSpec {
+	if contact == "" || contact == "NOASSERTION" {
+		return nil
+	}
+	name, email := common.ParseContact(contact)
+	info := fmt.Sprintf("%s: %s", role, name)
+	if contactType != "" {
+		info = fmt.Sprintf("%s (%s)", info, strings.ToLower(contactType))
+	}
+	return &model.PointOfContactInputSpec{
+		Email:         email,
+		Info:          info,
+		Since:         s.timeScanned,
+		Justification: fmt.Sprintf("Found in SPDX package %s.", role),
+	}
+}
+
 func (s *spdxParser) getFiles() error {
 	for _, file := range s.spdxDoc.Files {
 		// if checksums exists create an artifact for each of them
@@ -360,6 +397,34 @@ func (s *spdxParser) GetPredicates(ctx context.Context) *assembler.IngestPredica
 		}
 	}
 
+	for id, srcs := range s.packageSources {
+		for _, src := range srcs {
+			for _, pkg := range s.packagePackages[id] {
+				preds.HasSourceAt = append(preds.HasSourceAt, assembler.HasSourceAtIngest{
+					Pkg:          pkg,
+					PkgMatchFlag: common.GetMatchFlagsFromPkgInput(pkg),
+					Src:          src,
+					HasSourceAt: &model.HasSourceAtInputSpec{
+						KnownSince:    s.timeScanned,
+						Justification: "Found in SPDX package download location.",
+					},
+				})
+			}
+		}
+	}
+
+	for id, pocs := range s.packageContacts {
+		for _, poc := range pocs {
+			for _, pkg := range s.packagePackages[id] {
+				preds.PointOfContact = append(preds.PointOfContact, assembler.PointOfContactIngest{
+					Pkg:            pkg,
+					PkgMatchFlag:   common.GetMatchFlagsFromPkgInput(pkg),
+					PointOfContact: poc,
+				})
+			}
+		}
+	}
+
 	for _, pkg := range s.spdxDoc.Packages {
 		pkgInputSpecs := s.getPackageElement(string(pkg.PackageSPDXIdentifier))
 		for _, extRef := range pkg.PackageExternalReferences {
@@ -840,6 +840,24 @@ func Test_spdxParser(t *testing.T) {
 				},
 			},
 			wantPredicates: &assembler.IngestPredicates{
+				PointOfContact: []assembler.PointOfContactIngest{
+					{
+						Pkg: &generated.PkgInputSpec{
+							Type:      "guac",
+							Namespace: ptrfrom.String("pkg"),
+							Name:      "mypackage",
+							Version:   ptrfrom.String("3.2.0-r22"),
+							Subpath:   ptrfrom.String(""),
+						},
+						PkgMatchFlag: generated.MatchFlags{Pkg: generated.PkgMatchTypeSpecificVersion},
+						PointOfContact: &generated.PointOfContactInputSpec{
+							Email:         "ncopa@alpinelinux.org",
+							Info:          "originator: Natanael Copa (person)",
+							Since:         parseRfc3339("2022-09-24T17:27:55.556104Z"),
+							Justification: "Found in SPDX package originator.",
+						},
+					},
+				},
 				CertifyLegal: []assembler.CertifyLegalIngest{
 					{
 						Pkg: &generated.PkgInputSpec{
@@ -923,6 +941,24 @@ func Test_spdxParser(t *testing.T) {
 				},
 			},
 			wantPredicates: &assembler.IngestPredicates{
+				PointOfContact: []assembler.PointOfContactIngest{
+					{
+						Pkg: &generated.PkgInputSpec{
+							Type:      "guac",
+							Namespace: ptrfrom.String("pkg"),
+							Name:      "mypackage",
+							Version:   ptrfrom.String("3.2.0-r22"),
+							Subpath:   ptrfrom.String(""),
+						},
+						PkgMatchFlag: generated.MatchFlags{Pkg: generated.PkgMatchTypeSpecificVersion},
+						PointOfContact: &generated.PointOfContactInputSpec{
+							Email:         "ncopa@alpinelinux.org",
+							Info:          "originator: Natanael Copa (person)",
+							Since:         parseRfc3339("2022-09-24T17:27:55.556104Z"),
+							Justification: "Found in SPDX package originator.",
+						},
+					},
+				},
 				CertifyLegal: []assembler.CertifyLegalIngest{
 					{
 						Pkg: &generated.PkgInputSpec{
@@ -1007,6 +1043,24 @@ func Test_spdxParser(t *testing.T) {
 				},
 			},
 			wantPredicates: &assembler.IngestPredicates{
+				PointOfContact: []assembler.PointOfContactIngest{
+					{
+						Pkg: &generated.PkgInputSpec{
+							Type:      "guac",
+							Namespace: ptrfrom.String("pkg"),
+							Name:      "mypackage",
+							Version:   ptrfrom.String("3.2.0-r22"),
+							Subpath:   ptrfrom.String(""),
+						},
+						PkgMatchFlag: generated.MatchFlags{Pkg: generated.PkgMatchTypeSpecificVersion},
+						PointOfContact: &generated.PointOfContactInputSpec{
+							Email:         "ncopa@alpinelinux.org",
+							Info:          "originator: Natanael Copa (person)",
+							Since:         parseRfc3339("2022-09-24T17:27:55.556104Z"),
+							Justification: "Found in SPDX package originator.",
+						},
+					},
+				},
 				CertifyLegal: []assembler.CertifyLegalIngest{
 					{
 						Pkg: &generated.PkgInputSpec{
@@ -1033,6 +1087,85 @@ func Test_spdxParser(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "SPDX with source repository and supplier",
+			additionalOpts: []cmp.Option{
+				cmpopts.IgnoreFields(assembler.IngestPredicates{},
+					"HasSBOM", "IsDependency", "IsOccurrence", "CertifyLegal"),
+			},
+			doc: &processor.Document{
+				Blob: []byte(`
+{
+  "SPDXID":"SPDXRef-DOCUMENT",
+  "spdxVersion": "SPDX-2.2",
+  "name":"testsbom",
+  "creationInfo": {
+    "created": "2022-09-24T17:27:55.556104Z"
+  },
+  "packages": [
+    {
+      "SPDXID": "SPDXRef-35085779bdf473bb",
+      "name": "mypackage",
+      "downloadLocation": "git+https://github.com/example/mypackage.git@v3.2.0",
+      "filesAnalyzed": false,
+      "supplier": "Organization: ExampleCo (security@example.com)",
+      "originator": "NOASSERTION",
+      "versionInfo": "3.2.0"
+    }
+  ]
+}
+	`),
+				Format: processor.FormatJSON,
+				Type:   processor.DocumentSPDX,
+				SourceInformation: processor.SourceInformation{
+					Collector: "TestCollector",
+					Source:    "TestSource",
+				},
+			},
+			wantPredicates: &assembler.IngestPredicates{
+				HasSourceAt: []assembler.HasSourceAtIngest{
+					{
+						Pkg: &generated.PkgInputSpec{
+							Type:      "guac",
+							Namespace: ptrfrom.String("pkg"),
+							Name:      "mypackage",
+							Version:   ptrfrom.String("3.2.0"),
+							Subpath:   ptrfrom.String(""),
+						},
+						PkgMatchFlag: generated.MatchFlags{Pkg: generated.PkgMatchTypeSpecificVersion},
+						Src: &generated.SourceInputSpec{
+							Type:      "git",
+							Namespace: "github.com/example",
+							Name:      "mypackage",
+							Tag:       ptrfrom.String("v3.2.0"),
+						},
+						HasSourceAt: &generated.HasSourceAtInputSpec{
+							KnownSince:    parseRfc3339("2022-09-24T17:27:55.556104Z"),
+							Justification: "Found in SPDX package download location.",
+						},
+					},
+				},
+				PointOfContact: []assembler.PointOfContactIngest{
+					{
+						Pkg: &generated.PkgInputSpec{
+							Type:      "guac",
+							Namespace: ptrfrom.String("pkg"),
+							Name:      "mypackage",
+							Version:   ptrfrom.String("3.2.0"),
+							Subpath:   ptrfrom.String(""),
+						},
+						PkgMatchFlag: generated.MatchFlags{Pkg: generated.PkgMatchTypeSpecificVersion},
+						PointOfContact: &generated.PointOfContactInputSpec{
+							Email:         "security@example.com",
+							Info:          "supplier: ExampleCo (organization)",
+							Since:         parseRfc3339("2022-09-24T17:27:55.556104Z"),
+							Justification: "Found in SPDX package supplier.",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
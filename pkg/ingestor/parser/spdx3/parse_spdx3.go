@@ -0,0 +1,633 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spdx3
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/guacsec/guac/pkg/assembler"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	asmhelpers "github.com/guacsec/guac/pkg/assembler/helpers"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/ingestor/parser/common"
+	"github.com/guacsec/guac/pkg/logging"
+	"github.com/jeremywohl/flatten"
+	jsoniter "github.com/json-iterator/go"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// element types of the SPDX 3 Core, Software, Security and Build profiles, as
+// named by the SPDX 3 JSON-LD context
+const (
+	typeCreationInfo          = "CreationInfo"
+	typeSpdxDocument          = "SpdxDocument"
+	typeBom                   = "Bom"
+	typeBundle                = "Bundle"
+	typeRelationship          = "Relationship"
+	typeLifecycleRelationship = "LifecycleScopedRelationship"
+	typePackage               = "software_Package"
+	typeFile                  = "software_File"
+	typeSbom                  = "software_Sbom"
+	typeVulnerability         = "security_Vulnerability"
+	typeBuild                 = "build_Build"
+
+	vexTypePrefix = "security_Vex"
+)
+
+var dependencyRelationships = map[string]bool{
+	"contains":              true,
+	"dependsOn":             true,
+	"hasDynamicLink":        true,
+	"hasStaticLink":         true,
+	"hasOptionalDependency": true,
+	"hasProvidedDependency": true,
+}
+
+var vexStatusMap = map[string]model.VexStatus{
+	"affects":               model.VexStatusAffected,
+	"doesNotAffect":         model.VexStatusNotAffected,
+	"fixedIn":               model.VexStatusFixed,
+	"underInvestigationFor": model.VexStatusUnderInvestigation,
+}
+
+var justificationsMap = map[string]model.VexJustification{
+	"componentNotPresent":                         model.VexJustificationComponentNotPresent,
+	"vulnerableCodeNotPresent":                    model.VexJustificationVulnerableCodeNotPresent,
+	"vulnerableCodeNotInExecutePath":              model.VexJustificationVulnerableCodeNotInExecutePath,
+	"vulnerableCodeCannotBeControlledByAdversary": model.VexJustificationVulnerableCodeCannotBeControlledByAdversary,
+	"inlineMitigationsAlreadyExist":               model.VexJustificationInlineMitigationsAlreadyExist,
+}
+
+// idList is a list of element references, which JSON-LD serializes either as
+// a single reference or as an array of references or inlined elements
+type idList []string
+
+func (l *idList) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	items, ok := v.([]interface{})
+	if !ok {
+		items = []interface{}{v}
+	}
+	for _, item := range items {
+		switch i := item.(type) {
+		case string:
+			*l = append(*l, i)
+		case map[string]interface{}:
+			for _, key := range []string{"spdxId", "@id"} {
+				if id, ok := i[key].(string); ok {
+					*l = append(*l, id)
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type hash struct {
+	Algorithm string `json:"algorithm"`
+	HashValue string `json:"hashValue"`
+}
+
+type externalIdentifier struct {
+	ExternalIdentifierType string `json:"externalIdentifierType"`
+	Identifier             string `json:"identifier"`
+}
+
+// element is the union of the SPDX 3 element properties GUAC ingests
+type element struct {
+	Type         string              `json:"type"`
+	AtType       string              `json:"@type"`
+	SpdxID       string              `json:"spdxId"`
+	AtID         string              `json:"@id"`
+	Name         string              `json:"name"`
+	CreationInfo jsoniter.RawMessage `json:"creationInfo"`
+
+	// CreationInfo
+	SpecVersion  string `json:"specVersion"`
+	Created      string `json:"created"`
+	CreatedBy    idList `json:"createdBy"`
+	CreatedUsing idList `json:"createdUsing"`
+
+	// SpdxDocument, Bom and Sbom
+	RootElement idList `json:"rootElement"`
+
+	VerifiedUsing      []hash               `json:"verifiedUsing"`
+	ExternalIdentifier []externalIdentifier `json:"externalIdentifier"`
+
+	// Relationship
+	From             string `json:"from"`
+	To               idList `json:"to"`
+	RelationshipType string `json:"relationshipType"`
+	Comment          string `json:"comment"`
+
+	// Software profile
+	PackageVersion string `json:"software_packageVersion"`
+	PackageURL     string `json:"software_packageUrl"`
+
+	// Security profile
+	AssessedElement   string `json:"security_assessedElement"`
+	PublishedTime     string `json:"security_publishedTime"`
+	StatusNotes       string `json:"security_statusNotes"`
+	JustificationType string `json:"security_justificationType"`
+	ImpactStatement   string `json:"security_impactStatement"`
+	ActionStatement   string `json:"security_actionStatement"`
+
+	// Build profile
+	BuildType      string `json:"build_buildType"`
+	BuildStartTime string `json:"build_buildStartTime"`
+	BuildEndTime   string `json:"build_buildEndTime"`
+}
+
+func (e *element) id() string {
+	if e.SpdxID != "" {
+		return e.SpdxID
+	}
+	return e.AtID
+}
+
+func (e *element) elementType() string {
+	if e.Type != "" {
+		return e.Type
+	}
+	return e.AtType
+}
+
+type spdx3Parser struct {
+	doc                 *processor.Document
+	spdxDoc             *element
+	creationInfo        *element
+	elements            map[string]*element
+	relationships       []*element
+	vexAssessments      []*element
+	builds              []*element
+	buildProperties     map[string]map[string]interface{}
+	timeScanned         time.Time
+	packagePackages     map[string][]*model.PkgInputSpec
+	packageArtifacts    map[string][]*model.ArtifactInputSpec
+	filePackages        map[string][]*model.PkgInputSpec
+	fileArtifacts       map[string][]*model.ArtifactInputSpec
+	topLevelPackages    []*model.PkgInputSpec
+	topLevelIsHeuristic bool
+	identifierStrings   *common.IdentifierStrings
+}
+
+func NewSpdx3Parser() common.DocumentParser {
+	return &spdx3Parser{
+		elements:          map[string]*element{},
+		buildProperties:   map[string]map[string]interface{}{},
+		packagePackages:   map[string][]*model.PkgInputSpec{},
+		packageArtifacts:  map[string][]*model.ArtifactInputSpec{},
+		filePackages:      map[string][]*model.PkgInputSpec{},
+		fileArtifacts:     map[string][]*model.ArtifactInputSpec{},
+		identifierStrings: &common.IdentifierStrings{},
+	}
+}
+
+// Parse breaks out the document into the graph components
+func (s *spdx3Parser) Parse(ctx context.Context, doc *processor.Document) error {
+	s.doc = doc
+	if err := s.parseGraph(doc.Blob); err != nil {
+		return fmt.Errorf("failed to parse SPDX 3 document: %w", err)
+	}
+	if s.spdxDoc == nil {
+		return fmt.Errorf("SPDX 3 document is missing the SpdxDocument element")
+	}
+	if err := s.getCreationInfo(); err != nil {
+		return err
+	}
+	if err := s.getPackages(); err != nil {
+		return err
+	}
+	if err := s.getFiles(); err != nil {
+		return err
+	}
+	return s.getTopLevelPackages()
+}
+
+func (s *spdx3Parser) parseGraph(blob []byte) error {
+	var doc struct {
+		Graph []jsoniter.RawMessage `json:"@graph"`
+	}
+	if err := json.Unmarshal(blob, &doc); err != nil {
+		return err
+	}
+	for _, raw := range doc.Graph {
+		e := &element{}
+		if err := json.Unmarshal(raw, e); err != nil {
+			return err
+		}
+		s.elements[e.id()] = e
+
+		switch t := e.elementType(); {
+		case t == typeSpdxDocument:
+			s.spdxDoc = e
+		case t == typeRelationship || t == typeLifecycleRelationship:
+			s.relationships = append(s.relationships, e)
+		case strings.HasPrefix(t, vexTypePrefix):
+			s.vexAssessments = append(s.vexAssessments, e)
+		case t == typeBuild:
+			s.builds = append(s.builds, e)
+			properties := map[string]interface{}{}
+			if err := json.Unmarshal(raw, &properties); err != nil {
+				return err
+			}
+			s.buildProperties[e.id()] = properties
+		}
+	}
+	return nil
+}
+
+// getCreationInfo resolves the creation info of the SpdxDocument, which is
+// either inlined or a reference to a CreationInfo blank node
+func (s *spdx3Parser) getCreationInfo() error {
+	var ref string
+	if err := json.Unmarshal(s.spdxDoc.CreationInfo, &ref); err == nil {
+		if e, ok := s.elements[ref]; ok && e.elementType() == typeCreationInfo {
+			s.creationInfo = e
+		}
+	} else {
+		ci := &element{}
+		if err := json.Unmarshal(s.spdxDoc.CreationInfo, ci); err == nil {
+			s.creationInfo = ci
+		}
+	}
+	if s.creationInfo == nil {
+		return fmt.Errorf("SPDX 3 document is missing the creationInfo of the SpdxDocument")
+	}
+	created, err := time.Parse(time.RFC3339, s.creationInfo.Created)
+	if err != nil {
+		return fmt.Errorf("SPDX 3 document had invalid created time %q : %w", s.creationInfo.Created, err)
+	}
+	s.timeScanned = created
+	return nil
+}
+
+func (s *spdx3Parser) getPackages() error {
+	for id, e := range s.elements {
+		if e.elementType() != typePackage {
+			continue
+		}
+		purl := e.PackageURL
+		if purl == "" {
+			for _, ext := range e.ExternalIdentifier {
+				if ext.ExternalIdentifierType == "packageUrl" {
+					purl = ext.Identifier
+				}
+			}
+		}
+		if purl == "" {
+			purl = asmhelpers.GuacPkgPurl(e.Name, &e.PackageVersion)
+		}
+		s.identifierStrings.PurlStrings = append(s.identifierStrings.PurlStrings, purl)
+
+		pkg, err := asmhelpers.PurlToPkg(purl)
+		if err != nil {
+			return err
+		}
+		s.packagePackages[id] = append(s.packagePackages[id], pkg)
+
+		// if hashes exists create an artifact for each of them
+		for _, h := range e.VerifiedUsing {
+			if h.HashValue == "" {
+				continue
+			}
+			s.packageArtifacts[id] = append(s.packageArtifacts[id], &model.ArtifactInputSpec{
+				Algorithm: strings.ToLower(h.Algorithm),
+				Digest:    h.HashValue,
+			})
+		}
+	}
+	return nil
+}
+
+func (s *spdx3Parser) getFiles() error {
+	for id, e := range s.elements {
+		if e.elementType() != typeFile {
+			continue
+		}
+		for _, h := range e.VerifiedUsing {
+			if h.HashValue == "" || strings.Trim(h.HashValue, "0") == "" {
+				continue
+			}
+			algorithm := strings.ToLower(h.Algorithm)
+			// for each file create a package for each of them so they can be referenced as a dependency
+			purl := asmhelpers.GuacFilePurl(algorithm, h.HashValue, &e.Name)
+			pkg, err := asmhelpers.PurlToPkg(purl)
+			if err != nil {
+				return err
+			}
+			s.filePackages[id] = append(s.filePackages[id], pkg)
+			s.fileArtifacts[id] = append(s.fileArtifacts[id], &model.ArtifactInputSpec{
+				Algorithm: algorithm,
+				Digest:    h.HashValue,
+			})
+		}
+	}
+	return nil
+}
+
+// getTopLevelPackages finds the packages the document is about, which are
+// the root elements of the SpdxDocument or of the Sboms it contains, or the
+// targets of their "describes" relationships
+func (s *spdx3Parser) getTopLevelPackages() error {
+	visited := map[string]bool{}
+	var visit func(ids []string)
+	visit = func(ids []string) {
+		for _, id := range ids {
+			if visited[id] {
+				continue
+			}
+			visited[id] = true
+			if pkgs, ok := s.packagePackages[id]; ok {
+				s.topLevelPackages = append(s.topLevelPackages, pkgs...)
+				continue
+			}
+			e, ok := s.elements[id]
+			if !ok {
+				continue
+			}
+			switch e.elementType() {
+			case typeSpdxDocument, typeBom, typeBundle, typeSbom:
+				visit(e.RootElement)
+				for _, rel := range s.relationships {
+					if rel.From == id && rel.RelationshipType == "describes" {
+						visit(rel.To)
+					}
+				}
+			}
+		}
+	}
+	visit([]string{s.spdxDoc.id()})
+
+	// If there is no top level package that can be derived from the root elements, we take a best guess for the top level package.
+	if len(s.topLevelPackages) == 0 {
+		purl := "pkg:guac/spdx/" + asmhelpers.SanitizeString(s.spdxDoc.Name)
+		topPackage, err := asmhelpers.PurlToPkg(purl)
+		if err != nil {
+			return err
+		}
+		s.topLevelPackages = append(s.topLevelPackages, topPackage)
+		s.identifierStrings.PurlStrings = append(s.identifierStrings.PurlStrings, purl)
+		s.topLevelIsHeuristic = true
+	}
+	return nil
+}
+
+func (s *spdx3Parser) GetPredicates(ctx context.Context) *assembler.IngestPredicates {
+	logger := logging.FromContext(ctx)
+	preds := &assembler.IngestPredicates{}
+
+	for _, topLevelPkg := range s.topLevelPackages {
+		preds.HasSBOM = append(preds.HasSBOM, common.CreateTopLevelHasSBOM(topLevelPkg, s.doc, s.spdxDoc.id(), s.timeScanned))
+	}
+	if s.topLevelIsHeuristic {
+		preds.IsDependency = append(preds.IsDependency,
+			common.CreateTopLevelIsDeps(s.topLevelPackages[0], s.packagePackages, s.filePackages,
+				"top-level package GUAC heuristic connecting to each file/package")...)
+	}
+
+	for _, rel := range s.relationships {
+		if !dependencyRelationships[rel.RelationshipType] {
+			continue
+		}
+		justification := fmt.Sprintf("Derived from SPDX 3 %s relationship", rel.RelationshipType)
+		if rel.Comment != "" {
+			justification += fmt.Sprintf(" with comment: %s", rel.Comment)
+		}
+		foundNodes := append(append([]*model.PkgInputSpec{}, s.packagePackages[rel.From]...), s.filePackages[rel.From]...)
+		for _, to := range rel.To {
+			for _, node := range foundNodes {
+				p, err := common.GetIsDep(node, s.packagePackages[to], s.filePackages[to], justification)
+				if err != nil {
+					logger.Errorf("error generating spdx 3 edge %v", err)
+					continue
+				}
+				if p != nil {
+					preds.IsDependency = append(preds.IsDependency, *p)
+				}
+			}
+		}
+	}
+
+	// Create predicates for IsOccurrence for all artifacts found
+	for id := range s.fileArtifacts {
+		for _, pkg := range s.filePackages[id] {
+			for _, art := range s.fileArtifacts[id] {
+				preds.IsOccurrence = append(preds.IsOccurrence, assembler.IsOccurrenceIngest{
+					Pkg:      pkg,
+					Artifact: art,
+					IsOccurrence: &model.IsOccurrenceInputSpec{
+						Justification: "spdx file with checksum",
+					},
+				})
+			}
+		}
+	}
+	for id := range s.packagePackages {
+		for _, pkg := range s.packagePackages[id] {
+			for _, art := range s.packageArtifacts[id] {
+				preds.IsOccurrence = append(preds.IsOccurrence, assembler.IsOccurrenceIngest{
+					Pkg:      pkg,
+					Artifact: art,
+					IsOccurrence: &model.IsOccurrenceInputSpec{
+						Justification: "spdx package with checksum",
+					},
+				})
+			}
+		}
+	}
+
+	s.addVex(ctx, preds)
+	s.addBuilds(ctx, preds)
+
+	return preds
+}
+
+// addVex creates a CertifyVEXStatement for each package assessed by a Security
+// profile VEX relationship, and a CertifyVuln when it is affected
+func (s *spdx3Parser) addVex(ctx context.Context, preds *assembler.IngestPredicates) {
+	logger := logging.FromContext(ctx)
+	for _, vex := range s.vexAssessments {
+		status, ok := vexStatusMap[vex.RelationshipType]
+		if !ok {
+			logger.Errorf("unknown SPDX 3 VEX relationship type %q", vex.RelationshipType)
+			continue
+		}
+		vulnElement, ok := s.elements[vex.From]
+		if !ok || vulnElement.elementType() != typeVulnerability {
+			logger.Errorf("SPDX 3 VEX relationship %q references unknown vulnerability %q", vex.id(), vex.From)
+			continue
+		}
+		vuln, err := asmhelpers.CreateVulnInput(vulnerabilityID(vulnElement))
+		if err != nil {
+			logger.Errorf("failed to create vuln input spec %v", err)
+			continue
+		}
+
+		knownSince := s.timeScanned
+		if vex.PublishedTime != "" {
+			if published, err := time.Parse(time.RFC3339, vex.PublishedTime); err == nil {
+				knownSince = published
+			}
+		}
+		vd := &model.VexStatementInputSpec{
+			Status:           status,
+			VexJustification: model.VexJustificationNotProvided,
+			KnownSince:       knownSince,
+			StatusNotes:      vex.StatusNotes,
+		}
+		if justification, ok := justificationsMap[vex.JustificationType]; ok {
+			vd.VexJustification = justification
+		}
+		if status == model.VexStatusNotAffected {
+			vd.Statement = vex.ImpactStatement
+		} else if status == model.VexStatusAffected {
+			vd.Statement = vex.ActionStatement
+		}
+
+		assessed := []string(vex.To)
+		if _, ok := s.packagePackages[vex.AssessedElement]; ok {
+			assessed = []string{vex.AssessedElement}
+		}
+		for _, id := range assessed {
+			for _, pkg := range s.packagePackages[id] {
+				preds.Vex = append(preds.Vex, assembler.VexIngest{
+					Pkg:           pkg,
+					Vulnerability: vuln,
+					VexData:       vd,
+				})
+				if status == model.VexStatusAffected || status == model.VexStatusUnderInvestigation {
+					preds.CertifyVuln = append(preds.CertifyVuln, assembler.CertifyVulnIngest{
+						Pkg:           pkg,
+						Vulnerability: vuln,
+						VulnData: &model.ScanMetadataInput{
+							TimeScanned: knownSince,
+						},
+					})
+				}
+			}
+		}
+	}
+}
+
+// vulnerabilityID returns the CVE, or other security identifier, of a
+// Vulnerability element, falling back to its name
+func vulnerabilityID(vuln *element) string {
+	for _, ext := range vuln.ExternalIdentifier {
+		if ext.ExternalIdentifierType == "cve" {
+			return ext.Identifier
+		}
+	}
+	for _, ext := range vuln.ExternalIdentifier {
+		if ext.ExternalIdentifierType == "securityOther" {
+			return ext.Identifier
+		}
+	}
+	return vuln.Name
+}
+
+// addBuilds creates a HasSLSA for each artifact output by a Build profile
+// build, with its inputs as materials and the agent that invoked it as the
+// builder
+func (s *spdx3Parser) addBuilds(ctx context.Context, preds *assembler.IngestPredicates) {
+	logger := logging.FromContext(ctx)
+	for _, build := range s.builds {
+		var outputs, inputs, agents []string
+		for _, rel := range s.relationships {
+			if rel.From != build.id() {
+				continue
+			}
+			switch rel.RelationshipType {
+			case "hasOutput":
+				outputs = append(outputs, rel.To...)
+			case "hasInput":
+				inputs = append(inputs, rel.To...)
+			case "invokedBy":
+				agents = append(agents, rel.To...)
+			}
+		}
+		// fall back to the tools and agents that created the document
+		agents = append(agents, s.creationInfo.CreatedUsing...)
+		agents = append(agents, s.creationInfo.CreatedBy...)
+		if len(agents) == 0 {
+			logger.Errorf("SPDX 3 build %q has no builder", build.id())
+			continue
+		}
+
+		specVersion := s.creationInfo.SpecVersion
+		if specVersion == "" {
+			specVersion = "3.0.1"
+		}
+		slsa := &model.SLSAInputSpec{
+			BuildType:   build.BuildType,
+			SlsaVersion: fmt.Sprintf("https://spdx.org/rdf/%s/terms/Build/Build", specVersion),
+		}
+		if started, err := time.Parse(time.RFC3339, build.BuildStartTime); err == nil {
+			slsa.StartedOn = &started
+		}
+		if finished, err := time.Parse(time.RFC3339, build.BuildEndTime); err == nil {
+			slsa.FinishedOn = &finished
+		}
+		flatMap, err := flatten.Flatten(s.buildProperties[build.id()], "spdx3.", flatten.SeparatorStyle{Middle: "."})
+		if err != nil {
+			logger.Errorf("could not flatten SPDX 3 build %q: %v", build.id(), err)
+			continue
+		}
+		for k, v := range flatMap {
+			slsa.SlsaPredicate = append(slsa.SlsaPredicate, model.SLSAPredicateInputSpec{
+				Key:   k,
+				Value: fmt.Sprintf("%v", v),
+			})
+		}
+
+		var materials []model.ArtifactInputSpec
+		for _, id := range inputs {
+			for _, art := range s.artifacts(id) {
+				materials = append(materials, *art)
+			}
+		}
+		for _, id := range outputs {
+			for _, art := range s.artifacts(id) {
+				preds.HasSlsa = append(preds.HasSlsa, assembler.HasSlsaIngest{
+					Artifact:  art,
+					HasSlsa:   slsa,
+					Materials: materials,
+					Builder:   &model.BuilderInputSpec{Uri: agents[0]},
+				})
+			}
+		}
+	}
+}
+
+func (s *spdx3Parser) artifacts(id string) []*model.ArtifactInputSpec {
+	return append(append([]*model.ArtifactInputSpec{}, s.packageArtifacts[id]...), s.fileArtifacts[id]...)
+}
+
+func (s *spdx3Parser) GetIdentities(ctx context.Context) []common.TrustInformation {
+	return nil
+}
+
+func (s *spdx3Parser) GetIdentifiers(ctx context.Context) (*common.IdentifierStrings, error) {
+	return s.identifierStrings, nil
+}
@@ -0,0 +1,194 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spdx3
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/guacsec/guac/internal/testing/testdata"
+	"github.com/guacsec/guac/pkg/assembler"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	asmhelpers "github.com/guacsec/guac/pkg/assembler/helpers"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/ingestor/parser/common"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+func parseRfc3339(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func Test_spdx3Parser(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	doc := &processor.Document{
+		Blob:   testdata.Spdx3Example,
+		Format: processor.FormatJSON,
+		Type:   processor.DocumentSPDX3,
+		SourceInformation: processor.SourceInformation{
+			Collector: "TestCollector",
+			Source:    "TestSource",
+		},
+	}
+
+	app, _ := asmhelpers.PurlToPkg("pkg:golang/example.com/app@v1.0.0")
+	lib, _ := asmhelpers.PurlToPkg("pkg:golang/example.com/lib@v2.3.4")
+	mainGoName := "main.go"
+	mainGo, _ := asmhelpers.PurlToPkg(asmhelpers.GuacFilePurl("sha1", "7d8c9a1b2c3d4e5f60718293a4b5c6d7e8f90123", &mainGoName))
+	appArtifact := &model.ArtifactInputSpec{Algorithm: "sha256", Digest: "4f9a4f8c0b7a1f1c7e4a3b2c1d0e9f8a7b6c5d4e3f2a1b0c9d8e7f6a5b4c3d2e"}
+	mainGoArtifact := &model.ArtifactInputSpec{Algorithm: "sha1", Digest: "7d8c9a1b2c3d4e5f60718293a4b5c6d7e8f90123"}
+	cve, _ := asmhelpers.CreateVulnInput("CVE-2024-1234")
+	ghsa, _ := asmhelpers.CreateVulnInput("GHSA-xxxx-yyyy-zzzz")
+	created := parseRfc3339("2024-05-01T10:00:00Z")
+	started := parseRfc3339("2024-05-01T09:00:00Z")
+	finished := parseRfc3339("2024-05-01T09:30:00Z")
+
+	want := &assembler.IngestPredicates{
+		HasSBOM: []assembler.HasSBOMIngest{
+			common.CreateTopLevelHasSBOM(app, doc, "https://example.com/spdx3/document", created),
+		},
+		IsDependency: []assembler.IsDependencyIngest{
+			{
+				Pkg:             app,
+				DepPkg:          lib,
+				DepPkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+				IsDependency: &model.IsDependencyInputSpec{
+					DependencyType: model.DependencyTypeUnknown,
+					Justification:  "Derived from SPDX 3 dependsOn relationship",
+					VersionRange:   "v2.3.4",
+				},
+			},
+		},
+		IsOccurrence: []assembler.IsOccurrenceIngest{
+			{
+				Pkg:          app,
+				Artifact:     appArtifact,
+				IsOccurrence: &model.IsOccurrenceInputSpec{Justification: "spdx package with checksum"},
+			},
+			{
+				Pkg:          mainGo,
+				Artifact:     mainGoArtifact,
+				IsOccurrence: &model.IsOccurrenceInputSpec{Justification: "spdx file with checksum"},
+			},
+		},
+		Vex: []assembler.VexIngest{
+			{
+				Pkg:           app,
+				Vulnerability: cve,
+				VexData: &model.VexStatementInputSpec{
+					Status:           model.VexStatusNotAffected,
+					VexJustification: model.VexJustificationVulnerableCodeNotInExecutePath,
+					Statement:        "The vulnerable function is never called.",
+					KnownSince:       parseRfc3339("2024-05-02T10:00:00Z"),
+				},
+			},
+			{
+				Pkg:           lib,
+				Vulnerability: ghsa,
+				VexData: &model.VexStatementInputSpec{
+					Status:           model.VexStatusAffected,
+					VexJustification: model.VexJustificationNotProvided,
+					Statement:        "Upgrade to v2.3.5.",
+					StatusNotes:      "Reachable from the HTTP handler.",
+					KnownSince:       created,
+				},
+			},
+		},
+		CertifyVuln: []assembler.CertifyVulnIngest{
+			{
+				Pkg:           lib,
+				Vulnerability: ghsa,
+				VulnData:      &model.ScanMetadataInput{TimeScanned: created},
+			},
+		},
+		HasSlsa: []assembler.HasSlsaIngest{
+			{
+				Artifact:  appArtifact,
+				Materials: []model.ArtifactInputSpec{*mainGoArtifact},
+				Builder:   &model.BuilderInputSpec{Uri: "https://example.com/spdx3/agent/ci"},
+				HasSlsa: &model.SLSAInputSpec{
+					BuildType:   "https://example.com/build/go",
+					SlsaVersion: "https://spdx.org/rdf/3.0.1/terms/Build/Build",
+					StartedOn:   &started,
+					FinishedOn:  &finished,
+					SlsaPredicate: []model.SLSAPredicateInputSpec{
+						{Key: "spdx3.type", Value: "build_Build"},
+						{Key: "spdx3.spdxId", Value: "https://example.com/spdx3/build/1"},
+						{Key: "spdx3.creationInfo", Value: "_:creationinfo"},
+						{Key: "spdx3.build_buildType", Value: "https://example.com/build/go"},
+						{Key: "spdx3.build_buildId", Value: "build-1"},
+						{Key: "spdx3.build_buildStartTime", Value: "2024-05-01T09:00:00Z"},
+						{Key: "spdx3.build_buildEndTime", Value: "2024-05-01T09:30:00Z"},
+					},
+				},
+			},
+		},
+	}
+
+	s := NewSpdx3Parser()
+	if err := s.Parse(ctx, doc); err != nil {
+		t.Fatalf("spdx3Parser.Parse() error = %v", err)
+	}
+	got := s.GetPredicates(ctx)
+	if d := cmp.Diff(want, got, testdata.IngestPredicatesCmpOpts...); len(d) != 0 {
+		t.Errorf("spdx3.GetPredicate mismatch values (+got, -expected): %s", d)
+	}
+
+	ids, err := s.GetIdentifiers(ctx)
+	if err != nil {
+		t.Fatalf("spdx3Parser.GetIdentifiers() error = %v", err)
+	}
+	if len(ids.PurlStrings) != 2 {
+		t.Errorf("got purls %v, want the purls of app and lib", ids.PurlStrings)
+	}
+}
+
+func Test_spdx3Parser_heuristicTopLevel(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	doc := &processor.Document{
+		Blob: []byte(`{
+  "@context": "https://spdx.org/rdf/3.0.1/spdx-context.jsonld",
+  "@graph": [
+    {"type": "SpdxDocument", "spdxId": "urn:doc", "name": "no-root", "creationInfo": {"type": "CreationInfo", "specVersion": "3.0.1", "created": "2024-05-01T10:00:00Z", "createdBy": ["urn:agent"]}},
+    {"type": "software_Package", "spdxId": "urn:lib", "name": "lib", "software_packageVersion": "1.0"}
+  ]
+}`),
+		Format: processor.FormatJSON,
+		Type:   processor.DocumentSPDX3,
+	}
+	s := NewSpdx3Parser()
+	if err := s.Parse(ctx, doc); err != nil {
+		t.Fatalf("spdx3Parser.Parse() error = %v", err)
+	}
+	preds := s.GetPredicates(ctx)
+	top, _ := asmhelpers.PurlToPkg("pkg:guac/spdx/no-root")
+	if len(preds.HasSBOM) != 1 || !cmp.Equal(preds.HasSBOM[0].Pkg, top) {
+		t.Errorf("got HasSBOM %+v, want the heuristic top level package", preds.HasSBOM)
+	}
+	if len(preds.IsDependency) != 1 || preds.IsDependency[0].DepPkg.Name != "lib" {
+		t.Errorf("got IsDependency %+v, want the heuristic top level package to depend on lib", preds.IsDependency)
+	}
+
+	if err := NewSpdx3Parser().Parse(ctx, &processor.Document{Blob: []byte(`{"@graph": []}`)}); err == nil {
+		t.Errorf("spdx3Parser.Parse() expected an error for a document without an SpdxDocument")
+	}
+}
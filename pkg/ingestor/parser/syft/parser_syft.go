@@ -0,0 +1,239 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package syft parses the native JSON format of Syft.
+//
+// - The packages found by Syft become packages, identified by their purl.
+//
+// - The source that was scanned becomes the top level package of the SBOM,
+// which depends on every package found in it. The manifest digest of an
+// image, or the digests of a file, are occurrences of the top level package.
+//
+// - dependency-of relationships become IsDependency. contains and evident-by
+// relationships from a package to a file with digests become IsOccurrence,
+// contains relationships between packages become IsDependency.
+package syft
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/guacsec/guac/pkg/assembler"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	asmhelpers "github.com/guacsec/guac/pkg/assembler/helpers"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/handler/processor/syft"
+	"github.com/guacsec/guac/pkg/ingestor/parser/common"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+// Syft documents don't record when they were created
+var zeroTime = time.Unix(0, 0)
+
+type syftParser struct {
+	doc               *processor.Document
+	syftDoc           *syft.Document
+	topLevelPackage   *model.PkgInputSpec
+	topLevelArtifacts []*model.ArtifactInputSpec
+	packagePackages   map[string][]*model.PkgInputSpec
+	fileArtifacts     map[string][]*model.ArtifactInputSpec
+	identifierStrings *common.IdentifierStrings
+}
+
+func NewSyftParser() common.DocumentParser {
+	return &syftParser{
+		packagePackages:   map[string][]*model.PkgInputSpec{},
+		fileArtifacts:     map[string][]*model.ArtifactInputSpec{},
+		identifierStrings: &common.IdentifierStrings{},
+	}
+}
+
+// Parse breaks out the document into the graph components
+func (s *syftParser) Parse(ctx context.Context, doc *processor.Document) error {
+	s.doc = doc
+	syftDoc, err := syft.ParseDocument(doc.Blob)
+	if err != nil {
+		return fmt.Errorf("failed to parse Syft document: %w", err)
+	}
+	s.syftDoc = syftDoc
+	if err := s.getTopLevelPackage(); err != nil {
+		return err
+	}
+	if err := s.getPackages(); err != nil {
+		return err
+	}
+	s.getFiles()
+	return nil
+}
+
+// getTopLevelPackage creates the package of the scanned source, named after
+// the source or, failing that, the input given to Syft
+func (s *syftParser) getTopLevelPackage() error {
+	source := s.syftDoc.Source
+	name := source.Name
+	if name == "" {
+		name = source.Metadata.UserInput
+	}
+	if name == "" {
+		return nil
+	}
+	var version *string
+	if source.Version != "" {
+		version = &source.Version
+	}
+	purl := asmhelpers.GuacPkgPurl(name, version)
+	pkg, err := asmhelpers.PurlToPkg(purl)
+	if err != nil {
+		return fmt.Errorf("unable to create package input spec for Syft source %q: %w", name, err)
+	}
+	s.topLevelPackage = pkg
+	s.identifierStrings.PurlStrings = append(s.identifierStrings.PurlStrings, purl)
+
+	if art := digestArtifact(source.Metadata.ManifestDigest); art != nil {
+		s.topLevelArtifacts = append(s.topLevelArtifacts, art)
+	}
+	for _, digest := range source.Metadata.Digests {
+		s.topLevelArtifacts = append(s.topLevelArtifacts, &model.ArtifactInputSpec{
+			Algorithm: strings.ToLower(digest.Algorithm),
+			Digest:    digest.Value,
+		})
+	}
+	return nil
+}
+
+func (s *syftParser) getPackages() error {
+	for _, artifact := range s.syftDoc.Artifacts {
+		purl := artifact.PURL
+		if purl == "" {
+			var version *string
+			if artifact.Version != "" {
+				version = &artifact.Version
+			}
+			purl = asmhelpers.GuacPkgPurl(artifact.Name, version)
+		}
+		pkg, err := asmhelpers.PurlToPkg(purl)
+		if err != nil {
+			return fmt.Errorf("unable to create package input spec for Syft artifact %q: %w", artifact.ID, err)
+		}
+		s.packagePackages[artifact.ID] = append(s.packagePackages[artifact.ID], pkg)
+		s.identifierStrings.PurlStrings = append(s.identifierStrings.PurlStrings, purl)
+	}
+	return nil
+}
+
+func (s *syftParser) getFiles() {
+	for _, file := range s.syftDoc.Files {
+		for _, digest := range file.Digests {
+			if digest.Value == "" {
+				continue
+			}
+			s.fileArtifacts[file.ID] = append(s.fileArtifacts[file.ID], &model.ArtifactInputSpec{
+				Algorithm: strings.ToLower(digest.Algorithm),
+				Digest:    digest.Value,
+			})
+		}
+	}
+}
+
+// digestArtifact creates the artifact of a digest in the algorithm:value form
+func digestArtifact(digest string) *model.ArtifactInputSpec {
+	algorithm, value, ok := strings.Cut(digest, ":")
+	if !ok || value == "" {
+		return nil
+	}
+	return &model.ArtifactInputSpec{
+		Algorithm: strings.ToLower(algorithm),
+		Digest:    value,
+	}
+}
+
+func (s *syftParser) GetPredicates(ctx context.Context) *assembler.IngestPredicates {
+	logger := logging.FromContext(ctx)
+	preds := &assembler.IngestPredicates{}
+
+	if s.topLevelPackage != nil {
+		uri := s.syftDoc.Source.ID
+		if uri == "" {
+			uri = s.doc.SourceInformation.Source
+		}
+		preds.HasSBOM = append(preds.HasSBOM, common.CreateTopLevelHasSBOM(s.topLevelPackage, s.doc, uri, zeroTime))
+		preds.IsDependency = append(preds.IsDependency, common.CreateTopLevelIsDeps(s.topLevelPackage, s.packagePackages, nil,
+			"top-level package GUAC heuristic connecting to each file/package")...)
+		for _, art := range s.topLevelArtifacts {
+			preds.IsOccurrence = append(preds.IsOccurrence, assembler.IsOccurrenceIngest{
+				Pkg:      s.topLevelPackage,
+				Artifact: art,
+				IsOccurrence: &model.IsOccurrenceInputSpec{
+					Justification: "syft source with digest",
+				},
+			})
+		}
+	} else {
+		logger.Warnf("Syft document has no source, unable to create the top level package")
+	}
+
+	for _, rel := range s.syftDoc.ArtifactRelationships {
+		justification := "syft relationship: " + rel.Type
+		switch rel.Type {
+		case syft.RelationshipDependencyOf:
+			// the parent is a dependency of the child
+			s.appendIsDependency(ctx, preds, rel.Child, rel.Parent, justification)
+		case syft.RelationshipContains, syft.RelationshipEvidentBy:
+			if arts, ok := s.fileArtifacts[rel.Child]; ok {
+				for _, pkg := range s.packagePackages[rel.Parent] {
+					for _, art := range arts {
+						preds.IsOccurrence = append(preds.IsOccurrence, assembler.IsOccurrenceIngest{
+							Pkg:      pkg,
+							Artifact: art,
+							IsOccurrence: &model.IsOccurrenceInputSpec{
+								Justification: justification,
+							},
+						})
+					}
+				}
+			} else if rel.Type == syft.RelationshipContains {
+				s.appendIsDependency(ctx, preds, rel.Parent, rel.Child, justification)
+			}
+		}
+	}
+
+	return preds
+}
+
+func (s *syftParser) appendIsDependency(ctx context.Context, preds *assembler.IngestPredicates, pkgID string, depID string, justification string) {
+	logger := logging.FromContext(ctx)
+	deps := s.packagePackages[depID]
+	for _, pkg := range s.packagePackages[pkgID] {
+		p, err := common.GetIsDep(pkg, deps, nil, justification)
+		if err != nil {
+			logger.Errorf("error generating syft edge %v", err)
+			continue
+		}
+		if p != nil {
+			preds.IsDependency = append(preds.IsDependency, *p)
+		}
+	}
+}
+
+// GetIdentities gets the identity node from the document if they exist
+func (s *syftParser) GetIdentities(ctx context.Context) []common.TrustInformation {
+	return nil
+}
+
+func (s *syftParser) GetIdentifiers(ctx context.Context) (*common.IdentifierStrings, error) {
+	return s.identifierStrings, nil
+}
@@ -0,0 +1,136 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syft
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/guacsec/guac/internal/testing/testdata"
+	"github.com/guacsec/guac/pkg/assembler"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	asmhelpers "github.com/guacsec/guac/pkg/assembler/helpers"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/ingestor/parser/common"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+func mustPkg(t *testing.T, purl string) *model.PkgInputSpec {
+	t.Helper()
+	pkg, err := asmhelpers.PurlToPkg(purl)
+	if err != nil {
+		t.Fatalf("PurlToPkg(%q) failed: %v", purl, err)
+	}
+	return pkg
+}
+
+func Test_syftParser(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+
+	topLevel := mustPkg(t, "pkg:guac/pkg/alpine@3.19")
+	busybox := mustPkg(t, "pkg:apk/alpine/busybox@1.36.1-r15?arch=x86_64&distro=alpine-3.19.1")
+	musl := mustPkg(t, "pkg:apk/alpine/musl@1.2.4_git20230717-r4?arch=x86_64&distro=alpine-3.19.1")
+	exampleTool := mustPkg(t, "pkg:guac/pkg/example-tool@0.1.0")
+
+	isDep := func(pkg, dep *model.PkgInputSpec, justification string) assembler.IsDependencyIngest {
+		return assembler.IsDependencyIngest{
+			Pkg:             pkg,
+			DepPkg:          dep,
+			DepPkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+			IsDependency: &model.IsDependencyInputSpec{
+				DependencyType: model.DependencyTypeUnknown,
+				Justification:  justification,
+				VersionRange:   *dep.Version,
+			},
+		}
+	}
+	isOcc := func(pkg *model.PkgInputSpec, algorithm, digest, justification string) assembler.IsOccurrenceIngest {
+		return assembler.IsOccurrenceIngest{
+			Pkg:          pkg,
+			Artifact:     &model.ArtifactInputSpec{Algorithm: algorithm, Digest: digest},
+			IsOccurrence: &model.IsOccurrenceInputSpec{Justification: justification},
+		}
+	}
+
+	heuristic := "top-level package GUAC heuristic connecting to each file/package"
+	sum := sha256.Sum256(testdata.SyftExampleAlpine)
+	want := &assembler.IngestPredicates{
+		HasSBOM: []assembler.HasSBOMIngest{{
+			Pkg: topLevel,
+			HasSBOM: &model.HasSBOMInputSpec{
+				Uri:        "d8e7f6a5b4c3d2e1",
+				Algorithm:  "sha256",
+				Digest:     hex.EncodeToString(sum[:]),
+				KnownSince: time.Unix(0, 0),
+			},
+		}},
+		IsDependency: []assembler.IsDependencyIngest{
+			isDep(topLevel, busybox, heuristic),
+			isDep(topLevel, musl, heuristic),
+			isDep(topLevel, exampleTool, heuristic),
+			isDep(busybox, musl, "syft relationship: dependency-of"),
+		},
+		IsOccurrence: []assembler.IsOccurrenceIngest{
+			isOcc(topLevel, "sha256", "6457d53fb065d6f250e1504b9bc42d5b6c65941d57532c072d929dd0628977d0", "syft source with digest"),
+			isOcc(busybox, "sha256", "e6b8e5a5d1e6f5ec1ba5c9a6e7d8e9f0a1b2c3d4e5f60718293a4b5c6d7e8f90", "syft relationship: contains"),
+			isOcc(exampleTool, "sha1", "3b1f0e2d4c5a69788796a5b4c3d2e1f00f1e2d3c", "syft relationship: evident-by"),
+		},
+	}
+
+	s := NewSyftParser()
+	err := s.Parse(ctx, &processor.Document{
+		Blob:   testdata.SyftExampleAlpine,
+		Type:   processor.DocumentSyft,
+		Format: processor.FormatJSON,
+	})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if d := cmp.Diff(want, s.GetPredicates(ctx), testdata.IngestPredicatesCmpOpts...); d != "" {
+		t.Errorf("GetPredicates() mismatch (-want +got):\n%s", d)
+	}
+
+	ids, err := s.GetIdentifiers(ctx)
+	if err != nil {
+		t.Fatalf("GetIdentifiers() error = %v", err)
+	}
+	wantIDs := &common.IdentifierStrings{PurlStrings: []string{
+		"pkg:guac/pkg/alpine@3.19",
+		"pkg:apk/alpine/busybox@1.36.1-r15?arch=x86_64&distro=alpine-3.19.1",
+		"pkg:apk/alpine/musl@1.2.4_git20230717-r4?arch=x86_64&distro=alpine-3.19.1",
+		"pkg:guac/pkg/example-tool@0.1.0",
+	}}
+	if d := cmp.Diff(wantIDs, ids); d != "" {
+		t.Errorf("GetIdentifiers() mismatch (-want +got):\n%s", d)
+	}
+}
+
+func Test_syftParser_invalid(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	s := NewSyftParser()
+	err := s.Parse(ctx, &processor.Document{
+		Blob:   []byte(`{"descriptor": {"name": "syft"}}`),
+		Type:   processor.DocumentSyft,
+		Format: processor.FormatJSON,
+	})
+	if err == nil {
+		t.Errorf("expected an error parsing a Syft document without artifacts")
+	}
+}
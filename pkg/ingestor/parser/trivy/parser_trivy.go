@@ -0,0 +1,257 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package trivy parses the native JSON report of Trivy.
+//
+// - Each vulnerability found in an installed package becomes a CertifyVuln,
+// packages listed without vulnerabilities (with --list-all-pkgs) are
+// certified as having none.
+//
+// - The CVSS scores each vendor assigned to a vulnerability become
+// VulnerabilityMetadata, as do the severities of the vendors that rated it
+// without a CVSS score (see common.SeverityMetadata).
+package trivy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/guacsec/guac/pkg/assembler"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	asmhelpers "github.com/guacsec/guac/pkg/assembler/helpers"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/handler/processor/trivy"
+	"github.com/guacsec/guac/pkg/ingestor/parser/common"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+const (
+	scannerURI = "https://github.com/aquasecurity/trivy"
+	// dbURI is the default Trivy database, reports don't record which
+	// database or version of it was used
+	dbURI = "ghcr.io/aquasecurity/trivy-db"
+)
+
+var zeroTime = time.Unix(0, 0)
+
+var noVulnInput = &model.VulnerabilityInputSpec{Type: "noVuln", VulnerabilityID: ""}
+
+type trivyParser struct {
+	identifierStrings *common.IdentifierStrings
+	certifyVulns      []assembler.CertifyVulnIngest
+	vulnMetadata      []assembler.VulnMetadataIngest
+}
+
+func NewTrivyParser() common.DocumentParser {
+	return &trivyParser{
+		identifierStrings: &common.IdentifierStrings{},
+	}
+}
+
+// Parse breaks out the document into the graph components
+func (t *trivyParser) Parse(ctx context.Context, doc *processor.Document) error {
+	logger := logging.FromContext(ctx)
+	report, err := trivy.ParseReport(doc.Blob)
+	if err != nil {
+		return fmt.Errorf("failed to parse Trivy report: %w", err)
+	}
+
+	timeScanned := zeroTime
+	if report.CreatedAt != "" {
+		if timeScanned, err = time.Parse(time.RFC3339, report.CreatedAt); err != nil {
+			logger.Warnf("Trivy report had invalid creation time %q: %v", report.CreatedAt, err)
+			timeScanned = zeroTime
+		}
+	}
+	scanMetadata := &model.ScanMetadataInput{
+		TimeScanned: timeScanned,
+		DbUri:       dbURI,
+		ScannerUri:  scannerURI,
+	}
+	if report.Trivy != nil {
+		scanMetadata.ScannerVersion = report.Trivy.Version
+	}
+
+	vulnerable := map[string]bool{}
+	seenMetadata := map[string]bool{}
+	for _, result := range report.Results {
+		for _, v := range result.Vulnerabilities {
+			purl := packagePurl(v.PkgIdentifier.PURL, v.PkgName, v.InstalledVersion)
+			pkg, err := t.createPackage(purl)
+			if err != nil {
+				return err
+			}
+			vuln, err := asmhelpers.CreateVulnInput(v.VulnerabilityID)
+			if err != nil {
+				return fmt.Errorf("failed to create vulnerability input: %w", err)
+			}
+			vulnerable[purl] = true
+			t.certifyVulns = append(t.certifyVulns, assembler.CertifyVulnIngest{
+				Pkg:           pkg,
+				Vulnerability: vuln,
+				VulnData:      scanMetadata,
+			})
+
+			if seenMetadata[vuln.VulnerabilityID] {
+				continue
+			}
+			seenMetadata[vuln.VulnerabilityID] = true
+			t.vulnMetadata = append(t.vulnMetadata, cvssMetadata(vuln, v.CVSS, timeScanned)...)
+			t.vulnMetadata = append(t.vulnMetadata, severityMetadata(ctx, vuln, v, timeScanned)...)
+		}
+	}
+
+	for _, result := range report.Results {
+		for _, p := range result.Packages {
+			purl := packagePurl(p.Identifier.PURL, p.Name, p.Version)
+			if vulnerable[purl] {
+				continue
+			}
+			vulnerable[purl] = true
+			pkg, err := t.createPackage(purl)
+			if err != nil {
+				return err
+			}
+			t.certifyVulns = append(t.certifyVulns, assembler.CertifyVulnIngest{
+				Pkg:           pkg,
+				Vulnerability: noVulnInput,
+				VulnData:      scanMetadata,
+			})
+		}
+	}
+	return nil
+}
+
+// packagePurl returns the purl of a package, creating a GUAC purl from its
+// name and version if Trivy didn't identify it
+func packagePurl(purl string, name string, version string) string {
+	if purl != "" {
+		return purl
+	}
+	if version == "" {
+		return asmhelpers.GuacPkgPurl(name, nil)
+	}
+	return asmhelpers.GuacPkgPurl(name, &version)
+}
+
+func (t *trivyParser) createPackage(purl string) (*model.PkgInputSpec, error) {
+	pkg, err := asmhelpers.PurlToPkg(purl)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create package input spec from %q: %w", purl, err)
+	}
+	t.identifierStrings.PurlStrings = append(t.identifierStrings.PurlStrings, purl)
+	return pkg, nil
+}
+
+// cvssMetadata creates the metadata of the CVSS scores of each vendor, in
+// the order of the vendor names
+func cvssMetadata(vuln *model.VulnerabilityInputSpec, scores map[string]trivy.CVSS, timestamp time.Time) []assembler.VulnMetadataIngest {
+	var vendors []string
+	for vendor := range scores {
+		vendors = append(vendors, vendor)
+	}
+	sort.Strings(vendors)
+
+	var metadata []assembler.VulnMetadataIngest
+	add := func(vendor string, scoreType model.VulnerabilityScoreType, score float64) {
+		metadata = append(metadata, assembler.VulnMetadataIngest{
+			Vulnerability: vuln,
+			VulnMetadata: &model.VulnerabilityMetadataInputSpec{
+				ScoreType:  scoreType,
+				ScoreValue: score,
+				Timestamp:  timestamp,
+				Origin:     vendor,
+			},
+		})
+	}
+	for _, vendor := range vendors {
+		cvss := scores[vendor]
+		if cvss.V2Score > 0 {
+			add(vendor, model.VulnerabilityScoreTypeCvssv2, cvss.V2Score)
+		}
+		if cvss.V3Score > 0 {
+			scoreType := model.VulnerabilityScoreTypeCvssv3
+			if strings.HasPrefix(cvss.V3Vector, "CVSS:3.1/") {
+				scoreType = model.VulnerabilityScoreTypeCvssv31
+			}
+			add(vendor, scoreType, cvss.V3Score)
+		}
+		if cvss.V40Score > 0 {
+			add(vendor, model.VulnerabilityScoreTypeCvssv4, cvss.V40Score)
+		}
+	}
+	return metadata
+}
+
+// severityMetadata creates the metadata of the severities vendors rated the
+// vulnerability without a CVSS score, as distro feeds often do, in the order
+// of the vendor names. A vulnerability without any vendor rating has the
+// severity of the report recorded, from its severity source.
+func severityMetadata(ctx context.Context, vuln *model.VulnerabilityInputSpec, v trivy.Vulnerability, timestamp time.Time) []assembler.VulnMetadataIngest {
+	logger := logging.FromContext(ctx)
+	severities := map[string]string{}
+	for vendor, severity := range v.VendorSeverity {
+		if _, ok := v.CVSS[vendor]; ok {
+			continue
+		}
+		if severity > 0 && severity < len(trivy.SeverityNames) {
+			severities[vendor] = trivy.SeverityNames[severity]
+		}
+	}
+	if len(v.VendorSeverity) == 0 && len(v.CVSS) == 0 && v.Severity != "" {
+		source := v.SeveritySource
+		if source == "" {
+			source = "trivy"
+		}
+		severities[source] = v.Severity
+	}
+
+	var vendors []string
+	for vendor := range severities {
+		vendors = append(vendors, vendor)
+	}
+	sort.Strings(vendors)
+
+	var metadata []assembler.VulnMetadataIngest
+	for _, vendor := range vendors {
+		severity := common.SeverityMetadata(vendor, severities[vendor])
+		if severity == nil {
+			logger.Debugf("[trivy] skipping %s severity %s of %s", vendor, severities[vendor], v.VulnerabilityID)
+			continue
+		}
+		severity.Timestamp = timestamp
+		metadata = append(metadata, assembler.VulnMetadataIngest{Vulnerability: vuln, VulnMetadata: severity})
+	}
+	return metadata
+}
+
+func (t *trivyParser) GetPredicates(ctx context.Context) *assembler.IngestPredicates {
+	return &assembler.IngestPredicates{
+		CertifyVuln:  t.certifyVulns,
+		VulnMetadata: t.vulnMetadata,
+	}
+}
+
+// GetIdentities gets the identity node from the document if they exist
+func (t *trivyParser) GetIdentities(ctx context.Context) []common.TrustInformation {
+	return nil
+}
+
+func (t *trivyParser) GetIdentifiers(ctx context.Context) (*common.IdentifierStrings, error) {
+	return t.identifierStrings, nil
+}
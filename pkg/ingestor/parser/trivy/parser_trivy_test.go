@@ -0,0 +1,134 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trivy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/guacsec/guac/internal/testing/testdata"
+	"github.com/guacsec/guac/pkg/assembler"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	asmhelpers "github.com/guacsec/guac/pkg/assembler/helpers"
+	"github.com/guacsec/guac/pkg/handler/processor"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+func mustPkg(t *testing.T, purl string) *model.PkgInputSpec {
+	t.Helper()
+	pkg, err := asmhelpers.PurlToPkg(purl)
+	if err != nil {
+		t.Fatalf("PurlToPkg(%q) failed: %v", purl, err)
+	}
+	return pkg
+}
+
+func Test_trivyParser(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	scanned, _ := time.Parse(time.RFC3339, "2024-03-11T09:30:00.123456789Z")
+
+	scanMetadata := &model.ScanMetadataInput{
+		TimeScanned:    scanned,
+		DbUri:          "ghcr.io/aquasecurity/trivy-db",
+		ScannerUri:     "https://github.com/aquasecurity/trivy",
+		ScannerVersion: "0.50.0",
+	}
+	cve := &model.VulnerabilityInputSpec{Type: "cve", VulnerabilityID: "cve-2024-0727"}
+	ghsa := &model.VulnerabilityInputSpec{Type: "ghsa", VulnerabilityID: "ghsa-m425-mq94-257g"}
+	metadata := func(vuln *model.VulnerabilityInputSpec, scoreType model.VulnerabilityScoreType, score float64, origin string) assembler.VulnMetadataIngest {
+		return assembler.VulnMetadataIngest{
+			Vulnerability: vuln,
+			VulnMetadata: &model.VulnerabilityMetadataInputSpec{
+				ScoreType:  scoreType,
+				ScoreValue: score,
+				Timestamp:  scanned,
+				Origin:     origin,
+			},
+		}
+	}
+
+	want := &assembler.IngestPredicates{
+		CertifyVuln: []assembler.CertifyVulnIngest{
+			{
+				Pkg:           mustPkg(t, "pkg:apk/alpine/libcrypto3@3.1.4-r5?arch=x86_64&distro=3.19.1"),
+				Vulnerability: cve,
+				VulnData:      scanMetadata,
+			},
+			{
+				Pkg:           mustPkg(t, "pkg:guac/pkg/google.golang.org/grpc@v1.56.2"),
+				Vulnerability: ghsa,
+				VulnData:      scanMetadata,
+			},
+			{
+				Pkg:           mustPkg(t, "pkg:apk/alpine/musl@1.2.4_git20230717-r4?arch=x86_64&distro=3.19.1"),
+				Vulnerability: noVulnInput,
+				VulnData:      scanMetadata,
+			},
+		},
+		VulnMetadata: []assembler.VulnMetadataIngest{
+			metadata(cve, model.VulnerabilityScoreTypeCvssv31, 5.5, "nvd"),
+			metadata(cve, model.VulnerabilityScoreTypeCvssv31, 5.5, "redhat"),
+			metadata(cve, model.VulnerabilityScoreTypeSeverity, 2, "alpine"),
+			metadata(ghsa, model.VulnerabilityScoreTypeCvssv3, 7.5, "ghsa"),
+		},
+	}
+
+	p := NewTrivyParser()
+	err := p.Parse(ctx, &processor.Document{
+		Blob:   testdata.TrivyExampleAlpine,
+		Type:   processor.DocumentTrivy,
+		Format: processor.FormatJSON,
+	})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if d := cmp.Diff(want, p.GetPredicates(ctx), testdata.IngestPredicatesCmpOpts...); d != "" {
+		t.Errorf("GetPredicates() mismatch (-want +got):\n%s", d)
+	}
+}
+
+func Test_trivyParser_invalid(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	tests := []struct {
+		name string
+		blob string
+	}{
+		{
+			name: "not a Trivy report",
+			blob: `{"abc": "def"}`,
+		},
+		{
+			name: "malformed vulnerability id",
+			blob: `{"SchemaVersion": 2, "ArtifactName": "app", "ArtifactType": "filesystem", "Results": [
+				{"Target": "go.mod", "Vulnerabilities": [{"VulnerabilityID": "bad", "PkgName": "foo", "InstalledVersion": "1.0.0"}]}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewTrivyParser()
+			err := p.Parse(ctx, &processor.Document{
+				Blob:   []byte(tt.blob),
+				Type:   processor.DocumentTrivy,
+				Format: processor.FormatJSON,
+			})
+			if err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,315 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sigstore_verifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/guacsec/guac/pkg/ingestor/key"
+	"github.com/guacsec/guac/pkg/ingestor/verifier"
+	"github.com/guacsec/guac/pkg/logging"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+const bundleMediaType = "application/vnd.dev.sigstore.bundle"
+
+var (
+	// Fulcio certificate extensions holding the OIDC issuer, the first one
+	// is deprecated in favour of the DER encoded second one
+	oidIssuerV1 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	oidIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+// bundle is a Sigstore bundle (application/vnd.dev.sigstore.bundle+json)
+// wrapping a DSSE envelope. Bundles up to v0.2 carry a certificate chain,
+// v0.3 only the leaf certificate.
+type bundle struct {
+	MediaType            string `json:"mediaType"`
+	VerificationMaterial struct {
+		X509CertificateChain *struct {
+			Certificates []rawBytes `json:"certificates"`
+		} `json:"x509CertificateChain"`
+		Certificate *rawBytes `json:"certificate"`
+		PublicKey   *struct {
+			Hint string `json:"hint"`
+		} `json:"publicKey"`
+		TlogEntries []tlogEntry `json:"tlogEntries"`
+	} `json:"verificationMaterial"`
+	DSSEEnvelope *dsse.Envelope `json:"dsseEnvelope"`
+}
+
+// isBundle reports whether the payload is a Sigstore bundle rather than a
+// bare DSSE envelope
+func isBundle(payloadBytes []byte) bool {
+	var b struct {
+		MediaType string `json:"mediaType"`
+	}
+	return json.Unmarshal(payloadBytes, &b) == nil && strings.HasPrefix(b.MediaType, bundleMediaType)
+}
+
+// verifyBundle verifies the DSSE envelope of the bundle. Keyless signatures
+// are verified against the certificate issued by Fulcio, whose chain and
+// transparency log entries are checked against the trusted root. Signatures
+// by a public key are verified against the keys stored through key.Store.
+func (d *sigstoreVerifier) verifyBundle(ctx context.Context, payloadBytes []byte) ([]verifier.Identity, error) {
+	var b bundle
+	if err := json.Unmarshal(payloadBytes, &b); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sigstore bundle: %w", err)
+	}
+	if b.DSSEEnvelope == nil {
+		return nil, errors.New("sigstore bundle does not contain a DSSE envelope")
+	}
+	envelopeBytes, err := json.Marshal(b.DSSEEnvelope)
+	if err != nil {
+		return nil, err
+	}
+
+	material := b.VerificationMaterial
+	if material.PublicKey != nil {
+		k, err := key.Find(ctx, material.PublicKey.Hint)
+		if err != nil {
+			return nil, err
+		}
+		if err := verifySignature(k.Val, envelopeBytes); err != nil {
+			return nil, err
+		}
+		return []verifier.Identity{{ID: material.PublicKey.Hint, Key: *k, Verified: true}}, nil
+	}
+
+	var certs []*x509.Certificate
+	if material.X509CertificateChain != nil {
+		for _, raw := range material.X509CertificateChain.Certificates {
+			cert, err := x509.ParseCertificate(raw.RawBytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse certificate: %w", err)
+			}
+			certs = append(certs, cert)
+		}
+	} else if material.Certificate != nil {
+		cert, err := x509.ParseCertificate(material.Certificate.RawBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("sigstore bundle has neither a certificate nor a public key")
+	}
+	if d.trustedRoot == nil {
+		return nil, errors.New("a trusted root is required to verify keyless signatures")
+	}
+	leaf := certs[0]
+
+	integratedTime, err := d.verifyTlogEntries(ctx, material.TlogEntries, b.DSSEEnvelope, leaf)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.trustedRoot.verifyCertificate(leaf, certs[1:], integratedTime); err != nil {
+		return nil, err
+	}
+	if err := verifySignature(leaf.PublicKey, envelopeBytes); err != nil {
+		logger := logging.FromContext(ctx)
+		logger.Errorf("failed to verify signature with certificate issued to: %v", cryptoutils.GetSubjectAlternateNames(leaf))
+		return nil, err
+	}
+
+	k, err := key.NewKey(leaf.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	identity := verifier.Identity{
+		Key:      *k,
+		Verified: true,
+		Issuer:   certificateIssuer(leaf),
+	}
+	if sans := cryptoutils.GetSubjectAlternateNames(leaf); len(sans) > 0 {
+		identity.SubjectAlternativeName = sans[0]
+		identity.ID = sans[0]
+	}
+	return []verifier.Identity{identity}, nil
+}
+
+// verifyTlogEntries verifies the transparency log entries of the envelope and
+// returns the time it was integrated into the log, at which the short-lived
+// signing certificate must have been valid. Only entries logging the
+// signature of the envelope by the leaf certificate are considered, so that
+// an entry of another signer of the same payload can't vouch for the
+// certificate.
+func (d *sigstoreVerifier) verifyTlogEntries(ctx context.Context, entries []tlogEntry, envelope *dsse.Envelope, leaf *x509.Certificate) (time.Time, error) {
+	logger := logging.FromContext(ctx)
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to decode payload: %w", err)
+	}
+	payloadHash := sha256.Sum256(payload)
+
+	for i := range entries {
+		entry := &entries[i]
+		if err := verifyEntryBody(entry, hex.EncodeToString(payloadHash[:]), envelope, leaf); err != nil {
+			logger.Warnf("skipping transparency log entry %d: %v", entry.LogIndex, err)
+			continue
+		}
+		integratedTime, err := entry.verify(d.trustedRoot)
+		if err != nil {
+			logger.Warnf("failed to verify transparency log entry %d: %v", entry.LogIndex, err)
+			continue
+		}
+		return integratedTime, nil
+	}
+	return time.Time{}, errors.New("sigstore bundle has no verified transparency log entry")
+}
+
+// entrySignature is a signature recorded in a transparency log entry along
+// with the PEM encoded certificate or public key verifying it
+type entrySignature struct {
+	signature []byte
+	verifier  []byte
+}
+
+// verifyEntryBody checks that the transparency log entry records the payload
+// of the envelope, along with one of its signatures made by the leaf
+// certificate
+func verifyEntryBody(entry *tlogEntry, payloadHash string, envelope *dsse.Envelope, leaf *x509.Certificate) error {
+	type hash struct {
+		Algorithm string `json:"algorithm"`
+		Value     string `json:"value"`
+	}
+	var body struct {
+		Kind string `json:"kind"`
+		Spec struct {
+			// dsse entries
+			PayloadHash *hash `json:"payloadHash"`
+			Signatures  []struct {
+				Signature string `json:"signature"`
+				Verifier  string `json:"verifier"`
+			} `json:"signatures"`
+			// intoto entries
+			Content struct {
+				PayloadHash *hash `json:"payloadHash"`
+				Envelope    struct {
+					Signatures []struct {
+						Sig       string `json:"sig"`
+						PublicKey string `json:"publicKey"`
+					} `json:"signatures"`
+				} `json:"envelope"`
+			} `json:"content"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(entry.CanonicalizedBody, &body); err != nil {
+		return fmt.Errorf("failed to unmarshal entry body: %w", err)
+	}
+
+	var recorded *hash
+	var signatures []entrySignature
+	switch body.Kind {
+	case "dsse":
+		recorded = body.Spec.PayloadHash
+		for _, sig := range body.Spec.Signatures {
+			signature, err := base64.StdEncoding.DecodeString(sig.Signature)
+			if err != nil {
+				return fmt.Errorf("failed to decode entry signature: %w", err)
+			}
+			verifier, err := base64.StdEncoding.DecodeString(sig.Verifier)
+			if err != nil {
+				return fmt.Errorf("failed to decode entry verifier: %w", err)
+			}
+			signatures = append(signatures, entrySignature{signature: signature, verifier: verifier})
+		}
+	case "intoto":
+		recorded = body.Spec.Content.PayloadHash
+		for _, sig := range body.Spec.Content.Envelope.Signatures {
+			signature, err := base64.StdEncoding.DecodeString(sig.Sig)
+			if err != nil {
+				return fmt.Errorf("failed to decode entry signature: %w", err)
+			}
+			// intoto v0.0.2 entries encode the base64 signature of the
+			// envelope in base64 again
+			if decoded, err := base64.StdEncoding.DecodeString(string(signature)); err == nil {
+				signature = decoded
+			}
+			verifier, err := base64.StdEncoding.DecodeString(sig.PublicKey)
+			if err != nil {
+				return fmt.Errorf("failed to decode entry public key: %w", err)
+			}
+			signatures = append(signatures, entrySignature{signature: signature, verifier: verifier})
+		}
+	default:
+		return fmt.Errorf("unsupported entry kind %q", body.Kind)
+	}
+	if recorded == nil || recorded.Algorithm != "sha256" || recorded.Value != payloadHash {
+		return errors.New("entry does not match the payload of the envelope")
+	}
+
+	for _, sig := range signatures {
+		if !verifierMatches(sig.verifier, leaf) {
+			continue
+		}
+		for _, envelopeSig := range envelope.Signatures {
+			decoded, err := base64.StdEncoding.DecodeString(envelopeSig.Sig)
+			if err == nil && bytes.Equal(decoded, sig.signature) {
+				return nil
+			}
+		}
+	}
+	return errors.New("entry does not record a signature of the envelope by the signing certificate")
+}
+
+// verifierMatches reports whether the PEM encoded verifier of an entry is the
+// leaf certificate or its public key
+func verifierMatches(verifier []byte, leaf *x509.Certificate) bool {
+	block, _ := pem.Decode(verifier)
+	if block == nil {
+		return false
+	}
+	switch block.Type {
+	case "CERTIFICATE":
+		return bytes.Equal(block.Bytes, leaf.Raw)
+	case "PUBLIC KEY":
+		der, err := x509.MarshalPKIXPublicKey(leaf.PublicKey)
+		return err == nil && bytes.Equal(block.Bytes, der)
+	}
+	return false
+}
+
+// certificateIssuer returns the OIDC issuer recorded in a Fulcio certificate
+func certificateIssuer(cert *x509.Certificate) string {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidIssuerV2) {
+			var issuer string
+			if _, err := asn1.Unmarshal(ext.Value, &issuer); err == nil {
+				return issuer
+			}
+		}
+	}
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidIssuerV1) {
+			return string(ext.Value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,427 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sigstore_verifier
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guacsec/guac/pkg/ingestor/verifier"
+	"github.com/guacsec/guac/pkg/logging"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/sigstore/pkg/signature"
+	sig_dsse "github.com/sigstore/sigstore/pkg/signature/dsse"
+)
+
+const (
+	testIssuer   = "https://token.actions.githubusercontent.com"
+	testWorkflow = "https://github.com/guacsec/guac/.github/workflows/release.yaml@refs/tags/v0.1.0"
+)
+
+// testSigstore is a certificate authority and transparency log that issue
+// and log bundles the way Fulcio and Rekor do
+type testSigstore struct {
+	t              *testing.T
+	caKey          *ecdsa.PrivateKey
+	caCert         *x509.Certificate
+	logKey         *ecdsa.PrivateKey
+	logID          []byte
+	integratedTime time.Time
+}
+
+func newTestSigstore(t *testing.T) *testSigstore {
+	s := &testSigstore{t: t, integratedTime: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+	s.caKey, s.caCert = s.newCA()
+
+	s.logKey = s.generateKey()
+	der, err := x509.MarshalPKIXPublicKey(&s.logKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logID := sha256.Sum256(der)
+	s.logID = logID[:]
+	return s
+}
+
+func (s *testSigstore) newCA() (*ecdsa.PrivateKey, *x509.Certificate) {
+	key := s.generateKey()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "sigstore", Organization: []string{"sigstore.dev"}},
+		NotBefore:             s.integratedTime.AddDate(-1, 0, 0),
+		NotAfter:              s.integratedTime.AddDate(1, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	return key, s.createCertificate(template, template, &key.PublicKey, key)
+}
+
+func (s *testSigstore) generateKey() *ecdsa.PrivateKey {
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	return k
+}
+
+func (s *testSigstore) createCertificate(template, parent *x509.Certificate, pub crypto.PublicKey, priv crypto.Signer) *x509.Certificate {
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, priv)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	return cert
+}
+
+func (s *testSigstore) trustedRoot() []byte {
+	logKey, err := x509.MarshalPKIXPublicKey(&s.logKey.PublicKey)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	return []byte(fmt.Sprintf(`{
+		"mediaType": "application/vnd.dev.sigstore.trustedroot+json;version=0.1",
+		"tlogs": [{
+			"baseUrl": "https://rekor.example.com",
+			"hashAlgorithm": "SHA2_256",
+			"publicKey": {"rawBytes": %q, "keyDetails": "PKIX_ECDSA_P256_SHA_256", "validFor": {"start": "2021-01-01T00:00:00Z"}},
+			"logId": {"keyId": %q}
+		}],
+		"certificateAuthorities": [{
+			"subject": {"organization": "sigstore.dev", "commonName": "sigstore"},
+			"uri": "https://fulcio.example.com",
+			"certChain": {"certificates": [{"rawBytes": %q}]},
+			"validFor": {"start": "2021-01-01T00:00:00Z"}
+		}]
+	}`, base64.StdEncoding.EncodeToString(logKey), base64.StdEncoding.EncodeToString(s.logID), base64.StdEncoding.EncodeToString(s.caCert.Raw)))
+}
+
+// sign issues a short-lived certificate for the workflow identity and signs
+// the payload with it, returning the certificate and the DSSE envelope
+func (s *testSigstore) sign(caKey *ecdsa.PrivateKey, caCert *x509.Certificate, payload []byte) (*x509.Certificate, []byte) {
+	key := s.generateKey()
+	issuer, err := asn1.MarshalWithParams(testIssuer, "utf8")
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	uri, _ := url.Parse(testWorkflow)
+	cert := s.createCertificate(&x509.Certificate{
+		SerialNumber:    big.NewInt(2),
+		NotBefore:       s.integratedTime.Add(-time.Minute),
+		NotAfter:        s.integratedTime.Add(10 * time.Minute),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		URIs:            []*url.URL{uri},
+		ExtraExtensions: []pkix.Extension{{Id: oidIssuerV2, Value: issuer}},
+	}, caCert, &key.PublicKey, caKey)
+
+	signer, err := signature.LoadECDSASignerVerifier(key, crypto.SHA256)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	envelope, err := sig_dsse.WrapSigner(signer, "application/vnd.in-toto+json").SignMessage(bytes.NewReader(payload))
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	return cert, envelope
+}
+
+// entryBody is the canonicalized body of a dsse entry logging the payload and
+// the signature of the envelope by the certificate
+func (s *testSigstore) entryBody(payload []byte, cert *x509.Certificate, envelope []byte) []byte {
+	hash := sha256.Sum256(payload)
+	return []byte(fmt.Sprintf(`{"apiVersion":"0.0.1","kind":"dsse","spec":{"payloadHash":{"algorithm":"sha256","value":%q},"signatures":[{"signature":%q,"verifier":%q}]}}`,
+		hex.EncodeToString(hash[:]), s.envelopeSignature(envelope), base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))))
+}
+
+// intotoEntryBody is the canonicalized body of an intoto v0.0.2 entry, which
+// encodes the signature in base64 twice and records the public key of the
+// certificate
+func (s *testSigstore) intotoEntryBody(payload []byte, cert *x509.Certificate, envelope []byte) []byte {
+	hash := sha256.Sum256(payload)
+	der, err := x509.MarshalPKIXPublicKey(cert.PublicKey)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	return []byte(fmt.Sprintf(`{"apiVersion":"0.0.2","kind":"intoto","spec":{"content":{"envelope":{"payloadType":"application/vnd.in-toto+json","signatures":[{"publicKey":%q,"sig":%q}]},"payloadHash":{"algorithm":"sha256","value":%q}}}}`,
+		base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+		base64.StdEncoding.EncodeToString([]byte(s.envelopeSignature(envelope))), hex.EncodeToString(hash[:])))
+}
+
+// envelopeSignature returns the base64 signature of the DSSE envelope
+func (s *testSigstore) envelopeSignature(envelope []byte) string {
+	var env dsse.Envelope
+	if err := json.Unmarshal(envelope, &env); err != nil || len(env.Signatures) == 0 {
+		s.t.Fatalf("invalid envelope: %v", err)
+	}
+	return env.Signatures[0].Sig
+}
+
+func (s *testSigstore) signLog(msg []byte) []byte {
+	digest := sha256.Sum256(msg)
+	sig, err := ecdsa.SignASN1(rand.Reader, s.logKey, digest[:])
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	return sig
+}
+
+// setEntry logs the body with a signed entry timestamp
+func (s *testSigstore) setEntry(body []byte) string {
+	set := s.signLog([]byte(fmt.Sprintf(`{"body":%q,"integratedTime":%d,"logID":%q,"logIndex":7}`,
+		base64.StdEncoding.EncodeToString(body), s.integratedTime.Unix(), hex.EncodeToString(s.logID))))
+	return fmt.Sprintf(`{
+		"logIndex": "7",
+		"logId": {"keyId": %q},
+		"kindVersion": {"kind": "dsse", "version": "0.0.1"},
+		"integratedTime": "%d",
+		"inclusionPromise": {"signedEntryTimestamp": %q},
+		"canonicalizedBody": %q
+	}`, base64.StdEncoding.EncodeToString(s.logID), s.integratedTime.Unix(), base64.StdEncoding.EncodeToString(set), base64.StdEncoding.EncodeToString(body))
+}
+
+// proofEntry logs the body as the last leaf of a tree of three leaves, with
+// an inclusion proof and a checkpoint of the origin signed by the log, and a
+// signed entry timestamp if set is true
+func (s *testSigstore) proofEntry(body []byte, origin string, set bool) string {
+	sibling := hashChildren(hashLeaf([]byte("leaf 0")), hashLeaf([]byte("leaf 1")))
+	root := hashChildren(sibling, hashLeaf(body))
+	note := fmt.Sprintf("%s\n3\n%s\n", origin, base64.StdEncoding.EncodeToString(root))
+	sig := append([]byte{0, 1, 2, 3}, s.signLog([]byte(note))...)
+	checkpoint := fmt.Sprintf("%s\n— rekor.example.com %s\n", note, base64.StdEncoding.EncodeToString(sig))
+	promise := ""
+	if set {
+		promise = fmt.Sprintf(`"inclusionPromise": {"signedEntryTimestamp": %q},`, base64.StdEncoding.EncodeToString(s.signLog([]byte(fmt.Sprintf(
+			`{"body":%q,"integratedTime":%d,"logID":%q,"logIndex":9}`, base64.StdEncoding.EncodeToString(body), s.integratedTime.Unix(), hex.EncodeToString(s.logID))))))
+	}
+	return fmt.Sprintf(`{
+		"logIndex": "9",
+		"logId": {"keyId": %q},
+		"kindVersion": {"kind": "dsse", "version": "0.0.1"},
+		"integratedTime": "%d",
+		%s
+		"inclusionProof": {"logIndex": "2", "rootHash": %q, "treeSize": "3", "hashes": [%q], "checkpoint": {"envelope": %q}},
+		"canonicalizedBody": %q
+	}`, base64.StdEncoding.EncodeToString(s.logID), s.integratedTime.Unix(), promise, base64.StdEncoding.EncodeToString(root),
+		base64.StdEncoding.EncodeToString(sibling), checkpoint, base64.StdEncoding.EncodeToString(body))
+}
+
+func testBundle(mediaType string, certificate string, envelope []byte, entry string) []byte {
+	return []byte(fmt.Sprintf(`{"mediaType": %q, "verificationMaterial": {%s, "tlogEntries": [%s]}, "dsseEnvelope": %s}`,
+		mediaType, certificate, entry, envelope))
+}
+
+func certificateChain(cert *x509.Certificate) string {
+	return fmt.Sprintf(`"x509CertificateChain": {"certificates": [{"rawBytes": %q}]}`, base64.StdEncoding.EncodeToString(cert.Raw))
+}
+
+func TestSigstoreVerifier_VerifyBundle(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	s := newTestSigstore(t)
+	trustedRoot, err := ParseTrustedRoot(s.trustedRoot())
+	if err != nil {
+		t.Fatalf("ParseTrustedRoot() error = %v", err)
+	}
+
+	payload := []byte(`{"_type": "https://in-toto.io/Statement/v1", "predicateType": "https://slsa.dev/provenance/v1"}`)
+	cert, envelope := s.sign(s.caKey, s.caCert, payload)
+	_, otherEnvelope := s.sign(s.caKey, s.caCert, []byte(`{"_type": "https://in-toto.io/Statement/v1"}`))
+
+	otherSignerCert, otherSignerEnvelope := s.sign(s.caKey, s.caCert, payload)
+
+	untrustedKey, untrustedCA := s.newCA()
+	untrustedCert, untrustedEnvelope := s.sign(untrustedKey, untrustedCA, payload)
+
+	body := s.entryBody(payload, cert, envelope)
+	badSET := s.setEntry(body)
+	s.integratedTime = s.integratedTime.Add(time.Second)
+	tamperedEntry := s.setEntry(body)
+	s.integratedTime = s.integratedTime.Add(-time.Second)
+	// an integrated time moved back into the validity of the certificate,
+	// once it has expired
+	forgedTime := func(entry string) string {
+		return strings.Replace(entry, fmt.Sprintf(`"integratedTime": "%d"`, s.integratedTime.Unix()),
+			fmt.Sprintf(`"integratedTime": "%d"`, s.integratedTime.Add(-30*time.Second).Unix()), 1)
+	}
+
+	want := []verifier.Identity{{
+		ID:                     testWorkflow,
+		Verified:               true,
+		Issuer:                 testIssuer,
+		SubjectAlternativeName: testWorkflow,
+	}}
+
+	tests := []struct {
+		name        string
+		bundle      []byte
+		trustedRoot *TrustedRoot
+		want        []verifier.Identity
+		wantErr     bool
+	}{{
+		name:        "keyless bundle with signed entry timestamp",
+		bundle:      testBundle("application/vnd.dev.sigstore.bundle+json;version=0.2", certificateChain(cert), envelope, s.setEntry(body)),
+		trustedRoot: trustedRoot,
+		want:        want,
+	}, {
+		name: "keyless bundle v0.3 with inclusion proof",
+		bundle: testBundle("application/vnd.dev.sigstore.bundle.v0.3+json",
+			fmt.Sprintf(`"certificate": {"rawBytes": %q}`, base64.StdEncoding.EncodeToString(cert.Raw)), envelope, s.proofEntry(body, "rekor.example.com - 1", true)),
+		trustedRoot: trustedRoot,
+		want:        want,
+	}, {
+		name:        "keyless bundle with intoto entry",
+		bundle:      testBundle("application/vnd.dev.sigstore.bundle+json;version=0.2", certificateChain(cert), envelope, s.setEntry(s.intotoEntryBody(payload, cert, envelope))),
+		trustedRoot: trustedRoot,
+		want:        want,
+	}, {
+		name: "inclusion proof without signed entry timestamp",
+		bundle: testBundle("application/vnd.dev.sigstore.bundle.v0.3+json",
+			fmt.Sprintf(`"certificate": {"rawBytes": %q}`, base64.StdEncoding.EncodeToString(cert.Raw)), envelope, forgedTime(s.proofEntry(body, "rekor.example.com - 1", false))),
+		trustedRoot: trustedRoot,
+		wantErr:     true,
+	}, {
+		name: "inclusion proof with forged integrated time",
+		bundle: testBundle("application/vnd.dev.sigstore.bundle.v0.3+json",
+			fmt.Sprintf(`"certificate": {"rawBytes": %q}`, base64.StdEncoding.EncodeToString(cert.Raw)), envelope, forgedTime(s.proofEntry(body, "rekor.example.com - 1", true))),
+		trustedRoot: trustedRoot,
+		wantErr:     true,
+	}, {
+		name: "checkpoint of another log",
+		bundle: testBundle("application/vnd.dev.sigstore.bundle.v0.3+json",
+			fmt.Sprintf(`"certificate": {"rawBytes": %q}`, base64.StdEncoding.EncodeToString(cert.Raw)), envelope, s.proofEntry(body, "rekor.other.example.com - 1", true)),
+		trustedRoot: trustedRoot,
+		wantErr:     true,
+	}, {
+		name:        "transparency log entry of another signer of the payload",
+		bundle:      testBundle("application/vnd.dev.sigstore.bundle+json;version=0.2", certificateChain(cert), envelope, s.setEntry(s.entryBody(payload, otherSignerCert, otherSignerEnvelope))),
+		trustedRoot: trustedRoot,
+		wantErr:     true,
+	}, {
+		name:    "keyless bundle without trusted root",
+		bundle:  testBundle("application/vnd.dev.sigstore.bundle+json;version=0.2", certificateChain(cert), envelope, s.setEntry(body)),
+		wantErr: true,
+	}, {
+		name:        "transparency log entry of another payload",
+		bundle:      testBundle("application/vnd.dev.sigstore.bundle+json;version=0.2", certificateChain(cert), otherEnvelope, s.setEntry(body)),
+		trustedRoot: trustedRoot,
+		wantErr:     true,
+	}, {
+		name: "signed entry timestamp of another entry",
+		bundle: testBundle("application/vnd.dev.sigstore.bundle+json;version=0.2", certificateChain(cert), envelope,
+			tamperedEntry[:bytes.Index([]byte(tamperedEntry), []byte(`"inclusionPromise"`))]+badSET[bytes.Index([]byte(badSET), []byte(`"inclusionPromise"`)):]),
+		trustedRoot: trustedRoot,
+		wantErr:     true,
+	}, {
+		name:        "certificate of an untrusted authority",
+		bundle:      testBundle("application/vnd.dev.sigstore.bundle+json;version=0.2", certificateChain(untrustedCert), untrustedEnvelope, s.setEntry(s.entryBody(payload, untrustedCert, untrustedEnvelope))),
+		trustedRoot: trustedRoot,
+		wantErr:     true,
+	}, {
+		name:        "envelope signed by another key",
+		bundle:      testBundle("application/vnd.dev.sigstore.bundle+json;version=0.2", certificateChain(cert), untrustedEnvelope, s.setEntry(s.entryBody(payload, cert, untrustedEnvelope))),
+		trustedRoot: trustedRoot,
+		wantErr:     true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sigVerifier := NewSigstoreAndKeyVerifier(WithTrustedRoot(tt.trustedRoot))
+			got, err := sigVerifier.Verify(ctx, tt.bundle)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SigstoreVerifier.Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("SigstoreVerifier.Verify() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].Key.Val == nil {
+					t.Errorf("SigstoreVerifier.Verify() identity %d has no key", i)
+				}
+				got[i].Key = tt.want[i].Key
+				if got[i] != tt.want[i] {
+					t.Errorf("SigstoreVerifier.Verify() = %v, want %v", got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_rootFromInclusionProof(t *testing.T) {
+	leaves := [][]byte{hashLeaf([]byte("a")), hashLeaf([]byte("b")), hashLeaf([]byte("c")), hashLeaf([]byte("d")), hashLeaf([]byte("e"))}
+	ab, cd := hashChildren(leaves[0], leaves[1]), hashChildren(leaves[2], leaves[3])
+	root := hashChildren(hashChildren(ab, cd), leaves[4])
+
+	tests := []struct {
+		name    string
+		index   uint64
+		proof   [][]byte
+		wantErr bool
+	}{{
+		name:  "first leaf",
+		index: 0,
+		proof: [][]byte{leaves[1], cd, leaves[4]},
+	}, {
+		name:  "inner leaf",
+		index: 2,
+		proof: [][]byte{leaves[3], ab, leaves[4]},
+	}, {
+		name:  "last leaf",
+		index: 4,
+		proof: [][]byte{hashChildren(ab, cd)},
+	}, {
+		name:    "wrong proof size",
+		index:   4,
+		proof:   [][]byte{ab, cd},
+		wantErr: true,
+	}, {
+		name:    "index beyond tree",
+		index:   5,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rootFromInclusionProof(tt.index, uint64(len(leaves)), leaves[tt.index%uint64(len(leaves))], tt.proof)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("rootFromInclusionProof() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !bytes.Equal(got, root) {
+				t.Errorf("rootFromInclusionProof() = %x, want %x", got, root)
+			}
+		})
+	}
+}
@@ -34,16 +34,35 @@ import (
 var json = jsoniter.ConfigCompatibleWithStandardLibrary
 
 type sigstoreVerifier struct {
+	trustedRoot *TrustedRoot
+}
+
+type Opt func(*sigstoreVerifier)
+
+// WithTrustedRoot sets the trusted root used to verify keyless signatures
+// of Sigstore bundles
+func WithTrustedRoot(trustedRoot *TrustedRoot) Opt {
+	return func(d *sigstoreVerifier) {
+		d.trustedRoot = trustedRoot
+	}
 }
 
 // NewSigstoreVerifier initializes the sigstore verifier
-func NewSigstoreAndKeyVerifier() *sigstoreVerifier {
-	return &sigstoreVerifier{}
+func NewSigstoreAndKeyVerifier(opts ...Opt) *sigstoreVerifier {
+	d := &sigstoreVerifier{}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
 }
 
-// Verify validates that the signature is valid for the payload
+// Verify validates that the signature is valid for the payload, which is
+// either a DSSE envelope or a Sigstore bundle
 // TODO: this currently only supports SHA256 hash function when validating signatures
 func (d *sigstoreVerifier) Verify(ctx context.Context, payloadBytes []byte) ([]verifier.Identity, error) {
+	if isBundle(payloadBytes) {
+		return d.verifyBundle(ctx, payloadBytes)
+	}
 	identities := []verifier.Identity{}
 	envelope, err := parseDSSE(payloadBytes)
 	if err != nil {
@@ -0,0 +1,245 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sigstore_verifier
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+// tlogEntry is a Rekor transparency log entry of a Sigstore bundle, which is
+// proven to be in the log by a signed entry timestamp (the inclusion promise),
+// along with an inclusion proof with a signed checkpoint in newer bundles
+type tlogEntry struct {
+	LogIndex int64 `json:"logIndex,string"`
+	LogID    struct {
+		KeyID []byte `json:"keyId"`
+	} `json:"logId"`
+	KindVersion struct {
+		Kind    string `json:"kind"`
+		Version string `json:"version"`
+	} `json:"kindVersion"`
+	IntegratedTime   int64 `json:"integratedTime,string"`
+	InclusionPromise *struct {
+		SignedEntryTimestamp []byte `json:"signedEntryTimestamp"`
+	} `json:"inclusionPromise"`
+	InclusionProof    *inclusionProof `json:"inclusionProof"`
+	CanonicalizedBody []byte          `json:"canonicalizedBody"`
+}
+
+type inclusionProof struct {
+	LogIndex   int64    `json:"logIndex,string"`
+	RootHash   []byte   `json:"rootHash"`
+	TreeSize   int64    `json:"treeSize,string"`
+	Hashes     [][]byte `json:"hashes"`
+	Checkpoint struct {
+		Envelope string `json:"envelope"`
+	} `json:"checkpoint"`
+}
+
+// verify checks that the entry was included in a transparency log of the
+// trusted root and returns the time it was integrated into the log. The
+// integrated time is only authenticated by the signed entry timestamp, so it
+// is always verified, even alongside an inclusion proof, and entries without
+// one are rejected as RFC 3161 timestamps are not supported.
+func (e *tlogEntry) verify(root *TrustedRoot) (time.Time, error) {
+	logID := hex.EncodeToString(e.LogID.KeyID)
+	log, ok := root.tlogs[logID]
+	if !ok {
+		return time.Time{}, fmt.Errorf("transparency log %s is not in the trusted root", logID)
+	}
+
+	if e.InclusionProof != nil && e.InclusionProof.Checkpoint.Envelope != "" {
+		if err := e.verifyInclusionProof(log); err != nil {
+			return time.Time{}, err
+		}
+	}
+	if e.InclusionPromise == nil {
+		return time.Time{}, errors.New("transparency log entry has no inclusion promise to authenticate its integrated time")
+	}
+	if err := e.verifySET(logID, log); err != nil {
+		return time.Time{}, err
+	}
+
+	integratedTime := time.Unix(e.IntegratedTime, 0).UTC()
+	if !log.validFor.contains(integratedTime) {
+		return time.Time{}, fmt.Errorf("key of transparency log %s was not valid at %v", log.baseURL, integratedTime)
+	}
+	return integratedTime, nil
+}
+
+// verifySET verifies the signed entry timestamp, which is the signature of the
+// log over the canonical JSON of the entry
+func (e *tlogEntry) verifySET(logID string, log tlog) error {
+	// fields are in the order required by canonical JSON
+	payload, err := json.Marshal(struct {
+		Body           string `json:"body"`
+		IntegratedTime int64  `json:"integratedTime"`
+		LogID          string `json:"logID"`
+		LogIndex       int64  `json:"logIndex"`
+	}{
+		Body:           base64.StdEncoding.EncodeToString(e.CanonicalizedBody),
+		IntegratedTime: e.IntegratedTime,
+		LogID:          logID,
+		LogIndex:       e.LogIndex,
+	})
+	if err != nil {
+		return err
+	}
+	if err := verifyLogSignature(log.publicKey, e.InclusionPromise.SignedEntryTimestamp, payload); err != nil {
+		return fmt.Errorf("failed to verify signed entry timestamp: %w", err)
+	}
+	return nil
+}
+
+// verifyInclusionProof verifies the RFC 6962 inclusion proof of the entry and
+// the checkpoint signed by the log that commits to the root hash
+func (e *tlogEntry) verifyInclusionProof(log tlog) error {
+	proof := e.InclusionProof
+	if proof.LogIndex < 0 || proof.TreeSize <= 0 {
+		return fmt.Errorf("invalid inclusion proof for log index %d and tree size %d", proof.LogIndex, proof.TreeSize)
+	}
+	leafHash := hashLeaf(e.CanonicalizedBody)
+	calculated, err := rootFromInclusionProof(uint64(proof.LogIndex), uint64(proof.TreeSize), leafHash, proof.Hashes)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(calculated, proof.RootHash) {
+		return errors.New("inclusion proof does not match the root hash")
+	}
+
+	treeSize, rootHash, err := verifyCheckpoint(log.publicKey, log.origin, proof.Checkpoint.Envelope)
+	if err != nil {
+		return err
+	}
+	if treeSize != uint64(proof.TreeSize) || !bytes.Equal(rootHash, proof.RootHash) {
+		return errors.New("checkpoint does not match the inclusion proof")
+	}
+	return nil
+}
+
+// verifyCheckpoint verifies a checkpoint in the signed note format, whose
+// origin must be the one of the log, and returns the tree size and root hash
+// it commits to
+func verifyCheckpoint(pub crypto.PublicKey, origin string, envelope string) (uint64, []byte, error) {
+	text, signatures, ok := strings.Cut(envelope, "\n\n")
+	if !ok {
+		return 0, nil, errors.New("malformed checkpoint: no signatures")
+	}
+	text += "\n"
+
+	verified := false
+	for _, line := range strings.Split(strings.TrimSuffix(signatures, "\n"), "\n") {
+		// — <name> <base64 of key hint and signature>
+		fields := strings.Fields(strings.TrimPrefix(line, "— "))
+		if len(fields) != 2 {
+			continue
+		}
+		sig, err := base64.StdEncoding.DecodeString(fields[1])
+		if err != nil || len(sig) <= 4 {
+			continue
+		}
+		if verifyLogSignature(pub, sig[4:], []byte(text)) == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return 0, nil, errors.New("checkpoint is not signed by the transparency log")
+	}
+
+	// origin, tree size and base64 root hash, followed by optional extensions
+	lines := strings.Split(text, "\n")
+	if len(lines) < 3 {
+		return 0, nil, errors.New("malformed checkpoint body")
+	}
+	// the origin of a log shard is suffixed with the ID of its tree
+	if lines[0] != origin && !strings.HasPrefix(lines[0], origin+" - ") {
+		return 0, nil, fmt.Errorf("checkpoint origin %q does not match the transparency log %s", lines[0], origin)
+	}
+	treeSize, err := strconv.ParseUint(lines[1], 10, 64)
+	if err != nil {
+		return 0, nil, fmt.Errorf("malformed checkpoint tree size: %w", err)
+	}
+	rootHash, err := base64.StdEncoding.DecodeString(lines[2])
+	if err != nil {
+		return 0, nil, fmt.Errorf("malformed checkpoint root hash: %w", err)
+	}
+	return treeSize, rootHash, nil
+}
+
+func verifyLogSignature(pub crypto.PublicKey, sig []byte, payload []byte) error {
+	vfr, err := signature.LoadVerifier(pub, crypto.SHA256)
+	if err != nil {
+		return fmt.Errorf("could not load verifier: %w", err)
+	}
+	return vfr.VerifySignature(bytes.NewReader(sig), bytes.NewReader(payload))
+}
+
+func hashLeaf(leaf []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0})
+	h.Write(leaf)
+	return h.Sum(nil)
+}
+
+func hashChildren(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{1})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// rootFromInclusionProof calculates the root hash of the tree from the leaf
+// hash and its audit path, as described in RFC 9162 section 2.1.3.2
+func rootFromInclusionProof(index, size uint64, leafHash []byte, proof [][]byte) ([]byte, error) {
+	if index >= size {
+		return nil, fmt.Errorf("log index %d is beyond the tree size %d", index, size)
+	}
+	// the inner part of the path is below the point where the paths to the
+	// leaf and to the last leaf of the tree diverge, the border part is
+	// along the right border of the tree
+	inner := bits.Len64(index ^ (size - 1))
+	border := bits.OnesCount64(index >> uint(inner))
+	if len(proof) != inner+border {
+		return nil, fmt.Errorf("wrong inclusion proof size %d, expected %d", len(proof), inner+border)
+	}
+
+	hash := leafHash
+	for i, sibling := range proof[:inner] {
+		if (index>>uint(i))&1 == 0 {
+			hash = hashChildren(hash, sibling)
+		} else {
+			hash = hashChildren(sibling, hash)
+		}
+	}
+	for _, sibling := range proof[inner:] {
+		hash = hashChildren(sibling, hash)
+	}
+	return hash, nil
+}
@@ -0,0 +1,193 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sigstore_verifier
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+)
+
+// trustedRootJSON is the Sigstore trusted root, as distributed through TUF in
+// trusted_root.json (application/vnd.dev.sigstore.trustedroot+json).
+// Timestamp authorities and certificate transparency logs aren't used.
+type trustedRootJSON struct {
+	MediaType              string                    `json:"mediaType"`
+	Tlogs                  []transparencyLogInstance `json:"tlogs"`
+	CertificateAuthorities []certificateAuthority    `json:"certificateAuthorities"`
+}
+
+type transparencyLogInstance struct {
+	BaseURL   string `json:"baseUrl"`
+	PublicKey struct {
+		RawBytes []byte    `json:"rawBytes"`
+		ValidFor timeRange `json:"validFor"`
+	} `json:"publicKey"`
+	LogID struct {
+		KeyID []byte `json:"keyId"`
+	} `json:"logId"`
+}
+
+type certificateAuthority struct {
+	URI       string `json:"uri"`
+	CertChain struct {
+		Certificates []rawBytes `json:"certificates"`
+	} `json:"certChain"`
+	ValidFor timeRange `json:"validFor"`
+}
+
+type rawBytes struct {
+	RawBytes []byte `json:"rawBytes"`
+}
+
+type timeRange struct {
+	Start *time.Time `json:"start"`
+	End   *time.Time `json:"end"`
+}
+
+// contains reports whether t is within the range, an unset bound is open
+func (r timeRange) contains(t time.Time) bool {
+	if r.Start != nil && t.Before(*r.Start) {
+		return false
+	}
+	if r.End != nil && t.After(*r.End) {
+		return false
+	}
+	return true
+}
+
+// TrustedRoot holds the Fulcio certificate authorities and Rekor transparency
+// logs that keyless signatures are verified against
+type TrustedRoot struct {
+	authorities []authority
+	// tlogs is keyed by the hex encoded log ID
+	tlogs map[string]tlog
+}
+
+type authority struct {
+	uri           string
+	root          *x509.Certificate
+	intermediates []*x509.Certificate
+	validFor      timeRange
+}
+
+type tlog struct {
+	baseURL string
+	// origin is the host name of the log, which starts the origin line of
+	// its checkpoints, e.g. rekor.sigstore.dev - 1193050959916656506
+	origin    string
+	publicKey crypto.PublicKey
+	validFor  timeRange
+}
+
+// LoadTrustedRoot reads the trusted root from a local trusted_root.json file,
+// so that verification doesn't require network access
+func LoadTrustedRoot(path string) (*TrustedRoot, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trusted root: %w", err)
+	}
+	return ParseTrustedRoot(content)
+}
+
+// ParseTrustedRoot parses the JSON encoded trusted root
+func ParseTrustedRoot(content []byte) (*TrustedRoot, error) {
+	var rootJSON trustedRootJSON
+	if err := json.Unmarshal(content, &rootJSON); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal trusted root: %w", err)
+	}
+
+	root := &TrustedRoot{tlogs: map[string]tlog{}}
+	for _, ca := range rootJSON.CertificateAuthorities {
+		var certs []*x509.Certificate
+		for _, raw := range ca.CertChain.Certificates {
+			cert, err := x509.ParseCertificate(raw.RawBytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse certificate of authority %s: %w", ca.URI, err)
+			}
+			certs = append(certs, cert)
+		}
+		if len(certs) == 0 {
+			return nil, fmt.Errorf("certificate authority %s has no certificates", ca.URI)
+		}
+		// the chain is ordered from the intermediates to the root
+		root.authorities = append(root.authorities, authority{
+			uri:           ca.URI,
+			root:          certs[len(certs)-1],
+			intermediates: certs[:len(certs)-1],
+			validFor:      ca.ValidFor,
+		})
+	}
+	for _, t := range rootJSON.Tlogs {
+		pub, err := x509.ParsePKIXPublicKey(t.PublicKey.RawBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key of transparency log %s: %w", t.BaseURL, err)
+		}
+		u, err := url.Parse(t.BaseURL)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid base URL of transparency log: %q", t.BaseURL)
+		}
+		root.tlogs[hex.EncodeToString(t.LogID.KeyID)] = tlog{
+			baseURL:   t.BaseURL,
+			origin:    u.Host,
+			publicKey: pub,
+			validFor:  t.PublicKey.ValidFor,
+		}
+	}
+	if len(root.authorities) == 0 || len(root.tlogs) == 0 {
+		return nil, errors.New("trusted root must contain at least one certificate authority and one transparency log")
+	}
+	return root, nil
+}
+
+// verifyCertificate verifies that the certificate was issued by one of the
+// certificate authorities for code signing, and was valid at the given time
+func (r *TrustedRoot) verifyCertificate(cert *x509.Certificate, chain []*x509.Certificate, at time.Time) error {
+	var errs []error
+	for _, ca := range r.authorities {
+		if !ca.validFor.contains(at) {
+			continue
+		}
+		roots := x509.NewCertPool()
+		roots.AddCert(ca.root)
+		intermediates := x509.NewCertPool()
+		for _, c := range ca.intermediates {
+			intermediates.AddCert(c)
+		}
+		for _, c := range chain {
+			intermediates.AddCert(c)
+		}
+		_, err := cert.Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			CurrentTime:   at,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		})
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", ca.uri, err))
+	}
+	if len(errs) == 0 {
+		return fmt.Errorf("no certificate authority of the trusted root was valid at %v", at)
+	}
+	return fmt.Errorf("certificate was not issued by a trusted certificate authority: %w", errors.Join(errs...))
+}
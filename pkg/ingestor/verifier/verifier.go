@@ -47,10 +47,20 @@ type Verifier interface {
 // identity has been verified, usually based on signature matching the key.
 // This shouldn't be used to indicate that the Identity is trusted in any
 // way.
+//
+// For keyless signatures, ID is the subject alternative name of the signing
+// certificate and Issuer is the OIDC issuer that authenticated it, for
+// example a GitHub Actions workflow URI issued by
+// https://token.actions.githubusercontent.com.
 type Identity struct {
 	ID       string
 	Key      key.Key
 	Verified bool
+	// Issuer is the OIDC issuer of a keyless signing certificate
+	Issuer string
+	// SubjectAlternativeName is the identity (email address or URI) that
+	// the keyless signing certificate was issued to
+	SubjectAlternativeName string
 }
 
 var (
@@ -70,7 +80,7 @@ func RegisterVerifier(k Verifier, providerType VerifierType) error {
 // VerifyIdentity goes through the registered providers and verifies the signatures in the payload
 func VerifyIdentity(ctx context.Context, doc *processor.Document) ([]Identity, error) {
 	switch doc.Type {
-	case processor.DocumentDSSE:
+	case processor.DocumentDSSE, processor.DocumentSigstoreBundle:
 		if verifier, ok := verifierProviders["sigstore"]; ok {
 			return verifier.Verify(ctx, doc.Blob)
 		}
@@ -0,0 +1,498 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package versioncmp orders versions following the semantics of package
+// ecosystems, such as semver, PEP 440, Maven or dpkg.
+package versioncmp
+
+import (
+	"math/big"
+	"strings"
+	"unicode"
+)
+
+// Func compares two versions, returning a negative number, zero or a
+// positive number when a is lower than, equal to or greater than b
+type Func func(a, b string) int
+
+// compareInts compares decimal strings of any length
+func compareInts(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	return strings.Compare(a, b)
+}
+
+// semver is a semantic version, parsed leniently: a leading v is ignored and
+// missing minor or patch components are 0
+type semver struct {
+	core       [3]string
+	prerelease []string
+}
+
+func parseSemver(s string) semver {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	s, _, _ = strings.Cut(s, "+")
+	core, prerelease, hasPrerelease := strings.Cut(s, "-")
+	v := semver{core: [3]string{"0", "0", "0"}}
+	for i, part := range strings.SplitN(core, ".", 3) {
+		if part != "" {
+			v.core[i] = part
+		}
+	}
+	if hasPrerelease {
+		v.prerelease = strings.Split(prerelease, ".")
+	}
+	return v
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Semver orders versions following semver 2.0: pre-releases are lower
+// than their release and build metadata is ignored
+func Semver(a, b string) int {
+	va, vb := parseSemver(a), parseSemver(b)
+	for i := range va.core {
+		if c := compareIdentifier(va.core[i], vb.core[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case va.prerelease == nil && vb.prerelease == nil:
+		return 0
+	case va.prerelease == nil:
+		return 1
+	case vb.prerelease == nil:
+		return -1
+	}
+	for i := 0; i < len(va.prerelease) && i < len(vb.prerelease); i++ {
+		if c := compareIdentifier(va.prerelease[i], vb.prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return len(va.prerelease) - len(vb.prerelease)
+}
+
+// compareIdentifier compares numeric identifiers numerically and lower than
+// alphanumeric ones, which are compared lexically
+func compareIdentifier(a, b string) int {
+	an, bn := isNumeric(a), isNumeric(b)
+	switch {
+	case an && bn:
+		return compareInts(a, b)
+	case an:
+		return -1
+	case bn:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// splitRuns splits s into runs of digits and runs of letters, dropping any
+// other character
+func splitRuns(s string) []string {
+	var runs []string
+	start := -1
+	digit := false
+	for i, r := range s {
+		isDigit := unicode.IsDigit(r)
+		isLetter := unicode.IsLetter(r)
+		if start >= 0 && (!(isDigit || isLetter) || isDigit != digit) {
+			runs = append(runs, s[start:i])
+			start = -1
+		}
+		if start < 0 && (isDigit || isLetter) {
+			start = i
+			digit = isDigit
+		}
+	}
+	if start >= 0 {
+		runs = append(runs, s[start:])
+	}
+	return runs
+}
+
+// Generic compares the runs of digits and letters of versions in
+// order, numerically for digits and lexically for letters, digits being
+// greater than letters. A version extended with letters is a pre-release,
+// lower than the version itself: 1.0.beta1 < 1.0 < 1.0.1. It suits most
+// ecosystems without specific semantics, such as RubyGems, NuGet, Packagist
+// or Alpine.
+func Generic(a, b string) int {
+	ra, rb := splitRuns(strings.ToLower(a)), splitRuns(strings.ToLower(b))
+	for i := 0; i < len(ra) && i < len(rb); i++ {
+		an, bn := isNumeric(ra[i]), isNumeric(rb[i])
+		switch {
+		case an && bn:
+			if c := compareInts(ra[i], rb[i]); c != 0 {
+				return c
+			}
+		case an:
+			return 1
+		case bn:
+			return -1
+		default:
+			if c := strings.Compare(ra[i], rb[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	switch {
+	case len(ra) > len(rb):
+		if isNumeric(ra[len(rb)]) {
+			return 1
+		}
+		return -1
+	case len(ra) < len(rb):
+		if isNumeric(rb[len(ra)]) {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// pep440 is a Python package version as defined by PEP 440
+type pep440 struct {
+	epoch   string
+	release []string
+	// pre is the pre-release phase (a, b or rc) and number
+	pre    [2]string
+	post   string
+	dev    string
+	local  []string
+	hasPre bool
+	// hasPost and hasDev distinguish 1.0.post0 from 1.0
+	hasPost bool
+	hasDev  bool
+}
+
+var pep440Phases = map[string]string{
+	"a": "a", "alpha": "a",
+	"b": "b", "beta": "b",
+	"c": "rc", "rc": "rc", "pre": "rc", "preview": "rc",
+}
+
+var pep440Post = map[string]bool{"post": true, "rev": true, "r": true}
+
+func parsePEP440(s string) pep440 {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.TrimPrefix(s, "v")
+	var v pep440
+	s, local, hasLocal := strings.Cut(s, "+")
+	if hasLocal {
+		v.local = strings.FieldsFunc(local, func(r rune) bool { return r == '.' || r == '-' || r == '_' })
+	}
+	if epoch, rest, ok := strings.Cut(s, "!"); ok {
+		v.epoch = epoch
+		s = rest
+	}
+
+	runs := splitRuns(s)
+	i := 0
+	for ; i < len(runs) && isNumeric(runs[i]); i++ {
+		v.release = append(v.release, runs[i])
+	}
+	number := func() string {
+		if i+1 < len(runs) && isNumeric(runs[i+1]) {
+			i++
+			return runs[i]
+		}
+		return "0"
+	}
+	for ; i < len(runs); i++ {
+		switch run := runs[i]; {
+		case pep440Phases[run] != "" && !v.hasPre:
+			v.hasPre = true
+			v.pre = [2]string{pep440Phases[run], number()}
+		case pep440Post[run]:
+			v.hasPost = true
+			v.post = number()
+		case run == "dev":
+			v.hasDev = true
+			v.dev = number()
+		}
+	}
+	// trailing zeros do not matter: 1.0 == 1.0.0
+	for len(v.release) > 1 && strings.TrimLeft(v.release[len(v.release)-1], "0") == "" {
+		v.release = v.release[:len(v.release)-1]
+	}
+	return v
+}
+
+// PEP440 orders Python versions: dev releases come before
+// pre-releases, which come before the release, followed by post releases
+func PEP440(a, b string) int {
+	va, vb := parsePEP440(a), parsePEP440(b)
+	if c := compareInts(va.epoch, vb.epoch); c != 0 {
+		return c
+	}
+	for i := 0; i < len(va.release) || i < len(vb.release); i++ {
+		ra, rb := "0", "0"
+		if i < len(va.release) {
+			ra = va.release[i]
+		}
+		if i < len(vb.release) {
+			rb = vb.release[i]
+		}
+		if c := compareInts(ra, rb); c != 0 {
+			return c
+		}
+	}
+	if c := va.preKey().cmp(vb.preKey()); c != 0 {
+		return c
+	}
+	if c := va.postKey().cmp(vb.postKey()); c != 0 {
+		return c
+	}
+	if c := va.devKey().cmp(vb.devKey()); c != 0 {
+		return c
+	}
+	for i := 0; i < len(va.local) && i < len(vb.local); i++ {
+		if c := compareIdentifier(va.local[i], vb.local[i]); c != 0 {
+			return c
+		}
+	}
+	return len(va.local) - len(vb.local)
+}
+
+// pepKey orders a component of a PEP 440 version, rank first
+type pepKey struct {
+	rank  int
+	phase string
+	n     string
+}
+
+func (k pepKey) cmp(o pepKey) int {
+	if k.rank != o.rank {
+		return k.rank - o.rank
+	}
+	if c := strings.Compare(k.phase, o.phase); c != 0 {
+		return c
+	}
+	return compareInts(k.n, o.n)
+}
+
+func (v pep440) preKey() pepKey {
+	switch {
+	case !v.hasPre && !v.hasPost && v.hasDev:
+		// 1.0.dev0 is lower than 1.0a0
+		return pepKey{rank: -1}
+	case !v.hasPre:
+		return pepKey{rank: 1}
+	default:
+		return pepKey{phase: v.pre[0], n: v.pre[1]}
+	}
+}
+
+func (v pep440) postKey() pepKey {
+	if !v.hasPost {
+		return pepKey{rank: -1}
+	}
+	return pepKey{n: v.post}
+}
+
+func (v pep440) devKey() pepKey {
+	if !v.hasDev {
+		return pepKey{rank: 1}
+	}
+	return pepKey{n: v.dev}
+}
+
+// mavenQualifiers orders the well-known Maven qualifiers. Releases have the
+// empty qualifier, unknown qualifiers come after all known ones.
+var mavenQualifiers = map[string]int{
+	"alpha": 0, "a": 0,
+	"beta": 1, "b": 1,
+	"milestone": 2, "m": 2,
+	"rc": 3, "cr": 3,
+	"snapshot": 4,
+	"":         5, "ga": 5, "final": 5, "release": 5,
+	"sp": 6,
+}
+
+// mavenItem is a number or a qualifier of a Maven version
+type mavenItem struct {
+	number    *big.Int
+	qualifier string
+}
+
+func parseMaven(s string) []mavenItem {
+	var items []mavenItem
+	for _, run := range splitRuns(strings.ToLower(strings.TrimSpace(s))) {
+		if isNumeric(run) {
+			n, _ := new(big.Int).SetString(run, 10)
+			items = append(items, mavenItem{number: n})
+		} else {
+			items = append(items, mavenItem{qualifier: run})
+		}
+	}
+	// trailing zeros and release qualifiers do not matter: 1.0.0 == 1-ga
+	for len(items) > 0 {
+		last := items[len(items)-1]
+		if (last.number != nil && last.number.Sign() == 0) || (last.number == nil && mavenQualifiers[last.qualifier] == 5 && last.qualifier != "") {
+			items = items[:len(items)-1]
+			continue
+		}
+		break
+	}
+	return items
+}
+
+func (i mavenItem) cmp(o mavenItem) int {
+	switch {
+	case i.number != nil && o.number != nil:
+		return i.number.Cmp(o.number)
+	case i.number != nil:
+		// numbers are greater than qualifiers: 1.1 > 1-sp
+		return 1
+	case o.number != nil:
+		return -1
+	}
+	ri, iKnown := mavenQualifiers[i.qualifier]
+	ro, oKnown := mavenQualifiers[o.qualifier]
+	switch {
+	case iKnown && oKnown:
+		return ri - ro
+	case iKnown:
+		return -1
+	case oKnown:
+		return 1
+	default:
+		return strings.Compare(i.qualifier, o.qualifier)
+	}
+}
+
+// Maven orders versions like Maven's ComparableVersion: numbers are
+// compared numerically, and qualifiers such as alpha, beta, rc or snapshot
+// sort before the release while sp sorts after it
+func Maven(a, b string) int {
+	ia, ib := parseMaven(a), parseMaven(b)
+	// a missing item is 0 when compared to a number, and the release
+	// qualifier when compared to a qualifier
+	padding := func(o mavenItem) mavenItem {
+		if o.number != nil {
+			return mavenItem{number: new(big.Int)}
+		}
+		return mavenItem{}
+	}
+	for i := 0; i < len(ia) || i < len(ib); i++ {
+		var x, y mavenItem
+		switch {
+		case i >= len(ia):
+			y = ib[i]
+			x = padding(y)
+		case i >= len(ib):
+			x = ia[i]
+			y = padding(x)
+		default:
+			x, y = ia[i], ib[i]
+		}
+		if c := x.cmp(y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// Debian orders versions like dpkg: the epoch first, then the
+// upstream version and the Debian revision
+func Debian(a, b string) int {
+	ea, ua, ra := splitDebian(a)
+	eb, ub, rb := splitDebian(b)
+	if c := compareInts(ea, eb); c != 0 {
+		return c
+	}
+	if c := compareDebianPart(ua, ub); c != 0 {
+		return c
+	}
+	return compareDebianPart(ra, rb)
+}
+
+func splitDebian(s string) (epoch, upstream, revision string) {
+	s = strings.TrimSpace(s)
+	epoch = "0"
+	if e, rest, ok := strings.Cut(s, ":"); ok {
+		epoch, s = e, rest
+	}
+	upstream = s
+	if i := strings.LastIndex(s, "-"); i >= 0 {
+		upstream, revision = s[:i], s[i+1:]
+	}
+	return epoch, upstream, revision
+}
+
+// debianOrder is the dpkg order of a character: ~ before the end of the
+// string, letters before other characters
+func debianOrder(c byte) int {
+	switch {
+	case c == '~':
+		return -1
+	case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z':
+		return int(c)
+	default:
+		return int(c) + 256
+	}
+}
+
+// compareDebianPart alternately compares the non-digit prefixes with
+// debianOrder and the numeric prefixes numerically
+func compareDebianPart(a, b string) int {
+	for a != "" || b != "" {
+		var pa, pb string
+		pa, a = cutPrefix(a, false)
+		pb, b = cutPrefix(b, false)
+		for i := 0; i < len(pa) || i < len(pb); i++ {
+			var ca, cb int
+			if i < len(pa) {
+				ca = debianOrder(pa[i])
+			}
+			if i < len(pb) {
+				cb = debianOrder(pb[i])
+			}
+			if ca != cb {
+				return ca - cb
+			}
+		}
+		pa, a = cutPrefix(a, true)
+		pb, b = cutPrefix(b, true)
+		if c := compareInts(pa, pb); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// cutPrefix splits the leading digits, or non-digits, of s
+func cutPrefix(s string, digits bool) (prefix, rest string) {
+	i := 0
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9') == digits {
+		i++
+	}
+	return s[:i], s[i:]
+}
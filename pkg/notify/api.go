@@ -0,0 +1,137 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/guacsec/guac/pkg/auth"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+// maxWatchSize limits the size of the body registering a watch
+const maxWatchSize = 1 << 16
+
+// NewAPI returns the HTTP API managing the watches of the store:
+//
+//	POST   /watches       registers a watch, returning it with its secret
+//	GET    /watches       lists the watches, without their secrets
+//	GET    /watches/{id}  returns a watch, without its secret
+//	DELETE /watches/{id}  removes a watch
+//
+// Registering and removing watches requires a role that can write when
+// authentication is enabled.
+func NewAPI(store *Store) http.Handler {
+	api := &watchAPI{store: store}
+	router := chi.NewRouter()
+	router.Get("/watches", api.list)
+	router.Get("/watches/{id}", api.get)
+	router.With(requireWrite).Post("/watches", api.create)
+	router.With(requireWrite).Delete("/watches/{id}", api.delete)
+	return router
+}
+
+type watchAPI struct {
+	store *Store
+}
+
+// requireWrite rejects principals whose role cannot write. Requests without
+// a principal are allowed, as authentication is then disabled.
+func requireWrite(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal := auth.PrincipalFromContext(r.Context())
+		if principal != nil && !principal.Role.CanWrite() {
+			writeError(w, http.StatusForbidden, fmt.Errorf("role %s cannot change watches", principal.Role))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (a *watchAPI) create(w http.ResponseWriter, r *http.Request) {
+	var watch Watch
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWatchSize)).Decode(&watch); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid watch: %w", err))
+		return
+	}
+	if err := watch.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	var err error
+	if watch.ID, err = randomHex(16); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if watch.Secret == "" {
+		if watch.Secret, err = randomHex(32); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	watch.CreatedAt = time.Now().UTC()
+	if err := a.store.AddWatch(watch); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	logging.FromContext(r.Context()).Infof("registered %s watch %s on %s", watch.Type, watch.ID, watch.Value)
+	writeJSON(w, http.StatusCreated, watch)
+}
+
+func (a *watchAPI) list(w http.ResponseWriter, r *http.Request) {
+	watches := a.store.Watches()
+	for i := range watches {
+		watches[i].Secret = ""
+	}
+	writeJSON(w, http.StatusOK, watches)
+}
+
+func (a *watchAPI) get(w http.ResponseWriter, r *http.Request) {
+	watch, ok := a.store.Watch(chi.URLParam(r, "id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("watch not found"))
+		return
+	}
+	watch.Secret = ""
+	writeJSON(w, http.StatusOK, watch)
+}
+
+func (a *watchAPI) delete(w http.ResponseWriter, r *http.Request) {
+	found, err := a.store.DeleteWatch(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if !found {
+		writeError(w, http.StatusNotFound, fmt.Errorf("watch not found"))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
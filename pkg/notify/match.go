@@ -0,0 +1,289 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/Khan/genqlient/graphql"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	"github.com/guacsec/guac/pkg/assembler/helpers"
+	"github.com/guacsec/guac/pkg/misc/depversion"
+)
+
+// affected is a package, source or artifact affected by evidence, either as
+// its subject or through its dependencies
+type affected struct {
+	id string
+	// nameID is the package name of a package version
+	nameID string
+	// label is the purl, VCS URI or algorithm:digest of the node
+	label string
+	// pkg is nil for sources and artifacts
+	pkg *model.AllPkgTree
+	// dependency is the node through which this one is affected, nil for the
+	// subject of the evidence
+	dependency *affected
+	depth      int
+}
+
+func affectedPackage(pkg *model.AllPkgTree) *affected {
+	name := pkg.Namespaces[0].Names[0]
+	a := &affected{id: name.Id, label: helpers.AllPkgTreeToPurl(pkg), pkg: pkg}
+	if len(name.Versions) > 0 {
+		a.id = name.Versions[0].Id
+		a.nameID = name.Id
+	}
+	return a
+}
+
+func affectedSource(src *model.AllSourceTree) *affected {
+	ns := src.Namespaces[0]
+	name := ns.Names[0]
+	label := fmt.Sprintf("%s+https://%s/%s", src.Type, ns.Namespace, name.Name)
+	if name.Tag != nil && *name.Tag != "" {
+		label += "@" + *name.Tag
+	} else if name.Commit != nil && *name.Commit != "" {
+		label += "@" + *name.Commit
+	}
+	return &affected{id: name.Id, label: label}
+}
+
+func affectedArtifact(artifact *model.AllArtifactTree) *affected {
+	return &affected{id: artifact.Id, label: artifact.Algorithm + ":" + artifact.Digest}
+}
+
+// path returns the labels from this node down to the subject of the evidence
+func (a *affected) path() []string {
+	var path []string
+	for n := a; n != nil; n = n.dependency {
+		path = append(path, n.label)
+	}
+	return path
+}
+
+// version returns the version of a package version, or false for any other
+// node
+func (a *affected) version() (string, bool) {
+	if a.pkg == nil || a.nameID == "" {
+		return "", false
+	}
+	return a.pkg.Namespaces[0].Names[0].Versions[0].Version, true
+}
+
+// dependents returns the subject followed by the packages depending on it,
+// directly or transitively through IsDependency, in breadth-first order. The
+// walk stops at maxDepth levels of dependencies, unless maxDepth is 0.
+func dependents(ctx context.Context, gqlClient graphql.Client, subject *affected, maxDepth int) ([]*affected, error) {
+	nodes := []*affected{subject}
+	seen := map[string]bool{subject.id: true}
+	for i := 0; i < len(nodes); i++ {
+		current := nodes[i]
+		if current.pkg == nil || (maxDepth > 0 && current.depth >= maxDepth) {
+			continue
+		}
+		ns := current.pkg.Namespaces[0]
+		filter := model.IsDependencySpec{
+			DependencyPackage: &model.PkgSpec{Type: &current.pkg.Type, Namespace: &ns.Namespace, Name: &ns.Names[0].Name},
+		}
+		resp, err := model.Dependencies(ctx, gqlClient, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query the dependents of %s: %w", current.label, err)
+		}
+		for j := range resp.IsDependency {
+			dep := &resp.IsDependency[j]
+			ok, err := dependsOn(&dep.AllIsDependencyTree, current)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			dependent := affectedPackage(&dep.Package.AllPkgTree)
+			if seen[dependent.id] {
+				continue
+			}
+			seen[dependent.id] = true
+			dependent.dependency = current
+			dependent.depth = current.depth + 1
+			nodes = append(nodes, dependent)
+		}
+	}
+	return nodes, nil
+}
+
+// dependsOn reports whether the dependency links to the node. A dependency on
+// a package name links to the versions in its version range, and all versions
+// of a package name are affected when the evidence is about the name.
+func dependsOn(dep *model.AllIsDependencyTree, node *affected) (bool, error) {
+	version, isVersion := node.version()
+	if !isVersion {
+		return true, nil
+	}
+	versions := dep.DependencyPackage.Namespaces[0].Names[0].Versions
+	if len(versions) > 0 {
+		return versions[0].Id == node.id, nil
+	}
+	include, err := depversion.DoesRangeInclude([]string{version}, dep.VersionRange)
+	if err != nil {
+		return false, fmt.Errorf("failed to check the version range of dependency %s: %w", dep.Id, err)
+	}
+	return include, nil
+}
+
+// target is a watch resolved to what it matches in the graph
+type target struct {
+	watch Watch
+	// pkg matches purl watches
+	pkg *model.PkgInputSpec
+	// pattern matches purl pattern watches
+	pattern *regexp.Regexp
+	// ids are the sources, artifacts and packages of sbom and source watches
+	ids map[string]bool
+	// artifactIDs are the artifacts of sbom watches, whose VEX statements
+	// apply to the path
+	artifactIDs []string
+}
+
+// resolve looks up the nodes matched by the watch
+func resolve(ctx context.Context, gqlClient graphql.Client, w Watch) (*target, error) {
+	t := &target{watch: w, ids: map[string]bool{}}
+	switch w.Type {
+	case WatchPurl:
+		pkg, err := helpers.PurlToPkg(w.Value)
+		if err != nil {
+			return nil, err
+		}
+		t.pkg = pkg
+	case WatchPurlPattern:
+		pattern, err := purlPattern(w.Value)
+		if err != nil {
+			return nil, err
+		}
+		t.pattern = pattern
+	case WatchSBOM:
+		algorithm, digest, ok := artifactDigest(w.Value)
+		if !ok {
+			pkg, err := helpers.PurlToPkg(w.Value)
+			if err != nil {
+				return nil, err
+			}
+			t.pkg = pkg
+			break
+		}
+		if err := t.resolveArtifact(ctx, gqlClient, algorithm, digest); err != nil {
+			return nil, err
+		}
+	case WatchSource:
+		if err := t.resolveSource(ctx, gqlClient, w.Value); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown watch type %q", w.Type)
+	}
+	return t, nil
+}
+
+// resolveArtifact adds the artifact and the packages it is an occurrence of
+func (t *target) resolveArtifact(ctx context.Context, gqlClient graphql.Client, algorithm, digest string) error {
+	resp, err := model.Artifacts(ctx, gqlClient, model.ArtifactSpec{Algorithm: &algorithm, Digest: &digest})
+	if err != nil {
+		return fmt.Errorf("failed to query artifact %s:%s: %w", algorithm, digest, err)
+	}
+	for _, artifact := range resp.Artifacts {
+		t.ids[artifact.Id] = true
+		t.artifactIDs = append(t.artifactIDs, artifact.Id)
+		neighbors, err := model.Neighbors(ctx, gqlClient, artifact.Id, []model.Edge{model.EdgeArtifactIsOccurrence})
+		if err != nil {
+			return fmt.Errorf("failed to query the occurrences of artifact %s:%s: %w", algorithm, digest, err)
+		}
+		for _, neighbor := range neighbors.Neighbors {
+			occurrence, ok := neighbor.(*model.NeighborsNeighborsIsOccurrence)
+			if !ok {
+				continue
+			}
+			switch subject := occurrence.Subject.(type) {
+			case *model.AllIsOccurrencesTreeSubjectPackage:
+				t.ids[affectedPackage(&subject.AllPkgTree).id] = true
+			case *model.AllIsOccurrencesTreeSubjectSource:
+				t.ids[affectedSource(&subject.AllSourceTree).id] = true
+			}
+		}
+	}
+	return nil
+}
+
+// resolveSource adds the source and the packages built from it
+func (t *target) resolveSource(ctx context.Context, gqlClient graphql.Client, vcs string) error {
+	src, err := helpers.VcsToSrc(vcs)
+	if err != nil {
+		return err
+	}
+	filter := model.SourceSpec{Type: &src.Type, Namespace: &src.Namespace, Name: &src.Name, Tag: src.Tag, Commit: src.Commit}
+	resp, err := model.Sources(ctx, gqlClient, filter)
+	if err != nil {
+		return fmt.Errorf("failed to query source %s: %w", vcs, err)
+	}
+	for _, source := range resp.Sources {
+		for _, ns := range source.Namespaces {
+			for _, name := range ns.Names {
+				t.ids[name.Id] = true
+				neighbors, err := model.Neighbors(ctx, gqlClient, name.Id, []model.Edge{model.EdgeSourceHasSourceAt})
+				if err != nil {
+					return fmt.Errorf("failed to query the packages of source %s: %w", vcs, err)
+				}
+				for _, neighbor := range neighbors.Neighbors {
+					if hasSourceAt, ok := neighbor.(*model.NeighborsNeighborsHasSourceAt); ok {
+						t.ids[affectedPackage(&hasSourceAt.Package.AllPkgTree).id] = true
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// matches reports whether the affected node is watched
+func (t *target) matches(a *affected) bool {
+	switch {
+	case t.pkg != nil:
+		return a.pkg != nil && samePackage(t.pkg, a)
+	case t.pattern != nil:
+		return a.pkg != nil && t.pattern.MatchString(a.label)
+	default:
+		return t.ids[a.id] || (a.nameID != "" && t.ids[a.nameID])
+	}
+}
+
+// samePackage compares the package of a purl with an affected package, on
+// the version only if the purl has one
+func samePackage(pkg *model.PkgInputSpec, a *affected) bool {
+	ns := a.pkg.Namespaces[0]
+	name := ns.Names[0]
+	if pkg.Type != a.pkg.Type || pkg.Name != name.Name {
+		return false
+	}
+	if pkg.Namespace != nil && *pkg.Namespace != ns.Namespace {
+		return false
+	}
+	if pkg.Version == nil || *pkg.Version == "" {
+		return true
+	}
+	version, ok := a.version()
+	return ok && version == *pkg.Version
+}
@@ -0,0 +1,318 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notify implements the guacnotify service. Users register watches on
+// packages, SBOM subjects and sources, and every CertifyVuln or CertifyBad
+// ingested for a watched subject, or for a package it depends on through
+// IsDependency, is posted to the webhook of the watch as a signed
+// notification.
+package notify
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	"github.com/guacsec/guac/pkg/assembler/eventbus"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+// NATSSubject is the NATS subject notifications are published to
+const NATSSubject = "GUAC.notifications"
+
+// Notification is the payload posted to webhooks
+type Notification struct {
+	// ID identifies the notification across retries and restarts
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Watch     WatchRef  `json:"watch"`
+	// Kind is CertifyVuln or CertifyBad
+	Kind       eventbus.Kind `json:"kind"`
+	EvidenceID string        `json:"evidenceId"`
+	// Vulnerability is set for CertifyVuln notifications
+	Vulnerability *Vulnerability `json:"vulnerability,omitempty"`
+	// Justification is set for CertifyBad notifications
+	Justification string `json:"justification,omitempty"`
+	// Path lists the watched subject first, then the packages it depends on,
+	// down to the subject of the evidence
+	Path []string `json:"path"`
+	// VEX is the latest VEX statement for the vulnerability on the path, if
+	// any
+	VEX    *VEX   `json:"vex,omitempty"`
+	Origin string `json:"origin"`
+}
+
+// WatchRef identifies the watch a notification is sent for
+type WatchRef struct {
+	ID    string    `json:"id"`
+	Type  WatchType `json:"type"`
+	Value string    `json:"value"`
+}
+
+// Vulnerability is the vulnerability of a CertifyVuln notification
+type Vulnerability struct {
+	Type string   `json:"type"`
+	IDs  []string `json:"ids"`
+}
+
+// VEX is the VEX status of the vulnerability of a notification
+type VEX struct {
+	Status        model.VexStatus        `json:"status"`
+	Justification model.VexJustification `json:"justification"`
+	Statement     string                 `json:"statement,omitempty"`
+	// Subject is the element of the path the statement is about
+	Subject    string    `json:"subject"`
+	KnownSince time.Time `json:"knownSince"`
+}
+
+// Config configures the matching and delivery of notifications
+type Config struct {
+	// MaxDepth limits the levels of dependencies between a watched subject
+	// and the subject of the evidence, 0 for no limit
+	MaxDepth int
+	// Webhook posts the notifications
+	Webhook *Webhook
+	// Publish optionally publishes every notification, e.g. to a pubsub topic
+	Publish func(context.Context, []byte) error
+}
+
+// Notifier matches ingestion events with the watches of the store and
+// delivers the notifications
+type Notifier struct {
+	gqlClient graphql.Client
+	store     *Store
+	config    Config
+}
+
+// NewNotifier returns a notifier looking up the graph through the graphql
+// client
+func NewNotifier(gqlClient graphql.Client, store *Store, config Config) *Notifier {
+	if config.Webhook == nil {
+		config.Webhook = NewWebhook()
+	}
+	return &Notifier{gqlClient: gqlClient, store: store, config: config}
+}
+
+// Run handles events until the channel is closed or ctx is done
+func (n *Notifier) Run(ctx context.Context, events <-chan eventbus.Event) {
+	logger := logging.FromContext(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := n.Handle(ctx, event); err != nil {
+				logger.Errorf("failed to handle %s event %s: %v", event.Kind, event.ID, err)
+			}
+		}
+	}
+}
+
+// Handle notifies the watches affected by the evidence of the event
+func (n *Notifier) Handle(ctx context.Context, event eventbus.Event) error {
+	if event.Kind != eventbus.CertifyVuln && event.Kind != eventbus.CertifyBad {
+		return nil
+	}
+	watches := n.store.Watches()
+	if len(watches) == 0 {
+		return nil
+	}
+	notifications, err := n.Notifications(ctx, event, watches)
+	if err != nil {
+		return err
+	}
+	var errs []string
+	for _, notification := range notifications {
+		if err := n.deliver(ctx, notification); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to deliver notifications: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Notifications returns the notifications of the event for the watches,
+// including the ones already delivered
+func (n *Notifier) Notifications(ctx context.Context, event eventbus.Event, watches []Watch) ([]*Notification, error) {
+	resp, err := model.Node(ctx, n.gqlClient, event.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s %s: %w", event.Kind, event.ID, err)
+	}
+
+	base := Notification{Kind: event.Kind, EvidenceID: event.ID}
+	var subject *affected
+	var vuln *model.AllVulnerabilityTree
+	switch node := resp.Node.(type) {
+	case *model.NodeNodeCertifyVuln:
+		vuln = &node.Vulnerability.AllVulnerabilityTree
+		if strings.EqualFold(vuln.Type, "novuln") {
+			return nil, nil
+		}
+		base.Vulnerability = &Vulnerability{Type: vuln.Type}
+		for _, id := range vuln.VulnerabilityIDs {
+			base.Vulnerability.IDs = append(base.Vulnerability.IDs, id.VulnerabilityID)
+		}
+		sort.Strings(base.Vulnerability.IDs)
+		base.Origin = node.Metadata.Origin
+		subject = affectedPackage(&node.Package.AllPkgTree)
+	case *model.NodeNodeCertifyBad:
+		base.Justification = node.Justification
+		base.Origin = node.Origin
+		switch s := node.Subject.(type) {
+		case *model.AllCertifyBadSubjectPackage:
+			subject = affectedPackage(&s.AllPkgTree)
+		case *model.AllCertifyBadSubjectSource:
+			subject = affectedSource(&s.AllSourceTree)
+		case *model.AllCertifyBadSubjectArtifact:
+			subject = affectedArtifact(&s.AllArtifactTree)
+		default:
+			return nil, fmt.Errorf("unexpected subject of CertifyBad %s", event.ID)
+		}
+	default:
+		return nil, fmt.Errorf("node %s is not a %s", event.ID, event.Kind)
+	}
+
+	nodes, err := dependents(ctx, n.gqlClient, subject, n.config.MaxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	var notifications []*Notification
+	for _, w := range watches {
+		t, err := resolve(ctx, n.gqlClient, w)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve watch %s: %w", w.ID, err)
+		}
+		for _, node := range nodes {
+			if !t.matches(node) {
+				continue
+			}
+			notification := base
+			notification.Watch = WatchRef{ID: w.ID, Type: w.Type, Value: w.Value}
+			notification.Path = node.path()
+			notification.ID = notificationID(&notification, node, subject)
+			if vuln != nil {
+				notification.VEX, err = n.latestVEX(ctx, vuln, node, t.artifactIDs)
+				if err != nil {
+					return nil, err
+				}
+			}
+			notifications = append(notifications, &notification)
+		}
+	}
+	return notifications, nil
+}
+
+// notificationID derives the deduplication key of a notification. A
+// vulnerability is notified once per watched node and affected package,
+// regardless of how many scans report it. CertifyBad notifications are
+// keyed on the evidence instead, as each one has its own justification.
+func notificationID(notification *Notification, watched, subject *affected) string {
+	parts := []string{notification.Watch.ID, string(notification.Kind), watched.id, subject.id}
+	if notification.Vulnerability != nil {
+		parts = append(parts, notification.Vulnerability.Type)
+		parts = append(parts, notification.Vulnerability.IDs...)
+	} else {
+		parts = append(parts, notification.EvidenceID)
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// latestVEX returns the most recent VEX statement for the vulnerability on
+// the package versions of the path or on the watched artifacts
+func (n *Notifier) latestVEX(ctx context.Context, vuln *model.AllVulnerabilityTree, watched *affected, artifactIDs []string) (*VEX, error) {
+	var latest *VEX
+	find := func(subject model.PackageOrArtifactSpec, label string) error {
+		for _, id := range vuln.VulnerabilityIDs {
+			vulnID := id.VulnerabilityID
+			filter := model.CertifyVEXStatementSpec{
+				Subject:       &subject,
+				Vulnerability: &model.VulnerabilitySpec{Type: &vuln.Type, VulnerabilityID: &vulnID},
+			}
+			resp, err := model.CertifyVEXStatements(ctx, n.gqlClient, filter)
+			if err != nil {
+				return fmt.Errorf("failed to query the VEX statements of %s: %w", label, err)
+			}
+			for _, vex := range resp.CertifyVEXStatement {
+				if latest == nil || vex.KnownSince.After(latest.KnownSince) {
+					latest = &VEX{
+						Status:        vex.Status,
+						Justification: vex.VexJustification,
+						Statement:     vex.Statement,
+						Subject:       label,
+						KnownSince:    vex.KnownSince,
+					}
+				}
+			}
+		}
+		return nil
+	}
+
+	for _, id := range artifactIDs {
+		artifactID := id
+		if err := find(model.PackageOrArtifactSpec{Artifact: &model.ArtifactSpec{Id: &artifactID}}, watched.label); err != nil {
+			return nil, err
+		}
+	}
+	for node := watched; node != nil; node = node.dependency {
+		if _, ok := node.version(); !ok {
+			continue
+		}
+		id := node.id
+		if err := find(model.PackageOrArtifactSpec{Package: &model.PkgSpec{Id: &id}}, node.label); err != nil {
+			return nil, err
+		}
+	}
+	return latest, nil
+}
+
+// deliver sends a notification unless it was already delivered
+func (n *Notifier) deliver(ctx context.Context, notification *Notification) error {
+	if n.store.Delivered(notification.ID) {
+		return nil
+	}
+	w, ok := n.store.Watch(notification.Watch.ID)
+	if !ok {
+		return nil
+	}
+	notification.Timestamp = time.Now().UTC()
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+	if err := n.config.Webhook.Post(ctx, w.Webhook, w.Secret, notification.ID, body); err != nil {
+		return err
+	}
+	if n.config.Publish != nil {
+		if err := n.config.Publish(ctx, body); err != nil {
+			logging.FromContext(ctx).Errorf("failed to publish notification %s: %v", notification.ID, err)
+		}
+	}
+	logging.FromContext(ctx).Infof("notified watch %s of %s %s", w.ID, notification.Kind, notification.EvidenceID)
+	return n.store.MarkDelivered(notification.ID, w.ID)
+}
@@ -0,0 +1,427 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/Khan/genqlient/graphql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/guacsec/guac/internal/testing/ptrfrom"
+	"github.com/guacsec/guac/pkg/assembler"
+	"github.com/guacsec/guac/pkg/assembler/backends"
+	_ "github.com/guacsec/guac/pkg/assembler/backends/keyvalue"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	"github.com/guacsec/guac/pkg/assembler/clients/helpers"
+	"github.com/guacsec/guac/pkg/assembler/eventbus"
+	"github.com/guacsec/guac/pkg/assembler/graphql/generated"
+	"github.com/guacsec/guac/pkg/assembler/graphql/resolvers"
+	"github.com/guacsec/guac/pkg/auth"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+var tm = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func testPkg(name, version string) *model.PkgInputSpec {
+	return &model.PkgInputSpec{Type: "guac", Namespace: ptrfrom.String("notify"), Name: name, Version: ptrfrom.String(version)}
+}
+
+// testGraph ingests app@1.0.0 -> lib@1.0.0 -> base@^2.0.0, where base@2.1.0
+// is vulnerable and lib@1.0.0 is not affected according to a VEX statement.
+// The artifact sha256:abc is an occurrence of app@1.0.0, which is built from
+// the source github.com/guac/app, itself certified bad.
+func testGraph() assembler.IngestPredicates {
+	app, lib, base, other := testPkg("app", "1.0.0"), testPkg("lib", "1.0.0"), testPkg("base", "2.1.0"), testPkg("other", "1.0.0")
+	src := &model.SourceInputSpec{Type: "git", Namespace: "github.com/guac", Name: "app"}
+	vuln := &model.VulnerabilityInputSpec{Type: "osv", VulnerabilityID: "cve-2024-0001"}
+	dependency := &model.IsDependencyInputSpec{DependencyType: model.DependencyTypeDirect, Origin: "test", Collector: "test"}
+	return assembler.IngestPredicates{
+		IsDependency: []assembler.IsDependencyIngest{
+			{Pkg: app, DepPkg: lib, DepPkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion}, IsDependency: dependency},
+			{
+				Pkg: lib, DepPkg: &model.PkgInputSpec{Type: "guac", Namespace: ptrfrom.String("notify"), Name: "base"},
+				DepPkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeAllVersions},
+				IsDependency:    &model.IsDependencyInputSpec{VersionRange: "^2.0.0", DependencyType: model.DependencyTypeDirect, Origin: "test", Collector: "test"},
+			},
+			{Pkg: other, DepPkg: testPkg("base", "3.0.0"), DepPkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion}, IsDependency: dependency},
+		},
+		CertifyVuln: []assembler.CertifyVulnIngest{
+			{Pkg: base, Vulnerability: vuln, VulnData: &model.ScanMetadataInput{TimeScanned: tm, Origin: "osv", Collector: "test"}},
+		},
+		Vex: []assembler.VexIngest{
+			{Pkg: lib, Vulnerability: vuln, VexData: &model.VexStatementInputSpec{
+				Status: model.VexStatusNotAffected, VexJustification: model.VexJustificationVulnerableCodeNotInExecutePath,
+				KnownSince: tm, Origin: "test", Collector: "test",
+			}},
+		},
+		IsOccurrence: []assembler.IsOccurrenceIngest{
+			{Pkg: app, Artifact: &model.ArtifactInputSpec{Algorithm: "sha256", Digest: "abc"}, IsOccurrence: &model.IsOccurrenceInputSpec{Justification: "test", Origin: "test", Collector: "test"}},
+		},
+		HasSourceAt: []assembler.HasSourceAtIngest{
+			{Pkg: app, PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeAllVersions}, Src: src, HasSourceAt: &model.HasSourceAtInputSpec{KnownSince: tm, Justification: "test", Origin: "test", Collector: "test"}},
+		},
+		CertifyBad: []assembler.CertifyBadIngest{
+			{Src: src, CertifyBad: &model.CertifyBadInputSpec{Justification: "compromised", KnownSince: tm, Origin: "test", Collector: "test"}},
+		},
+	}
+}
+
+// newTestServer returns a graphql server over a keyvalue backend publishing
+// ingestion events
+func newTestServer(t *testing.T) (*httptest.Server, graphql.Client) {
+	t.Helper()
+	backend, err := backends.Get("keyvalue", context.Background(), nil)
+	if err != nil {
+		t.Fatalf("failed to create backend: %v", err)
+	}
+	bus := eventbus.New()
+	config := generated.Config{Resolvers: &resolvers.Resolver{Backend: eventbus.WithEvents(backend, bus), Events: bus}}
+	config.Directives.Filter = resolvers.Filter
+	server := httptest.NewServer(handler.NewDefaultServer(generated.NewExecutableSchema(config)))
+	t.Cleanup(server.Close)
+	return server, graphql.NewClient(server.URL, server.Client())
+}
+
+// testEvents ingests the test graph and returns the events of its
+// CertifyVuln and CertifyBad
+func testEvents(ctx context.Context, t *testing.T, gqlClient graphql.Client) (vuln, bad eventbus.Event) {
+	t.Helper()
+	if err := helpers.GetAssembler(ctx, gqlClient)([]assembler.IngestPredicates{testGraph()}); err != nil {
+		t.Fatalf("failed to ingest test data: %v", err)
+	}
+	vulns, err := model.CertifyVulns(ctx, gqlClient, model.CertifyVulnSpec{})
+	if err != nil || len(vulns.CertifyVuln) != 1 {
+		t.Fatalf("failed to query the CertifyVuln: %v", err)
+	}
+	bads, err := model.CertifyBads(ctx, gqlClient, model.CertifyBadSpec{})
+	if err != nil || len(bads.CertifyBad) != 1 {
+		t.Fatalf("failed to query the CertifyBad: %v", err)
+	}
+	return eventbus.Event{Kind: eventbus.CertifyVuln, ID: vulns.CertifyVuln[0].Id},
+		eventbus.Event{Kind: eventbus.CertifyBad, ID: bads.CertifyBad[0].Id}
+}
+
+func TestNotifications(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	_, gqlClient := newTestServer(t)
+	vulnEvent, badEvent := testEvents(ctx, t, gqlClient)
+
+	watches := []Watch{
+		{ID: "app", Type: WatchPurl, Value: "pkg:guac/notify/app"},
+		{ID: "lib", Type: WatchPurlPattern, Value: "pkg:guac/notify/lib@*"},
+		{ID: "sbom", Type: WatchSBOM, Value: "sha256:abc"},
+		{ID: "source", Type: WatchSource, Value: "git+https://github.com/guac/app"},
+		{ID: "other", Type: WatchPurl, Value: "pkg:guac/notify/other@1.0.0"},
+		{ID: "shallow", Type: WatchPurl, Value: "pkg:guac/notify/app@2.0.0"},
+	}
+	vex := &VEX{
+		Status:        model.VexStatusNotAffected,
+		Justification: model.VexJustificationVulnerableCodeNotInExecutePath,
+		Subject:       "pkg:guac/notify/lib@1.0.0",
+		KnownSince:    tm,
+	}
+	appPath := []string{"pkg:guac/notify/app@1.0.0", "pkg:guac/notify/lib@1.0.0", "pkg:guac/notify/base@2.1.0"}
+	vulnNotification := func(w Watch, path []string) *Notification {
+		return &Notification{
+			Watch:         WatchRef{ID: w.ID, Type: w.Type, Value: w.Value},
+			Kind:          eventbus.CertifyVuln,
+			EvidenceID:    vulnEvent.ID,
+			Vulnerability: &Vulnerability{Type: "osv", IDs: []string{"cve-2024-0001"}},
+			Path:          path,
+			VEX:           vex,
+			Origin:        "osv",
+		}
+	}
+
+	tests := []struct {
+		name     string
+		event    eventbus.Event
+		maxDepth int
+		want     []*Notification
+	}{
+		{
+			name:  "vulnerability",
+			event: vulnEvent,
+			want: []*Notification{
+				vulnNotification(watches[0], appPath),
+				vulnNotification(watches[1], appPath[1:]),
+				vulnNotification(watches[2], appPath),
+				vulnNotification(watches[3], appPath),
+			},
+		},
+		{
+			name:     "depth limit",
+			event:    vulnEvent,
+			maxDepth: 1,
+			want:     []*Notification{vulnNotification(watches[1], appPath[1:])},
+		},
+		{
+			name:  "bad source",
+			event: badEvent,
+			want: []*Notification{{
+				Watch:         WatchRef{ID: "source", Type: WatchSource, Value: "git+https://github.com/guac/app"},
+				Kind:          eventbus.CertifyBad,
+				EvidenceID:    badEvent.ID,
+				Justification: "compromised",
+				Path:          []string{"git+https://github.com/guac/app"},
+				Origin:        "test",
+			}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			n := NewNotifier(gqlClient, nil, Config{MaxDepth: test.maxDepth})
+			got, err := n.Notifications(ctx, test.event, watches)
+			if err != nil {
+				t.Fatalf("Notifications() error: %v", err)
+			}
+			ids := map[string]bool{}
+			for _, notification := range got {
+				ids[notification.ID] = true
+			}
+			if len(ids) != len(got) {
+				t.Errorf("expected distinct notification IDs, got %d for %d notifications", len(ids), len(got))
+			}
+			if diff := cmp.Diff(test.want, got, cmpopts.IgnoreFields(Notification{}, "ID")); diff != "" {
+				t.Errorf("Notifications() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandle(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	_, gqlClient := newTestServer(t)
+	vulnEvent, _ := testEvents(ctx, t, gqlClient)
+
+	var mu sync.Mutex
+	var received []Notification
+	failures := 1
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := Verify("secret", r.Header, body, time.Minute); err != nil {
+			t.Errorf("Verify() error: %v", err)
+		}
+		if err := Verify("other", r.Header, body, 0); err == nil {
+			t.Errorf("expected the signature check to fail with another secret")
+		}
+		var notification Notification
+		if err := json.Unmarshal(body, &notification); err != nil {
+			t.Errorf("failed to parse notification: %v", err)
+		}
+		if got := r.Header.Get(DeliveryHeader); got != notification.ID {
+			t.Errorf("expected delivery ID %s, got %s", notification.ID, got)
+		}
+		received = append(received, notification)
+	}))
+	defer webhook.Close()
+
+	storeFile := filepath.Join(t.TempDir(), "store.json")
+	store, err := OpenStore(storeFile)
+	if err != nil {
+		t.Fatalf("OpenStore() error: %v", err)
+	}
+	if err := store.AddWatch(Watch{ID: "app", Type: WatchPurl, Value: "pkg:guac/notify/app@1.0.0", Webhook: webhook.URL, Secret: "secret", CreatedAt: tm}); err != nil {
+		t.Fatalf("AddWatch() error: %v", err)
+	}
+
+	var published [][]byte
+	config := Config{
+		Webhook: &Webhook{Client: webhook.Client(), Attempts: 2, Backoff: time.Millisecond},
+		Publish: func(_ context.Context, data []byte) error {
+			published = append(published, data)
+			return nil
+		},
+	}
+	n := NewNotifier(gqlClient, store, config)
+	// handling an event again, e.g. when a vulnerability is ingested again, is
+	// deduplicated
+	for i := 0; i < 2; i++ {
+		if err := n.Handle(ctx, vulnEvent); err != nil {
+			t.Fatalf("Handle() error: %v", err)
+		}
+	}
+	if len(received) != 1 || len(published) != 1 {
+		t.Fatalf("expected a single notification, got %d delivered and %d published", len(received), len(published))
+	}
+	if received[0].VEX == nil || received[0].VEX.Status != model.VexStatusNotAffected {
+		t.Errorf("expected the VEX status of the path, got %+v", received[0].VEX)
+	}
+
+	// the deduplication persists across restarts
+	store, err = OpenStore(storeFile)
+	if err != nil {
+		t.Fatalf("OpenStore() error: %v", err)
+	}
+	if err := NewNotifier(gqlClient, store, config).Handle(ctx, vulnEvent); err != nil {
+		t.Fatalf("Handle() error: %v", err)
+	}
+	if len(received) != 1 {
+		t.Errorf("expected no notification after a restart, got %d", len(received)-1)
+	}
+
+	// deleting the watch forgets its deliveries
+	if found, err := store.DeleteWatch("app"); err != nil || !found {
+		t.Fatalf("DeleteWatch() = %v, %v", found, err)
+	}
+	if store.Delivered(received[0].ID) {
+		t.Errorf("expected the deliveries of the deleted watch to be removed")
+	}
+}
+
+func TestWebhookErrors(t *testing.T) {
+	ctx := context.Background()
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	webhook := &Webhook{Client: server.Client(), Attempts: 3, Backoff: time.Millisecond}
+
+	if err := webhook.Post(ctx, server.URL+"/missing", "secret", "1", []byte("{}")); err == nil || attempts != 1 {
+		t.Errorf("expected a client error without retries, got %v after %d attempts", err, attempts)
+	}
+	attempts = 0
+	if err := webhook.Post(ctx, server.URL, "secret", "1", []byte("{}")); err == nil || attempts != 3 {
+		t.Errorf("expected a server error after 3 attempts, got %v after %d attempts", err, attempts)
+	}
+}
+
+func TestAPI(t *testing.T) {
+	store, err := OpenStore(filepath.Join(t.TempDir(), "store.json"))
+	if err != nil {
+		t.Fatalf("OpenStore() error: %v", err)
+	}
+	api := NewAPI(store)
+	request := func(principal *auth.Principal, method, path, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		if principal != nil {
+			r = r.WithContext(auth.WithPrincipal(r.Context(), principal))
+		}
+		w := httptest.NewRecorder()
+		api.ServeHTTP(w, r)
+		return w
+	}
+	writer := &auth.Principal{Subject: "writer", Role: auth.RoleReadWrite}
+	reader := &auth.Principal{Subject: "reader", Role: auth.RoleReadOnly}
+
+	for _, body := range []string{
+		`{"type": "purl", "value": "not a purl", "webhook": "https://example.com"}`,
+		`{"type": "unknown", "value": "pkg:npm/foo", "webhook": "https://example.com"}`,
+		`{"type": "purl", "value": "pkg:npm/foo", "webhook": "ftp://example.com"}`,
+		`{"type": "source", "value": "github.com", "webhook": "https://example.com"}`,
+	} {
+		if w := request(writer, http.MethodPost, "/watches", body); w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 for %s, got %d", body, w.Code)
+		}
+	}
+	body := `{"type": "sbom", "value": "sha256:abc", "webhook": "https://example.com/hook"}`
+	if w := request(reader, http.MethodPost, "/watches", body); w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for a read-only principal, got %d", w.Code)
+	}
+
+	w := request(writer, http.MethodPost, "/watches", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body)
+	}
+	var created Watch
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("failed to parse watch: %v", err)
+	}
+	if created.ID == "" || created.Secret == "" || created.Value != "sha256:abc" {
+		t.Errorf("unexpected watch %+v", created)
+	}
+
+	w = request(reader, http.MethodGet, "/watches", "")
+	var watches []Watch
+	if err := json.Unmarshal(w.Body.Bytes(), &watches); err != nil {
+		t.Fatalf("failed to parse watches: %v", err)
+	}
+	created.Secret = ""
+	if diff := cmp.Diff([]Watch{created}, watches); diff != "" {
+		t.Errorf("unexpected watches without secrets (-want +got):\n%s", diff)
+	}
+	if w := request(reader, http.MethodGet, "/watches/"+created.ID, ""); w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+
+	if w := request(reader, http.MethodDelete, "/watches/"+created.ID, ""); w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403 for a read-only principal, got %d", w.Code)
+	}
+	if w := request(nil, http.MethodDelete, "/watches/"+created.ID, ""); w.Code != http.StatusNoContent {
+		t.Errorf("expected status 204 without authentication, got %d", w.Code)
+	}
+	if w := request(nil, http.MethodGet, "/watches/"+created.ID, ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 after deletion, got %d", w.Code)
+	}
+}
+
+func TestSubscriber(t *testing.T) {
+	ctx, cancel := context.WithCancel(logging.WithLogger(context.Background()))
+	defer cancel()
+	server, gqlClient := newTestServer(t)
+
+	events := make(chan eventbus.Event, 16)
+	go (&Subscriber{URL: server.URL}).Listen(ctx, events)
+
+	// the subscription starts asynchronously, so keep ingesting until an
+	// event is received
+	timeout := time.After(10 * time.Second)
+	for i := 0; ; i++ {
+		graph := assembler.IngestPredicates{CertifyBad: []assembler.CertifyBadIngest{{
+			Pkg:          testPkg("app", fmt.Sprint(i)),
+			PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+			CertifyBad:   &model.CertifyBadInputSpec{Justification: "test", KnownSince: tm, Origin: "test", Collector: "test"},
+		}}}
+		if err := helpers.GetAssembler(ctx, gqlClient)([]assembler.IngestPredicates{graph}); err != nil {
+			t.Fatalf("failed to ingest: %v", err)
+		}
+		select {
+		case event := <-events:
+			if event.Kind != eventbus.CertifyBad || event.ID == "" {
+				t.Errorf("unexpected event %+v", event)
+			}
+			return
+		case <-timeout:
+			t.Fatalf("timed out waiting for an event")
+		case <-time.After(20 * time.Millisecond):
+		}
+	}
+}
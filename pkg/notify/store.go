@@ -0,0 +1,183 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Store persists the watches and the notifications already delivered, so
+// that a restart neither loses watches nor repeats notifications
+type Store struct {
+	path string
+
+	mu    sync.Mutex
+	state storeState
+}
+
+type storeState struct {
+	Watches map[string]*Watch `json:"watches"`
+	// Delivered maps the key of each delivered notification to its watch
+	Delivered map[string]delivery `json:"delivered"`
+}
+
+type delivery struct {
+	WatchID string    `json:"watchId"`
+	Time    time.Time `json:"time"`
+}
+
+// OpenStore loads the store from the JSON file at path, which is created on
+// the first change if it does not exist
+func OpenStore(path string) (*Store, error) {
+	s := &Store{
+		path: path,
+		state: storeState{
+			Watches:   map[string]*Watch{},
+			Delivered: map[string]delivery{},
+		},
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read store %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &s.state); err != nil {
+		return nil, fmt.Errorf("failed to parse store %s: %w", path, err)
+	}
+	if s.state.Watches == nil {
+		s.state.Watches = map[string]*Watch{}
+	}
+	if s.state.Delivered == nil {
+		s.state.Delivered = map[string]delivery{}
+	}
+	return s, nil
+}
+
+// save writes the state to a temporary file renamed over the store, so that
+// a crash never leaves a partially written store. The store holds the webhook
+// secrets and is only readable by its owner.
+func (s *Store) save() error {
+	data, err := json.Marshal(&s.state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal store: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to replace store %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// AddWatch stores a new watch
+func (s *Store) AddWatch(w Watch) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.state.Watches[w.ID]; ok {
+		return fmt.Errorf("watch %s already exists", w.ID)
+	}
+	s.state.Watches[w.ID] = &w
+	if err := s.save(); err != nil {
+		delete(s.state.Watches, w.ID)
+		return err
+	}
+	return nil
+}
+
+// Watch returns the watch with the given ID
+func (s *Store) Watch(id string) (Watch, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w, ok := s.state.Watches[id]
+	if !ok {
+		return Watch{}, false
+	}
+	return *w, true
+}
+
+// Watches returns all watches in the order they were created
+func (s *Store) Watches() []Watch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	watches := make([]Watch, 0, len(s.state.Watches))
+	for _, w := range s.state.Watches {
+		watches = append(watches, *w)
+	}
+	sort.Slice(watches, func(i, j int) bool {
+		if !watches[i].CreatedAt.Equal(watches[j].CreatedAt) {
+			return watches[i].CreatedAt.Before(watches[j].CreatedAt)
+		}
+		return watches[i].ID < watches[j].ID
+	})
+	return watches
+}
+
+// DeleteWatch removes a watch and its delivery history, returning false if
+// the watch does not exist
+func (s *Store) DeleteWatch(id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.state.Watches[id]; !ok {
+		return false, nil
+	}
+	delete(s.state.Watches, id)
+	for key, d := range s.state.Delivered {
+		if d.WatchID == id {
+			delete(s.state.Delivered, key)
+		}
+	}
+	return true, s.save()
+}
+
+// Delivered reports whether the notification with the given key was already
+// delivered
+func (s *Store) Delivered(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.state.Delivered[key]
+	return ok
+}
+
+// MarkDelivered records the delivery of a notification for a watch
+func (s *Store) MarkDelivered(key, watchID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.state.Watches[watchID]; !ok {
+		// the watch was deleted during the delivery
+		return nil
+	}
+	s.state.Delivered[key] = delivery{WatchID: watchID, Time: time.Now().UTC()}
+	return s.save()
+}
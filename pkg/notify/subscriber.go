@@ -0,0 +1,171 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/guacsec/guac/pkg/assembler/eventbus"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+// subscriptions are the graphql subscriptions of the ingestion events, keyed
+// by the ID of the subscription in the websocket protocol
+var subscriptions = map[string]struct {
+	kind  eventbus.Kind
+	field string
+}{
+	"certifyVuln": {eventbus.CertifyVuln, "certifyVulnIngested"},
+	"certifyBad":  {eventbus.CertifyBad, "certifyBadIngested"},
+}
+
+// maxBackoff is the longest delay between reconnections to the graphql server
+const maxBackoff = time.Minute
+
+// Subscriber receives the ingestion events of the graphql server through its
+// subscriptions, using the graphql-transport-ws websocket protocol
+type Subscriber struct {
+	// URL is the graphql endpoint, http and https are replaced by ws and wss
+	URL string
+	// Header is sent with the websocket handshake, e.g. for authentication
+	Header http.Header
+	Dialer *websocket.Dialer
+}
+
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// Listen sends the events to the channel until ctx is done, reconnecting to
+// the graphql server with exponential backoff. Events ingested while
+// disconnected are missed.
+func (s *Subscriber) Listen(ctx context.Context, events chan<- eventbus.Event) {
+	logger := logging.FromContext(ctx)
+	backoff := time.Second
+	for {
+		start := time.Now()
+		err := s.listen(ctx, events)
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(start) > maxBackoff {
+			backoff = time.Second
+		}
+		logger.Errorf("graphql subscription failed, reconnecting in %s: %v", backoff, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
+
+func (s *Subscriber) listen(ctx context.Context, events chan<- eventbus.Event) error {
+	dialer := *websocket.DefaultDialer
+	if s.Dialer != nil {
+		dialer = *s.Dialer
+	}
+	dialer.Subprotocols = []string{"graphql-transport-ws"}
+	url := s.URL
+	if rest, ok := strings.CutPrefix(url, "http"); ok {
+		url = "ws" + rest
+	}
+	conn, _, err := dialer.DialContext(ctx, url, s.Header)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", url, err)
+	}
+	defer conn.Close()
+	// unblock reads when ctx is done
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	if err := conn.WriteJSON(wsMessage{Type: "connection_init", Payload: json.RawMessage("{}")}); err != nil {
+		return fmt.Errorf("failed to initialize connection: %w", err)
+	}
+	var ack wsMessage
+	if err := conn.ReadJSON(&ack); err != nil {
+		return fmt.Errorf("failed to initialize connection: %w", err)
+	}
+	if ack.Type != "connection_ack" {
+		return fmt.Errorf("connection was not acknowledged: %s %s", ack.Type, ack.Payload)
+	}
+	for id, sub := range subscriptions {
+		query := fmt.Sprintf(`subscription { %s(%sSpec: {}) { id } }`, sub.field, id)
+		payload, err := json.Marshal(map[string]string{"query": query})
+		if err != nil {
+			return err
+		}
+		if err := conn.WriteJSON(wsMessage{ID: id, Type: "subscribe", Payload: payload}); err != nil {
+			return fmt.Errorf("failed to subscribe to %s: %w", sub.field, err)
+		}
+	}
+	logging.FromContext(ctx).Infof("subscribed to the ingestion events of %s", s.URL)
+
+	for {
+		var msg wsMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			return fmt.Errorf("failed to read message: %w", err)
+		}
+		switch msg.Type {
+		case "ping":
+			if err := conn.WriteJSON(wsMessage{Type: "pong"}); err != nil {
+				return fmt.Errorf("failed to answer ping: %w", err)
+			}
+		case "next":
+			sub, ok := subscriptions[msg.ID]
+			if !ok {
+				continue
+			}
+			var result struct {
+				Data map[string]struct {
+					ID string `json:"id"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(msg.Payload, &result); err != nil {
+				return fmt.Errorf("failed to parse %s event: %w", sub.field, err)
+			}
+			node, ok := result.Data[sub.field]
+			if !ok {
+				return fmt.Errorf("%s event without data: %s", sub.field, msg.Payload)
+			}
+			select {
+			case events <- eventbus.Event{Kind: sub.kind, ID: node.ID}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		case "error":
+			return fmt.Errorf("subscription %s failed: %s", msg.ID, msg.Payload)
+		case "complete":
+			return fmt.Errorf("subscription %s was completed by the server", msg.ID)
+		}
+	}
+}
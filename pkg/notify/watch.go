@@ -0,0 +1,137 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/guacsec/guac/pkg/assembler/helpers"
+)
+
+// WatchType is the kind of subject a watch is registered for
+type WatchType string
+
+const (
+	// WatchPurl watches a package. Without a version, all versions of the
+	// package are watched.
+	WatchPurl WatchType = "purl"
+	// WatchPurlPattern watches the packages whose purl matches a pattern,
+	// where * matches any sequence of characters and ? any single character.
+	WatchPurlPattern WatchType = "purlPattern"
+	// WatchSBOM watches the subject of an SBOM, either a purl or an artifact
+	// in the form algorithm:digest. The packages an artifact is an occurrence
+	// of are watched along with the artifact.
+	WatchSBOM WatchType = "sbom"
+	// WatchSource watches a source repository, given as a VCS URI, and the
+	// packages built from it.
+	WatchSource WatchType = "source"
+)
+
+// Watch registers a webhook to notify when new vulnerabilities or bad
+// certifications affect a subject or its dependencies
+type Watch struct {
+	ID    string    `json:"id"`
+	Type  WatchType `json:"type"`
+	Value string    `json:"value"`
+	// Webhook is the http(s) URL notifications are posted to
+	Webhook string `json:"webhook"`
+	// Secret is the key of the HMAC signature of the notifications. It is
+	// generated when not provided at registration.
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Validate checks that the value of the watch is well formed for its type
+func (w *Watch) Validate() error {
+	switch w.Type {
+	case WatchPurl:
+		if _, err := helpers.PurlToPkg(w.Value); err != nil {
+			return err
+		}
+	case WatchPurlPattern:
+		if w.Value == "" {
+			return fmt.Errorf("the purl pattern is empty")
+		}
+	case WatchSBOM:
+		if _, _, ok := artifactDigest(w.Value); !ok {
+			if _, err := helpers.PurlToPkg(w.Value); err != nil {
+				return fmt.Errorf("the sbom subject must be a purl or an artifact algorithm:digest: %w", err)
+			}
+		}
+	case WatchSource:
+		if _, err := helpers.VcsToSrc(w.Value); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown watch type %q, expected one of %s, %s, %s or %s", w.Type, WatchPurl, WatchPurlPattern, WatchSBOM, WatchSource)
+	}
+
+	u, err := url.Parse(w.Webhook)
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("the webhook must be an http or https URL")
+	}
+	return nil
+}
+
+// artifactDigest splits an artifact given as algorithm:digest. Purls also
+// contain a colon, so values starting with pkg: are not artifacts.
+func artifactDigest(s string) (algorithm, digest string, ok bool) {
+	if strings.HasPrefix(s, "pkg:") {
+		return "", "", false
+	}
+	algorithm, digest, ok = strings.Cut(s, ":")
+	if !ok || algorithm == "" || digest == "" || strings.ContainsAny(digest, "/@") {
+		return "", "", false
+	}
+	return strings.ToLower(algorithm), strings.ToLower(digest), true
+}
+
+// purlPattern compiles a purl pattern to a regular expression matching the
+// whole purl
+func purlPattern(pattern string) (*regexp.Regexp, error) {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.Compile(expr.String())
+}
+
+// randomHex returns n random bytes in hexadecimal
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
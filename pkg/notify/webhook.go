@@ -0,0 +1,132 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// SignatureHeader holds "sha256=" followed by the hex encoded HMAC-SHA256,
+	// keyed with the secret of the watch, of the timestamp header value, a
+	// dot and the request body
+	SignatureHeader = "X-GUAC-Signature-256"
+	// TimestampHeader holds the Unix time the notification was signed at
+	TimestampHeader = "X-GUAC-Timestamp"
+	// DeliveryHeader holds the ID of the notification, identical across
+	// retries so that receivers can discard duplicates
+	DeliveryHeader = "X-GUAC-Delivery"
+)
+
+// Sign returns the signature of a webhook body sent at the given timestamp
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a webhook request, for receivers of the
+// notifications. Requests signed longer than tolerance ago are rejected to
+// prevent replays, unless tolerance is 0.
+func Verify(secret string, header http.Header, body []byte, tolerance time.Duration) error {
+	timestamp := header.Get(TimestampHeader)
+	signed, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s header: %w", TimestampHeader, err)
+	}
+	if tolerance > 0 && time.Since(time.Unix(signed, 0)).Abs() > tolerance {
+		return fmt.Errorf("the signature timestamp is outside of the tolerance")
+	}
+	if !hmac.Equal([]byte(header.Get(SignatureHeader)), []byte(Sign(secret, timestamp, body))) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
+// Webhook posts signed notifications, retrying on network errors, rate
+// limiting and server errors
+type Webhook struct {
+	Client *http.Client
+	// Attempts is the number of times a notification is tried
+	Attempts int
+	// Backoff is the delay before the first retry, doubled on every retry
+	Backoff time.Duration
+}
+
+// NewWebhook returns a webhook sender with a 30s timeout per request and 4
+// attempts
+func NewWebhook() *Webhook {
+	return &Webhook{
+		Client:   &http.Client{Timeout: 30 * time.Second},
+		Attempts: 4,
+		Backoff:  time.Second,
+	}
+}
+
+// Post sends the body to the URL, signed with the secret
+func (w *Webhook) Post(ctx context.Context, url, secret, deliveryID string, body []byte) error {
+	backoff := w.Backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		var retry bool
+		retry, err = w.post(ctx, url, secret, deliveryID, body)
+		if err == nil || !retry || attempt >= w.Attempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (w *Webhook) post(ctx context.Context, url, secret, deliveryID string, body []byte) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "guacnotify")
+	req.Header.Set(DeliveryHeader, deliveryID)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(secret, timestamp, body))
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("failed to post webhook to %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("webhook %s returned status %d", url, resp.StatusCode)
+}
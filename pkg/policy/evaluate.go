@@ -0,0 +1,504 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	"github.com/guacsec/guac/pkg/assembler/helpers"
+	"github.com/guacsec/guac/pkg/guacanalytics"
+	"github.com/guacsec/guac/pkg/misc/depversion"
+)
+
+const noVulnType string = "novuln"
+
+// target is a package version or artifact that rules are checked against
+type target struct {
+	id         string
+	nameID     string // packageName node of a package version, empty for artifacts
+	isArtifact bool
+	depth      int
+	path       []string
+}
+
+// evaluator holds the targets found from the subject so that they are only
+// searched for once per policy evaluation
+type evaluator struct {
+	gqlClient graphql.Client
+	targets   []target
+	// checkedArtifacts avoids reporting an artifact once per package it is an
+	// occurrence of. It is reset for every rule.
+	checkedArtifacts map[string]bool
+}
+
+// FindSubject resolves a purl or an artifact in algorithm:digest form to the
+// ID of the packageVersion or artifact node. Purls are resolved with
+// guacanalytics.PackageVersionID, as for SBOM diffs.
+func FindSubject(ctx context.Context, gqlClient graphql.Client, subject string) (string, error) {
+	if strings.HasPrefix(subject, "pkg:") {
+		return guacanalytics.PackageVersionID(ctx, gqlClient, subject)
+	}
+
+	split := strings.Split(subject, ":")
+	if len(split) != 2 {
+		return "", fmt.Errorf("failed to parse subject %s. Needs to be a purl or in algorithm:digest form", subject)
+	}
+	algorithm := strings.ToLower(split[0])
+	digest := strings.ToLower(split[1])
+	artifactResponse, err := model.Artifacts(ctx, gqlClient, model.ArtifactSpec{
+		Algorithm: &algorithm,
+		Digest:    &digest,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error querying for artifact: %w", err)
+	}
+	if len(artifactResponse.Artifacts) != 1 {
+		return "", fmt.Errorf("failed to locate artifact %s", subject)
+	}
+	return artifactResponse.Artifacts[0].Id, nil
+}
+
+// Evaluate evaluates the policy against the packageVersion or artifact node
+// with ID subjectID
+func Evaluate(ctx context.Context, gqlClient graphql.Client, p *Policy, subjectID string) (*Result, error) {
+	e := &evaluator{gqlClient: gqlClient}
+
+	subjectLabel, err := e.collectTargets(ctx, subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Policy:  p.Name,
+		Subject: subjectLabel,
+		Passed:  true,
+		Rules:   []RuleResult{},
+	}
+
+	for _, rule := range p.Rules {
+		ruleResult := RuleResult{
+			Name:       rule.Name,
+			Type:       rule.Type,
+			Violations: []Violation{},
+		}
+		e.checkedArtifacts = map[string]bool{}
+
+		for _, t := range e.targets {
+			if !inScope(rule.Scope, t.depth) {
+				continue
+			}
+
+			var violations []Violation
+			switch rule.Type {
+			case RuleNoVulnerability:
+				violations, err = e.checkVulnerabilities(ctx, rule, t)
+			case RuleNoCertifyBad:
+				violations, err = e.checkCertifyBad(ctx, t)
+			case RuleSLSABuilder:
+				violations, err = e.checkSLSABuilder(ctx, rule, t)
+			case RuleMinScorecard:
+				violations, err = e.checkScorecard(ctx, rule, t)
+			default:
+				err = fmt.Errorf("unknown rule type %q", rule.Type)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed evaluating rule %s: %w", rule.Name, err)
+			}
+			ruleResult.Violations = append(ruleResult.Violations, violations...)
+		}
+
+		ruleResult.Passed = len(ruleResult.Violations) == 0
+		result.Passed = result.Passed && ruleResult.Passed
+		result.Rules = append(result.Rules, ruleResult)
+	}
+
+	return result, nil
+}
+
+func inScope(scope Scope, depth int) bool {
+	switch scope {
+	case ScopeSubject:
+		return depth == 0
+	case ScopeDirect:
+		return depth == 1
+	}
+	return true
+}
+
+// collectTargets walks the dependencies of the subject in breadth-first order
+// and records the shortest path to each of them
+func (e *evaluator) collectTargets(ctx context.Context, subjectID string) (string, error) {
+	nodeResponse, err := model.Node(ctx, e.gqlClient, subjectID)
+	if err != nil {
+		return "", fmt.Errorf("failed getting subject node: %w", err)
+	}
+
+	var queue []target
+	var subjectLabel string
+	switch node := nodeResponse.Node.(type) {
+	case *model.NodeNodePackage:
+		if len(node.Namespaces) == 0 || len(node.Namespaces[0].Names) == 0 || len(node.Namespaces[0].Names[0].Versions) == 0 {
+			return "", fmt.Errorf("policy subject must be a packageVersion or an artifact")
+		}
+		subjectLabel = helpers.AllPkgTreeToPurl(&node.AllPkgTree)
+		queue = append(queue, target{
+			id:     subjectID,
+			nameID: node.Namespaces[0].Names[0].Id,
+			path:   []string{subjectLabel},
+		})
+	case *model.NodeNodeArtifact:
+		subjectLabel = node.Algorithm + ":" + node.Digest
+		subjectTarget := target{
+			id:         subjectID,
+			isArtifact: true,
+			path:       []string{subjectLabel},
+		}
+		e.targets = append(e.targets, subjectTarget)
+
+		// the packages an artifact is an occurrence of share its dependencies
+		neighbors, err := model.Neighbors(ctx, e.gqlClient, subjectID, []model.Edge{model.EdgeArtifactIsOccurrence})
+		if err != nil {
+			return "", fmt.Errorf("failed getting artifact occurrences: %w", err)
+		}
+		for _, neighbor := range neighbors.Neighbors {
+			if isOccurrence, ok := neighbor.(*model.NeighborsNeighborsIsOccurrence); ok {
+				if pkg, ok := isOccurrence.Subject.(*model.AllIsOccurrencesTreeSubjectPackage); ok {
+					queue = append(queue, target{
+						id:     pkg.Namespaces[0].Names[0].Versions[0].Id,
+						nameID: pkg.Namespaces[0].Names[0].Id,
+						path:   []string{subjectLabel, helpers.AllPkgTreeToPurl(&pkg.AllPkgTree)},
+					})
+				}
+			}
+		}
+	default:
+		return "", fmt.Errorf("policy subject must be a packageVersion or an artifact")
+	}
+
+	seen := map[string]bool{}
+	for len(queue) > 0 {
+		now := queue[0]
+		queue = queue[1:]
+		if seen[now.id] {
+			continue
+		}
+		seen[now.id] = true
+		e.targets = append(e.targets, now)
+
+		dependencies, err := e.dependencies(ctx, now)
+		if err != nil {
+			return "", err
+		}
+		for _, dep := range dependencies {
+			if !seen[dep.id] {
+				queue = append(queue, dep)
+			}
+		}
+	}
+
+	return subjectLabel, nil
+}
+
+// dependencies returns the package versions that the package version t
+// depends on. Dependencies on a packageName are expanded to the versions that
+// are included in the version range.
+func (e *evaluator) dependencies(ctx context.Context, t target) ([]target, error) {
+	neighbors, err := model.Neighbors(ctx, e.gqlClient, t.id, []model.Edge{model.EdgePackageIsDependency})
+	if err != nil {
+		return nil, fmt.Errorf("failed getting dependencies: %w", err)
+	}
+
+	var deps []target
+	for _, neighbor := range neighbors.Neighbors {
+		isDependency, ok := neighbor.(*model.NeighborsNeighborsIsDependency)
+		if !ok || isDependency.Package.Namespaces[0].Names[0].Versions[0].Id != t.id {
+			continue
+		}
+
+		depPkg := isDependency.DependencyPackage
+		depName := depPkg.Namespaces[0].Names[0]
+		if len(depName.Versions) > 0 {
+			deps = append(deps, target{
+				id:     depName.Versions[0].Id,
+				nameID: depName.Id,
+				depth:  t.depth + 1,
+				path:   appendPath(t.path, helpers.AllPkgTreeToPurl(&depPkg.AllPkgTree)),
+			})
+			continue
+		}
+
+		pkgResponse, err := model.Packages(ctx, e.gqlClient, model.PkgSpec{
+			Type:      &depPkg.Type,
+			Namespace: &depPkg.Namespaces[0].Namespace,
+			Name:      &depName.Name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed getting dependency package versions: %w", err)
+		}
+		for _, pkg := range pkgResponse.Packages {
+			for _, version := range pkg.Namespaces[0].Names[0].Versions {
+				included, err := depversion.DoesRangeInclude([]string{version.Version}, isDependency.VersionRange)
+				if err != nil || !included {
+					continue
+				}
+				deps = append(deps, target{
+					id:     version.Id,
+					nameID: depName.Id,
+					depth:  t.depth + 1,
+					path: appendPath(t.path, helpers.PkgToPurl(pkg.Type, pkg.Namespaces[0].Namespace,
+						pkg.Namespaces[0].Names[0].Name, version.Version, version.Subpath, nil)),
+				})
+			}
+		}
+	}
+	return deps, nil
+}
+
+func appendPath(path []string, label string) []string {
+	newPath := make([]string, len(path), len(path)+1)
+	copy(newPath, path)
+	return append(newPath, label)
+}
+
+func (e *evaluator) checkVulnerabilities(ctx context.Context, rule Rule, t target) ([]Violation, error) {
+	if t.isArtifact {
+		return nil, nil
+	}
+
+	neighbors, err := model.Neighbors(ctx, e.gqlClient, t.id, []model.Edge{model.EdgePackageCertifyVuln, model.EdgePackageCertifyVexStatement})
+	if err != nil {
+		return nil, fmt.Errorf("failed getting vulnerabilities: %w", err)
+	}
+
+	type vexStatus struct {
+		status     model.VexStatus
+		knownSince time.Time
+	}
+	latestVex := map[string]vexStatus{}
+	var certifyVulns []*model.NeighborsNeighborsCertifyVuln
+	for _, neighbor := range neighbors.Neighbors {
+		switch neighbor := neighbor.(type) {
+		case *model.NeighborsNeighborsCertifyVuln:
+			if neighbor.Vulnerability.Type != noVulnType {
+				certifyVulns = append(certifyVulns, neighbor)
+			}
+		case *model.NeighborsNeighborsCertifyVEXStatement:
+			for _, vulnID := range neighbor.Vulnerability.VulnerabilityIDs {
+				if latest, ok := latestVex[vulnID.VulnerabilityID]; !ok || neighbor.KnownSince.After(latest.knownSince) {
+					latestVex[vulnID.VulnerabilityID] = vexStatus{status: neighbor.Status, knownSince: neighbor.KnownSince}
+				}
+			}
+		}
+	}
+
+	var violations []Violation
+	reported := map[string]bool{}
+	for _, certifyVuln := range certifyVulns {
+		for _, vulnID := range certifyVuln.Vulnerability.VulnerabilityIDs {
+			if reported[vulnID.VulnerabilityID] {
+				continue
+			}
+			if vex, ok := latestVex[vulnID.VulnerabilityID]; ok && (vex.status == model.VexStatusNotAffected || vex.status == model.VexStatusFixed) {
+				continue
+			}
+
+			message := fmt.Sprintf("%s is affected by %s", t.path[len(t.path)-1], vulnID.VulnerabilityID)
+			if rule.MinScore > 0 {
+				score, found, err := e.vulnerabilityScore(ctx, vulnID.Id, rule.ScoreType)
+				if err != nil {
+					return nil, err
+				}
+				if !found || score < rule.MinScore {
+					continue
+				}
+				message = fmt.Sprintf("%s (score %.1f)", message, score)
+			}
+
+			reported[vulnID.VulnerabilityID] = true
+			violations = append(violations, Violation{Message: message, Path: t.path})
+		}
+	}
+	return violations, nil
+}
+
+// vulnerabilityScore returns the highest score of the vulnerability, only considering scoreType if set
+func (e *evaluator) vulnerabilityScore(ctx context.Context, vulnID string, scoreType string) (float64, bool, error) {
+	neighbors, err := model.Neighbors(ctx, e.gqlClient, vulnID, []model.Edge{model.EdgeVulnerabilityVulnMetadata})
+	if err != nil {
+		return 0, false, fmt.Errorf("failed getting vulnerability metadata: %w", err)
+	}
+
+	var score float64
+	found := false
+	for _, neighbor := range neighbors.Neighbors {
+		if vulnMetadata, ok := neighbor.(*model.NeighborsNeighborsVulnerabilityMetadata); ok {
+			if scoreType != "" && !strings.EqualFold(string(vulnMetadata.ScoreType), scoreType) {
+				continue
+			}
+			if !found || vulnMetadata.ScoreValue > score {
+				score = vulnMetadata.ScoreValue
+				found = true
+			}
+		}
+	}
+	return score, found, nil
+}
+
+func (e *evaluator) checkCertifyBad(ctx context.Context, t target) ([]Violation, error) {
+	ids := []string{t.id}
+	edges := []model.Edge{model.EdgeArtifactCertifyBad}
+	if !t.isArtifact {
+		// CertifyBad can be attached to all versions of a package through its packageName
+		ids = append(ids, t.nameID)
+		edges = []model.Edge{model.EdgePackageCertifyBad}
+	}
+
+	var violations []Violation
+	for _, id := range ids {
+		neighbors, err := model.Neighbors(ctx, e.gqlClient, id, edges)
+		if err != nil {
+			return nil, fmt.Errorf("failed getting certifyBad: %w", err)
+		}
+		for _, neighbor := range neighbors.Neighbors {
+			if certifyBad, ok := neighbor.(*model.NeighborsNeighborsCertifyBad); ok {
+				violations = append(violations, Violation{
+					Message: fmt.Sprintf("%s is certified bad: %s", t.path[len(t.path)-1], certifyBad.Justification),
+					Path:    t.path,
+				})
+			}
+		}
+	}
+	return violations, nil
+}
+
+func (e *evaluator) checkSLSABuilder(ctx context.Context, rule Rule, t target) ([]Violation, error) {
+	artifacts := map[string]string{}
+	if t.isArtifact {
+		artifacts[t.id] = t.path[len(t.path)-1]
+	} else {
+		neighbors, err := model.Neighbors(ctx, e.gqlClient, t.id, []model.Edge{model.EdgePackageIsOccurrence})
+		if err != nil {
+			return nil, fmt.Errorf("failed getting package occurrences: %w", err)
+		}
+		for _, neighbor := range neighbors.Neighbors {
+			if isOccurrence, ok := neighbor.(*model.NeighborsNeighborsIsOccurrence); ok {
+				artifacts[isOccurrence.Artifact.Id] = isOccurrence.Artifact.Algorithm + ":" + isOccurrence.Artifact.Digest
+			}
+		}
+		if len(artifacts) == 0 {
+			if rule.AllowMissing {
+				return nil, nil
+			}
+			return []Violation{{
+				Message: fmt.Sprintf("%s has no artifacts to check for SLSA provenance", t.path[len(t.path)-1]),
+				Path:    t.path,
+			}}, nil
+		}
+	}
+
+	var violations []Violation
+	for artifactID, artifactLabel := range artifacts {
+		if e.checkedArtifacts[artifactID] {
+			continue
+		}
+		e.checkedArtifacts[artifactID] = true
+
+		neighbors, err := model.Neighbors(ctx, e.gqlClient, artifactID, []model.Edge{model.EdgeArtifactHasSlsa})
+		if err != nil {
+			return nil, fmt.Errorf("failed getting hasSLSA: %w", err)
+		}
+
+		builtByAllowed := false
+		for _, neighbor := range neighbors.Neighbors {
+			if hasSLSA, ok := neighbor.(*model.NeighborsNeighborsHasSLSA); ok && hasSLSA.Subject.Id == artifactID {
+				for _, builder := range rule.Builders {
+					if hasSLSA.Slsa.BuiltBy.Uri == builder {
+						builtByAllowed = true
+					}
+				}
+			}
+		}
+
+		if !builtByAllowed {
+			path := t.path
+			if !t.isArtifact {
+				path = appendPath(t.path, artifactLabel)
+			}
+			violations = append(violations, Violation{
+				Message: fmt.Sprintf("%s has no SLSA provenance from an allowed builder", artifactLabel),
+				Path:    path,
+			})
+		}
+	}
+	return violations, nil
+}
+
+func (e *evaluator) checkScorecard(ctx context.Context, rule Rule, t target) ([]Violation, error) {
+	if t.isArtifact {
+		return nil, nil
+	}
+
+	sourceIDs := map[string]bool{}
+	for _, id := range []string{t.id, t.nameID} {
+		neighbors, err := model.Neighbors(ctx, e.gqlClient, id, []model.Edge{model.EdgePackageHasSourceAt})
+		if err != nil {
+			return nil, fmt.Errorf("failed getting hasSourceAt: %w", err)
+		}
+		for _, neighbor := range neighbors.Neighbors {
+			if hasSourceAt, ok := neighbor.(*model.NeighborsNeighborsHasSourceAt); ok {
+				sourceIDs[hasSourceAt.Source.Namespaces[0].Names[0].Id] = true
+			}
+		}
+	}
+
+	var latest *model.NeighborsNeighborsCertifyScorecard
+	for sourceID := range sourceIDs {
+		neighbors, err := model.Neighbors(ctx, e.gqlClient, sourceID, []model.Edge{model.EdgeSourceCertifyScorecard})
+		if err != nil {
+			return nil, fmt.Errorf("failed getting scorecard: %w", err)
+		}
+		for _, neighbor := range neighbors.Neighbors {
+			if scorecard, ok := neighbor.(*model.NeighborsNeighborsCertifyScorecard); ok {
+				if latest == nil || scorecard.Scorecard.TimeScanned.After(latest.Scorecard.TimeScanned) {
+					latest = scorecard
+				}
+			}
+		}
+	}
+
+	if latest == nil {
+		if rule.AllowMissing {
+			return nil, nil
+		}
+		return []Violation{{
+			Message: fmt.Sprintf("%s has no scorecard", t.path[len(t.path)-1]),
+			Path:    t.path,
+		}}, nil
+	}
+
+	if latest.Scorecard.AggregateScore < rule.MinScore {
+		return []Violation{{
+			Message: fmt.Sprintf("%s has scorecard score %.1f, below %.1f", t.path[len(t.path)-1], latest.Scorecard.AggregateScore, rule.MinScore),
+			Path:    t.path,
+		}}, nil
+	}
+	return nil, nil
+}
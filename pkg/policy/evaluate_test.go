@@ -0,0 +1,289 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/Khan/genqlient/graphql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/guacsec/guac/internal/testing/ptrfrom"
+	"github.com/guacsec/guac/pkg/assembler"
+	"github.com/guacsec/guac/pkg/assembler/backends"
+	_ "github.com/guacsec/guac/pkg/assembler/backends/keyvalue"
+	model "github.com/guacsec/guac/pkg/assembler/clients/generated"
+	"github.com/guacsec/guac/pkg/assembler/clients/helpers"
+	"github.com/guacsec/guac/pkg/assembler/graphql/generated"
+	"github.com/guacsec/guac/pkg/assembler/graphql/resolvers"
+	"github.com/guacsec/guac/pkg/logging"
+)
+
+const (
+	trustedBuilder = "https://github.com/slsa-framework/slsa-github-generator"
+	appPurl        = "pkg:guac/policy/app@1.0.0"
+	libPurl        = "pkg:guac/policy/lib@2.0.0"
+	utilPurl       = "pkg:guac/policy/util@1.5.0"
+	appDigest      = "sha256:app123"
+)
+
+var (
+	tm, _ = time.Parse(time.RFC3339, "2024-01-17T17:45:50.52Z")
+
+	appPkg  = &model.PkgInputSpec{Type: "guac", Namespace: ptrfrom.String("policy"), Name: "app", Version: ptrfrom.String("1.0.0")}
+	libPkg  = &model.PkgInputSpec{Type: "guac", Namespace: ptrfrom.String("policy"), Name: "lib", Version: ptrfrom.String("2.0.0")}
+	utilPkg = &model.PkgInputSpec{Type: "guac", Namespace: ptrfrom.String("policy"), Name: "util", Version: ptrfrom.String("1.5.0")}
+
+	libSrc  = &model.SourceInputSpec{Type: "git", Namespace: "github.com/policy", Name: "lib"}
+	utilSrc = &model.SourceInputSpec{Type: "git", Namespace: "github.com/policy", Name: "util"}
+
+	appArtifact = &model.ArtifactInputSpec{Algorithm: "sha256", Digest: "app123"}
+
+	criticalVuln = &model.VulnerabilityInputSpec{Type: "osv", VulnerabilityID: "cve-2024-0001"}
+	lowVuln      = &model.VulnerabilityInputSpec{Type: "osv", VulnerabilityID: "cve-2024-0002"}
+	vexedVuln    = &model.VulnerabilityInputSpec{Type: "osv", VulnerabilityID: "cve-2024-0003"}
+
+	scanMetadata = &model.ScanMetadataInput{TimeScanned: tm, Origin: "test", Collector: "test"}
+
+	policyGraph = assembler.IngestPredicates{
+		IsDependency: []assembler.IsDependencyIngest{
+			{
+				Pkg:             appPkg,
+				DepPkg:          libPkg,
+				DepPkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+				IsDependency:    &model.IsDependencyInputSpec{VersionRange: "2.0.0", DependencyType: model.DependencyTypeDirect, Origin: "test", Collector: "test"},
+			},
+			{
+				Pkg:             libPkg,
+				DepPkg:          utilPkg,
+				DepPkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeAllVersions},
+				IsDependency:    &model.IsDependencyInputSpec{VersionRange: ">=1.0.0", DependencyType: model.DependencyTypeDirect, Origin: "test", Collector: "test"},
+			},
+		},
+		IsOccurrence: []assembler.IsOccurrenceIngest{
+			{
+				Pkg:          appPkg,
+				Artifact:     appArtifact,
+				IsOccurrence: &model.IsOccurrenceInputSpec{Justification: "test", Origin: "test", Collector: "test"},
+			},
+		},
+		HasSlsa: []assembler.HasSlsaIngest{
+			{
+				Artifact:  appArtifact,
+				Builder:   &model.BuilderInputSpec{Uri: trustedBuilder},
+				Materials: []model.ArtifactInputSpec{{Algorithm: "sha256", Digest: "src123"}},
+				HasSlsa:   &model.SLSAInputSpec{BuildType: "test", SlsaVersion: "v1", SlsaPredicate: []model.SLSAPredicateInputSpec{}, StartedOn: &tm, FinishedOn: &tm, Origin: "test", Collector: "test"},
+			},
+		},
+		CertifyVuln: []assembler.CertifyVulnIngest{
+			{Pkg: libPkg, Vulnerability: criticalVuln, VulnData: scanMetadata},
+			{Pkg: libPkg, Vulnerability: lowVuln, VulnData: scanMetadata},
+			{Pkg: utilPkg, Vulnerability: vexedVuln, VulnData: scanMetadata},
+		},
+		VulnMetadata: []assembler.VulnMetadataIngest{
+			{
+				Vulnerability: criticalVuln,
+				VulnMetadata:  &model.VulnerabilityMetadataInputSpec{ScoreType: model.VulnerabilityScoreTypeCvssv3, ScoreValue: 9.8, Timestamp: tm, Origin: "test", Collector: "test"},
+			},
+			{
+				Vulnerability: lowVuln,
+				VulnMetadata:  &model.VulnerabilityMetadataInputSpec{ScoreType: model.VulnerabilityScoreTypeCvssv3, ScoreValue: 4.0, Timestamp: tm, Origin: "test", Collector: "test"},
+			},
+		},
+		Vex: []assembler.VexIngest{
+			{
+				Pkg:           utilPkg,
+				Vulnerability: vexedVuln,
+				VexData: &model.VexStatementInputSpec{
+					Status:           model.VexStatusNotAffected,
+					VexJustification: model.VexJustificationVulnerableCodeNotPresent,
+					KnownSince:       tm,
+					Origin:           "test",
+					Collector:        "test",
+				},
+			},
+		},
+		CertifyBad: []assembler.CertifyBadIngest{
+			{
+				Pkg:          libPkg,
+				PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeAllVersions},
+				CertifyBad:   &model.CertifyBadInputSpec{Justification: "malicious", KnownSince: tm, Origin: "test", Collector: "test"},
+			},
+		},
+		HasSourceAt: []assembler.HasSourceAtIngest{
+			{
+				Pkg:          libPkg,
+				PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeAllVersions},
+				Src:          libSrc,
+				HasSourceAt:  &model.HasSourceAtInputSpec{KnownSince: tm, Origin: "test", Collector: "test"},
+			},
+			{
+				Pkg:          utilPkg,
+				PkgMatchFlag: model.MatchFlags{Pkg: model.PkgMatchTypeSpecificVersion},
+				Src:          utilSrc,
+				HasSourceAt:  &model.HasSourceAtInputSpec{KnownSince: tm, Origin: "test", Collector: "test"},
+			},
+		},
+		CertifyScorecard: []assembler.CertifyScorecardIngest{
+			{
+				Source:    libSrc,
+				Scorecard: &model.ScorecardInputSpec{Checks: []model.ScorecardCheckInputSpec{}, AggregateScore: 4.0, TimeScanned: tm, Origin: "test", Collector: "test"},
+			},
+			{
+				Source:    utilSrc,
+				Scorecard: &model.ScorecardInputSpec{Checks: []model.ScorecardCheckInputSpec{}, AggregateScore: 8.0, TimeScanned: tm, Origin: "test", Collector: "test"},
+			},
+		},
+	}
+)
+
+func startTestClient(t *testing.T) graphql.Client {
+	backend, err := backends.Get("keyvalue", nil, nil)
+	if err != nil {
+		t.Fatalf("error creating keyvalue backend: %v", err)
+	}
+	config := generated.Config{Resolvers: &resolvers.Resolver{Backend: backend}}
+	server := httptest.NewServer(handler.NewDefaultServer(generated.NewExecutableSchema(config)))
+	t.Cleanup(server.Close)
+	return graphql.NewClient(server.URL, server.Client())
+}
+
+func TestEvaluate(t *testing.T) {
+	ctx := logging.WithLogger(context.Background())
+	gqlClient := startTestClient(t)
+
+	if err := helpers.GetAssembler(ctx, gqlClient)([]assembler.IngestPredicates{policyGraph}); err != nil {
+		t.Fatalf("error ingesting test data: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		subject string
+		rule    Rule
+		want    []Violation
+	}{
+		{
+			name:    "any unmitigated vulnerability",
+			subject: appPurl,
+			rule:    Rule{Type: RuleNoVulnerability},
+			want: []Violation{
+				{Message: libPurl + " is affected by cve-2024-0001", Path: []string{appPurl, libPurl}},
+				{Message: libPurl + " is affected by cve-2024-0002", Path: []string{appPurl, libPurl}},
+			},
+		},
+		{
+			name:    "critical vulnerabilities",
+			subject: appPurl,
+			rule:    Rule{Type: RuleNoVulnerability, MinScore: 9, ScoreType: "CVSSv3"},
+			want: []Violation{
+				{Message: libPurl + " is affected by cve-2024-0001 (score 9.8)", Path: []string{appPurl, libPurl}},
+			},
+		},
+		{
+			name:    "vulnerabilities of the subject only",
+			subject: appPurl,
+			rule:    Rule{Type: RuleNoVulnerability, Scope: ScopeSubject},
+		},
+		{
+			name:    "certify bad through package name",
+			subject: appPurl,
+			rule:    Rule{Type: RuleNoCertifyBad},
+			want: []Violation{
+				{Message: libPurl + " is certified bad: malicious", Path: []string{appPurl, libPurl}},
+			},
+		},
+		{
+			name:    "scorecard of direct dependencies",
+			subject: appPurl,
+			rule:    Rule{Type: RuleMinScorecard, Scope: ScopeDirect, MinScore: 6},
+			want: []Violation{
+				{Message: libPurl + " has scorecard score 4.0, below 6.0", Path: []string{appPurl, libPurl}},
+			},
+		},
+		{
+			name:    "scorecard of transitive dependencies through version range",
+			subject: libPurl,
+			rule:    Rule{Type: RuleMinScorecard, Scope: ScopeDirect, MinScore: 9},
+			want: []Violation{
+				{Message: utilPurl + " has scorecard score 8.0, below 9.0", Path: []string{libPurl, utilPurl}},
+			},
+		},
+		{
+			name:    "trusted builder",
+			subject: appPurl,
+			rule:    Rule{Type: RuleSLSABuilder, Scope: ScopeSubject, Builders: []string{trustedBuilder}},
+		},
+		{
+			name:    "untrusted builder",
+			subject: appDigest,
+			rule:    Rule{Type: RuleSLSABuilder, Scope: ScopeSubject, Builders: []string{"https://example.com/builder"}},
+			want: []Violation{
+				{Message: appDigest + " has no SLSA provenance from an allowed builder", Path: []string{appDigest}},
+			},
+		},
+		{
+			name:    "artifact subject includes dependencies of its package",
+			subject: appDigest,
+			rule:    Rule{Type: RuleNoCertifyBad},
+			want: []Violation{
+				{Message: libPurl + " is certified bad: malicious", Path: []string{appDigest, appPurl, libPurl}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Policy{Name: "test", Rules: []Rule{tt.rule}}
+			if err := p.Validate(); err != nil {
+				t.Fatalf("invalid policy: %v", err)
+			}
+
+			subjectID, err := FindSubject(ctx, gqlClient, tt.subject)
+			if err != nil {
+				t.Fatalf("FindSubject() error: %v", err)
+			}
+
+			got, err := Evaluate(ctx, gqlClient, p, subjectID)
+			if err != nil {
+				t.Fatalf("Evaluate() error: %v", err)
+			}
+
+			if got.Subject != tt.subject {
+				t.Errorf("Evaluate() subject = %s, want %s", got.Subject, tt.subject)
+			}
+			wantPassed := len(tt.want) == 0
+			if got.Passed != wantPassed || got.Rules[0].Passed != wantPassed {
+				t.Errorf("Evaluate() passed = %v, want %v", got.Passed, wantPassed)
+			}
+
+			violations := got.Rules[0].Violations
+			sort.Slice(violations, func(i, j int) bool { return violations[i].Message < violations[j].Message })
+			if diff := cmp.Diff(tt.want, violations, cmpEquateEmpty); diff != "" {
+				t.Errorf("Evaluate() violations (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+var cmpEquateEmpty = cmp.Comparer(func(a, b []Violation) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return cmp.Equal(a, b)
+})
@@ -0,0 +1,160 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy evaluates admission-style policies against a package or
+// artifact in the GUAC graph. A policy is a list of structured rules, each of
+// which is checked against the subject and, depending on its scope, the
+// subject's dependencies found through IsDependency.
+package policy
+
+import (
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RuleType is the kind of check a rule performs
+type RuleType string
+
+const (
+	// RuleNoVulnerability fails for every CertifyVuln that is not mitigated by
+	// a NOT_AFFECTED or FIXED VEX statement. If MinScore is set, only
+	// vulnerabilities with VulnerabilityMetadata at or above the score count.
+	RuleNoVulnerability RuleType = "noVulnerability"
+	// RuleNoCertifyBad fails for every CertifyBad attached to a package or artifact
+	RuleNoCertifyBad RuleType = "noCertifyBad"
+	// RuleSLSABuilder fails for every artifact that has no HasSLSA built by one of Builders
+	RuleSLSABuilder RuleType = "slsaBuilder"
+	// RuleMinScorecard fails for every package whose source has an aggregate
+	// scorecard score below MinScore
+	RuleMinScorecard RuleType = "minScorecard"
+)
+
+// Scope is the part of the dependency graph a rule is evaluated against
+type Scope string
+
+const (
+	// ScopeSubject only evaluates the package or artifact the policy is evaluated against
+	ScopeSubject Scope = "subject"
+	// ScopeDirect only evaluates the direct dependencies of the subject
+	ScopeDirect Scope = "direct"
+	// ScopeTransitive evaluates the subject and all of its transitive dependencies
+	ScopeTransitive Scope = "transitive"
+)
+
+// Policy is a named set of rules. A policy passes when all of its rules pass.
+type Policy struct {
+	Name  string `json:"name" yaml:"name"`
+	Rules []Rule `json:"rules" yaml:"rules"`
+}
+
+// Rule is a single check of a policy
+type Rule struct {
+	Name string   `json:"name" yaml:"name"`
+	Type RuleType `json:"type" yaml:"type"`
+	// Scope defaults to ScopeTransitive
+	Scope Scope `json:"scope,omitempty" yaml:"scope,omitempty"`
+	// MinScore is the scorecard threshold for RuleMinScorecard and the
+	// vulnerability score threshold for RuleNoVulnerability
+	MinScore float64 `json:"minScore,omitempty" yaml:"minScore,omitempty"`
+	// ScoreType restricts the VulnerabilityMetadata considered by
+	// RuleNoVulnerability, for example CVSSv3. All score types are used if empty.
+	ScoreType string `json:"scoreType,omitempty" yaml:"scoreType,omitempty"`
+	// Builders are the builder URIs accepted by RuleSLSABuilder
+	Builders []string `json:"builders,omitempty" yaml:"builders,omitempty"`
+	// AllowMissing passes packages that have no source or scorecard for
+	// RuleMinScorecard and packages with no artifacts for RuleSLSABuilder
+	AllowMissing bool `json:"allowMissing,omitempty" yaml:"allowMissing,omitempty"`
+}
+
+// Result is the outcome of evaluating a policy against a subject
+type Result struct {
+	Policy  string       `json:"policy"`
+	Subject string       `json:"subject"`
+	Passed  bool         `json:"passed"`
+	Rules   []RuleResult `json:"rules"`
+}
+
+// RuleResult is the outcome of a single rule
+type RuleResult struct {
+	Name       string      `json:"name"`
+	Type       RuleType    `json:"type"`
+	Passed     bool        `json:"passed"`
+	Violations []Violation `json:"violations"`
+}
+
+// Violation describes an offending node. Path lists the packages and
+// artifacts from the subject down to the offending node.
+type Violation struct {
+	Message string   `json:"message"`
+	Path    []string `json:"path"`
+}
+
+// ParsePolicy parses a policy in YAML or JSON form and validates it
+func ParsePolicy(data []byte) (*Policy, error) {
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// LoadPolicy reads and parses a policy from r
+func LoadPolicy(r io.Reader) (*Policy, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+	return ParsePolicy(data)
+}
+
+// Validate checks that all rules of the policy are well formed and fills in
+// the default scope
+func (p *Policy) Validate() error {
+	if len(p.Rules) == 0 {
+		return fmt.Errorf("policy %q has no rules", p.Name)
+	}
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("%s-%d", rule.Type, i)
+		}
+		switch rule.Scope {
+		case "":
+			rule.Scope = ScopeTransitive
+		case ScopeSubject, ScopeDirect, ScopeTransitive:
+		default:
+			return fmt.Errorf("rule %q has unknown scope %q", rule.Name, rule.Scope)
+		}
+		switch rule.Type {
+		case RuleNoVulnerability, RuleNoCertifyBad:
+		case RuleSLSABuilder:
+			if len(rule.Builders) == 0 {
+				return fmt.Errorf("rule %q requires at least one builder", rule.Name)
+			}
+		case RuleMinScorecard:
+			if rule.MinScore <= 0 {
+				return fmt.Errorf("rule %q requires a positive minScore", rule.Name)
+			}
+		default:
+			return fmt.Errorf("rule %q has unknown type %q", rule.Name, rule.Type)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+//
+// Copyright 2024 The GUAC Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    *Policy
+		wantErr bool
+	}{
+		{
+			name: "yaml",
+			data: `
+name: release-gate
+rules:
+  - name: no-critical-vulns
+    type: noVulnerability
+    minScore: 9
+    scoreType: CVSSv3
+  - type: minScorecard
+    scope: direct
+    minScore: 6
+  - name: trusted-builder
+    type: slsaBuilder
+    scope: subject
+    builders:
+      - https://github.com/slsa-framework/slsa-github-generator
+`,
+			want: &Policy{
+				Name: "release-gate",
+				Rules: []Rule{
+					{Name: "no-critical-vulns", Type: RuleNoVulnerability, Scope: ScopeTransitive, MinScore: 9, ScoreType: "CVSSv3"},
+					{Name: "minScorecard-1", Type: RuleMinScorecard, Scope: ScopeDirect, MinScore: 6},
+					{Name: "trusted-builder", Type: RuleSLSABuilder, Scope: ScopeSubject, Builders: []string{"https://github.com/slsa-framework/slsa-github-generator"}},
+				},
+			},
+		},
+		{
+			name: "json",
+			data: `{"name": "bad", "rules": [{"name": "no-bad", "type": "noCertifyBad"}]}`,
+			want: &Policy{
+				Name:  "bad",
+				Rules: []Rule{{Name: "no-bad", Type: RuleNoCertifyBad, Scope: ScopeTransitive}},
+			},
+		},
+		{
+			name:    "no rules",
+			data:    `name: empty`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown type",
+			data:    `{"rules": [{"type": "noUnicorns"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown scope",
+			data:    `{"rules": [{"type": "noCertifyBad", "scope": "siblings"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "slsa builder without builders",
+			data:    `{"rules": [{"type": "slsaBuilder"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "scorecard without minimum",
+			data:    `{"rules": [{"type": "minScorecard"}]}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePolicy([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParsePolicy() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}